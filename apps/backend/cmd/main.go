@@ -1,29 +1,52 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/attendance"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/auth"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/company"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/custom_field"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/document"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/employee"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/employee_duplicate"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/employee_family"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/employment_contract"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/employment_event"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/import_job"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/import_mapping"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/leave_encashment"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/leave_policy"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/leave_staffing_rule"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/location"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/offboarding"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/onboarding"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/organization"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/probation"
+	"github.com/SukaMajuu/hris/apps/backend/internal/repository/profile_change"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/work_schedule"
 	"github.com/SukaMajuu/hris/apps/backend/internal/repository/xendit"
 	"github.com/SukaMajuu/hris/apps/backend/internal/rest"
 	attendanceUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/attendance"
 	authUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/auth"
+	contractUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/contract"
+	customFieldUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/custom_field"
 	documentUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/document"
 	employeeUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
 	leaveRequestUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/leave_request"
 	locationUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/location"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/notification"
+	onboardingUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/onboarding"
+	organizationUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/organization"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/subscription"
 	workScheduleUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/work_schedule"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/config"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/database"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/jwt"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/midtrans"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"github.com/supabase-community/supabase-go"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -53,6 +76,22 @@ func main() {
 	locationRepo := location.NewLocationRepository(db)
 	workScheduleRepo := work_schedule.NewWorkScheduleRepository(db)
 	leaveRequestRepo := leave_request.NewPostgresRepository(db)
+	leaveStaffingRuleRepo := leave_staffing_rule.NewPostgresRepository(db)
+	leavePolicyRepo := leave_policy.NewPostgresRepository(db)
+	leaveEncashmentRepo := leave_encashment.NewPostgresRepository(db)
+	companyRepo := company.NewPostgresRepository(db)
+	organizationRepo := organization.NewPostgresRepository(db)
+	employmentEventRepo := employment_event.NewPostgresRepository(db)
+	employmentContractRepo := employment_contract.NewPostgresRepository(db)
+	offboardingRepo := offboarding.NewPostgresRepository(db)
+	onboardingRepo := onboarding.NewPostgresRepository(db)
+	probationRepo := probation.NewPostgresRepository(db)
+	customFieldRepo := custom_field.NewPostgresRepository(db)
+	profileChangeRepo := profile_change.NewPostgresRepository(db)
+	importJobRepo := import_job.NewPostgresRepository(db)
+	importMappingRepo := import_mapping.NewPostgresRepository(db)
+	employeeDuplicateRepo := employee_duplicate.NewPostgresRepository(db)
+	employeeFamilyRepo := employee_family.NewPostgresRepository(db)
 	xenditRepo := xendit.NewXenditRepository(db)
 	midtransClient := midtrans.NewClient(&cfg.Midtrans)
 	documentRepo := document.NewPostgresRepository(db)
@@ -73,13 +112,49 @@ func main() {
 		cfg,
 		subscriptionUseCase,
 	)
+	leaveRequestUseCase := leaveRequestUseCase.NewLeaveRequestUseCase(
+		leaveRequestRepo,
+		employeeRepo,
+		attendanceRepo,
+		leaveStaffingRuleRepo,
+		leavePolicyRepo,
+		leaveEncashmentRepo,
+		supabaseClient,
+	)
+	emailService := notification.NewEmailService(*cfg)
+
+	onboardingUseCase := onboardingUseCase.NewOnboardingUseCase(
+		onboardingRepo,
+		employeeRepo,
+		organizationRepo,
+		companyRepo,
+		authRepo,
+		emailService,
+	)
+
 	employeeUseCase := employeeUseCase.NewEmployeeUseCase(
 		employeeRepo,
 		authRepo,
 		xenditRepo,
 		supabaseClient,
 		db,
-	)
+	).WithDependencies(employeeUseCase.Dependencies{
+		LeaveEncashmentUC:   leaveRequestUseCase,
+		CompanyRepo:         companyRepo,
+		OrganizationRepo:    organizationRepo,
+		EmploymentEventRepo: employmentEventRepo,
+		ContractRepo:        employmentContractRepo,
+		OffboardingRepo:     offboardingRepo,
+		OnboardingUC:        onboardingUseCase,
+		ProbationRepo:       probationRepo,
+		Notifier:            emailService,
+		CustomFieldRepo:     customFieldRepo,
+		ProfileChangeRepo:   profileChangeRepo,
+		ImportJobRepo:       importJobRepo,
+		ImportMappingRepo:   importMappingRepo,
+		DuplicateRepo:       employeeDuplicateRepo,
+		FamilyRepo:          employeeFamilyRepo,
+	})
 
 	attendanceUseCase := attendanceUseCase.NewAttendanceUseCase(
 		attendanceRepo,
@@ -95,18 +170,24 @@ func main() {
 		locationRepo,
 	)
 
+	organizationUseCase := organizationUseCase.NewOrganizationUseCase(organizationRepo, employeeRepo)
+
+	customFieldUseCase := customFieldUseCase.NewCustomFieldUseCase(customFieldRepo)
+
+	contractUseCase := contractUseCase.NewContractUseCase(
+		employmentContractRepo,
+		employeeRepo,
+		companyRepo,
+		authRepo,
+		emailService,
+	)
+
 	midtransSubscriptionUseCase := subscription.NewMidtransSubscriptionUseCase(xenditRepo, midtransClient, employeeRepo, authRepo, cfg)
 	documentUseCase := documentUseCase.NewDocumentUseCase(
 		documentRepo,
 		employeeRepo,
 		supabaseClient,
 	)
-	leaveRequestUseCase := leaveRequestUseCase.NewLeaveRequestUseCase(
-		leaveRequestRepo,
-		employeeRepo,
-		attendanceRepo,
-		supabaseClient,
-	)
 
 	router := rest.NewRouter(
 		authUseCase,
@@ -116,10 +197,20 @@ func main() {
 		leaveRequestUseCase,
 		workScheduleUseCase,
 		documentUseCase,
+		organizationUseCase,
+		contractUseCase,
+		onboardingUseCase,
+		customFieldUseCase,
 		subscriptionUseCase,
 		midtransSubscriptionUseCase,
 	)
 
+	go func() {
+		if err := employeeUseCase.ResumeImportJobs(tenant.AcrossCompanies(context.Background())); err != nil {
+			log.Printf("Warning: failed to resume import jobs: %v", err)
+		}
+	}()
+
 	ginRouter := router.Setup()
 
 	ginRouter.StaticFile("/swagger.yaml", "../../docs/api/swagger.yaml")
@@ -18,6 +18,7 @@ type Attendance struct {
 	ID           uint             `gorm:"primaryKey"`
 	EmployeeID   uint             `gorm:"not null"`
 	Employee     Employee         `gorm:"foreignKey:EmployeeID"`
+	CompanyID    *uint            `gorm:"index"`
 	Date         time.Time        `gorm:"type:date;not null"`
 	ClockIn      *time.Time       `gorm:"type:timestamp"`
 	ClockOut     *time.Time       `gorm:"type:timestamp"`
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// Branch is an office or site of a company that employees are assigned to.
+type Branch struct {
+	ID        uint   `gorm:"primaryKey"`
+	CompanyID *uint  `gorm:"index"`
+	Name      string `gorm:"type:varchar(255);not null"`
+
+	// Admin user who created the branch
+	CreatedBy uint `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (b *Branch) TableName() string {
+	return "branches"
+}
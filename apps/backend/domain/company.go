@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// Company is the tenant every user, employee and their records belong to. It is owned by the
+// admin who registered it.
+type Company struct {
+	ID          uint   `gorm:"primaryKey"`
+	Name        string `gorm:"type:varchar(255);not null"`
+	OwnerUserID uint   `gorm:"not null;uniqueIndex"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (c *Company) TableName() string {
+	return "companies"
+}
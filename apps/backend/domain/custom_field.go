@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldType is the kind of value a custom employee field holds.
+type CustomFieldType string
+
+const (
+	CustomFieldText   CustomFieldType = "text"
+	CustomFieldNumber CustomFieldType = "number"
+	CustomFieldDate   CustomFieldType = "date"
+	CustomFieldSelect CustomFieldType = "select"
+	// CustomFieldFile holds the URL of an uploaded document.
+	CustomFieldFile CustomFieldType = "file"
+)
+
+// CustomFieldVisibility is who can see and edit a custom employee field. Admin-only fields are
+// hidden from the employee's own profile; employee-editable fields can be changed by the employee.
+type CustomFieldVisibility string
+
+const (
+	CustomFieldAdminOnly        CustomFieldVisibility = "admin_only"
+	CustomFieldEmployeeEditable CustomFieldVisibility = "employee_editable"
+)
+
+// CustomFieldDefinition is an extra employee field defined by a company, such as shirt size, BPJS
+// number or blood type. Values are stored on the employee under Key.
+type CustomFieldDefinition struct {
+	ID         uint                  `gorm:"primaryKey"`
+	CompanyID  *uint                 `gorm:"uniqueIndex:idx_custom_field_company_key"`
+	Key        string                `gorm:"type:varchar(100);not null;uniqueIndex:idx_custom_field_company_key"`
+	Label      string                `gorm:"type:varchar(255);not null"`
+	Type       CustomFieldType       `gorm:"type:custom_field_type;not null"`
+	Visibility CustomFieldVisibility `gorm:"type:custom_field_visibility;not null;default:'admin_only'"`
+	Required   bool                  `gorm:"not null;default:false"`
+
+	// Options are the allowed values of a select field.
+	Options []string `gorm:"type:jsonb;serializer:json"`
+	// Pattern is a regular expression text values must match.
+	Pattern *string `gorm:"type:varchar(255)"`
+	// Min and Max bound number values.
+	Min *float64 `gorm:"type:decimal(15,2)"`
+	Max *float64 `gorm:"type:decimal(15,2)"`
+
+	SortOrder int `gorm:"not null;default:0"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (d *CustomFieldDefinition) TableName() string {
+	return "custom_field_definitions"
+}
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,99}$`)
+
+// Check reports whether the definition itself is usable.
+func (d *CustomFieldDefinition) Check() error {
+	if !customFieldKeyPattern.MatchString(d.Key) {
+		return fmt.Errorf("%w: key %q must start with a letter and contain only lowercase letters, digits and underscores", ErrInvalidCustomField, d.Key)
+	}
+	if d.Type == CustomFieldSelect && len(d.Options) == 0 {
+		return fmt.Errorf("%w: a select field needs at least one option", ErrInvalidCustomField)
+	}
+	if d.Pattern != nil {
+		if _, err := regexp.Compile(*d.Pattern); err != nil {
+			return fmt.Errorf("%w: invalid pattern: %v", ErrInvalidCustomField, err)
+		}
+	}
+	if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+		return fmt.Errorf("%w: min cannot be greater than max", ErrInvalidCustomField)
+	}
+	return nil
+}
+
+// Validate checks a value for the field and returns it in the form it is stored in: a string for
+// text, select and file fields, a number for number fields and a YYYY-MM-DD string for date
+// fields. Values may be given as strings, as they are in forms and imports.
+func (d *CustomFieldDefinition) Validate(value interface{}) (interface{}, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s %s", ErrInvalidCustomFieldValue, d.Key, fmt.Sprintf(format, args...))
+	}
+
+	if d.Type == CustomFieldNumber {
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case int:
+			number = float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, invalid("must be a number")
+			}
+			number = parsed
+		default:
+			return nil, invalid("must be a number")
+		}
+		if d.Min != nil && number < *d.Min {
+			return nil, invalid("must be at least %v", *d.Min)
+		}
+		if d.Max != nil && number > *d.Max {
+			return nil, invalid("must be at most %v", *d.Max)
+		}
+		return number, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, invalid("must be a string")
+	}
+	text = strings.TrimSpace(text)
+
+	switch d.Type {
+	case CustomFieldText:
+		if d.Pattern != nil && !regexp.MustCompile(*d.Pattern).MatchString(text) {
+			return nil, invalid("does not have the expected format")
+		}
+	case CustomFieldDate:
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, invalid("must be a date in YYYY-MM-DD format")
+		}
+	case CustomFieldSelect:
+		for _, option := range d.Options {
+			if text == option {
+				return text, nil
+			}
+		}
+		return nil, invalid("must be one of %s", strings.Join(d.Options, ", "))
+	case CustomFieldFile:
+		parsed, err := url.Parse(text)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, invalid("must be the URL of an uploaded file")
+		}
+	default:
+		return nil, invalid("has an unknown type %s", d.Type)
+	}
+	return text, nil
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+// Department is an organisational unit of a company. Departments form a tree through ParentID;
+// top-level departments have no parent.
+type Department struct {
+	ID        uint        `gorm:"primaryKey"`
+	CompanyID *uint       `gorm:"index"`
+	Name      string      `gorm:"type:varchar(255);not null"`
+	ParentID  *uint       `gorm:"index"`
+	Parent    *Department `gorm:"foreignKey:ParentID"`
+
+	// Admin user who created the department
+	CreatedBy uint `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (d *Department) TableName() string {
+	return "departments"
+}
@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ContractResponseDTO struct {
+	ID            uint      `json:"id"`
+	EmployeeID    uint      `json:"employee_id"`
+	ContractType  string    `json:"contract_type"`
+	StartDate     string    `json:"start_date"`
+	EndDate       *string   `json:"end_date"`
+	DocumentURL   *string   `json:"document_url"`
+	Status        string    `json:"status"`
+	DaysRemaining *int      `json:"days_remaining"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// UpcomingExpiryDTO is an active fixed-term contract on the expiry dashboard. DaysRemaining is
+// negative for contracts that have already ended without being renewed or converted.
+type UpcomingExpiryDTO struct {
+	ContractID    uint    `json:"contract_id"`
+	EmployeeID    uint    `json:"employee_id"`
+	EmployeeName  string  `json:"employee_name"`
+	EmployeeCode  *string `json:"employee_code"`
+	PositionName  string  `json:"position_name"`
+	ManagerName   *string `json:"manager_name"`
+	StartDate     string  `json:"start_date"`
+	EndDate       string  `json:"end_date"`
+	DaysRemaining int     `json:"days_remaining"`
+	DocumentURL   *string `json:"document_url"`
+}
+
+type ContractReminderResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Checked       int    `json:"checked"`
+	Reminded      int    `json:"reminded"`
+	Failed        int    `json:"failed"`
+}
+
+func ToContractResponseDTO(contract *domain.EmploymentContract, today time.Time) *ContractResponseDTO {
+	dto := &ContractResponseDTO{
+		ID:           contract.ID,
+		EmployeeID:   contract.EmployeeID,
+		ContractType: string(contract.ContractType),
+		StartDate:    contract.StartDate.Format("2006-01-02"),
+		DocumentURL:  contract.DocumentURL,
+		Status:       string(contract.Status),
+		CreatedAt:    contract.CreatedAt,
+	}
+	if contract.EndDate != nil {
+		endDate := contract.EndDate.Format("2006-01-02")
+		dto.EndDate = &endDate
+	}
+	if contract.Status == domain.ContractStatusActive {
+		dto.DaysRemaining = contract.DaysRemaining(today)
+	}
+	return dto
+}
+
+func ToContractResponseDTOList(contracts []*domain.EmploymentContract, today time.Time) []*ContractResponseDTO {
+	dtos := make([]*ContractResponseDTO, 0, len(contracts))
+	for _, contract := range contracts {
+		dtos = append(dtos, ToContractResponseDTO(contract, today))
+	}
+	return dtos
+}
@@ -0,0 +1,49 @@
+package custom_field
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type CustomFieldResponseDTO struct {
+	ID         uint      `json:"id"`
+	Key        string    `json:"key"`
+	Label      string    `json:"label"`
+	Type       string    `json:"type"`
+	Visibility string    `json:"visibility"`
+	Required   bool      `json:"required"`
+	Options    []string  `json:"options,omitempty"`
+	Pattern    *string   `json:"pattern,omitempty"`
+	Min        *float64  `json:"min,omitempty"`
+	Max        *float64  `json:"max,omitempty"`
+	SortOrder  int       `json:"sort_order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func ToCustomFieldResponseDTO(definition *domain.CustomFieldDefinition) *CustomFieldResponseDTO {
+	return &CustomFieldResponseDTO{
+		ID:         definition.ID,
+		Key:        definition.Key,
+		Label:      definition.Label,
+		Type:       string(definition.Type),
+		Visibility: string(definition.Visibility),
+		Required:   definition.Required,
+		Options:    definition.Options,
+		Pattern:    definition.Pattern,
+		Min:        definition.Min,
+		Max:        definition.Max,
+		SortOrder:  definition.SortOrder,
+		CreatedAt:  definition.CreatedAt,
+		UpdatedAt:  definition.UpdatedAt,
+	}
+}
+
+func ToCustomFieldResponseDTOList(definitions []*domain.CustomFieldDefinition) []*CustomFieldResponseDTO {
+	dtos := make([]*CustomFieldResponseDTO, len(definitions))
+	for i, definition := range definitions {
+		dtos[i] = ToCustomFieldResponseDTO(definition)
+	}
+	return dtos
+}
@@ -0,0 +1,29 @@
+package department
+
+import "time"
+
+type DepartmentResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  *uint     `json:"parent_id"`
+	HrID      uint      `json:"hr_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrgChartNodeResponse is a department in the org chart. Headcount counts the active employees
+// assigned to the department itself, TotalHeadcount also those of every sub-department.
+type OrgChartNodeResponse struct {
+	ID             uint                    `json:"id"`
+	Name           string                  `json:"name"`
+	ParentID       *uint                   `json:"parent_id"`
+	Headcount      int64                   `json:"headcount"`
+	TotalHeadcount int64                   `json:"total_headcount"`
+	Children       []*OrgChartNodeResponse `json:"children"`
+}
+
+type OrgChartResponse struct {
+	Departments         []*OrgChartNodeResponse `json:"departments"`
+	UnassignedHeadcount int64                   `json:"unassigned_headcount"`
+	TotalHeadcount      int64                   `json:"total_headcount"`
+}
@@ -0,0 +1,91 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// DuplicateEmployeeDTO is one employee of a duplicate pair, with the fields reviewers compare.
+type DuplicateEmployeeDTO struct {
+	ID               uint    `json:"id"`
+	FirstName        string  `json:"first_name"`
+	LastName         *string `json:"last_name,omitempty"`
+	EmployeeCode     *string `json:"employee_code,omitempty"`
+	NIK              *string `json:"nik,omitempty"`
+	DateOfBirth      *string `json:"date_of_birth,omitempty"`
+	Email            string  `json:"email"`
+	Phone            string  `json:"phone,omitempty"`
+	PositionName     string  `json:"position_name"`
+	HireDate         *string `json:"hire_date,omitempty"`
+	EmploymentStatus bool    `json:"employment_status"`
+	CreatedAt        string  `json:"created_at"`
+}
+
+type EmployeeDuplicateResponseDTO struct {
+	ID         uint                     `json:"id"`
+	Score      int                      `json:"score"`
+	Signals    []domain.DuplicateSignal `json:"signals"`
+	Status     string                   `json:"status"`
+	Employee   DuplicateEmployeeDTO     `json:"employee"`
+	Duplicate  DuplicateEmployeeDTO     `json:"duplicate"`
+	DetectedAt time.Time                `json:"detected_at"`
+	ResolvedAt *time.Time               `json:"resolved_at,omitempty"`
+}
+
+type EmployeeDuplicateListResponseData struct {
+	Items      []*EmployeeDuplicateResponseDTO `json:"items"`
+	Pagination domain.Pagination               `json:"pagination"`
+}
+
+// DuplicateDetectionResultDTO summarises a duplicate detection run: the candidate pairs it scored
+// and those it flagged for review.
+type DuplicateDetectionResultDTO struct {
+	Scored  int `json:"scored"`
+	Flagged int `json:"flagged"`
+}
+
+func toDuplicateEmployeeDTO(employee *domain.Employee) DuplicateEmployeeDTO {
+	dto := DuplicateEmployeeDTO{
+		ID:               employee.ID,
+		FirstName:        employee.FirstName,
+		LastName:         employee.LastName,
+		EmployeeCode:     employee.EmployeeCode,
+		NIK:              employee.NIK,
+		Email:            employee.User.Email,
+		Phone:            employee.User.Phone,
+		PositionName:     employee.PositionName,
+		EmploymentStatus: employee.EmploymentStatus,
+		CreatedAt:        employee.CreatedAt.Format(time.RFC3339),
+	}
+	if employee.DateOfBirth != nil {
+		dateOfBirth := employee.DateOfBirth.Format("2006-01-02")
+		dto.DateOfBirth = &dateOfBirth
+	}
+	if employee.HireDate != nil {
+		hireDate := employee.HireDate.Format("2006-01-02")
+		dto.HireDate = &hireDate
+	}
+	return dto
+}
+
+func ToEmployeeDuplicateResponseDTO(duplicate *domain.EmployeeDuplicate) *EmployeeDuplicateResponseDTO {
+	return &EmployeeDuplicateResponseDTO{
+		ID:         duplicate.ID,
+		Score:      duplicate.Score,
+		Signals:    duplicate.Signals,
+		Status:     string(duplicate.Status),
+		Employee:   toDuplicateEmployeeDTO(&duplicate.Employee),
+		Duplicate:  toDuplicateEmployeeDTO(&duplicate.Duplicate),
+		DetectedAt: duplicate.DetectedAt,
+		ResolvedAt: duplicate.ResolvedAt,
+	}
+}
+
+func ToEmployeeDuplicateResponseDTOList(duplicates []*domain.EmployeeDuplicate) []*EmployeeDuplicateResponseDTO {
+	dtos := make([]*EmployeeDuplicateResponseDTO, len(duplicates))
+	for i, duplicate := range duplicates {
+		dtos[i] = ToEmployeeDuplicateResponseDTO(duplicate)
+	}
+	return dtos
+}
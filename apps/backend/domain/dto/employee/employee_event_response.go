@@ -0,0 +1,53 @@
+package employee
+
+import (
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type EmployeeEventResponseDTO struct {
+	Type            string  `json:"type"`
+	Date            string  `json:"date"`
+	EmployeeID      uint    `json:"employee_id"`
+	EmployeeName    string  `json:"employee_name"`
+	PositionName    string  `json:"position_name"`
+	ProfilePhotoURL *string `json:"profile_photo_url,omitempty"`
+	Years           int     `json:"years,omitempty"`
+}
+
+type EmployeeEventListResponseData struct {
+	From  string                      `json:"from"`
+	To    string                      `json:"to"`
+	Items []*EmployeeEventResponseDTO `json:"items"`
+}
+
+type EventPreferencesResponseDTO struct {
+	HidePersonalEvents bool `json:"hide_personal_events"`
+	EventDigest        bool `json:"event_digest"`
+}
+
+type EventDigestResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Managers      int    `json:"managers"`
+	Sent          int    `json:"sent"`
+	Failed        int    `json:"failed"`
+}
+
+func ToEmployeeEventResponseDTO(event *domain.EmployeeEvent) *EmployeeEventResponseDTO {
+	return &EmployeeEventResponseDTO{
+		Type:            string(event.Type),
+		Date:            event.Date.Format("2006-01-02"),
+		EmployeeID:      event.Employee.ID,
+		EmployeeName:    event.Employee.FullName(),
+		PositionName:    event.Employee.PositionName,
+		ProfilePhotoURL: event.Employee.ProfilePhotoURL,
+		Years:           event.Years,
+	}
+}
+
+func ToEmployeeEventResponseDTOList(events []*domain.EmployeeEvent) []*EmployeeEventResponseDTO {
+	dtos := make([]*EmployeeEventResponseDTO, len(events))
+	for i, event := range events {
+		dtos[i] = ToEmployeeEventResponseDTO(event)
+	}
+	return dtos
+}
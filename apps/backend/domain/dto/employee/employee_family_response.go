@@ -0,0 +1,97 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type FamilyMemberResponseDTO struct {
+	ID                             uint    `json:"id"`
+	Name                           string  `json:"name"`
+	Relationship                   string  `json:"relationship"`
+	NIK                            *string `json:"nik,omitempty"`
+	DateOfBirth                    *string `json:"date_of_birth,omitempty"`
+	TaxDependant                   bool    `json:"tax_dependant"`
+	BPJSKesehatan                  bool    `json:"bpjs_kesehatan"`
+	BPJSKetenagakerjaanBeneficiary bool    `json:"bpjs_ketenagakerjaan_beneficiary"`
+}
+
+// TaxDependantsDTO compares the dependants declared by the tax status of an employee with those
+// recorded in the family.
+type TaxDependantsDTO struct {
+	TaxStatus *string `json:"tax_status,omitempty"`
+	Declared  int     `json:"declared"`
+	Recorded  int     `json:"recorded"`
+	Matches   bool    `json:"matches"`
+}
+
+type FamilyResponseDTO struct {
+	Members       []*FamilyMemberResponseDTO `json:"members"`
+	TaxDependants TaxDependantsDTO           `json:"tax_dependants"`
+}
+
+type EmergencyContactResponseDTO struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	Relationship string    `json:"relationship"`
+	Phone        string    `json:"phone"`
+	Address      *string   `json:"address,omitempty"`
+	IsPrimary    bool      `json:"is_primary"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func ToFamilyMemberResponseDTO(member *domain.FamilyMember) *FamilyMemberResponseDTO {
+	dto := &FamilyMemberResponseDTO{
+		ID:                             member.ID,
+		Name:                           member.Name,
+		Relationship:                   string(member.Relationship),
+		NIK:                            member.NIK,
+		TaxDependant:                   member.TaxDependant,
+		BPJSKesehatan:                  member.BPJSKesehatan,
+		BPJSKetenagakerjaanBeneficiary: member.BPJSKetenagakerjaanBeneficiary,
+	}
+	if member.DateOfBirth != nil {
+		dateOfBirth := member.DateOfBirth.Format("2006-01-02")
+		dto.DateOfBirth = &dateOfBirth
+	}
+	return dto
+}
+
+func ToFamilyResponseDTO(members []*domain.FamilyMember, taxStatus *enums.TaxStatus) *FamilyResponseDTO {
+	dtos := make([]*FamilyMemberResponseDTO, len(members))
+	for i, member := range members {
+		dtos[i] = ToFamilyMemberResponseDTO(member)
+	}
+
+	dependants := TaxDependantsDTO{Recorded: domain.CountTaxDependants(members)}
+	if taxStatus != nil {
+		status := string(*taxStatus)
+		dependants.TaxStatus = &status
+		dependants.Declared = taxStatus.Dependants()
+	}
+	dependants.Matches = domain.CheckTaxDependants(taxStatus, dependants.Recorded) == nil
+
+	return &FamilyResponseDTO{Members: dtos, TaxDependants: dependants}
+}
+
+func ToEmergencyContactResponseDTO(contact *domain.EmergencyContact) *EmergencyContactResponseDTO {
+	return &EmergencyContactResponseDTO{
+		ID:           contact.ID,
+		Name:         contact.Name,
+		Relationship: contact.Relationship,
+		Phone:        contact.Phone,
+		Address:      contact.Address,
+		IsPrimary:    contact.IsPrimary,
+		UpdatedAt:    contact.UpdatedAt,
+	}
+}
+
+func ToEmergencyContactResponseDTOList(contacts []*domain.EmergencyContact) []*EmergencyContactResponseDTO {
+	dtos := make([]*EmergencyContactResponseDTO, len(contacts))
+	for i, contact := range contacts {
+		dtos[i] = ToEmergencyContactResponseDTO(contact)
+	}
+	return dtos
+}
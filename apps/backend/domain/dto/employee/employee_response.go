@@ -17,6 +17,12 @@ type EmployeeResponseDTO struct {
 	EmployeeCode          *string                                `json:"employee_code,omitempty"`
 	Branch                *string                                `json:"branch,omitempty"`
 	PositionName          string                                 `json:"position_name"`
+	DepartmentID          *uint                                  `json:"department_id,omitempty"`
+	DepartmentName        *string                                `json:"department_name,omitempty"`
+	BranchID              *uint                                  `json:"branch_id,omitempty"`
+	PositionID            *uint                                  `json:"position_id,omitempty"`
+	ManagerID             *uint                                  `json:"manager_id,omitempty"`
+	ManagerName           *string                                `json:"manager_name,omitempty"`
 	Gender                *string                                `json:"gender,omitempty"`
 	NIK                   *string                                `json:"nik,omitempty"`
 	PlaceOfBirth          *string                                `json:"place_of_birth,omitempty"`
@@ -30,10 +36,18 @@ type EmployeeResponseDTO struct {
 	BankName              *string                                `json:"bank_name,omitempty"`
 	BankAccountNumber     *string                                `json:"bank_account_number,omitempty"`
 	BankAccountHolderName *string                                `json:"bank_account_holder_name,omitempty"`
+	BaseSalary            *float64                               `json:"base_salary,omitempty"`
 	TaxStatus             *string                                `json:"tax_status,omitempty"`
 	WorkScheduleID        *uint                                  `json:"work_schedule_id,omitempty"`
 	WorkSchedule          *work_schedule.WorkScheduleResponseDTO `json:"work_schedule,omitempty"`
 	ProfilePhotoURL       *string                                `json:"profile_photo_url,omitempty"`
+	AbsenceType           *string                                `json:"absence_type,omitempty"`
+	AbsenceStartDate      *string                                `json:"absence_start_date,omitempty"`
+	AbsenceEndDate        *string                                `json:"absence_end_date,omitempty"`
+	HidePersonalEvents    bool                                   `json:"hide_personal_events"`
+	EventDigest           bool                                   `json:"event_digest"`
+	CustomFields          map[string]interface{}                 `json:"custom_fields,omitempty"`
+	EmploymentHistory     []*EmploymentEventResponseDTO          `json:"employment_history,omitempty"`
 	CreatedAt             string                                 `json:"created_at"`
 	UpdatedAt             string                                 `json:"updated_at"`
 }
@@ -91,6 +105,10 @@ func (dto *EmployeeResponseDTO) FromDomain(employee *domain.Employee) *EmployeeR
 		LastName:              employee.LastName,
 		EmployeeCode:          employee.EmployeeCode,
 		PositionName:          employee.PositionName,
+		DepartmentID:          employee.DepartmentID,
+		BranchID:              employee.BranchID,
+		PositionID:            employee.PositionID,
+		ManagerID:             employee.ManagerID,
 		Branch:                employee.Branch,
 		Gender:                genderDTO,
 		NIK:                   employee.NIK,
@@ -100,9 +118,13 @@ func (dto *EmployeeResponseDTO) FromDomain(employee *domain.Employee) *EmployeeR
 		BankName:              employee.BankName,
 		BankAccountNumber:     employee.BankAccountNumber,
 		BankAccountHolderName: employee.BankAccountHolderName,
+		BaseSalary:            employee.BaseSalary,
 		ProfilePhotoURL:       employee.ProfilePhotoURL,
+		HidePersonalEvents:    employee.HidePersonalEvents,
+		EventDigest:           employee.EventDigest,
 		CreatedAt:             employee.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:             employee.UpdatedAt.Format(time.RFC3339),
+		CustomFields:          employee.CustomFields,
 	}
 
 	if employee.LastEducation != nil {
@@ -129,6 +151,28 @@ func (dto *EmployeeResponseDTO) FromDomain(employee *domain.Employee) *EmployeeR
 		resignationDateStr := employee.ResignationDate.Format("2006-01-02")
 		responseDTO.ResignationDate = &resignationDateStr
 	}
+	if employee.Department != nil {
+		responseDTO.DepartmentName = &employee.Department.Name
+	}
+	if employee.Manager != nil {
+		managerName := employee.Manager.FirstName
+		if employee.Manager.LastName != nil {
+			managerName += " " + *employee.Manager.LastName
+		}
+		responseDTO.ManagerName = &managerName
+	}
+	if employee.AbsenceType != nil {
+		absenceTypeStr := string(*employee.AbsenceType)
+		responseDTO.AbsenceType = &absenceTypeStr
+	}
+	if employee.AbsenceStartDate != nil {
+		absenceStartDateStr := employee.AbsenceStartDate.Format("2006-01-02")
+		responseDTO.AbsenceStartDate = &absenceStartDateStr
+	}
+	if employee.AbsenceEndDate != nil {
+		absenceEndDateStr := employee.AbsenceEndDate.Format("2006-01-02")
+		responseDTO.AbsenceEndDate = &absenceEndDateStr
+	}
 	if employee.WorkScheduleID != nil {
 		responseDTO.WorkScheduleID = employee.WorkScheduleID
 	}
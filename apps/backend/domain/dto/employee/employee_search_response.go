@@ -0,0 +1,24 @@
+package employee
+
+// EmployeeSearchResultDTO is an employee matching a typeahead search. Highlights hold the fields
+// the query matched, HTML-escaped with the matching parts wrapped in <mark> tags.
+type EmployeeSearchResultDTO struct {
+	ID               uint              `json:"id"`
+	FirstName        string            `json:"first_name"`
+	LastName         *string           `json:"last_name,omitempty"`
+	EmployeeCode     *string           `json:"employee_code,omitempty"`
+	NIK              *string           `json:"nik,omitempty"`
+	Email            string            `json:"email"`
+	PositionName     string            `json:"position_name"`
+	Branch           *string           `json:"branch,omitempty"`
+	ProfilePhotoURL  *string           `json:"profile_photo_url,omitempty"`
+	EmploymentStatus bool              `json:"employment_status"`
+	Rank             float64           `json:"rank"`
+	Highlights       map[string]string `json:"highlights"`
+}
+
+type EmployeeSearchResponseData struct {
+	Query  string                     `json:"query"`
+	Items  []*EmployeeSearchResultDTO `json:"items"`
+	TookMs int64                      `json:"took_ms"`
+}
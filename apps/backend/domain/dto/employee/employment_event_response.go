@@ -0,0 +1,105 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type EmploymentEventResponseDTO struct {
+	ID                   uint     `json:"id"`
+	EmployeeID           uint     `json:"employee_id"`
+	EventType            string   `json:"event_type"`
+	EffectiveDate        string   `json:"effective_date"`
+	Reason               *string  `json:"reason,omitempty"`
+	PreviousPositionName *string  `json:"previous_position_name,omitempty"`
+	NewPositionName      *string  `json:"new_position_name,omitempty"`
+	PreviousGrade        *string  `json:"previous_grade,omitempty"`
+	NewGrade             *string  `json:"new_grade,omitempty"`
+	PreviousBranch       *string  `json:"previous_branch,omitempty"`
+	NewBranch            *string  `json:"new_branch,omitempty"`
+	PreviousDepartmentID *uint    `json:"previous_department_id,omitempty"`
+	NewDepartmentID      *uint    `json:"new_department_id,omitempty"`
+	PreviousContractType *string  `json:"previous_contract_type,omitempty"`
+	NewContractType      *string  `json:"new_contract_type,omitempty"`
+	PreviousBaseSalary   *float64 `json:"previous_base_salary,omitempty"`
+	NewBaseSalary        *float64 `json:"new_base_salary,omitempty"`
+	Scheduled            bool     `json:"scheduled"`
+	RecordedBy           *uint    `json:"recorded_by,omitempty"`
+	CreatedAt            string   `json:"created_at"`
+}
+
+type TenureBucketDTO struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+type TenureReportResponseDTO struct {
+	AsOf                string            `json:"as_of"`
+	ActiveEmployees     int               `json:"active_employees"`
+	WithoutHireDate     int               `json:"without_hire_date"`
+	AverageTenureMonths float64           `json:"average_tenure_months"`
+	Buckets             []TenureBucketDTO `json:"buckets"`
+}
+
+type TurnoverReportResponseDTO struct {
+	StartDate      string  `json:"start_date"`
+	EndDate        string  `json:"end_date"`
+	HeadcountStart int     `json:"headcount_start"`
+	HeadcountEnd   int     `json:"headcount_end"`
+	Hires          int     `json:"hires"`
+	Resignations   int     `json:"resignations"`
+	Promotions     int     `json:"promotions"`
+	Demotions      int     `json:"demotions"`
+	Transfers      int     `json:"transfers"`
+	TurnoverRate   float64 `json:"turnover_rate"`
+}
+
+// EmploymentEventRunResultDTO is the outcome of applying the scheduled employment events due on a day.
+type EmploymentEventRunResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Due           int    `json:"due"`
+	Applied       int    `json:"applied"`
+	Failed        int    `json:"failed"`
+}
+
+// ToEmploymentEventResponseDTO converts a domain EmploymentEvent to EmploymentEventResponseDTO
+func ToEmploymentEventResponseDTO(event *domain.EmploymentEvent) *EmploymentEventResponseDTO {
+	responseDTO := &EmploymentEventResponseDTO{
+		ID:                   event.ID,
+		EmployeeID:           event.EmployeeID,
+		EventType:            string(event.EventType),
+		EffectiveDate:        event.EffectiveDate.Format("2006-01-02"),
+		Reason:               event.Reason,
+		PreviousPositionName: event.PreviousPositionName,
+		NewPositionName:      event.NewPositionName,
+		PreviousGrade:        event.PreviousGrade,
+		NewGrade:             event.NewGrade,
+		PreviousBranch:       event.PreviousBranch,
+		NewBranch:            event.NewBranch,
+		PreviousDepartmentID: event.PreviousDepartmentID,
+		NewDepartmentID:      event.NewDepartmentID,
+		PreviousBaseSalary:   event.PreviousBaseSalary,
+		NewBaseSalary:        event.NewBaseSalary,
+		Scheduled:            event.Scheduled,
+		RecordedBy:           event.RecordedBy,
+		CreatedAt:            event.CreatedAt.Format(time.RFC3339),
+	}
+	if event.PreviousContractType != nil {
+		previousContractType := string(*event.PreviousContractType)
+		responseDTO.PreviousContractType = &previousContractType
+	}
+	if event.NewContractType != nil {
+		newContractType := string(*event.NewContractType)
+		responseDTO.NewContractType = &newContractType
+	}
+	return responseDTO
+}
+
+func ToEmploymentEventResponseDTOList(events []*domain.EmploymentEvent) []*EmploymentEventResponseDTO {
+	responseDTOs := make([]*EmploymentEventResponseDTO, len(events))
+	for i, event := range events {
+		responseDTOs[i] = ToEmploymentEventResponseDTO(event)
+	}
+	return responseDTOs
+}
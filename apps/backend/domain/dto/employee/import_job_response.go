@@ -0,0 +1,52 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ImportJobResponseDTO struct {
+	ID            uint       `json:"id"`
+	FileName      string     `json:"file_name"`
+	Status        string     `json:"status"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	SucceededRows int        `json:"succeeded_rows"`
+	FailedRows    int        `json:"failed_rows"`
+	Error         *string    `json:"error"`
+	CreatedBy     uint       `json:"created_by"`
+	StartedAt     *time.Time `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type ImportJobListResponseData struct {
+	Items      []*ImportJobResponseDTO `json:"items"`
+	Pagination domain.Pagination       `json:"pagination"`
+}
+
+func ToImportJobResponseDTO(job *domain.ImportJob) *ImportJobResponseDTO {
+	return &ImportJobResponseDTO{
+		ID:            job.ID,
+		FileName:      job.FileName,
+		Status:        string(job.Status),
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		Error:         job.Error,
+		CreatedBy:     job.CreatedBy,
+		StartedAt:     job.StartedAt,
+		CompletedAt:   job.CompletedAt,
+		CreatedAt:     job.CreatedAt,
+	}
+}
+
+func ToImportJobResponseDTOList(jobs []*domain.ImportJob) []*ImportJobResponseDTO {
+	dtos := make([]*ImportJobResponseDTO, len(jobs))
+	for i, job := range jobs {
+		dtos[i] = ToImportJobResponseDTO(job)
+	}
+	return dtos
+}
@@ -0,0 +1,48 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ImportMappingProfileResponseDTO struct {
+	ID        uint                         `json:"id"`
+	Name      string                       `json:"name"`
+	Mappings  []domain.ImportColumnMapping `json:"mappings"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+}
+
+// ImportMappingSuggestionDTO is a suggested mapping of a sample file column. Samples are the
+// first values of the column.
+type ImportMappingSuggestionDTO struct {
+	domain.ImportColumnMapping
+	Samples []string `json:"samples"`
+}
+
+// ImportMappingDetectionResponseDTO suggests how the columns of a sample file map to the import
+// fields.
+type ImportMappingDetectionResponseDTO struct {
+	Mappings              []ImportMappingSuggestionDTO `json:"mappings"`
+	UnmappedHeaders       []string                     `json:"unmapped_headers"`
+	MissingRequiredFields []string                     `json:"missing_required_fields"`
+}
+
+func ToImportMappingProfileResponseDTO(profile *domain.ImportMappingProfile) *ImportMappingProfileResponseDTO {
+	return &ImportMappingProfileResponseDTO{
+		ID:        profile.ID,
+		Name:      profile.Name,
+		Mappings:  profile.Mappings,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+	}
+}
+
+func ToImportMappingProfileResponseDTOList(profiles []*domain.ImportMappingProfile) []*ImportMappingProfileResponseDTO {
+	dtos := make([]*ImportMappingProfileResponseDTO, len(profiles))
+	for i, profile := range profiles {
+		dtos[i] = ToImportMappingProfileResponseDTO(profile)
+	}
+	return dtos
+}
@@ -0,0 +1,130 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type OffboardingTaskResponseDTO struct {
+	ID           uint       `json:"id"`
+	Title        string     `json:"title"`
+	AssigneeID   *uint      `json:"assignee_id"`
+	AssigneeName *string    `json:"assignee_name"`
+	DueDate      string     `json:"due_date"`
+	Completed    bool       `json:"completed"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	CompletedBy  *uint      `json:"completed_by"`
+}
+
+type OffboardingResponseDTO struct {
+	ID              uint                          `json:"id"`
+	EmployeeID      uint                          `json:"employee_id"`
+	ResignationType string                        `json:"resignation_type"`
+	ExitReason      string                        `json:"exit_reason"`
+	Notes           *string                       `json:"notes"`
+	NoticeDate      string                        `json:"notice_date"`
+	LastWorkingDay  string                        `json:"last_working_day"`
+	EffectiveDate   string                        `json:"effective_date"`
+	Status          string                        `json:"status"`
+	InitiatedBy     uint                          `json:"initiated_by"`
+	CompletedAt     *time.Time                    `json:"completed_at"`
+	TasksCompleted  int                           `json:"tasks_completed"`
+	TasksTotal      int                           `json:"tasks_total"`
+	Tasks           []*OffboardingTaskResponseDTO `json:"tasks"`
+	CreatedAt       time.Time                     `json:"created_at"`
+}
+
+type OffboardingRunResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Due           int    `json:"due"`
+	Completed     int    `json:"completed"`
+	Failed        int    `json:"failed"`
+}
+
+// LeaveSettlementDTO is the annual leave of a leaver in the year of their last working day.
+type LeaveSettlementDTO struct {
+	Year             int     `json:"year"`
+	EntitledDays     int     `json:"entitled_days"`
+	UsedDays         int     `json:"used_days"`
+	RemainingDays    int     `json:"remaining_days"`
+	DailyRate        float64 `json:"daily_rate"`
+	EncashmentAmount float64 `json:"encashment_amount"`
+}
+
+// FinalSettlementResponseDTO summarises what is owed to a leaver: the salary of the final month up
+// to the last working day and the payout of their remaining annual leave.
+type FinalSettlementResponseDTO struct {
+	EmployeeID       uint                          `json:"employee_id"`
+	EmployeeName     string                        `json:"employee_name"`
+	ResignationType  string                        `json:"resignation_type"`
+	LastWorkingDay   string                        `json:"last_working_day"`
+	BaseSalary       *float64                      `json:"base_salary"`
+	DaysInFinalMonth int                           `json:"days_in_final_month"`
+	DaysWorked       int                           `json:"days_worked"`
+	ProratedSalary   float64                       `json:"prorated_salary"`
+	LeaveBalance     *LeaveSettlementDTO           `json:"leave_balance"`
+	TotalPayable     float64                       `json:"total_payable"`
+	OutstandingTasks []*OffboardingTaskResponseDTO `json:"outstanding_tasks"`
+}
+
+type ExitCountDTO struct {
+	Key        string  `json:"key"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+type ExitReasonReportResponseDTO struct {
+	StartDate                 string          `json:"start_date"`
+	EndDate                   string          `json:"end_date"`
+	TotalExits                int             `json:"total_exits"`
+	ByResignationType         []*ExitCountDTO `json:"by_resignation_type"`
+	ByExitReason              []*ExitCountDTO `json:"by_exit_reason"`
+	AverageTenureMonthsAtExit float64         `json:"average_tenure_months_at_exit"`
+}
+
+func ToOffboardingTaskResponseDTO(task *domain.OffboardingTask) *OffboardingTaskResponseDTO {
+	dto := &OffboardingTaskResponseDTO{
+		ID:          task.ID,
+		Title:       task.Title,
+		AssigneeID:  task.AssigneeID,
+		DueDate:     task.DueDate.Format("2006-01-02"),
+		Completed:   task.CompletedAt != nil,
+		CompletedAt: task.CompletedAt,
+		CompletedBy: task.CompletedBy,
+	}
+	if task.Assignee != nil {
+		assigneeName := task.Assignee.FirstName
+		if task.Assignee.LastName != nil {
+			assigneeName += " " + *task.Assignee.LastName
+		}
+		dto.AssigneeName = &assigneeName
+	}
+	return dto
+}
+
+func ToOffboardingResponseDTO(offboarding *domain.Offboarding) *OffboardingResponseDTO {
+	dto := &OffboardingResponseDTO{
+		ID:              offboarding.ID,
+		EmployeeID:      offboarding.EmployeeID,
+		ResignationType: string(offboarding.ResignationType),
+		ExitReason:      string(offboarding.ExitReason),
+		Notes:           offboarding.Notes,
+		NoticeDate:      offboarding.NoticeDate.Format("2006-01-02"),
+		LastWorkingDay:  offboarding.LastWorkingDay.Format("2006-01-02"),
+		EffectiveDate:   offboarding.EffectiveDate().Format("2006-01-02"),
+		Status:          string(offboarding.Status),
+		InitiatedBy:     offboarding.InitiatedBy,
+		CompletedAt:     offboarding.CompletedAt,
+		TasksTotal:      len(offboarding.Tasks),
+		Tasks:           make([]*OffboardingTaskResponseDTO, 0, len(offboarding.Tasks)),
+		CreatedAt:       offboarding.CreatedAt,
+	}
+	for i := range offboarding.Tasks {
+		if offboarding.Tasks[i].CompletedAt != nil {
+			dto.TasksCompleted++
+		}
+		dto.Tasks = append(dto.Tasks, ToOffboardingTaskResponseDTO(&offboarding.Tasks[i]))
+	}
+	return dto
+}
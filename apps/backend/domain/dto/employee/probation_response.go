@@ -0,0 +1,117 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ProbationReviewResponseDTO struct {
+	ID              uint      `json:"id"`
+	ReviewerID      uint      `json:"reviewer_id"`
+	Outcome         string    `json:"outcome"`
+	Rating          *int      `json:"rating"`
+	Comments        *string   `json:"comments"`
+	PreviousEndDate string    `json:"previous_end_date"`
+	NewEndDate      *string   `json:"new_end_date"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ProbationResponseDTO struct {
+	ID              uint                          `json:"id"`
+	EmployeeID      uint                          `json:"employee_id"`
+	StartDate       string                        `json:"start_date"`
+	EndDate         string                        `json:"end_date"`
+	ReviewDueDate   string                        `json:"review_due_date"`
+	DaysUntilReview int                           `json:"days_until_review"`
+	Status          string                        `json:"status"`
+	Reviews         []*ProbationReviewResponseDTO `json:"reviews"`
+	CreatedAt       time.Time                     `json:"created_at"`
+
+	// Offboarding is the offboarding scheduled when the review terminated the employment.
+	Offboarding *OffboardingResponseDTO `json:"offboarding,omitempty"`
+}
+
+// ProbationReportItemDTO is an employee on probation in the probation report. DaysUntilReview is
+// negative when the review is overdue.
+type ProbationReportItemDTO struct {
+	ProbationID     uint    `json:"probation_id"`
+	EmployeeID      uint    `json:"employee_id"`
+	EmployeeName    string  `json:"employee_name"`
+	EmployeeCode    *string `json:"employee_code"`
+	PositionName    string  `json:"position_name"`
+	ManagerName     *string `json:"manager_name"`
+	StartDate       string  `json:"start_date"`
+	EndDate         string  `json:"end_date"`
+	ReviewDueDate   string  `json:"review_due_date"`
+	DaysUntilReview int     `json:"days_until_review"`
+	ReviewOverdue   bool    `json:"review_overdue"`
+}
+
+type ProbationReminderResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Checked       int    `json:"checked"`
+	Reminded      int    `json:"reminded"`
+	Failed        int    `json:"failed"`
+}
+
+func ToProbationResponseDTO(probation *domain.Probation, today time.Time) *ProbationResponseDTO {
+	reviews := make([]*ProbationReviewResponseDTO, len(probation.Reviews))
+	for i, review := range probation.Reviews {
+		dto := &ProbationReviewResponseDTO{
+			ID:              review.ID,
+			ReviewerID:      review.ReviewerID,
+			Outcome:         string(review.Outcome),
+			Rating:          review.Rating,
+			Comments:        review.Comments,
+			PreviousEndDate: review.PreviousEndDate.Format("2006-01-02"),
+			CreatedAt:       review.CreatedAt,
+		}
+		if review.NewEndDate != nil {
+			newEndDate := review.NewEndDate.Format("2006-01-02")
+			dto.NewEndDate = &newEndDate
+		}
+		reviews[i] = dto
+	}
+
+	return &ProbationResponseDTO{
+		ID:              probation.ID,
+		EmployeeID:      probation.EmployeeID,
+		StartDate:       probation.StartDate.Format("2006-01-02"),
+		EndDate:         probation.EndDate.Format("2006-01-02"),
+		ReviewDueDate:   probation.ReviewDueDate().Format("2006-01-02"),
+		DaysUntilReview: probation.DaysUntilReview(today),
+		Status:          string(probation.Status),
+		Reviews:         reviews,
+		CreatedAt:       probation.CreatedAt,
+	}
+}
+
+func ToProbationReportItemDTO(probation *domain.Probation, today time.Time) *ProbationReportItemDTO {
+	employee := &probation.Employee
+	employeeName := employee.FirstName
+	if employee.LastName != nil {
+		employeeName += " " + *employee.LastName
+	}
+
+	item := &ProbationReportItemDTO{
+		ProbationID:     probation.ID,
+		EmployeeID:      probation.EmployeeID,
+		EmployeeName:    employeeName,
+		EmployeeCode:    employee.EmployeeCode,
+		PositionName:    employee.PositionName,
+		StartDate:       probation.StartDate.Format("2006-01-02"),
+		EndDate:         probation.EndDate.Format("2006-01-02"),
+		ReviewDueDate:   probation.ReviewDueDate().Format("2006-01-02"),
+		DaysUntilReview: probation.DaysUntilReview(today),
+	}
+	item.ReviewOverdue = item.DaysUntilReview < 0
+	if manager := employee.Manager; manager != nil {
+		managerName := manager.FirstName
+		if manager.LastName != nil {
+			managerName += " " + *manager.LastName
+		}
+		item.ManagerName = &managerName
+	}
+	return item
+}
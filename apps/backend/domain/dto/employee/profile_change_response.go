@@ -0,0 +1,70 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ProfileChangePolicyResponseDTO struct {
+	Fields          []domain.ProfileField `json:"fields"`
+	AvailableFields []domain.ProfileField `json:"available_fields"`
+}
+
+type ProfileChangeResponseDTO struct {
+	ID           uint                                              `json:"id"`
+	EmployeeID   uint                                              `json:"employee_id"`
+	EmployeeName string                                            `json:"employee_name"`
+	Changes      map[domain.ProfileField]domain.ProfileFieldChange `json:"changes"`
+	Reason       *string                                           `json:"reason"`
+	DocumentURL  *string                                           `json:"document_url"`
+	HasDocument  bool                                              `json:"has_document"`
+	Status       string                                            `json:"status"`
+	ReviewedBy   *uint                                             `json:"reviewed_by"`
+	ReviewedAt   *time.Time                                        `json:"reviewed_at"`
+	ReviewNote   *string                                           `json:"review_note"`
+	CreatedAt    time.Time                                         `json:"created_at"`
+}
+
+type ProfileChangeListResponseData struct {
+	Items      []*ProfileChangeResponseDTO `json:"items"`
+	Pagination domain.Pagination           `json:"pagination"`
+}
+
+func ToProfileChangePolicyResponseDTO(policy *domain.ProfileChangePolicy) *ProfileChangePolicyResponseDTO {
+	return &ProfileChangePolicyResponseDTO{
+		Fields:          policy.Fields,
+		AvailableFields: domain.ProfileFields,
+	}
+}
+
+// ToProfileChangeResponseDTO maps a request without the URL of its supporting document, which is
+// private and only handed out signed.
+func ToProfileChangeResponseDTO(request *domain.ProfileChangeRequest) *ProfileChangeResponseDTO {
+	employeeName := request.Employee.FirstName
+	if request.Employee.LastName != nil {
+		employeeName += " " + *request.Employee.LastName
+	}
+
+	return &ProfileChangeResponseDTO{
+		ID:           request.ID,
+		EmployeeID:   request.EmployeeID,
+		EmployeeName: employeeName,
+		Changes:      request.Changes,
+		Reason:       request.Reason,
+		HasDocument:  request.DocumentURL != nil && *request.DocumentURL != "",
+		Status:       string(request.Status),
+		ReviewedBy:   request.ReviewedBy,
+		ReviewedAt:   request.ReviewedAt,
+		ReviewNote:   request.ReviewNote,
+		CreatedAt:    request.CreatedAt,
+	}
+}
+
+func ToProfileChangeResponseDTOList(requests []*domain.ProfileChangeRequest) []*ProfileChangeResponseDTO {
+	dtos := make([]*ProfileChangeResponseDTO, len(requests))
+	for i, request := range requests {
+		dtos[i] = ToProfileChangeResponseDTO(request)
+	}
+	return dtos
+}
@@ -0,0 +1,28 @@
+package leave_request
+
+// MonthlyBalanceDTO is one month of a projected annual leave balance.
+type MonthlyBalanceDTO struct {
+	Month          string  `json:"month"`
+	Accrued        float64 `json:"accrued"`
+	ApprovedDays   int     `json:"approved_days"`
+	PendingDays    int     `json:"pending_days"`
+	ExpiringDays   float64 `json:"expiring_days"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+type LeaveBalanceProjectionDTO struct {
+	EmployeeID           uint                 `json:"employee_id"`
+	AsOf                 string               `json:"as_of"`
+	AnnualLeaveAllowance uint                 `json:"annual_leave_allowance"`
+	CarriedOver          float64              `json:"carried_over"`
+	CurrentBalance       float64              `json:"current_balance"`
+	IncludePending       bool                 `json:"include_pending"`
+	Months               []*MonthlyBalanceDTO `json:"months"`
+}
+
+// LeaveBalancePreviewDTO shows what an annual leave request leaves the employee with once approved.
+type LeaveBalancePreviewDTO struct {
+	CurrentBalance       float64 `json:"current_balance"`
+	RequestedDays        int     `json:"requested_days"`
+	BalanceAfterApproval float64 `json:"balance_after_approval"`
+}
@@ -0,0 +1,38 @@
+package leave_request
+
+import "github.com/SukaMajuu/hris/apps/backend/domain"
+
+type LeaveEncashmentResponseDTO struct {
+	ID            uint    `json:"id"`
+	EmployeeID    uint    `json:"employee_id"`
+	EmployeeName  string  `json:"employee_name"`
+	PositionName  string  `json:"position_name"`
+	Reason        string  `json:"reason"`
+	Year          int     `json:"year"`
+	EntitledDays  int     `json:"entitled_days"`
+	UsedDays      int     `json:"used_days"`
+	CarryOverDays int     `json:"carry_over_days"`
+	EncashedDays  int     `json:"encashed_days"`
+	DailyRate     float64 `json:"daily_rate"`
+	Amount        float64 `json:"amount"`
+	Status        string  `json:"status"`
+	AdminNote     *string `json:"admin_note,omitempty"`
+	ReviewedBy    *uint   `json:"reviewed_by,omitempty"`
+	FinalizedAt   *string `json:"finalized_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type LeaveEncashmentListResponseData struct {
+	Items      []*LeaveEncashmentResponseDTO `json:"items"`
+	Pagination domain.Pagination             `json:"pagination"`
+}
+
+// EncashmentRunResultDTO summarises a year-end encashment run.
+type EncashmentRunResultDTO struct {
+	Year    int `json:"year"`
+	Checked int `json:"checked"`
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
@@ -0,0 +1,20 @@
+package leave_request
+
+type LeavePolicyResponseDTO struct {
+	SickCertificateRequiredAfterDays uint   `json:"sick_certificate_required_after_days"`
+	SickCertificateGraceDays         uint   `json:"sick_certificate_grace_days"`
+	SickCertificateFallbackType      string `json:"sick_certificate_fallback_type"`
+	ExcludeLongTermAbsenceFromSeats  bool   `json:"exclude_long_term_absence_from_seats"`
+	AnnualLeaveAccruesMonthly        bool   `json:"annual_leave_accrues_monthly"`
+	AnnualLeaveCarryOverCap          uint   `json:"annual_leave_carry_over_cap"`
+	EncashmentWorkingDaysPerMonth    uint   `json:"encashment_working_days_per_month"`
+	IsDefault                        bool   `json:"is_default"`
+}
+
+// CertificateConversionResultDTO summarises a run of the missing certificate job.
+type CertificateConversionResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Checked       int    `json:"checked"`
+	Converted     int    `json:"converted"`
+	Failed        int    `json:"failed"`
+}
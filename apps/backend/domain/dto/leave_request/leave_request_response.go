@@ -11,11 +11,18 @@ type LeaveRequestResponseDTO struct {
 	StartDate      string  `json:"start_date"`
 	EndDate        string  `json:"end_date"`
 	Attachment     *string `json:"attachment,omitempty"`
+	HasAttachment  bool    `json:"has_attachment"`
 	EmployeeNote   *string `json:"employee_note,omitempty"`
 	AdminNote      *string `json:"admin_note,omitempty"`
 	Status         string  `json:"status"`
+	CertificateStatus  string  `json:"certificate_status,omitempty"`
+	CertificateDueDate *string `json:"certificate_due_date,omitempty"`
+	OriginalLeaveType  *string `json:"original_leave_type,omitempty"`
 	CreatedAt      string  `json:"created_at"`
 	UpdatedAt      string  `json:"updated_at"`
+
+	StaffingConflicts []*StaffingConflictDTO   `json:"staffing_conflicts,omitempty"`
+	BalancePreview    *LeaveBalancePreviewDTO `json:"balance_preview,omitempty"`
 }
 
 type LeaveRequestListResponseData struct {
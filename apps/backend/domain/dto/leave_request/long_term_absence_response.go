@@ -0,0 +1,28 @@
+package leave_request
+
+type LongTermAbsenceResponseDTO struct {
+	EmployeeID        uint   `json:"employee_id"`
+	EmployeeName      string `json:"employee_name"`
+	PositionName      string `json:"position_name"`
+	AbsenceType       string `json:"absence_type"`
+	StartDate         string `json:"start_date"`
+	EndDate           string `json:"end_date"`
+	ExcludedFromSeats bool   `json:"excluded_from_seats"`
+	IsActive          bool   `json:"is_active"`
+}
+
+// UnpaidDaysResponseDTO lists the unpaid working days of one employee for payroll.
+type UnpaidDaysResponseDTO struct {
+	EmployeeID          uint   `json:"employee_id"`
+	EmployeeName        string `json:"employee_name"`
+	PositionName        string `json:"position_name"`
+	UnpaidLeaveDays     int    `json:"unpaid_leave_days"`
+	LongTermAbsenceDays int    `json:"long_term_absence_days"`
+	TotalUnpaidDays     int    `json:"total_unpaid_days"`
+}
+
+type UnpaidDaysReportDTO struct {
+	StartDate string                   `json:"start_date"`
+	EndDate   string                   `json:"end_date"`
+	Items     []*UnpaidDaysResponseDTO `json:"items"`
+}
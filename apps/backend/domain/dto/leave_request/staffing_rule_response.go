@@ -0,0 +1,36 @@
+package leave_request
+
+type StaffingRuleResponseDTO struct {
+	ID               uint     `json:"id"`
+	Name             string   `json:"name"`
+	Scope            string   `json:"scope"`
+	RootEmployeeID   *uint    `json:"root_employee_id,omitempty"`
+	RootEmployeeName string   `json:"root_employee_name,omitempty"`
+	Branch           *string  `json:"branch,omitempty"`
+	MaxAbsent        *uint    `json:"max_absent,omitempty"`
+	MaxAbsentPercent *float64 `json:"max_absent_percent,omitempty"`
+	IsActive         bool     `json:"is_active"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// StaffingConflictDTO describes one day on which a staffing rule would be breached.
+type StaffingConflictDTO struct {
+	RuleID          uint                       `json:"rule_id"`
+	RuleName        string                     `json:"rule_name"`
+	Date            string                     `json:"date"`
+	Headcount       int64                      `json:"headcount"`
+	AllowedAbsences int64                      `json:"allowed_absences"`
+	AbsentCount     int64                      `json:"absent_count"`
+	Colleagues      []*ConflictingColleagueDTO `json:"colleagues"`
+}
+
+type ConflictingColleagueDTO struct {
+	EmployeeID     uint   `json:"employee_id"`
+	EmployeeName   string `json:"employee_name"`
+	PositionName   string `json:"position_name"`
+	LeaveRequestID uint   `json:"leave_request_id"`
+	LeaveType      string `json:"leave_type"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+}
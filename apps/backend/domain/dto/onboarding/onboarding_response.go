@@ -0,0 +1,155 @@
+package onboarding
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type TemplateTaskResponseDTO struct {
+	ID          uint    `json:"id"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Owner       string  `json:"owner"`
+	DueDays     int     `json:"due_days"`
+	SortOrder   int     `json:"sort_order"`
+}
+
+type TemplateResponseDTO struct {
+	ID           uint                       `json:"id"`
+	Name         string                     `json:"name"`
+	DepartmentID *uint                      `json:"department_id"`
+	PositionID   *uint                      `json:"position_id"`
+	Tasks        []*TemplateTaskResponseDTO `json:"tasks"`
+	CreatedBy    uint                       `json:"created_by"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+}
+
+type TaskResponseDTO struct {
+	ID           uint       `json:"id"`
+	EmployeeID   uint       `json:"employee_id"`
+	EmployeeName *string    `json:"employee_name,omitempty"`
+	TemplateID   *uint      `json:"template_id"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description"`
+	Owner        string     `json:"owner"`
+	AssigneeID   *uint      `json:"assignee_id"`
+	AssigneeName *string    `json:"assignee_name"`
+	DueDate      string     `json:"due_date"`
+	Completed    bool       `json:"completed"`
+	Overdue      bool       `json:"overdue"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	CompletedBy  *uint      `json:"completed_by"`
+}
+
+// ChecklistResponseDTO is the onboarding checklist of a new hire with their progress.
+type ChecklistResponseDTO struct {
+	EmployeeID     uint               `json:"employee_id"`
+	TasksCompleted int                `json:"tasks_completed"`
+	TasksOverdue   int                `json:"tasks_overdue"`
+	TasksTotal     int                `json:"tasks_total"`
+	Progress       float64            `json:"progress"`
+	Tasks          []*TaskResponseDTO `json:"tasks"`
+}
+
+type ReminderResultDTO struct {
+	ProcessedDate string `json:"processed_date"`
+	Checked       int    `json:"checked"`
+	Reminded      int    `json:"reminded"`
+	Failed        int    `json:"failed"`
+}
+
+func employeeName(employee *domain.Employee) string {
+	name := employee.FirstName
+	if employee.LastName != nil {
+		name += " " + *employee.LastName
+	}
+	return name
+}
+
+func ToTemplateResponseDTO(template *domain.OnboardingTemplate) *TemplateResponseDTO {
+	tasks := make([]*TemplateTaskResponseDTO, len(template.Tasks))
+	for i, task := range template.Tasks {
+		tasks[i] = &TemplateTaskResponseDTO{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: task.Description,
+			Owner:       string(task.Owner),
+			DueDays:     task.DueDays,
+			SortOrder:   task.SortOrder,
+		}
+	}
+
+	return &TemplateResponseDTO{
+		ID:           template.ID,
+		Name:         template.Name,
+		DepartmentID: template.DepartmentID,
+		PositionID:   template.PositionID,
+		Tasks:        tasks,
+		CreatedBy:    template.CreatedBy,
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
+
+func ToTemplateResponseDTOList(templates []*domain.OnboardingTemplate) []*TemplateResponseDTO {
+	dtos := make([]*TemplateResponseDTO, len(templates))
+	for i, template := range templates {
+		dtos[i] = ToTemplateResponseDTO(template)
+	}
+	return dtos
+}
+
+func ToTaskResponseDTO(task *domain.OnboardingTask, today time.Time) *TaskResponseDTO {
+	dto := &TaskResponseDTO{
+		ID:          task.ID,
+		EmployeeID:  task.EmployeeID,
+		TemplateID:  task.TemplateID,
+		Title:       task.Title,
+		Description: task.Description,
+		Owner:       string(task.Owner),
+		AssigneeID:  task.AssigneeID,
+		DueDate:     task.DueDate.Format("2006-01-02"),
+		Completed:   task.CompletedAt != nil,
+		Overdue:     task.Overdue(today),
+		CompletedAt: task.CompletedAt,
+		CompletedBy: task.CompletedBy,
+	}
+	if task.Employee.ID != 0 {
+		name := employeeName(&task.Employee)
+		dto.EmployeeName = &name
+	}
+	if task.Assignee != nil {
+		name := employeeName(task.Assignee)
+		dto.AssigneeName = &name
+	}
+	return dto
+}
+
+func ToTaskResponseDTOList(tasks []*domain.OnboardingTask, today time.Time) []*TaskResponseDTO {
+	dtos := make([]*TaskResponseDTO, len(tasks))
+	for i, task := range tasks {
+		dtos[i] = ToTaskResponseDTO(task, today)
+	}
+	return dtos
+}
+
+func ToChecklistResponseDTO(employeeID uint, tasks []*domain.OnboardingTask, today time.Time) *ChecklistResponseDTO {
+	checklist := &ChecklistResponseDTO{
+		EmployeeID: employeeID,
+		TasksTotal: len(tasks),
+		Tasks:      ToTaskResponseDTOList(tasks, today),
+	}
+	for _, task := range checklist.Tasks {
+		if task.Completed {
+			checklist.TasksCompleted++
+		} else if task.Overdue {
+			checklist.TasksOverdue++
+		}
+	}
+	if checklist.TasksTotal > 0 {
+		checklist.Progress = float64(checklist.TasksCompleted*10000/checklist.TasksTotal) / 100
+	}
+	return checklist
+}
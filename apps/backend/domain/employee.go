@@ -11,10 +11,18 @@ type Employee struct {
 	ID               uint   `gorm:"primaryKey"`
 	UserID           uint   `gorm:"not null"`
 	User             User   `gorm:"foreignKey:UserID"`
+	CompanyID        *uint  `gorm:"index"`
 	FirstName        string `gorm:"type:varchar(255);not null"`
 	PositionName     string `gorm:"type:varchar(255)"`
 	EmploymentStatus bool   `gorm:"type:boolean;default:true;not null"`
 
+	// Organisational assignment. Branch and PositionName below mirror the names of the
+	// assigned branch and position.
+	DepartmentID *uint       `gorm:"index"`
+	Department   *Department `gorm:"foreignKey:DepartmentID"`
+	BranchID     *uint       `gorm:"index"`
+	PositionID   *uint       `gorm:"index"`
+
 	// Work Schedule Relationship
 	WorkScheduleID *uint         `gorm:"type:index"`
 	WorkSchedule   *WorkSchedule `gorm:"foreignKey:WorkScheduleID"`
@@ -43,9 +51,25 @@ type Employee struct {
 	BankName              *string               `gorm:"type:varchar(100)"`
 	BankAccountNumber     *string               `gorm:"type:varchar(100)"`
 	BankAccountHolderName *string               `gorm:"type:varchar(255)"`
+	BaseSalary            *float64              `gorm:"type:decimal(15,2)"`
 	TaxStatus             *enums.TaxStatus      `gorm:"type:tax_status"`
 	ProfilePhotoURL       *string               `gorm:"type:varchar(255)"`
 
+	// Long-term absence (sabbatical, suspension, extended leave)
+	AbsenceType              *enums.LongTermAbsenceType `gorm:"type:long_term_absence_type"`
+	AbsenceStartDate         *time.Time                 `gorm:"type:date"`
+	AbsenceEndDate           *time.Time                 `gorm:"type:date"`
+	AbsenceExcludedFromSeats bool                       `gorm:"type:boolean;default:false;not null"`
+
+	// Events feed preferences. HidePersonalEvents keeps the employee's birthday and work
+	// anniversary out of the events feed and digests; EventDigest subscribes a manager to the
+	// daily digest of the events in their reporting line.
+	HidePersonalEvents bool `gorm:"type:boolean;default:false;not null"`
+	EventDigest        bool `gorm:"type:boolean;default:false;not null"`
+
+	// Company-defined custom fields, keyed by CustomFieldDefinition.Key
+	CustomFields map[string]interface{} `gorm:"type:jsonb;serializer:json"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
@@ -53,3 +77,20 @@ type Employee struct {
 func (a *Employee) TableName() string {
 	return "employees"
 }
+
+// IsOnLongTermAbsence reports whether the employee is on a long-term absence on the given date.
+func (a *Employee) IsOnLongTermAbsence(date time.Time) bool {
+	if a.AbsenceType == nil || a.AbsenceStartDate == nil {
+		return false
+	}
+	day := date.Format("2006-01-02")
+	if day < a.AbsenceStartDate.Format("2006-01-02") {
+		return false
+	}
+	return a.AbsenceEndDate == nil || day <= a.AbsenceEndDate.Format("2006-01-02")
+}
+
+// IsExcludedFromSeats reports whether the employee does not count towards subscription seats on the given date.
+func (a *Employee) IsExcludedFromSeats(date time.Time) bool {
+	return a.AbsenceExcludedFromSeats && a.IsOnLongTermAbsence(date)
+}
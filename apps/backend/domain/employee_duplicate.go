@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type EmployeeDuplicateStatus string
+
+const (
+	EmployeeDuplicatePending   EmployeeDuplicateStatus = "pending"
+	EmployeeDuplicateDismissed EmployeeDuplicateStatus = "dismissed"
+)
+
+// DuplicateSignal is a reason two employees look like the same person.
+type DuplicateSignal string
+
+const (
+	DuplicateSignalName        DuplicateSignal = "name"
+	DuplicateSignalDateOfBirth DuplicateSignal = "date_of_birth"
+	DuplicateSignalNIK         DuplicateSignal = "nik"
+	DuplicateSignalPhone       DuplicateSignal = "phone"
+)
+
+// EmployeeDuplicateThreshold is the score from which a pair of employees is flagged for review. A
+// shared name alone stays below it; a similar name with the same birth date, NIK or phone reaches
+// it.
+const EmployeeDuplicateThreshold = 50
+
+// The weights of the signals, adding up to a score of 100. The name weight is scaled by how
+// similar the names are, and a NIK one typo apart scores a little less than an equal one.
+const (
+	duplicateNameWeight        = 40
+	duplicateDateOfBirthWeight = 20
+	duplicateNIKWeight         = 25
+	duplicateNIKTypoWeight     = 20
+	duplicatePhoneWeight       = 15
+
+	// duplicateMinNameSimilarity is the similarity from which names count as a signal.
+	duplicateMinNameSimilarity = 0.5
+)
+
+// EmployeeDuplicate is a pair of employees the duplicate detection job scored as likely the same
+// person, awaiting review. EmployeeID is always the lower of the two IDs so each pair is stored
+// once. Merging the pair deletes the duplicate employee together with every pair it was part of;
+// dismissed pairs are kept so later runs do not flag them again.
+type EmployeeDuplicate struct {
+	ID          uint                    `gorm:"primaryKey"`
+	CompanyID   *uint                   `gorm:"index"`
+	EmployeeID  uint                    `gorm:"not null;uniqueIndex:idx_employee_duplicate_pair"`
+	Employee    Employee                `gorm:"foreignKey:EmployeeID"`
+	DuplicateID uint                    `gorm:"not null;uniqueIndex:idx_employee_duplicate_pair;index"`
+	Duplicate   Employee                `gorm:"foreignKey:DuplicateID"`
+	Score       int                     `gorm:"type:int;not null"`
+	Signals     []DuplicateSignal       `gorm:"type:jsonb;serializer:json;not null"`
+	Status      EmployeeDuplicateStatus `gorm:"type:employee_duplicate_status;not null;default:'pending'"`
+	DetectedAt  time.Time               `gorm:"type:timestamp;not null"`
+
+	ResolvedBy *uint      `gorm:"type:uint"`
+	ResolvedAt *time.Time `gorm:"type:timestamp"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (d *EmployeeDuplicate) TableName() string {
+	return "employee_duplicates"
+}
+
+// Other returns the ID of the employee of the pair that is not id.
+func (d *EmployeeDuplicate) Other(id uint) uint {
+	if d.EmployeeID == id {
+		return d.DuplicateID
+	}
+	return d.EmployeeID
+}
+
+// ScoreEmployeeDuplicate scores from 0 to 100 how likely two employees are the same person, from
+// the similarity of their names and whether their birth dates, NIKs and phone numbers match. It
+// returns the signals that contributed to the score.
+func ScoreEmployeeDuplicate(a, b *Employee) (int, []DuplicateSignal) {
+	score := 0.0
+	var signals []DuplicateSignal
+
+	if similarity := NameSimilarity(a.FullName(), b.FullName()); similarity >= duplicateMinNameSimilarity {
+		score += duplicateNameWeight * similarity
+		signals = append(signals, DuplicateSignalName)
+	}
+
+	if a.DateOfBirth != nil && b.DateOfBirth != nil &&
+		a.DateOfBirth.Format("2006-01-02") == b.DateOfBirth.Format("2006-01-02") {
+		score += duplicateDateOfBirthWeight
+		signals = append(signals, DuplicateSignalDateOfBirth)
+	}
+
+	if a.NIK != nil && b.NIK != nil {
+		nikA, nikB := digitsOnly(*a.NIK), digitsOnly(*b.NIK)
+		if nikA != "" && nikA == nikB {
+			score += duplicateNIKWeight
+			signals = append(signals, DuplicateSignalNIK)
+		} else if len(nikA) > 8 && editDistance(nikA, nikB) == 1 {
+			score += duplicateNIKTypoWeight
+			signals = append(signals, DuplicateSignalNIK)
+		}
+	}
+
+	if phoneA := NormalizePhone(a.User.Phone); phoneA != "" && phoneA == NormalizePhone(b.User.Phone) {
+		score += duplicatePhoneWeight
+		signals = append(signals, DuplicateSignalPhone)
+	}
+
+	return int(math.Round(score)), signals
+}
+
+// FullName returns the first and last name of the employee.
+func (a *Employee) FullName() string {
+	if a.LastName == nil || *a.LastName == "" {
+		return a.FirstName
+	}
+	return a.FirstName + " " + *a.LastName
+}
+
+// NameSimilarity returns how similar two names are, from 0 to 1, as the Dice coefficient of the
+// letter pairs of their words. Case, punctuation and word order do not matter, and a typo costs
+// little.
+func NameSimilarity(a, b string) float64 {
+	pairsA, pairsB := namePairs(a), namePairs(b)
+	if len(pairsA) == 0 || len(pairsB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(pairsA))
+	for _, pair := range pairsA {
+		counts[pair]++
+	}
+	shared := 0
+	for _, pair := range pairsB {
+		if counts[pair] > 0 {
+			counts[pair]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(pairsA)+len(pairsB))
+}
+
+func namePairs(name string) []string {
+	var pairs []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i < len(runes)-1; i++ {
+			pairs = append(pairs, string(runes[i:i+2]))
+		}
+	}
+	return pairs
+}
+
+// NormalizePhone reduces an Indonesian phone number to its digits without the country code or
+// trunk prefix, so +62 812-3456 and 08123456 compare equal.
+func NormalizePhone(phone string) string {
+	digits := digitsOnly(phone)
+	switch {
+	case strings.HasPrefix(digits, "62"):
+		return digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		return digits[1:]
+	}
+	return digits
+}
+
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// editDistance returns the number of single character insertions, deletions, substitutions and
+// swaps of adjacent characters that turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+// MergeFrom fills the empty personal fields of the employee with those of a duplicate of the same
+// person before the duplicate is removed. The earlier hire date is kept so tenure is not reset.
+// Employment values, such as the position and salary, are left alone: they only change through an
+// employment event.
+func (a *Employee) MergeFrom(duplicate *Employee) {
+	fill := func(field **string, value *string) {
+		if (*field == nil || **field == "") && value != nil && *value != "" {
+			*field = value
+		}
+	}
+	fill(&a.LastName, duplicate.LastName)
+	fill(&a.EmployeeCode, duplicate.EmployeeCode)
+	fill(&a.NIK, duplicate.NIK)
+	fill(&a.PlaceOfBirth, duplicate.PlaceOfBirth)
+	fill(&a.BankName, duplicate.BankName)
+	fill(&a.BankAccountNumber, duplicate.BankAccountNumber)
+	fill(&a.BankAccountHolderName, duplicate.BankAccountHolderName)
+	fill(&a.ProfilePhotoURL, duplicate.ProfilePhotoURL)
+
+	if a.Gender == nil {
+		a.Gender = duplicate.Gender
+	}
+	if a.DateOfBirth == nil {
+		a.DateOfBirth = duplicate.DateOfBirth
+	}
+	if a.LastEducation == nil {
+		a.LastEducation = duplicate.LastEducation
+	}
+	if a.TaxStatus == nil {
+		a.TaxStatus = duplicate.TaxStatus
+	}
+	if a.WorkScheduleID == nil {
+		a.WorkScheduleID = duplicate.WorkScheduleID
+	}
+	if a.ManagerID != nil && *a.ManagerID == duplicate.ID {
+		a.ManagerID = nil
+	}
+	if a.ManagerID == nil && duplicate.ManagerID != nil && *duplicate.ManagerID != a.ID {
+		a.ManagerID = duplicate.ManagerID
+	}
+	if duplicate.HireDate != nil && (a.HireDate == nil || duplicate.HireDate.Before(*a.HireDate)) {
+		a.HireDate = duplicate.HireDate
+	}
+	for key, value := range duplicate.CustomFields {
+		if _, ok := a.CustomFields[key]; ok {
+			continue
+		}
+		if a.CustomFields == nil {
+			a.CustomFields = make(map[string]interface{})
+		}
+		a.CustomFields[key] = value
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// EmployeeEventType is the kind of date an employee event marks.
+type EmployeeEventType string
+
+const (
+	EmployeeEventBirthday        EmployeeEventType = "birthday"
+	EmployeeEventWorkAnniversary EmployeeEventType = "work_anniversary"
+	EmployeeEventContractEnd     EmployeeEventType = "contract_end"
+	EmployeeEventProbationEnd    EmployeeEventType = "probation_end"
+)
+
+const (
+	// DefaultEmployeeEventDays is how many days ahead the events feed looks by default.
+	DefaultEmployeeEventDays = 30
+	// MaxEmployeeEventDays caps how many days ahead the events feed can look.
+	MaxEmployeeEventDays = 90
+)
+
+// EmployeeEvent is an upcoming birthday, work anniversary or contract milestone of an employee.
+// Years is the number of years of service completed on a work anniversary.
+type EmployeeEvent struct {
+	Type     EmployeeEventType
+	Date     time.Time
+	Employee *Employee
+	Years    int
+}
+
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// nextYearly returns the first anniversary of date on or after from, together with the number of
+// years since date. February 29 falls on February 28 in other years.
+func nextYearly(date, from time.Time) (time.Time, int) {
+	date, from = calendarDate(date), calendarDate(from)
+	for year := from.Year(); ; year++ {
+		day := date.Day()
+		if date.Month() == time.February && day == 29 && !isLeapYear(year) {
+			day = 28
+		}
+		next := time.Date(year, date.Month(), day, 0, 0, 0, 0, time.UTC)
+		if !next.Before(from) {
+			return next, year - date.Year()
+		}
+	}
+}
+
+// PersonalEvents returns the birthday and work anniversary of the employee between from and to,
+// both included. Employees who hide their personal events have none; a hire date counts from the
+// first anniversary.
+func (a *Employee) PersonalEvents(from, to time.Time) []*EmployeeEvent {
+	if a.HidePersonalEvents {
+		return nil
+	}
+
+	to = calendarDate(to)
+	var events []*EmployeeEvent
+	if a.DateOfBirth != nil {
+		if date, _ := nextYearly(*a.DateOfBirth, from); !date.After(to) {
+			events = append(events, &EmployeeEvent{Type: EmployeeEventBirthday, Date: date, Employee: a})
+		}
+	}
+	if a.HireDate != nil {
+		if date, years := nextYearly(*a.HireDate, from); years > 0 && !date.After(to) {
+			events = append(events, &EmployeeEvent{Type: EmployeeEventWorkAnniversary, Date: date, Employee: a, Years: years})
+		}
+	}
+	return events
+}
+
+// InEventWindow reports whether date falls between from and to, both included, comparing calendar
+// dates only.
+func InEventWindow(date, from, to time.Time) bool {
+	date = calendarDate(date)
+	return !date.Before(calendarDate(from)) && !date.After(calendarDate(to))
+}
+
+// SortEmployeeEvents orders events by date, then by employee, keeping the order of the events of
+// one employee on the same day.
+func SortEmployeeEvents(events []*EmployeeEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].Employee.ID < events[j].Employee.ID
+	})
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+// FamilyRelationship is how a family member is related to the employee.
+type FamilyRelationship string
+
+const (
+	FamilySpouse      FamilyRelationship = "spouse"
+	FamilyChild       FamilyRelationship = "child"
+	FamilyParent      FamilyRelationship = "parent"
+	FamilyParentInLaw FamilyRelationship = "parent_in_law"
+	FamilySibling     FamilyRelationship = "sibling"
+	FamilyOther       FamilyRelationship = "other"
+)
+
+func (r FamilyRelationship) IsValid() bool {
+	switch r {
+	case FamilySpouse, FamilyChild, FamilyParent, FamilyParentInLaw, FamilySibling, FamilyOther:
+		return true
+	}
+	return false
+}
+
+// CanBeTaxDependant reports whether a family member can be declared a dependant (tanggungan) in
+// the tax status. Only relatives in a direct line by blood or marriage qualify; a spouse counts
+// towards the marital part of the status instead.
+func (r FamilyRelationship) CanBeTaxDependant() bool {
+	switch r {
+	case FamilyChild, FamilyParent, FamilyParentInLaw:
+		return true
+	}
+	return false
+}
+
+// CanJoinBPJSKesehatan reports whether a family member can be enrolled under the employee's BPJS
+// Kesehatan membership, either as a regular or as an additional family member.
+func (r FamilyRelationship) CanJoinBPJSKesehatan() bool {
+	switch r {
+	case FamilySpouse, FamilyChild, FamilyParent, FamilyParentInLaw:
+		return true
+	}
+	return false
+}
+
+// FamilyMember is a member of an employee's family. TaxDependant marks the dependants counted by
+// the employee's tax status. BPJSKesehatan marks members enrolled under the employee's BPJS
+// Kesehatan membership, and BPJSKetenagakerjaanBeneficiary those named as heirs for the BPJS
+// Ketenagakerjaan old-age and death benefits.
+type FamilyMember struct {
+	ID           uint               `gorm:"primaryKey"`
+	CompanyID    *uint              `gorm:"index"`
+	EmployeeID   uint               `gorm:"not null;index"`
+	Employee     Employee           `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
+	Name         string             `gorm:"type:varchar(255);not null"`
+	Relationship FamilyRelationship `gorm:"type:family_relationship;not null"`
+	NIK          *string            `gorm:"type:varchar(16)"`
+	DateOfBirth  *time.Time         `gorm:"type:date"`
+
+	TaxDependant                   bool `gorm:"type:boolean;default:false;not null"`
+	BPJSKesehatan                  bool `gorm:"type:boolean;default:false;not null"`
+	BPJSKetenagakerjaanBeneficiary bool `gorm:"type:boolean;default:false;not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (f *FamilyMember) TableName() string {
+	return "family_members"
+}
+
+// EmergencyContact is a person to reach when something happens to an employee. The primary
+// contact is called first.
+type EmergencyContact struct {
+	ID           uint     `gorm:"primaryKey"`
+	CompanyID    *uint    `gorm:"index"`
+	EmployeeID   uint     `gorm:"not null;index"`
+	Employee     Employee `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
+	Name         string   `gorm:"type:varchar(255);not null"`
+	Relationship string   `gorm:"type:varchar(100);not null"`
+	Phone        string   `gorm:"type:varchar(20);not null"`
+	Address      *string  `gorm:"type:text"`
+	IsPrimary    bool     `gorm:"type:boolean;default:false;not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (e *EmergencyContact) TableName() string {
+	return "emergency_contacts"
+}
+
+// CountTaxDependants returns the number of family members declared as tax dependants.
+func CountTaxDependants(members []*FamilyMember) int {
+	count := 0
+	for _, member := range members {
+		if member.TaxDependant {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckTaxDependants checks that a tax status declares as many dependants as are recorded. An
+// employee without a tax status declares none.
+func CheckTaxDependants(taxStatus *enums.TaxStatus, dependants int) error {
+	if taxStatus == nil {
+		if dependants > 0 {
+			return fmt.Errorf("%w: no tax status is set but %d dependants are recorded", ErrTaxDependantMismatch, dependants)
+		}
+		return nil
+	}
+	if declared := taxStatus.Dependants(); declared != dependants {
+		return fmt.Errorf("%w: %s declares %d dependants but %d are recorded", ErrTaxDependantMismatch, *taxStatus, declared, dependants)
+	}
+	return nil
+}
+
+// ValidateFamilyMembers checks the family of an employee: each member needs a name and a known
+// relationship, NIKs have 16 digits and are not shared, birth dates are not in the future and
+// there is at most one spouse. Tax dependants and BPJS Kesehatan enrolment are limited to the
+// relationships that qualify, and the dependants have to match the tax status.
+func ValidateFamilyMembers(members []*FamilyMember, taxStatus *enums.TaxStatus) error {
+	invalid := func(i int, format string, args ...interface{}) error {
+		return fmt.Errorf("%w: member %d %s", ErrInvalidFamilyMember, i+1, fmt.Sprintf(format, args...))
+	}
+
+	spouses := 0
+	niks := make(map[string]bool, len(members))
+	for i, member := range members {
+		member.Name = strings.TrimSpace(member.Name)
+		if member.Name == "" {
+			return invalid(i, "needs a name")
+		}
+		if !member.Relationship.IsValid() {
+			return invalid(i, "has an unknown relationship %q", member.Relationship)
+		}
+		if member.Relationship == FamilySpouse {
+			spouses++
+			if spouses > 1 {
+				return invalid(i, "is a second spouse")
+			}
+		}
+		if member.NIK != nil {
+			if nik := digitsOnly(*member.NIK); len(nik) != 16 || nik != *member.NIK {
+				return invalid(i, "has a NIK that is not 16 digits")
+			}
+			if niks[*member.NIK] {
+				return invalid(i, "has the NIK of another member")
+			}
+			niks[*member.NIK] = true
+		}
+		if member.DateOfBirth != nil && member.DateOfBirth.After(time.Now()) {
+			return invalid(i, "has a date of birth in the future")
+		}
+		if member.TaxDependant && !member.Relationship.CanBeTaxDependant() {
+			return invalid(i, "cannot be a tax dependant as %s", member.Relationship)
+		}
+		if member.BPJSKesehatan && !member.Relationship.CanJoinBPJSKesehatan() {
+			return invalid(i, "cannot be enrolled in BPJS Kesehatan as %s", member.Relationship)
+		}
+	}
+
+	return CheckTaxDependants(taxStatus, CountTaxDependants(members))
+}
+
+// ValidateEmergencyContacts checks the emergency contacts of an employee: each contact needs a
+// name, a relationship and a phone number, and at most one can be primary. When none is marked,
+// the first contact becomes the primary one.
+func ValidateEmergencyContacts(contacts []*EmergencyContact) error {
+	invalid := func(i int, format string, args ...interface{}) error {
+		return fmt.Errorf("%w: contact %d %s", ErrInvalidEmergencyContact, i+1, fmt.Sprintf(format, args...))
+	}
+
+	primary := -1
+	for i, contact := range contacts {
+		contact.Name = strings.TrimSpace(contact.Name)
+		contact.Relationship = strings.TrimSpace(contact.Relationship)
+		if contact.Name == "" {
+			return invalid(i, "needs a name")
+		}
+		if contact.Relationship == "" {
+			return invalid(i, "needs a relationship")
+		}
+		if len(digitsOnly(contact.Phone)) < 8 {
+			return invalid(i, "needs a phone number of at least 8 digits")
+		}
+		if contact.IsPrimary {
+			if primary >= 0 {
+				return invalid(i, "is a second primary contact")
+			}
+			primary = i
+		}
+	}
+
+	if primary < 0 && len(contacts) > 0 {
+		contacts[0].IsPrimary = true
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EmployeeSearchHit is an employee matching a search, with the relevance of the match. Higher
+// ranks are better matches.
+type EmployeeSearchHit struct {
+	Employee *Employee
+	Rank     float64
+}
+
+// EmployeeSearchScope narrows an employee search.
+type EmployeeSearchScope struct {
+	IncludeInactive bool
+	// ManagerID, when set, limits the search to the direct reports of the manager.
+	ManagerID *uint
+	// MatchNIK lets the search match and return NIKs, which only admins may see.
+	MatchNIK bool
+}
+
+// EmployeeSearchWords splits a search query into lower-case words of letters and digits, the
+// units names, codes and NIKs are matched on.
+func EmployeeSearchWords(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
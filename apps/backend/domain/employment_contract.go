@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type EmploymentContractStatus string
+
+const (
+	ContractStatusActive    EmploymentContractStatus = "active"
+	ContractStatusRenewed   EmploymentContractStatus = "renewed"
+	ContractStatusConverted EmploymentContractStatus = "converted"
+	ContractStatusEnded     EmploymentContractStatus = "ended"
+)
+
+// ContractExpiryReminderDays are the number of days before the end of a contract at which HR and
+// the employee's manager are reminded, from the first reminder to the last.
+var ContractExpiryReminderDays = []int{30, 14, 7}
+
+// EmploymentContract is one contract of an employee. Fixed-term (PKWT) contracts have an end date,
+// permanent ones do not. An employee has at most one active contract; renewing or converting it
+// closes it and starts the next one. LastReminderDays is the last expiry reminder sent for the
+// contract, so each reminder goes out only once.
+type EmploymentContract struct {
+	ID           uint                     `gorm:"primaryKey"`
+	CompanyID    *uint                    `gorm:"index"`
+	EmployeeID   uint                     `gorm:"not null;index"`
+	Employee     Employee                 `gorm:"foreignKey:EmployeeID"`
+	ContractType enums.ContractType       `gorm:"type:contract_type;not null"`
+	StartDate    time.Time                `gorm:"type:date;not null"`
+	EndDate      *time.Time               `gorm:"type:date"`
+	DocumentURL  *string                  `gorm:"type:varchar(255)"`
+	Status       EmploymentContractStatus `gorm:"type:employment_contract_status;not null;default:'active'"`
+
+	LastReminderDays *int  `gorm:"type:int"`
+	CreatedBy        *uint `gorm:"type:uint"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (c *EmploymentContract) TableName() string {
+	return "employment_contracts"
+}
+
+// DaysRemaining returns the number of days from the given day until the contract ends, negative
+// once it has ended, or nil for contracts without an end date.
+func (c *EmploymentContract) DaysRemaining(today time.Time) *int {
+	if c.EndDate == nil {
+		return nil
+	}
+	end := time.Date(c.EndDate.Year(), c.EndDate.Month(), c.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(end.Sub(start).Hours() / 24)
+	return &days
+}
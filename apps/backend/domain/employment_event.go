@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type EmploymentEventType string
+
+const (
+	EmploymentEventHire               EmploymentEventType = "hire"
+	EmploymentEventPromotion          EmploymentEventType = "promotion"
+	EmploymentEventDemotion           EmploymentEventType = "demotion"
+	EmploymentEventTransfer           EmploymentEventType = "transfer"
+	EmploymentEventContractRenewal    EmploymentEventType = "contract_renewal"
+	EmploymentEventContractConversion EmploymentEventType = "contract_conversion"
+	EmploymentEventProbationConfirmed EmploymentEventType = "probation_confirmation"
+	EmploymentEventSalaryChange       EmploymentEventType = "salary_change"
+	EmploymentEventResignation        EmploymentEventType = "resignation"
+	EmploymentEventImportUpdate       EmploymentEventType = "import_update"
+	EmploymentEventMerge              EmploymentEventType = "merge"
+)
+
+// EmploymentEvent is one entry of an employee's employment history. The previous and new values
+// record what the event changed; values the event did not touch are left empty. RecordedBy is the
+// user who recorded the event, or nil when it was recorded automatically.
+//
+// An event effective after the day it is recorded is Scheduled: it holds only the new values until
+// it is applied to the employee on its effective date, when the previous values are filled in.
+type EmploymentEvent struct {
+	ID            uint                `gorm:"primaryKey"`
+	CompanyID     *uint               `gorm:"index"`
+	EmployeeID    uint                `gorm:"not null;index"`
+	Employee      Employee            `gorm:"foreignKey:EmployeeID"`
+	EventType     EmploymentEventType `gorm:"type:employment_event_type;not null"`
+	EffectiveDate time.Time           `gorm:"type:date;not null"`
+	Reason        *string             `gorm:"type:text"`
+
+	PreviousPositionName *string             `gorm:"type:varchar(255)"`
+	NewPositionName      *string             `gorm:"type:varchar(255)"`
+	NewPositionID        *uint               `gorm:"type:uint"`
+	PreviousGrade        *string             `gorm:"type:varchar(50)"`
+	NewGrade             *string             `gorm:"type:varchar(50)"`
+	PreviousBranch       *string             `gorm:"type:varchar(255)"`
+	NewBranch            *string             `gorm:"type:varchar(255)"`
+	NewBranchID          *uint               `gorm:"type:uint"`
+	PreviousDepartmentID *uint               `gorm:"type:uint"`
+	NewDepartmentID      *uint               `gorm:"type:uint"`
+	PreviousContractType *enums.ContractType `gorm:"type:contract_type"`
+	NewContractType      *enums.ContractType `gorm:"type:contract_type"`
+	PreviousBaseSalary   *float64            `gorm:"type:decimal(15,2)"`
+	NewBaseSalary        *float64            `gorm:"type:decimal(15,2)"`
+
+	Scheduled  bool      `gorm:"not null;default:false;index"`
+	RecordedBy *uint     `gorm:"type:uint"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (e *EmploymentEvent) TableName() string {
+	return "employment_events"
+}
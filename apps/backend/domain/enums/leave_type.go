@@ -14,6 +14,7 @@ const (
 	MaternityLeave    LeaveType = "maternity_leave"     // Maternity Leave
 	AnnualLeave       LeaveType = "annual_leave"        // Annual Leave
 	MarriageLeave     LeaveType = "marriage_leave"      // Marriage Leave
+	UnpaidLeave       LeaveType = "unpaid_leave"        // Unpaid Leave
 )
 
 func (lt *LeaveType) Scan(value interface{}) error {
@@ -0,0 +1,28 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// LongTermAbsenceType represents an extended period in which an employee is not expected to work.
+type LongTermAbsenceType string
+
+const (
+	Sabbatical    LongTermAbsenceType = "sabbatical"
+	Suspension    LongTermAbsenceType = "suspension"
+	ExtendedLeave LongTermAbsenceType = "extended_leave"
+)
+
+func (lt *LongTermAbsenceType) Scan(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("failed to scan LongTermAbsenceType: invalid type %T", value)
+	}
+	*lt = LongTermAbsenceType(s)
+	return nil
+}
+
+func (lt LongTermAbsenceType) Value() (driver.Value, error) {
+	return string(lt), nil
+}
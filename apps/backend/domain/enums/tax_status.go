@@ -37,3 +37,20 @@ func (ts *TaxStatus) Scan(value interface{}) error {
 func (ts TaxStatus) Value() (driver.Value, error) {
 	return string(ts), nil
 }
+
+// IsValid reports whether the tax status is one of the known PTKP statuses.
+func (ts TaxStatus) IsValid() bool {
+	switch ts {
+	case TK0, TK1, TK2, TK3, K0, K1, K2, K3, KI0, KI1, KI2, KI3:
+		return true
+	}
+	return false
+}
+
+// Dependants returns the number of dependants (tanggungan) the tax status declares, from 0 to 3.
+func (ts TaxStatus) Dependants() int {
+	if !ts.IsValid() {
+		return 0
+	}
+	return int(ts[len(ts)-1] - '0')
+}
@@ -31,14 +31,129 @@ var (
 
 // Employee errors
 var (
-	ErrEmployeeNotFound = errors.New("employee not found")
-	ErrEmployeeResigned = errors.New("employee has resigned and cannot login")
+	ErrEmployeeNotFound       = errors.New("employee not found")
+	ErrEmployeeResigned       = errors.New("employee has resigned and cannot login")
+	ErrManagerNotFound        = errors.New("manager not found")
+	ErrManagerResigned        = errors.New("manager has resigned")
+	ErrManagerCycle           = errors.New("an employee cannot report to themselves or to someone in their own reporting line")
+	ErrInvalidEmploymentEvent = errors.New("invalid employment event")
+	ErrInvalidExportColumn    = errors.New("invalid export column")
+	ErrEmployeeSearchTimeout  = errors.New("employee search took too long, try a longer query")
+	ErrTeamEventsDenied       = errors.New("only the manager or an admin can list the events of a team")
+)
+
+// Offboarding errors
+var (
+	ErrOffboardingNotFound     = errors.New("offboarding not found")
+	ErrOffboardingExists       = errors.New("employee already has a scheduled offboarding")
+	ErrOffboardingNotScheduled = errors.New("only scheduled offboardings can be changed")
+	ErrOffboardingTaskNotFound = errors.New("offboarding task not found")
+	ErrInvalidOffboarding      = errors.New("invalid offboarding")
+)
+
+// Onboarding errors
+var (
+	ErrOnboardingTemplateNotFound = errors.New("onboarding template not found")
+	ErrOnboardingTemplateExists   = errors.New("an onboarding template for this position and department already exists")
+	ErrInvalidOnboardingTemplate  = errors.New("invalid onboarding template")
+	ErrOnboardingTaskNotFound     = errors.New("onboarding task not found")
+	ErrOnboardingTaskDenied       = errors.New("only the assignee of an onboarding task or the company owner can update it")
+)
+
+// Probation errors
+var (
+	ErrProbationNotFound      = errors.New("probation not found")
+	ErrProbationNotInProgress = errors.New("probation has already been concluded")
+	ErrInvalidProbation       = errors.New("invalid probation")
+	ErrProbationSelfReview    = errors.New("a probation cannot be reviewed by the employee on probation")
+	ErrProbationReviewDenied  = errors.New("only the employee's manager or the company owner can review a probation")
+)
+
+// Custom field errors
+var (
+	ErrCustomFieldNotFound     = errors.New("custom field not found")
+	ErrCustomFieldExists       = errors.New("a custom field with this key already exists")
+	ErrInvalidCustomField      = errors.New("invalid custom field")
+	ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+	ErrCustomFieldNotEditable  = errors.New("custom field cannot be edited by the employee")
+)
+
+// Profile change errors
+var (
+	ErrProfileChangeNotFound         = errors.New("profile change request not found")
+	ErrProfileChangePolicyNotFound   = errors.New("profile change policy not found")
+	ErrInvalidProfileChange          = errors.New("invalid profile change")
+	ErrProfileChangeNotPending       = errors.New("profile change request is no longer pending")
+	ErrProfileChangeAlreadyPending   = errors.New("a pending profile change request already covers this field")
+	ErrProfileChangeRequiresApproval = errors.New("changes to this field require an approved profile change request")
+	ErrProfileChangeSelfReview       = errors.New("a profile change request cannot be reviewed by its own employee")
+)
+
+// Import job errors
+var (
+	ErrImportJobNotFound    = errors.New("import job not found")
+	ErrImportJobNotFinished = errors.New("import job has not finished yet")
+)
+
+// Import mapping errors
+var (
+	ErrImportMappingProfileNotFound = errors.New("import mapping profile not found")
+	ErrImportMappingProfileExists   = errors.New("an import mapping profile with this name already exists")
+	ErrInvalidImportMapping         = errors.New("invalid import mapping")
+)
+
+// Employee duplicate errors
+var (
+	ErrEmployeeDuplicateNotFound  = errors.New("employee duplicate not found")
+	ErrEmployeeDuplicateDismissed = errors.New("employee duplicate has already been dismissed")
+	ErrInvalidEmployeeMerge       = errors.New("the surviving employee must be one of the duplicate pair")
+)
+
+// Family errors
+var (
+	ErrInvalidFamilyMember     = errors.New("invalid family member")
+	ErrInvalidEmergencyContact = errors.New("invalid emergency contact")
+	ErrTaxDependantMismatch    = errors.New("the tax status does not match the recorded tax dependants")
+)
+
+// Contract errors
+var (
+	ErrContractNotFound     = errors.New("contract not found")
+	ErrActiveContractExists = errors.New("employee already has an active contract")
+	ErrInvalidContract      = errors.New("invalid contract")
+	ErrContractNotRenewable = errors.New("only active fixed-term contracts can be renewed or converted")
+)
+
+// Company errors
+var (
+	ErrCompanyNotFound = errors.New("company not found")
+)
+
+// Organization errors
+var (
+	ErrDepartmentNotFound = errors.New("department not found")
+	ErrDepartmentCycle    = errors.New("a department cannot be placed under itself or one of its sub-departments")
+	ErrDepartmentInUse    = errors.New("department still has sub-departments or employees")
+	ErrBranchNotFound     = errors.New("branch not found")
+	ErrBranchInUse        = errors.New("branch still has employees")
+	ErrPositionNotFound   = errors.New("position not found")
+	ErrPositionInUse      = errors.New("position still has employees")
 )
 
 // Leave Request errors
 var (
 	ErrLeaveRequestNotFound    = errors.New("leave request not found")
 	ErrOverlappingLeaveRequest = errors.New("overlapping leave request already exists")
+	ErrStaffingRuleNotFound    = errors.New("staffing rule not found")
+	ErrInvalidStaffingRule     = errors.New("invalid staffing rule")
+	ErrStaffingRuleBreached    = errors.New("approving this leave request would breach a staffing rule")
+	ErrLeavePolicyNotFound     = errors.New("leave policy not found")
+	ErrCertificateNotExpected  = errors.New("this leave request does not accept a medical certificate")
+	ErrCertificateDeadlinePast = errors.New("the deadline for submitting the medical certificate has passed")
+	ErrLeaveEncashmentNotFound = errors.New("leave encashment not found")
+	ErrEncashmentNotDraft      = errors.New("only draft leave encashments can be changed")
+	ErrInvalidLongTermAbsence  = errors.New("invalid long-term absence")
+	ErrInvalidPeriod           = errors.New("invalid period")
 )
 
 // Location errors
@@ -50,5 +165,7 @@ var (
 
 // common errors
 var (
-	ErrForbidden = errors.New("forbidden")
+	ErrForbidden        = errors.New("forbidden")
+	ErrInvalidListQuery = errors.New("invalid list query")
+	ErrInvalidCursor    = errors.New("invalid cursor")
 )
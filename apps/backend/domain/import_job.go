@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+type ImportJobStatus string
+
+const (
+	ImportJobQueued    ImportJobStatus = "queued"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+)
+
+// IsFinished reports whether the job will not process any more rows.
+func (s ImportJobStatus) IsFinished() bool {
+	return s == ImportJobCompleted || s == ImportJobFailed
+}
+
+// ImportJob is an employee import running in the background. Its rows are stored with the job, so
+// a job interrupted by a restart carries on with the rows it has not processed yet.
+type ImportJob struct {
+	ID            uint            `gorm:"primaryKey"`
+	CompanyID     *uint           `gorm:"index"`
+	CreatedBy     uint            `gorm:"not null;index"`
+	FileName      string          `gorm:"type:varchar(255);not null"`
+	Status        ImportJobStatus `gorm:"type:import_job_status;not null;default:'queued';index"`
+	TotalRows     int             `gorm:"not null;default:0"`
+	ProcessedRows int             `gorm:"not null;default:0"`
+	SucceededRows int             `gorm:"not null;default:0"`
+	FailedRows    int             `gorm:"not null;default:0"`
+	Error         *string         `gorm:"type:text"`
+
+	StartedAt   *time.Time `gorm:"type:timestamp"`
+	CompletedAt *time.Time `gorm:"type:timestamp"`
+
+	// LeaseExpiresAt is when the server running the job stops holding it unless it renews the
+	// lease. A running job whose lease has lapsed was interrupted and can be claimed again.
+	LeaseExpiresAt *time.Time `gorm:"type:timestamp"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (j *ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+type ImportJobRowStatus string
+
+const (
+	ImportJobRowPending   ImportJobRowStatus = "pending"
+	ImportJobRowSucceeded ImportJobRowStatus = "succeeded"
+	ImportJobRowFailed    ImportJobRowStatus = "failed"
+)
+
+type ImportJobRowError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Value   string `json:"value"`
+}
+
+// ImportJobRow is a row of an import job with the employee it creates and, once processed, its
+// result. Row is the line of the row in the imported file.
+type ImportJobRow struct {
+	ID         uint                `gorm:"primaryKey"`
+	JobID      uint                `gorm:"not null;index"`
+	Row        int                 `gorm:"not null"`
+	Employee   *Employee           `gorm:"type:jsonb;serializer:json;not null"`
+	Status     ImportJobRowStatus  `gorm:"type:import_job_row_status;not null;default:'pending'"`
+	EmployeeID *uint               `gorm:"type:uint"`
+	Errors     []ImportJobRowError `gorm:"type:jsonb;serializer:json"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (r *ImportJobRow) TableName() string {
+	return "import_job_rows"
+}
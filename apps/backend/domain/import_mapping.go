@@ -0,0 +1,422 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ImportFieldType is the kind of value an import column holds.
+type ImportFieldType string
+
+const (
+	ImportFieldText ImportFieldType = "text"
+	ImportFieldDate ImportFieldType = "date"
+	ImportFieldEnum ImportFieldType = "enum"
+)
+
+// ImportDateLayout is the layout of dates in an import file.
+const ImportDateLayout = "2006-01-02"
+
+// ImportCustomFieldPrefix starts the import columns holding custom field values, such as
+// custom_blood_type for the blood_type field.
+const ImportCustomFieldPrefix = "custom_"
+
+// ImportField is a column of an employee import file. Aliases are headers legacy HR systems use
+// for the column, used to suggest mappings.
+type ImportField struct {
+	Key      string          `json:"key"`
+	Label    string          `json:"label"`
+	Type     ImportFieldType `json:"type"`
+	Required bool            `json:"required"`
+	Options  []string        `json:"options,omitempty"`
+	Aliases  []string        `json:"-"`
+}
+
+// ImportFields are the standard columns of an employee import file, in the order of the template.
+var ImportFields = []ImportField{
+	{Key: "email", Label: "Email", Type: ImportFieldText, Required: true, Aliases: []string{"e-mail", "email address", "alamat email", "work email", "email kantor"}},
+	{Key: "first_name", Label: "First Name", Type: ImportFieldText, Required: true, Aliases: []string{"nama depan", "given name", "nama", "name", "full name", "nama lengkap", "employee name", "nama karyawan"}},
+	{Key: "last_name", Label: "Last Name", Type: ImportFieldText, Aliases: []string{"nama belakang", "surname", "family name"}},
+	{Key: "phone", Label: "Phone", Type: ImportFieldText, Aliases: []string{"phone number", "mobile", "mobile phone", "no hp", "nomor hp", "no telepon", "nomor telepon", "handphone"}},
+	{Key: "employee_code", Label: "Employee Code", Type: ImportFieldText, Aliases: []string{"employee id", "employee number", "id karyawan", "nomor karyawan", "nip", "nik karyawan"}},
+	{Key: "position_name", Label: "Position", Type: ImportFieldText, Required: true, Aliases: []string{"position", "job position", "job title", "jabatan", "posisi"}},
+	{Key: "branch", Label: "Branch", Type: ImportFieldText, Aliases: []string{"cabang", "office", "kantor", "lokasi kerja"}},
+	{Key: "grade", Label: "Grade", Type: ImportFieldText, Aliases: []string{"job level", "level", "golongan"}},
+	{Key: "nik", Label: "NIK", Type: ImportFieldText, Aliases: []string{"nik ktp", "no ktp", "nomor ktp", "ktp", "identity number", "national id"}},
+	{Key: "gender", Label: "Gender", Type: ImportFieldEnum, Options: []string{"Male", "Female"}, Aliases: []string{"jenis kelamin", "sex", "kelamin"}},
+	{Key: "place_of_birth", Label: "Place of Birth", Type: ImportFieldText, Aliases: []string{"birth place", "tempat lahir"}},
+	{Key: "date_of_birth", Label: "Date of Birth", Type: ImportFieldDate, Aliases: []string{"birth date", "birthdate", "tanggal lahir", "tgl lahir", "dob"}},
+	{Key: "last_education", Label: "Last Education", Type: ImportFieldEnum, Options: []string{"SD", "SMP", "SMA/SMK", "D1", "D2", "D3", "S1/D4", "S2", "S3", "Other"}, Aliases: []string{"education", "pendidikan", "pendidikan terakhir"}},
+	{Key: "contract_type", Label: "Contract Type", Type: ImportFieldEnum, Options: []string{"permanent", "contract", "freelance"}, Aliases: []string{"employment status", "employment type", "status karyawan", "status kepegawaian", "jenis kontrak"}},
+	{Key: "hire_date", Label: "Hire Date", Type: ImportFieldDate, Aliases: []string{"join date", "joined date", "start date", "tanggal masuk", "tanggal bergabung", "tgl masuk"}},
+	{Key: "tax_status", Label: "Tax Status", Type: ImportFieldEnum, Options: []string{"TK/0", "TK/1", "TK/2", "TK/3", "K/0", "K/1", "K/2", "K/3", "K/I/0", "K/I/1", "K/I/2", "K/I/3"}, Aliases: []string{"ptkp", "status ptkp", "ptkp status", "status pajak"}},
+	{Key: "bank_name", Label: "Bank Name", Type: ImportFieldText, Aliases: []string{"bank", "nama bank"}},
+	{Key: "bank_account_number", Label: "Bank Account Number", Type: ImportFieldText, Aliases: []string{"account number", "bank account", "no rekening", "nomor rekening", "rekening"}},
+	{Key: "bank_account_holder_name", Label: "Bank Account Holder Name", Type: ImportFieldText, Aliases: []string{"account holder", "account name", "nama pemilik rekening", "atas nama rekening", "nama rekening"}},
+}
+
+// importEnumSynonyms are the values legacy HR systems use for the options of the enum fields,
+// keyed by field and normalised value.
+var importEnumSynonyms = map[string]map[string]string{
+	"gender": {
+		"m": "Male", "l": "Male", "pria": "Male", "lakilaki": "Male", "man": "Male",
+		"f": "Female", "p": "Female", "wanita": "Female", "perempuan": "Female", "woman": "Female",
+	},
+	"last_education": {
+		"sma": "SMA/SMK", "smk": "SMA/SMK", "slta": "SMA/SMK", "sltp": "SMP",
+		"s1": "S1/D4", "d4": "S1/D4", "bachelor": "S1/D4", "master": "S2", "doctorate": "S3",
+	},
+	"contract_type": {
+		"tetap": "permanent", "pkwtt": "permanent", "permanen": "permanent",
+		"kontrak": "contract", "pkwt": "contract", "probation": "contract",
+		"harian": "freelance", "lepas": "freelance", "outsource": "freelance",
+	},
+}
+
+// ImportFieldByKey returns the standard import field with the given key.
+func ImportFieldByKey(key string) (ImportField, bool) {
+	for _, field := range ImportFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return ImportField{}, false
+}
+
+// NormalizeImportEnum returns the option of an enum field a value stands for. Options match
+// regardless of case and punctuation, so tk0 is TK/0, and common synonyms such as Laki-laki for
+// Male are recognised.
+func NormalizeImportEnum(field ImportField, value string) (string, bool) {
+	normalized := normalizeImportToken(value)
+	for _, option := range field.Options {
+		if normalizeImportToken(option) == normalized {
+			return option, true
+		}
+	}
+	if option, ok := importEnumSynonyms[field.Key][normalized]; ok {
+		return option, true
+	}
+	return "", false
+}
+
+// NormalizeImportHeader returns the key a header of an import file stands for: lower case with
+// spaces replaced by underscores.
+func NormalizeImportHeader(header string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+}
+
+// MatchesHeader reports whether a header of a source file names the field: its key, its label or
+// one of its aliases, regardless of case, spacing and punctuation.
+func (f ImportField) MatchesHeader(header string) bool {
+	token := normalizeImportToken(header)
+	if token == "" {
+		return false
+	}
+	for _, name := range append([]string{f.Key, f.Label}, f.Aliases...) {
+		if normalizeImportToken(name) == token {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeImportToken(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ImportColumnMapping maps a column of a source file to an import field. DateFormat is the format
+// of dates in the column, such as DD/MM/YYYY, and ValueMap replaces source values, matched
+// regardless of case, before they are imported. No two ValueMap keys may differ only in case.
+type ImportColumnMapping struct {
+	SourceHeader string            `json:"source_header"`
+	Field        string            `json:"field"`
+	DateFormat   string            `json:"date_format,omitempty"`
+	ValueMap     map[string]string `json:"value_map,omitempty"`
+}
+
+// ImportMappingProfile is a saved mapping of the columns of a legacy HR system's export to the
+// columns of an employee import file.
+type ImportMappingProfile struct {
+	ID        uint                  `gorm:"primaryKey"`
+	CompanyID *uint                 `gorm:"uniqueIndex:idx_import_mapping_company_name"`
+	Name      string                `gorm:"type:varchar(255);not null;uniqueIndex:idx_import_mapping_company_name"`
+	Mappings  []ImportColumnMapping `gorm:"type:jsonb;serializer:json;not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *ImportMappingProfile) TableName() string {
+	return "import_mapping_profiles"
+}
+
+// Check reports whether the profile is usable: every mapping has a source header and a known
+// field, no field is mapped twice and date formats are only given for date fields. Whether a
+// custom field column names an existing custom field is left to the caller.
+func (p *ImportMappingProfile) Check() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidImportMapping)
+	}
+	if len(p.Mappings) == 0 {
+		return fmt.Errorf("%w: at least one column must be mapped", ErrInvalidImportMapping)
+	}
+
+	sources := make(map[string]bool)
+	fields := make(map[string]bool)
+	for _, mapping := range p.Mappings {
+		source := strings.ToLower(strings.TrimSpace(mapping.SourceHeader))
+		if source == "" {
+			return fmt.Errorf("%w: source header is required", ErrInvalidImportMapping)
+		}
+		if sources[source] {
+			return fmt.Errorf("%w: column %q is mapped twice", ErrInvalidImportMapping, mapping.SourceHeader)
+		}
+		sources[source] = true
+
+		if fields[mapping.Field] {
+			return fmt.Errorf("%w: field %s is mapped twice", ErrInvalidImportMapping, mapping.Field)
+		}
+		fields[mapping.Field] = true
+
+		field, ok := ImportFieldByKey(mapping.Field)
+		custom := strings.HasPrefix(mapping.Field, ImportCustomFieldPrefix)
+		if !ok && !custom {
+			return fmt.Errorf("%w: unknown field %s", ErrInvalidImportMapping, mapping.Field)
+		}
+		sourceValues := make(map[string]bool, len(mapping.ValueMap))
+		for sourceValue := range mapping.ValueMap {
+			key := strings.ToLower(strings.TrimSpace(sourceValue))
+			if sourceValues[key] {
+				return fmt.Errorf("%w: value %q of column %q is mapped twice", ErrInvalidImportMapping, sourceValue, mapping.SourceHeader)
+			}
+			sourceValues[key] = true
+		}
+		if mapping.DateFormat != "" {
+			if !custom && field.Type != ImportFieldDate {
+				return fmt.Errorf("%w: %s is not a date field", ErrInvalidImportMapping, mapping.Field)
+			}
+			if _, err := ImportDateLayoutOf(mapping.DateFormat); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidImportMapping, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ImportValueError is a value of a source file a mapping cannot convert.
+type ImportValueError struct {
+	Field   string
+	Value   string
+	Message string
+}
+
+func (e *ImportValueError) Error() string {
+	return e.Message
+}
+
+// MapRecord converts a row of a source file into the headers and values of an import file.
+// Columns without a mapping whose header already is an import column are kept as they are; other
+// columns are left out.
+func (p *ImportMappingProfile) MapRecord(headers, record []string) ([]string, []string, []*ImportValueError) {
+	bySource := make(map[string]ImportColumnMapping, len(p.Mappings))
+	mappedFields := make(map[string]bool, len(p.Mappings))
+	for _, mapping := range p.Mappings {
+		bySource[strings.ToLower(strings.TrimSpace(mapping.SourceHeader))] = mapping
+		mappedFields[mapping.Field] = true
+	}
+
+	var mappedHeaders, mappedRecord []string
+	var valueErrors []*ImportValueError
+	for i, header := range headers {
+		value := ""
+		if i < len(record) {
+			value = strings.TrimSpace(record[i])
+		}
+
+		mapping, ok := bySource[strings.ToLower(strings.TrimSpace(header))]
+		if !ok {
+			key := NormalizeImportHeader(header)
+			if _, known := ImportFieldByKey(key); (known || strings.HasPrefix(key, ImportCustomFieldPrefix)) && !mappedFields[key] {
+				mappedHeaders = append(mappedHeaders, key)
+				mappedRecord = append(mappedRecord, value)
+			}
+			continue
+		}
+
+		converted, err := mapping.Convert(value)
+		if err != nil {
+			valueErrors = append(valueErrors, err)
+			continue
+		}
+		mappedHeaders = append(mappedHeaders, mapping.Field)
+		mappedRecord = append(mappedRecord, converted)
+	}
+	return mappedHeaders, mappedRecord, valueErrors
+}
+
+// Convert turns a source value into the value of the import field: the value map is applied,
+// dates are reformatted and enum values are normalised to their option.
+func (m *ImportColumnMapping) Convert(value string) (string, *ImportValueError) {
+	if value == "" {
+		return "", nil
+	}
+	value = m.mapValue(value)
+
+	// Custom fields are validated on import; only their dates are converted here.
+	field, ok := ImportFieldByKey(m.Field)
+	if !ok && m.DateFormat != "" {
+		field.Type = ImportFieldDate
+	}
+
+	switch field.Type {
+	case ImportFieldDate:
+		if m.DateFormat == "" {
+			return value, nil
+		}
+		layout, err := ImportDateLayoutOf(m.DateFormat)
+		if err != nil {
+			return "", &ImportValueError{Field: m.Field, Value: value, Message: err.Error()}
+		}
+		date, err := ParseImportDate(layout, value)
+		if err != nil {
+			return "", &ImportValueError{Field: m.Field, Value: value, Message: fmt.Sprintf("%s must be a date in the %s format", m.SourceHeader, m.DateFormat)}
+		}
+		return date.Format(ImportDateLayout), nil
+	case ImportFieldEnum:
+		option, ok := NormalizeImportEnum(field, value)
+		if !ok {
+			return "", &ImportValueError{Field: m.Field, Value: value, Message: fmt.Sprintf("%s must be one of %s", m.SourceHeader, strings.Join(field.Options, ", "))}
+		}
+		return option, nil
+	}
+	return value, nil
+}
+
+// mapValue returns the value the value map replaces value with: the target of the key equal to
+// value, otherwise of the first key, in sorted order, equal to it regardless of case.
+func (m *ImportColumnMapping) mapValue(value string) string {
+	if target, ok := m.ValueMap[value]; ok {
+		return target
+	}
+	sources := make([]string, 0, len(m.ValueMap))
+	for source := range m.ValueMap {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		if strings.EqualFold(strings.TrimSpace(source), value) {
+			return m.ValueMap[source]
+		}
+	}
+	return value
+}
+
+// importMonthNames are the Indonesian names and abbreviations of the months, which dates exported
+// by Indonesian HR systems use as often as the English ones.
+var importMonthNames = map[string]time.Month{
+	"januari": time.January, "februari": time.February, "maret": time.March, "april": time.April,
+	"mei": time.May, "juni": time.June, "juli": time.July, "agustus": time.August,
+	"september": time.September, "oktober": time.October, "november": time.November, "desember": time.December,
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"jun": time.June, "jul": time.July, "agu": time.August, "agt": time.August, "ags": time.August,
+	"sep": time.September, "okt": time.October, "nov": time.November, "des": time.December,
+}
+
+// ParseImportDate parses a date of an import file with a layout from ImportDateLayoutOf. Month
+// names may be English or Indonesian, so both 5 Agustus 2021 and 5 August 2021 parse with the
+// D MMMM YYYY format.
+func ParseImportDate(layout, value string) (time.Time, error) {
+	date, err := time.Parse(layout, value)
+	if err == nil || !strings.Contains(layout, "Jan") {
+		return date, err
+	}
+
+	var translated strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) {
+			translated.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && unicode.IsLetter(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if month, ok := importMonthNames[strings.ToLower(word)]; ok {
+			word = month.String()
+			if !strings.Contains(layout, "January") {
+				word = word[:3]
+			}
+		}
+		translated.WriteString(word)
+		i = j
+	}
+	if date, translatedErr := time.Parse(layout, translated.String()); translatedErr == nil {
+		return date, nil
+	}
+	return time.Time{}, err
+}
+
+// importDateTokens are the parts of a date format and their Go layout, longest first.
+var importDateTokens = []struct {
+	token  string
+	layout string
+}{
+	{"YYYY", "2006"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"YY", "06"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"M", "1"},
+	{"D", "2"},
+}
+
+// ImportDateLayoutOf converts a date format such as DD/MM/YYYY or D MMM YYYY into a Go layout.
+func ImportDateLayoutOf(format string) (string, error) {
+	var layout strings.Builder
+	hasYear, hasMonth, hasDay := false, false, false
+	for rest := strings.ToUpper(format); rest != ""; {
+		matched := false
+		for _, t := range importDateTokens {
+			if strings.HasPrefix(rest, t.token) {
+				layout.WriteString(t.layout)
+				switch t.token[0] {
+				case 'Y':
+					hasYear = true
+				case 'M':
+					hasMonth = true
+				case 'D':
+					hasDay = true
+				}
+				rest = rest[len(t.token):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		r := []rune(rest)[0]
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return "", fmt.Errorf("unsupported date format %q, use YYYY, MM, MMM, DD and separators", format)
+		}
+		layout.WriteRune(r)
+		rest = rest[len(string(r)):]
+	}
+	if !hasYear || !hasMonth || !hasDay {
+		return "", fmt.Errorf("date format %q needs a year, a month and a day", format)
+	}
+	return layout.String(), nil
+}
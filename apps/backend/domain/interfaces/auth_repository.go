@@ -44,4 +44,7 @@ type AuthRepository interface {
 
 	// Method for updating user details
 	UpdateUser(ctx context.Context, user *domain.User) error
+
+	// DeactivateUser bans the Supabase account of the user so they can no longer sign in
+	DeactivateUser(ctx context.Context, userID uint) error
 }
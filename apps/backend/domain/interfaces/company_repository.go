@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type CompanyRepository interface {
+	Create(ctx context.Context, company *domain.Company) error
+	GetByID(ctx context.Context, id uint) (*domain.Company, error)
+	GetByOwnerUserID(ctx context.Context, ownerUserID uint) (*domain.Company, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type CustomFieldRepository interface {
+	Create(ctx context.Context, definition *domain.CustomFieldDefinition) error
+	GetByID(ctx context.Context, id uint) (*domain.CustomFieldDefinition, error)
+	GetByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error)
+	List(ctx context.Context) ([]*domain.CustomFieldDefinition, error)
+	Update(ctx context.Context, definition *domain.CustomFieldDefinition) error
+	Delete(ctx context.Context, definition *domain.CustomFieldDefinition) error
+}
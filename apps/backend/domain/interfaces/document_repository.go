@@ -9,6 +9,6 @@ import (
 type DocumentRepository interface {
 	Create(ctx context.Context, document *domain.Document) error
 	GetByID(ctx context.Context, id uint) (*domain.Document, error)
-	GetByEmployeeID(ctx context.Context, employeeID uint) ([]*domain.Document, error)
+	GetByEmployeeID(ctx context.Context, employeeID uint, query *domain.ListQuery) ([]*domain.Document, error)
 	Delete(ctx context.Context, id uint) error
 }
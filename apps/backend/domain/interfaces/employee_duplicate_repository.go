@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type EmployeeDuplicateRepository interface {
+	// ListCandidates returns the pairs of employees of the same company whose names, NIKs or phone
+	// numbers are close enough to be worth scoring, with both employees and their users loaded.
+	// Dismissed pairs are left out.
+	ListCandidates(ctx context.Context) ([]*domain.EmployeeDuplicate, error)
+	// Save stores a pending pair, refreshing its score when it was already flagged.
+	Save(ctx context.Context, duplicate *domain.EmployeeDuplicate) error
+	// DeletePendingDetectedBefore removes the pending pairs a detection run no longer flagged.
+	DeletePendingDetectedBefore(ctx context.Context, before time.Time) error
+	GetByID(ctx context.Context, id uint) (*domain.EmployeeDuplicate, error)
+	List(ctx context.Context, status domain.EmployeeDuplicateStatus, pagination domain.PaginationParams) ([]*domain.EmployeeDuplicate, int64, error)
+	Update(ctx context.Context, duplicate *domain.EmployeeDuplicate) error
+	// Merge moves the records of the duplicate employee to the survivor and the records of the
+	// retired user account to the survivor's account, saves the survivor with the employment event
+	// recording what it took from the duplicate, if any, and deletes the duplicate and the pairs it
+	// was part of, all in one transaction.
+	Merge(ctx context.Context, survivor *domain.Employee, event *domain.EmploymentEvent, duplicateID, retiredUserID uint) error
+}
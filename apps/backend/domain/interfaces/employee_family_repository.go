@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type EmployeeFamilyRepository interface {
+	ListFamilyMembers(ctx context.Context, employeeID uint) ([]*domain.FamilyMember, error)
+	CountTaxDependants(ctx context.Context, employeeID uint) (recorded bool, dependants int, err error)
+	// ReplaceFamilyMembers replaces the family of the employee and sets its tax status in one
+	// transaction, so the two stay consistent.
+	ReplaceFamilyMembers(ctx context.Context, employeeID uint, members []*domain.FamilyMember, taxStatus *enums.TaxStatus) error
+
+	ListEmergencyContacts(ctx context.Context, employeeID uint) ([]*domain.EmergencyContact, error)
+	ReplaceEmergencyContacts(ctx context.Context, employeeID uint, contacts []*domain.EmergencyContact) error
+}
@@ -14,8 +14,16 @@ type EmployeeRepository interface {
 	GetByEmployeeCode(ctx context.Context, employeeCode string) (*domain.Employee, error)
 	GetByNIK(ctx context.Context, nik string) (*domain.Employee, error)
 	Update(ctx context.Context, employee *domain.Employee) error
+	BulkUpdate(ctx context.Context, employees []*domain.Employee, events []*domain.EmploymentEvent) error
+	ApplyEmploymentEvent(ctx context.Context, employee *domain.Employee, event *domain.EmploymentEvent) error
 	Delete(ctx context.Context, id uint) error
 	List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.Employee, int64, error)
+	ForEachBatch(ctx context.Context, filters map[string]interface{}, fn func(employees []*domain.Employee) error) error
+	Search(ctx context.Context, query string, scope domain.EmployeeSearchScope, limit int) ([]*domain.EmployeeSearchHit, error)
+	GetReportingLineIDs(ctx context.Context, managerID uint) ([]uint, error)
+	ListActiveByIDs(ctx context.Context, ids []uint) ([]*domain.Employee, error)
+	ListEventDigestSubscribers(ctx context.Context) ([]*domain.Employee, error)
+	UpdateManager(ctx context.Context, employeeIDs []uint, managerID uint) error
 	GetStatisticsWithTrendsByManager(ctx context.Context, managerID uint) (
 		totalEmployees, newEmployees, activeEmployees, resignedEmployees,
 		permanentEmployees, contractEmployees, freelanceEmployees int64,
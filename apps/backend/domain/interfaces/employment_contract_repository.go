@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type EmploymentContractRepository interface {
+	Create(ctx context.Context, contract *domain.EmploymentContract) error
+	Update(ctx context.Context, contract *domain.EmploymentContract) error
+	Replace(ctx context.Context, active, next *domain.EmploymentContract, event *domain.EmploymentEvent) error
+	GetActiveByEmployee(ctx context.Context, employeeID uint) (*domain.EmploymentContract, error)
+	ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentContract, error)
+	ListActiveEndingBefore(ctx context.Context, endDate time.Time) ([]*domain.EmploymentContract, error)
+}
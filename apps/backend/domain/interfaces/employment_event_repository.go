@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type EmploymentEventRepository interface {
+	Create(ctx context.Context, event *domain.EmploymentEvent) error
+	ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentEvent, error)
+	ListByPeriod(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmploymentEvent, error)
+	ListDue(ctx context.Context, date time.Time) ([]*domain.EmploymentEvent, error)
+}
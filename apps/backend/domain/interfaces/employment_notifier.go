@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// EmploymentNotifier tells HR and managers about upcoming changes in an employee's employment.
+type EmploymentNotifier interface {
+	SendContractExpiryReminder(ctx context.Context, recipient *domain.User, contract *domain.EmploymentContract, daysLeft int) error
+	SendOnboardingTaskReminder(ctx context.Context, recipient *domain.User, tasks []*domain.OnboardingTask) error
+	SendProbationReviewReminder(ctx context.Context, recipient *domain.User, probation *domain.Probation, daysLeft int) error
+	SendEmployeeEventDigest(ctx context.Context, recipient *domain.User, events []*domain.EmployeeEvent) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob, rows []*domain.ImportJobRow) error
+	GetByID(ctx context.Context, id uint) (*domain.ImportJob, error)
+	Update(ctx context.Context, job *domain.ImportJob) error
+	List(ctx context.Context, pagination domain.PaginationParams) ([]*domain.ImportJob, int64, error)
+	ListUnfinished(ctx context.Context) ([]*domain.ImportJob, error)
+	Claim(ctx context.Context, job *domain.ImportJob, leaseExpiresAt time.Time) (bool, error)
+	ListRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error)
+	ListPendingRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error)
+	UpdateRow(ctx context.Context, row *domain.ImportJobRow) error
+}
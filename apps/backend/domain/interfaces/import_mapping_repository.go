@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ImportMappingRepository interface {
+	Create(ctx context.Context, profile *domain.ImportMappingProfile) error
+	GetByID(ctx context.Context, id uint) (*domain.ImportMappingProfile, error)
+	GetByName(ctx context.Context, name string) (*domain.ImportMappingProfile, error)
+	List(ctx context.Context) ([]*domain.ImportMappingProfile, error)
+	Update(ctx context.Context, profile *domain.ImportMappingProfile) error
+	Delete(ctx context.Context, profile *domain.ImportMappingProfile) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type LeaveEncashmentRepository interface {
+	Create(ctx context.Context, encashment *domain.LeaveEncashment) error
+	GetByID(ctx context.Context, id uint) (*domain.LeaveEncashment, error)
+	Update(ctx context.Context, encashment *domain.LeaveEncashment) error
+	List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.LeaveEncashment, int64, error)
+	Exists(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (bool, error)
+	GetByPeriod(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (*domain.LeaveEncashment, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// LeaveEncashmentUseCase defines the leave encashment logic used by other use cases
+type LeaveEncashmentUseCase interface {
+	// CreateResignationEncashment drafts the payout of a leaver's remaining annual leave
+	CreateResignationEncashment(ctx context.Context, employee *domain.Employee) error
+	// PreviewResignationEncashment calculates the payout of a leaver's remaining annual leave as of
+	// their last working day without saving it
+	PreviewResignationEncashment(ctx context.Context, employee *domain.Employee, lastWorkingDay time.Time) (*domain.LeaveEncashment, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type LeavePolicyRepository interface {
+	GetByCreator(ctx context.Context, createdBy uint) (*domain.LeavePolicy, error)
+	GetForEmployee(ctx context.Context, employeeID uint) (*domain.LeavePolicy, error)
+	Upsert(ctx context.Context, policy *domain.LeavePolicy) error
+}
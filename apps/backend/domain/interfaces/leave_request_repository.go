@@ -17,4 +17,5 @@ type LeaveRequestRepository interface {
 	UpdateStatus(ctx context.Context, id uint, status domain.LeaveStatus, adminNote *string) error
 	HasOverlappingLeaveRequest(ctx context.Context, employeeID uint, startDate, endDate time.Time, excludeRequestID *uint) (bool, error)
 	HasApprovedLeaveForDate(ctx context.Context, employeeID uint, date time.Time) (bool, error)
+	ListCertificatesOverdue(ctx context.Context, asOf time.Time) ([]*domain.LeaveRequest, error)
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type LeaveStaffingRuleRepository interface {
+	Create(ctx context.Context, rule *domain.LeaveStaffingRule) error
+	GetByID(ctx context.Context, id uint) (*domain.LeaveStaffingRule, error)
+	List(ctx context.Context) ([]*domain.LeaveStaffingRule, error)
+	Update(ctx context.Context, rule *domain.LeaveStaffingRule) error
+	Delete(ctx context.Context, id uint) error
+	ListApplicableToEmployee(ctx context.Context, employee *domain.Employee) ([]*domain.LeaveStaffingRule, error)
+	CountScopeEmployees(ctx context.Context, rule *domain.LeaveStaffingRule) (int64, error)
+	ListScopeLeaves(ctx context.Context, rule *domain.LeaveStaffingRule, startDate, endDate time.Time, statuses []domain.LeaveStatus) ([]*domain.LeaveRequest, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type OffboardingRepository interface {
+	Create(ctx context.Context, offboarding *domain.Offboarding) error
+	Update(ctx context.Context, offboarding *domain.Offboarding) error
+	GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Offboarding, error)
+	GetTaskByID(ctx context.Context, offboardingID, taskID uint) (*domain.OffboardingTask, error)
+	UpdateTask(ctx context.Context, task *domain.OffboardingTask) error
+	ListDue(ctx context.Context, date time.Time) ([]*domain.Offboarding, error)
+	ListCompletedBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Offboarding, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type OnboardingRepository interface {
+	CreateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error
+	GetTemplateByID(ctx context.Context, id uint) (*domain.OnboardingTemplate, error)
+	ListTemplates(ctx context.Context) ([]*domain.OnboardingTemplate, error)
+	UpdateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error
+	DeleteTemplate(ctx context.Context, id uint) error
+
+	CreateTasks(ctx context.Context, tasks []*domain.OnboardingTask) error
+	ListTasksByEmployee(ctx context.Context, employeeID uint) ([]*domain.OnboardingTask, error)
+	ListOpenTasksByAssignee(ctx context.Context, assigneeID uint) ([]*domain.OnboardingTask, error)
+	ListOpenTasksDueBefore(ctx context.Context, date time.Time) ([]*domain.OnboardingTask, error)
+	GetTaskByID(ctx context.Context, employeeID, taskID uint) (*domain.OnboardingTask, error)
+	UpdateTask(ctx context.Context, task *domain.OnboardingTask) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// OnboardingUseCase defines the onboarding logic used by other use cases
+type OnboardingUseCase interface {
+	// StartOnboarding creates the onboarding checklist of a new hire from the template that applies
+	// to their position and department
+	StartOnboarding(ctx context.Context, employee *domain.Employee) error
+}
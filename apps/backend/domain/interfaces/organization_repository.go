@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type OrganizationRepository interface {
+	CreateDepartment(ctx context.Context, department *domain.Department) error
+	GetDepartmentByID(ctx context.Context, id uint) (*domain.Department, error)
+	ListDepartments(ctx context.Context) ([]*domain.Department, error)
+	UpdateDepartment(ctx context.Context, department *domain.Department) error
+	DeleteDepartment(ctx context.Context, id uint) error
+	CountActiveEmployeesByDepartment(ctx context.Context) (map[uint]int64, error)
+
+	CreateBranch(ctx context.Context, branch *domain.Branch) error
+	GetBranchByID(ctx context.Context, id uint) (*domain.Branch, error)
+	ListBranches(ctx context.Context) ([]*domain.Branch, error)
+	UpdateBranch(ctx context.Context, branch *domain.Branch) error
+	DeleteBranch(ctx context.Context, id uint) error
+
+	CreatePosition(ctx context.Context, position *domain.Position) error
+	GetPositionByID(ctx context.Context, id uint) (*domain.Position, error)
+	ListPositions(ctx context.Context) ([]*domain.Position, error)
+	UpdatePosition(ctx context.Context, position *domain.Position) error
+	DeletePosition(ctx context.Context, id uint) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ProbationRepository interface {
+	Create(ctx context.Context, probation *domain.Probation) error
+	Update(ctx context.Context, probation *domain.Probation) error
+	GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Probation, error)
+	CreateReview(ctx context.Context, review *domain.ProbationReview) error
+	ListInProgress(ctx context.Context) ([]*domain.Probation, error)
+}
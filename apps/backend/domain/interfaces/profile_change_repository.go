@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type ProfileChangeRepository interface {
+	GetPolicy(ctx context.Context, companyID uint) (*domain.ProfileChangePolicy, error)
+	UpsertPolicy(ctx context.Context, policy *domain.ProfileChangePolicy) error
+	Create(ctx context.Context, request *domain.ProfileChangeRequest) error
+	GetByID(ctx context.Context, id uint) (*domain.ProfileChangeRequest, error)
+	Update(ctx context.Context, request *domain.ProfileChangeRequest) error
+	List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.ProfileChangeRequest, int64, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+)
+
+type EncashmentReason string
+
+const (
+	EncashmentReasonYearEnd     EncashmentReason = "year_end"
+	EncashmentReasonResignation EncashmentReason = "resignation"
+)
+
+type EncashmentStatus string
+
+const (
+	EncashmentStatusDraft     EncashmentStatus = "draft"
+	EncashmentStatusFinalized EncashmentStatus = "finalized"
+	EncashmentStatusCancelled EncashmentStatus = "cancelled"
+)
+
+// LeaveEncashment pays out unused annual leave. Entries start as drafts so an admin can review
+// them; once finalized they are ready for payroll or the final settlement of a leaver.
+type LeaveEncashment struct {
+	ID         uint             `gorm:"primaryKey"`
+	EmployeeID uint             `gorm:"not null;uniqueIndex:idx_leave_encashment_period"`
+	Employee   Employee         `gorm:"foreignKey:EmployeeID"`
+	Reason     EncashmentReason `gorm:"type:encashment_reason;not null;uniqueIndex:idx_leave_encashment_period"`
+	Year       int              `gorm:"type:int;not null;uniqueIndex:idx_leave_encashment_period"`
+
+	EntitledDays  int     `gorm:"type:int;not null"`
+	UsedDays      int     `gorm:"type:int;not null"`
+	CarryOverDays int     `gorm:"type:int;not null;default:0"`
+	EncashedDays  int     `gorm:"type:int;not null"`
+	DailyRate     float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Amount        float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	Status      EncashmentStatus `gorm:"type:encashment_status;not null;default:'draft'"`
+	AdminNote   *string          `gorm:"type:varchar(255)"`
+	ReviewedBy  *uint            `gorm:"type:uint"`
+	FinalizedAt *time.Time       `gorm:"type:timestamp"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (e *LeaveEncashment) TableName() string {
+	return "leave_encashments"
+}
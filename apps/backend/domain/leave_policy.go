@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+// LeavePolicy holds the leave settings an admin applies to every employee in their organisation.
+type LeavePolicy struct {
+	ID uint `gorm:"primaryKey"`
+
+	// Sick leave longer than this many days needs a medical certificate
+	SickCertificateRequiredAfterDays uint `gorm:"type:uint;not null;default:2"`
+	// Days after the leave ends in which a missing certificate may still be uploaded
+	SickCertificateGraceDays uint `gorm:"type:uint;not null;default:3"`
+	// Leave type a sick leave is converted to when the certificate never arrives
+	SickCertificateFallbackType enums.LeaveType `gorm:"type:leave_type;not null;default:'annual_leave'"`
+
+	// Annual leave is earned in monthly instalments instead of granted in full every January
+	AnnualLeaveAccruesMonthly bool `gorm:"type:boolean;default:false;not null"`
+	// Unused annual leave above this many days is encashed at year end instead of carried over
+	AnnualLeaveCarryOverCap uint `gorm:"type:uint;not null;default:5"`
+	// Working days per month used to derive a daily rate from the monthly base salary
+	EncashmentWorkingDaysPerMonth uint `gorm:"type:uint;not null;default:21"`
+
+	// Employees on a long-term absence recorded while this is set do not count towards subscription seats
+	ExcludeLongTermAbsenceFromSeats bool `gorm:"type:boolean;default:false;not null"`
+
+	// Admin user who owns the policy
+	CreatedBy uint `gorm:"not null;uniqueIndex"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *LeavePolicy) TableName() string {
+	return "leave_policies"
+}
+
+// DefaultLeavePolicy returns the settings used when an organisation has not configured its own policy.
+func DefaultLeavePolicy() *LeavePolicy {
+	return &LeavePolicy{
+		SickCertificateRequiredAfterDays: 2,
+		SickCertificateGraceDays:         3,
+		SickCertificateFallbackType:      enums.AnnualLeave,
+		AnnualLeaveCarryOverCap:          5,
+		EncashmentWorkingDaysPerMonth:    21,
+	}
+}
+
+// DailyRate derives the daily pay used for leave encashment from a monthly base salary.
+func (p *LeavePolicy) DailyRate(baseSalary *float64) float64 {
+	if baseSalary == nil || p.EncashmentWorkingDaysPerMonth == 0 {
+		return 0
+	}
+	return math.Round(*baseSalary/float64(p.EncashmentWorkingDaysPerMonth)*100) / 100
+}
+
+// RequiresSickCertificate reports whether a sick leave of the given number of days needs a certificate.
+func (p *LeavePolicy) RequiresSickCertificate(days int) bool {
+	return days > int(p.SickCertificateRequiredAfterDays)
+}
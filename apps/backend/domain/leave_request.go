@@ -14,10 +14,21 @@ const (
 	LeaveStatusRejected LeaveStatus = "Rejected"
 )
 
+// CertificateStatus tracks the medical certificate of a sick leave request.
+type CertificateStatus string
+
+const (
+	CertificateNotRequired CertificateStatus = "not_required"
+	CertificatePending     CertificateStatus = "pending"
+	CertificateSubmitted   CertificateStatus = "submitted"
+	CertificateMissing     CertificateStatus = "missing"
+)
+
 type LeaveRequest struct {
 	ID           uint            `gorm:"primaryKey"`
 	EmployeeID   uint            `gorm:"not null"`
 	Employee     Employee        `gorm:"foreignKey:EmployeeID"`
+	CompanyID    *uint           `gorm:"index"`
 	LeaveType    enums.LeaveType `gorm:"type:leave_type;not null"`
 	StartDate    time.Time       `gorm:"type:timestamp;not null"`
 	EndDate      time.Time       `gorm:"type:timestamp;not null"`
@@ -27,6 +38,12 @@ type LeaveRequest struct {
 	Duration     uint            `gorm:"type:uint;not null"`
 	Status       LeaveStatus     `gorm:"type:leave_status;not null;default:'Waiting Approval'"`
 
+	// Medical certificate tracking for sick leave
+	CertificateStatus  CertificateStatus `gorm:"type:certificate_status;not null;default:'not_required'"`
+	CertificateDueDate *time.Time        `gorm:"type:date"`
+	// Set when the request was converted because its certificate never arrived
+	OriginalLeaveType *enums.LeaveType `gorm:"type:leave_type"`
+
 	DateRequested time.Time  `gorm:"type:timestamp;not null"`
 	DateApproved  *time.Time `gorm:"type:timestamp"`
 
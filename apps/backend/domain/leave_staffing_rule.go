@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+type StaffingRuleScope string
+
+const (
+	StaffingRuleScopeManagerSubtree StaffingRuleScope = "manager_subtree"
+	StaffingRuleScopeBranch         StaffingRuleScope = "branch"
+)
+
+// LeaveStaffingRule limits how many employees of a team may be on leave on the same day.
+// A manager_subtree rule covers every active employee below RootEmployeeID in the reporting
+// tree. A branch rule covers every active employee of Branch, narrowed to the subtree of
+// RootEmployeeID when one is set. Either MaxAbsent or MaxAbsentPercent (or both) must be set.
+type LeaveStaffingRule struct {
+	ID               uint              `gorm:"primaryKey"`
+	CompanyID        *uint             `gorm:"index"`
+	Name             string            `gorm:"type:varchar(255);not null"`
+	Scope            StaffingRuleScope `gorm:"type:staffing_rule_scope;not null"`
+	RootEmployeeID   *uint             `gorm:"index"`
+	RootEmployee     *Employee         `gorm:"foreignKey:RootEmployeeID"`
+	Branch           *string           `gorm:"type:varchar(255)"`
+	MaxAbsent        *uint             `gorm:"type:uint"`
+	MaxAbsentPercent *float64          `gorm:"type:float"`
+	IsActive         bool              `gorm:"type:boolean;default:true;not null"`
+
+	// Admin user who created the rule
+	CreatedBy uint `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (r *LeaveStaffingRule) TableName() string {
+	return "leave_staffing_rules"
+}
+
+// AllowedAbsences returns the maximum number of people that may be absent on one day
+// for a team of the given headcount. When both limits are set the stricter one wins.
+func (r *LeaveStaffingRule) AllowedAbsences(headcount int64) int64 {
+	allowed := int64(-1)
+	if r.MaxAbsent != nil {
+		allowed = int64(*r.MaxAbsent)
+	}
+	if r.MaxAbsentPercent != nil {
+		byPercent := int64(float64(headcount) * *r.MaxAbsentPercent / 100)
+		if allowed < 0 || byPercent < allowed {
+			allowed = byPercent
+		}
+	}
+	return allowed
+}
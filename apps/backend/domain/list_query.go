@@ -0,0 +1,157 @@
+package domain
+
+// FilterOperator is how a list filter compares a field with its values.
+type FilterOperator string
+
+const (
+	FilterEq  FilterOperator = "eq"
+	FilterIn  FilterOperator = "in"
+	FilterGte FilterOperator = "gte"
+	FilterLte FilterOperator = "lte"
+	// FilterBetween matches dates from its first to its last value, both days included.
+	FilterBetween FilterOperator = "between"
+	// FilterNull matches empty fields when its value is true and filled fields when it is false.
+	FilterNull FilterOperator = "null"
+)
+
+// ListFieldType is the type of the values a list field is filtered on.
+type ListFieldType string
+
+const (
+	ListFieldString ListFieldType = "string"
+	ListFieldNumber ListFieldType = "number"
+	ListFieldBool   ListFieldType = "bool"
+	ListFieldDate   ListFieldType = "date"
+)
+
+// ListField is a field a list can be filtered or sorted on. Column is the qualified database
+// column the field reads. Only admins can filter or sort on an AdminOnly field, as the order of
+// the rows would disclose its values.
+type ListField struct {
+	Column    string
+	Type      ListFieldType
+	Operators []FilterOperator
+	Sortable  bool
+	AdminOnly bool
+}
+
+// Allows reports whether the field can be filtered with the operator.
+func (f ListField) Allows(operator FilterOperator) bool {
+	for _, allowed := range f.Operators {
+		if allowed == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSchema is the whitelist of the fields a list endpoint can be filtered and sorted on, keyed
+// by the name clients use.
+type ListSchema map[string]ListField
+
+// WithoutAdminOnly returns the schema without its admin-only fields.
+func (s ListSchema) WithoutAdminOnly() ListSchema {
+	schema := make(ListSchema, len(s))
+	for name, field := range s {
+		if !field.AdminOnly {
+			schema[name] = field
+		}
+	}
+	return schema
+}
+
+// QueryFilter is a filter of a list on a field. Values hold typed values: strings, float64s,
+// bools or time.Times by the type of the field.
+type QueryFilter struct {
+	Field    string
+	Operator FilterOperator
+	Values   []interface{}
+}
+
+type QuerySort struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery is the filtering, sorting and field selection of a list request, validated against
+// the schema of its list.
+type ListQuery struct {
+	Filters []QueryFilter
+	Sorts   []QuerySort
+	Fields  []string
+}
+
+// The operators of fields compared by equality, of numbers compared by range and of dates.
+var (
+	listEqualityOperators = []FilterOperator{FilterEq, FilterIn, FilterNull}
+	listRangeOperators    = []FilterOperator{FilterEq, FilterGte, FilterLte, FilterNull}
+	listDateOperators     = []FilterOperator{FilterEq, FilterGte, FilterLte, FilterBetween, FilterNull}
+)
+
+// EmployeeListSchema is the schema of the employee list.
+var EmployeeListSchema = ListSchema{
+	"id":                {Column: "employees.id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"first_name":        {Column: "employees.first_name", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"last_name":         {Column: "employees.last_name", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"email":             {Column: "users.email", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"employee_code":     {Column: "employees.employee_code", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"nik":               {Column: "employees.nik", Type: ListFieldString, Operators: listEqualityOperators, AdminOnly: true},
+	"position_name":     {Column: "employees.position_name", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"branch":            {Column: "employees.branch", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"grade":             {Column: "employees.grade", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"gender":            {Column: "employees.gender", Type: ListFieldString, Operators: listEqualityOperators},
+	"last_education":    {Column: "employees.last_education", Type: ListFieldString, Operators: listEqualityOperators},
+	"contract_type":     {Column: "employees.contract_type", Type: ListFieldString, Operators: listEqualityOperators, Sortable: true},
+	"tax_status":        {Column: "employees.tax_status", Type: ListFieldString, Operators: listEqualityOperators},
+	"employment_status": {Column: "employees.employment_status", Type: ListFieldBool, Operators: []FilterOperator{FilterEq}, Sortable: true},
+	"department_id":     {Column: "employees.department_id", Type: ListFieldNumber, Operators: listEqualityOperators},
+	"branch_id":         {Column: "employees.branch_id", Type: ListFieldNumber, Operators: listEqualityOperators},
+	"position_id":       {Column: "employees.position_id", Type: ListFieldNumber, Operators: listEqualityOperators},
+	"manager_id":        {Column: "employees.manager_id", Type: ListFieldNumber, Operators: listEqualityOperators},
+	"base_salary":       {Column: "employees.base_salary", Type: ListFieldNumber, Operators: listRangeOperators, Sortable: true, AdminOnly: true},
+	"date_of_birth":     {Column: "employees.date_of_birth", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"hire_date":         {Column: "employees.hire_date", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"resignation_date":  {Column: "employees.resignation_date", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"created_at":        {Column: "employees.created_at", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+}
+
+// AttendanceListSchema is the schema of the attendance lists.
+var AttendanceListSchema = ListSchema{
+	"id":          {Column: "attendances.id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"employee_id": {Column: "attendances.employee_id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"date":        {Column: "attendances.date", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"clock_in":    {Column: "attendances.clock_in", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"clock_out":   {Column: "attendances.clock_out", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"work_hours":  {Column: "attendances.work_hours", Type: ListFieldNumber, Operators: listRangeOperators, Sortable: true},
+	"status":      {Column: "attendances.status", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+}
+
+// LeaveRequestListSchema is the schema of the leave request lists.
+var LeaveRequestListSchema = ListSchema{
+	"id":                 {Column: "leave_requests.id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"employee_id":        {Column: "leave_requests.employee_id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"leave_type":         {Column: "leave_requests.leave_type", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"status":             {Column: "leave_requests.status", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"start_date":         {Column: "leave_requests.start_date", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"end_date":           {Column: "leave_requests.end_date", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"duration":           {Column: "leave_requests.duration", Type: ListFieldNumber, Operators: listRangeOperators, Sortable: true},
+	"certificate_status": {Column: "leave_requests.certificate_status", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}},
+	"date_requested":     {Column: "leave_requests.date_requested", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"created_at":         {Column: "leave_requests.created_at", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+}
+
+// DocumentListSchema is the schema of the document lists.
+var DocumentListSchema = ListSchema{
+	"id":         {Column: "documents.id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"name":       {Column: "documents.name", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"created_at": {Column: "documents.created_at", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+	"updated_at": {Column: "documents.updated_at", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+}
+
+// LocationListSchema is the schema of the location list.
+var LocationListSchema = ListSchema{
+	"id":         {Column: "locations.id", Type: ListFieldNumber, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"name":       {Column: "locations.name", Type: ListFieldString, Operators: []FilterOperator{FilterEq, FilterIn}, Sortable: true},
+	"radius_m":   {Column: "locations.radius_m", Type: ListFieldNumber, Operators: listRangeOperators, Sortable: true},
+	"created_at": {Column: "locations.created_at", Type: ListFieldDate, Operators: listDateOperators, Sortable: true},
+}
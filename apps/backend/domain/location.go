@@ -12,6 +12,7 @@ type Location struct {
 	Longitude     float64 `gorm:"not null"`
 	RadiusM       int     `gorm:"not null"` // radius dalam meter
 	IsActive      bool    `gorm:"type:boolean;default:true;not null"`
+	CompanyID     *uint   `gorm:"index"`
 
 	// User yang membuat location (admin)
 	CreatedBy uint `gorm:"not null"`
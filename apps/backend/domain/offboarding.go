@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+)
+
+type ResignationType string
+
+const (
+	ResignationVoluntary     ResignationType = "voluntary"
+	ResignationTermination   ResignationType = "termination"
+	ResignationEndOfContract ResignationType = "end_of_contract"
+)
+
+// ExitReason groups why employees leave for the exit reason analytics.
+type ExitReason string
+
+const (
+	ExitReasonCareerGrowth  ExitReason = "career_growth"
+	ExitReasonCompensation  ExitReason = "compensation"
+	ExitReasonWorkLife      ExitReason = "work_life_balance"
+	ExitReasonManagement    ExitReason = "management"
+	ExitReasonRelocation    ExitReason = "relocation"
+	ExitReasonPersonal      ExitReason = "personal"
+	ExitReasonPerformance   ExitReason = "performance"
+	ExitReasonMisconduct    ExitReason = "misconduct"
+	ExitReasonRestructuring ExitReason = "restructuring"
+	ExitReasonContractEnded ExitReason = "contract_ended"
+	ExitReasonOther         ExitReason = "other"
+)
+
+type OffboardingStatus string
+
+const (
+	OffboardingScheduled OffboardingStatus = "scheduled"
+	OffboardingCompleted OffboardingStatus = "completed"
+	OffboardingCancelled OffboardingStatus = "cancelled"
+)
+
+// Offboarding is the departure of an employee. The employee keeps their access until the end of
+// the last working day; the resignation takes effect on the day after, when their sessions are
+// revoked and their account is deactivated.
+type Offboarding struct {
+	ID              uint              `gorm:"primaryKey"`
+	CompanyID       *uint             `gorm:"index"`
+	EmployeeID      uint              `gorm:"not null;index"`
+	Employee        Employee          `gorm:"foreignKey:EmployeeID"`
+	ResignationType ResignationType   `gorm:"type:resignation_type;not null"`
+	ExitReason      ExitReason        `gorm:"type:varchar(50);not null"`
+	Notes           *string           `gorm:"type:text"`
+	NoticeDate      time.Time         `gorm:"type:date;not null"`
+	LastWorkingDay  time.Time         `gorm:"type:date;not null"`
+	Status          OffboardingStatus `gorm:"type:offboarding_status;not null;default:'scheduled'"`
+	InitiatedBy     uint              `gorm:"not null"`
+	CompletedAt     *time.Time        `gorm:"type:timestamp"`
+
+	Tasks []OffboardingTask `gorm:"foreignKey:OffboardingID"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (o *Offboarding) TableName() string {
+	return "offboardings"
+}
+
+// EffectiveDate is the first day the employee is no longer employed.
+func (o *Offboarding) EffectiveDate() time.Time {
+	return o.LastWorkingDay.AddDate(0, 0, 1)
+}
+
+// OffboardingTask is one item of the exit checklist. The assignee is the employee responsible
+// for it, such as the leaver's manager for the knowledge handover.
+type OffboardingTask struct {
+	ID            uint       `gorm:"primaryKey"`
+	OffboardingID uint       `gorm:"not null;index"`
+	Title         string     `gorm:"type:varchar(255);not null"`
+	AssigneeID    *uint      `gorm:"type:uint"`
+	Assignee      *Employee  `gorm:"foreignKey:AssigneeID"`
+	DueDate       time.Time  `gorm:"type:date;not null"`
+	CompletedAt   *time.Time `gorm:"type:timestamp"`
+	CompletedBy   *uint      `gorm:"type:uint"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (t *OffboardingTask) TableName() string {
+	return "offboarding_tasks"
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+)
+
+// OnboardingOwner is who is responsible for an onboarding task of a new hire. It is resolved to
+// an employee when the checklist is created.
+type OnboardingOwner string
+
+const (
+	OnboardingOwnerHR       OnboardingOwner = "hr"
+	OnboardingOwnerManager  OnboardingOwner = "manager"
+	OnboardingOwnerEmployee OnboardingOwner = "employee"
+)
+
+// OnboardingReminderDays is how many days before its due date the assignee of an open onboarding
+// task is reminded of it. A second reminder is sent once the task is overdue.
+const OnboardingReminderDays = 3
+
+// DefaultOnboardingTasks is the checklist used for new hires that no template applies to.
+var DefaultOnboardingTasks = []OnboardingTemplateTask{
+	{Title: "Collect NPWP and KTP documents", Owner: OnboardingOwnerEmployee, DueDays: 7, SortOrder: 1},
+	{Title: "Assign work schedule", Owner: OnboardingOwnerHR, DueDays: 0, SortOrder: 2},
+	{Title: "Equipment handover", Owner: OnboardingOwnerManager, DueDays: 0, SortOrder: 3},
+}
+
+// OnboardingTemplate is the checklist given to new hires of a position, a department or, when
+// both are empty, the whole company. The most specific template applies.
+type OnboardingTemplate struct {
+	ID           uint                     `gorm:"primaryKey"`
+	CompanyID    *uint                    `gorm:"index"`
+	Name         string                   `gorm:"type:varchar(255);not null"`
+	DepartmentID *uint                    `gorm:"index"`
+	PositionID   *uint                    `gorm:"index"`
+	Tasks        []OnboardingTemplateTask `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+	CreatedBy    uint                     `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (t *OnboardingTemplate) TableName() string {
+	return "onboarding_templates"
+}
+
+// OnboardingTemplateTask is a task of an onboarding template. The task is due DueDays after the
+// new hire's hire date.
+type OnboardingTemplateTask struct {
+	ID          uint            `gorm:"primaryKey"`
+	TemplateID  uint            `gorm:"not null;index"`
+	Title       string          `gorm:"type:varchar(255);not null"`
+	Description *string         `gorm:"type:text"`
+	Owner       OnboardingOwner `gorm:"type:onboarding_owner;not null"`
+	DueDays     int             `gorm:"not null;default:0"`
+	SortOrder   int             `gorm:"not null;default:0"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (t *OnboardingTemplateTask) TableName() string {
+	return "onboarding_template_tasks"
+}
+
+// OnboardingTask is an item of a new hire's onboarding checklist.
+type OnboardingTask struct {
+	ID          uint            `gorm:"primaryKey"`
+	CompanyID   *uint           `gorm:"index"`
+	EmployeeID  uint            `gorm:"not null;index"`
+	Employee    Employee        `gorm:"foreignKey:EmployeeID"`
+	TemplateID  *uint           `gorm:"index"`
+	Title       string          `gorm:"type:varchar(255);not null"`
+	Description *string         `gorm:"type:text"`
+	Owner       OnboardingOwner `gorm:"type:onboarding_owner;not null"`
+	AssigneeID  *uint           `gorm:"index"`
+	Assignee    *Employee       `gorm:"foreignKey:AssigneeID"`
+	DueDate     time.Time       `gorm:"type:date;not null"`
+	SortOrder   int             `gorm:"not null;default:0"`
+	CompletedAt *time.Time      `gorm:"type:timestamp"`
+	CompletedBy *uint           `gorm:"type:uint"`
+	RemindedAt  *time.Time      `gorm:"type:timestamp"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (t *OnboardingTask) TableName() string {
+	return "onboarding_tasks"
+}
+
+// Overdue reports whether the task is still open after its due date.
+func (t *OnboardingTask) Overdue(today time.Time) bool {
+	return t.CompletedAt == nil && t.DueDate.Before(today)
+}
@@ -1,15 +1,81 @@
 package domain
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Pagination describes a page of a list. Lists paginated by cursor do not count their items, so
+// they leave TotalItems, TotalPages and CurrentPage empty and link to their next page with
+// NextCursor instead.
 type Pagination struct {
-	TotalItems  int64 `json:"total_items"`
-	TotalPages  int   `json:"total_pages"`
-	CurrentPage int   `json:"current_page"`
-	PageSize    int   `json:"page_size"`
-	HasNextPage bool  `json:"has_next_page"`
-	HasPrevPage bool  `json:"has_prev_page"`
+	TotalItems  int64  `json:"total_items"`
+	TotalPages  int    `json:"total_pages"`
+	CurrentPage int    `json:"current_page"`
+	PageSize    int    `json:"page_size"`
+	HasNextPage bool   `json:"has_next_page"`
+	HasPrevPage bool   `json:"has_prev_page"`
+	NextCursor  string `json:"next_cursor,omitempty"`
 }
 
 type PaginationParams struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
+
+	// Query filters and sorts the lists that support it; nil keeps their default order.
+	Query *ListQuery `json:"-"`
+	// Cursor switches the lists that support it from pages to cursors; nil keeps them on pages.
+	// Cursor pages are always in the order of the cursor, so they cannot be sorted.
+	Cursor *Cursor `json:"-"`
+}
+
+// Cursor is a position in a list ordered by date and then id, both descending: the date and id of
+// the last item before it. The zero Cursor is the start of the list.
+type Cursor struct {
+	Date time.Time `json:"d"`
+	ID   uint      `json:"i"`
+}
+
+// IsStart reports whether the cursor is the start of its list.
+func (c Cursor) IsStart() bool {
+	return c.ID == 0
+}
+
+// Encode returns the opaque form of the cursor clients pass back.
+func (c Cursor) Encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor reads a cursor encoded by Encode. An empty token is the start of the list.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return &Cursor{}, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil || cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// NewCursorPage trims a page of a cursor-paginated list, fetched with one item more than its size
+// to tell whether another page follows, and returns its pagination.
+func NewCursorPage[T any](items []T, params PaginationParams, cursorOf func(T) Cursor) ([]T, Pagination) {
+	pagination := Pagination{
+		PageSize:    params.PageSize,
+		HasPrevPage: params.Cursor != nil && !params.Cursor.IsStart(),
+	}
+	if params.PageSize > 0 && len(items) > params.PageSize {
+		items = items[:params.PageSize]
+		pagination.HasNextPage = true
+		pagination.NextCursor = cursorOf(items[len(items)-1]).Encode()
+	}
+	return items, pagination
 }
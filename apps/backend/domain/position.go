@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// Position is a job title of a company that employees are assigned to.
+type Position struct {
+	ID        uint   `gorm:"primaryKey"`
+	CompanyID *uint  `gorm:"index"`
+	Name      string `gorm:"type:varchar(255);not null"`
+
+	// Admin user who created the position
+	CreatedBy uint `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *Position) TableName() string {
+	return "positions"
+}
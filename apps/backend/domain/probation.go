@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"time"
+)
+
+type ProbationStatus string
+
+const (
+	ProbationInProgress ProbationStatus = "in_progress"
+	ProbationConfirmed  ProbationStatus = "confirmed"
+	ProbationTerminated ProbationStatus = "terminated"
+)
+
+// ProbationOutcome is the decision of a probation review.
+type ProbationOutcome string
+
+const (
+	ProbationOutcomeConfirm   ProbationOutcome = "confirm"
+	ProbationOutcomeExtend    ProbationOutcome = "extend"
+	ProbationOutcomeTerminate ProbationOutcome = "terminate"
+)
+
+const (
+	// DefaultProbationMonths is the length of the probation new hires start with.
+	DefaultProbationMonths = 3
+	// MaxProbationMonths caps how far a probation can be extended, counted from its start.
+	MaxProbationMonths = 6
+	// ProbationReviewLeadDays is how many days before the end of the probation the manager's
+	// review is due.
+	ProbationReviewLeadDays = 7
+)
+
+// ProbationReminderDays are the number of days before the review due date at which the manager and
+// HR are reminded, from the first reminder to the last.
+var ProbationReminderDays = []int{14, 7, 1}
+
+// Probation is the probation period of a new hire. It ends with a review by the manager that
+// confirms the employee, extends the probation or terminates the employment. LastReminderDays is
+// the last review reminder sent, so each reminder goes out only once.
+type Probation struct {
+	ID         uint            `gorm:"primaryKey"`
+	CompanyID  *uint           `gorm:"index"`
+	EmployeeID uint            `gorm:"not null;index"`
+	Employee   Employee        `gorm:"foreignKey:EmployeeID"`
+	StartDate  time.Time       `gorm:"type:date;not null"`
+	EndDate    time.Time       `gorm:"type:date;not null"`
+	Status     ProbationStatus `gorm:"type:probation_status;not null;default:'in_progress'"`
+
+	LastReminderDays *int `gorm:"type:int"`
+
+	Reviews []ProbationReview `gorm:"foreignKey:ProbationID"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *Probation) TableName() string {
+	return "probations"
+}
+
+// ReviewDueDate is the day the manager's review has to be submitted by.
+func (p *Probation) ReviewDueDate() time.Time {
+	return p.EndDate.AddDate(0, 0, -ProbationReviewLeadDays)
+}
+
+// DaysUntilReview returns the number of days from the given day until the review is due, negative
+// once it is overdue.
+func (p *Probation) DaysUntilReview(today time.Time) int {
+	due := p.ReviewDueDate()
+	end := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// ProbationReview is a manager's review of a probation. For an extension NewEndDate is the end
+// date the probation was extended to.
+type ProbationReview struct {
+	ID              uint             `gorm:"primaryKey"`
+	ProbationID     uint             `gorm:"not null;index"`
+	ReviewerID      uint             `gorm:"not null"`
+	Outcome         ProbationOutcome `gorm:"type:probation_outcome;not null"`
+	Rating          *int             `gorm:"type:int"`
+	Comments        *string          `gorm:"type:text"`
+	PreviousEndDate time.Time        `gorm:"type:date;not null"`
+	NewEndDate      *time.Time       `gorm:"type:date"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (r *ProbationReview) TableName() string {
+	return "probation_reviews"
+}
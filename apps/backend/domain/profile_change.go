@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+// ProfileField is an employee profile field that can be changed through a profile change request.
+type ProfileField string
+
+const (
+	ProfileFieldFirstName             ProfileField = "first_name"
+	ProfileFieldLastName              ProfileField = "last_name"
+	ProfileFieldNIK                   ProfileField = "nik"
+	ProfileFieldBankName              ProfileField = "bank_name"
+	ProfileFieldBankAccountNumber     ProfileField = "bank_account_number"
+	ProfileFieldBankAccountHolderName ProfileField = "bank_account_holder_name"
+	ProfileFieldTaxStatus             ProfileField = "tax_status"
+)
+
+// ProfileFields are the fields a company can require approval for.
+var ProfileFields = []ProfileField{
+	ProfileFieldFirstName,
+	ProfileFieldLastName,
+	ProfileFieldNIK,
+	ProfileFieldBankName,
+	ProfileFieldBankAccountNumber,
+	ProfileFieldBankAccountHolderName,
+	ProfileFieldTaxStatus,
+}
+
+// DefaultApprovalProfileFields are the fields that need approval when a company has not configured
+// its own.
+var DefaultApprovalProfileFields = []ProfileField{
+	ProfileFieldFirstName,
+	ProfileFieldLastName,
+	ProfileFieldNIK,
+	ProfileFieldBankAccountNumber,
+	ProfileFieldTaxStatus,
+}
+
+// IsValid reports whether the field can be changed through a profile change request.
+func (f ProfileField) IsValid() bool {
+	for _, field := range ProfileFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfileChangePolicy lists the profile fields employees of a company can only change with the
+// approval of an admin.
+type ProfileChangePolicy struct {
+	ID        uint           `gorm:"primaryKey"`
+	CompanyID uint           `gorm:"not null;uniqueIndex"`
+	Fields    []ProfileField `gorm:"type:jsonb;serializer:json;not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *ProfileChangePolicy) TableName() string {
+	return "profile_change_policies"
+}
+
+// RequiresApproval reports whether changes to the field need approval.
+func (p *ProfileChangePolicy) RequiresApproval(field ProfileField) bool {
+	for _, f := range p.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultProfileChangePolicy returns the policy used when a company has not configured its own.
+func DefaultProfileChangePolicy() *ProfileChangePolicy {
+	fields := make([]ProfileField, len(DefaultApprovalProfileFields))
+	copy(fields, DefaultApprovalProfileFields)
+	return &ProfileChangePolicy{Fields: fields}
+}
+
+type ProfileChangeStatus string
+
+const (
+	ProfileChangePending   ProfileChangeStatus = "pending"
+	ProfileChangeApproved  ProfileChangeStatus = "approved"
+	ProfileChangeRejected  ProfileChangeStatus = "rejected"
+	ProfileChangeCancelled ProfileChangeStatus = "cancelled"
+)
+
+// ProfileFieldChange is the change of a single field. A nil value is an empty field.
+type ProfileFieldChange struct {
+	From *string `json:"from"`
+	To   *string `json:"to"`
+}
+
+// ProfileChangeRequest is a change of sensitive profile fields submitted by an employee. It is
+// applied to the employee only once an admin approves it. From holds the values at submission and
+// is refreshed with the values that were actually replaced on approval, so the requests of an
+// employee form the history of their profile.
+type ProfileChangeRequest struct {
+	ID          uint                                `gorm:"primaryKey"`
+	CompanyID   *uint                               `gorm:"index"`
+	EmployeeID  uint                                `gorm:"not null;index"`
+	Employee    Employee                            `gorm:"foreignKey:EmployeeID"`
+	Changes     map[ProfileField]ProfileFieldChange `gorm:"type:jsonb;serializer:json;not null"`
+	Reason      *string                             `gorm:"type:text"`
+	DocumentURL *string                             `gorm:"type:varchar(255)"`
+	Status      ProfileChangeStatus                 `gorm:"type:profile_change_status;not null;default:'pending'"`
+
+	ReviewedBy *uint      `gorm:"type:uint"`
+	ReviewedAt *time.Time `gorm:"type:timestamp"`
+	ReviewNote *string    `gorm:"type:text"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (r *ProfileChangeRequest) TableName() string {
+	return "profile_change_requests"
+}
+
+// ProfileFieldValue returns the value of a profile field, nil when it is empty.
+func (a *Employee) ProfileFieldValue(field ProfileField) *string {
+	switch field {
+	case ProfileFieldFirstName:
+		if a.FirstName == "" {
+			return nil
+		}
+		value := a.FirstName
+		return &value
+	case ProfileFieldLastName:
+		return copyString(a.LastName)
+	case ProfileFieldNIK:
+		return copyString(a.NIK)
+	case ProfileFieldBankName:
+		return copyString(a.BankName)
+	case ProfileFieldBankAccountNumber:
+		return copyString(a.BankAccountNumber)
+	case ProfileFieldBankAccountHolderName:
+		return copyString(a.BankAccountHolderName)
+	case ProfileFieldTaxStatus:
+		if a.TaxStatus == nil {
+			return nil
+		}
+		value := string(*a.TaxStatus)
+		return &value
+	}
+	return nil
+}
+
+// SetProfileField validates a new value of a profile field and sets it. A nil or blank value
+// clears the field, which is not allowed for the first name.
+func (a *Employee) SetProfileField(field ProfileField, value *string) error {
+	if value != nil {
+		trimmed := strings.TrimSpace(*value)
+		value = &trimmed
+		if trimmed == "" {
+			value = nil
+		}
+	}
+
+	switch field {
+	case ProfileFieldFirstName:
+		if value == nil {
+			return fmt.Errorf("%w: first_name cannot be empty", ErrInvalidProfileChange)
+		}
+		a.FirstName = *value
+	case ProfileFieldLastName:
+		a.LastName = value
+	case ProfileFieldNIK:
+		if value != nil && strings.IndexFunc(*value, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+			return fmt.Errorf("%w: nik must be numeric", ErrInvalidProfileChange)
+		}
+		a.NIK = value
+	case ProfileFieldBankName:
+		a.BankName = value
+	case ProfileFieldBankAccountNumber:
+		a.BankAccountNumber = value
+	case ProfileFieldBankAccountHolderName:
+		a.BankAccountHolderName = value
+	case ProfileFieldTaxStatus:
+		if value == nil {
+			a.TaxStatus = nil
+			return nil
+		}
+		taxStatus := enums.TaxStatus(*value)
+		if !taxStatus.IsValid() {
+			return fmt.Errorf("%w: %s is not a valid tax_status", ErrInvalidProfileChange, *value)
+		}
+		a.TaxStatus = &taxStatus
+	default:
+		return fmt.Errorf("%w: %s cannot be changed through a profile change request", ErrInvalidProfileChange, field)
+	}
+	return nil
+}
+
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
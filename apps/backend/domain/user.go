@@ -13,6 +13,7 @@ type User struct {
 	Password    string         `gorm:"-"`
 	Phone       string         `gorm:"type:varchar(20);unique;default:null"`
 	Role        enums.UserRole `gorm:"type:user_role;not null;default:user"`
+	CompanyID   *uint          `gorm:"index"`
 
 	HasUsedTrial bool          `gorm:"type:boolean;default:false;not null"`
 
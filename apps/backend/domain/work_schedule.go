@@ -25,6 +25,7 @@ type WorkSchedule struct {
 	Details   []WorkScheduleDetail `gorm:"foreignKey:WorkScheduleID"`
 	IsActive  bool                 `gorm:"type:boolean;default:true;not null"`
 	CreatedBy uint                 `gorm:"not null"` // Foreign key to User table
+	CompanyID *uint                `gorm:"index"`
 
 	CreatedAt time.Time `gorm:"autoCreateTime"` // Corrected casing
 	UpdatedAt time.Time `gorm:"autoUpdateTime"` // Corrected casing
@@ -7,9 +7,21 @@ import (
 	"time"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
+// reportingLineQuery selects the IDs of every employee reporting to the given manager, directly or
+// through other managers.
+const reportingLineQuery = `
+	WITH RECURSIVE team AS (
+		SELECT id FROM employees WHERE manager_id = ?
+		UNION
+		SELECT e.id FROM employees e JOIN team t ON e.manager_id = t.id
+	)
+	SELECT id FROM team`
+
 type AttendanceRepository struct {
 	db *gorm.DB
 }
@@ -21,6 +33,14 @@ func NewAttendanceRepository(db *gorm.DB) *AttendanceRepository {
 }
 
 func (r *AttendanceRepository) Create(ctx context.Context, attendance *domain.Attendance) error {
+	if attendance.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, attendance.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", attendance.EmployeeID, err)
+		}
+		attendance.CompanyID = companyID
+	}
+
 	if err := r.db.WithContext(ctx).Create(attendance).Error; err != nil {
 		return fmt.Errorf("failed to create attendance: %w", err)
 	}
@@ -30,6 +50,7 @@ func (r *AttendanceRepository) Create(ctx context.Context, attendance *domain.At
 func (r *AttendanceRepository) GetByID(ctx context.Context, id uint) (*domain.Attendance, error) {
 	var attendance domain.Attendance
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Preload("Employee").
 		First(&attendance, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -49,6 +70,7 @@ func (r *AttendanceRepository) GetByEmployeeAndDate(ctx context.Context, employe
 	}
 
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Preload("Employee").
 		Where("employee_id = ? AND DATE(date) = DATE(?)", employeeID, parsedDate).
 		First(&attendance).Error; err != nil {
@@ -64,7 +86,15 @@ func (r *AttendanceRepository) ListByEmployee(ctx context.Context, employeeID ui
 	var attendances []*domain.Attendance
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Attendance{}).Where("employee_id = ?", employeeID)
+	query := r.db.WithContext(ctx).Model(&domain.Attendance{}).Where("employee_id = ?", employeeID).
+		Scopes(tenant.Scope(ctx, "attendances"), listquery.Scope(paginationParams.Query, domain.AttendanceListSchema))
+
+	if paginationParams.Cursor != nil {
+		if err := query.Preload("Employee").Scopes(listquery.After(paginationParams, "attendances.date", "attendances.id")).Find(&attendances).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to list attendances by employee: %w", err)
+		}
+		return attendances, 0, nil
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count attendances by employee: %w", err)
@@ -72,7 +102,7 @@ func (r *AttendanceRepository) ListByEmployee(ctx context.Context, employeeID ui
   
 	// Get paginated records
 	offset := (paginationParams.Page - 1) * paginationParams.PageSize
-	if err := query.Preload("Employee").Offset(offset).Limit(paginationParams.PageSize).Order("date desc, clock_in desc").Find(&attendances).Error; err != nil {
+	if err := query.Preload("Employee").Offset(offset).Limit(paginationParams.PageSize).Order(listquery.Order(paginationParams.Query, domain.AttendanceListSchema, "date desc, clock_in desc")).Find(&attendances).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to list attendances by employee: %w", err)
 	}
 
@@ -83,7 +113,14 @@ func (r *AttendanceRepository) ListAll(ctx context.Context, paginationParams dom
 	var attendances []*domain.Attendance
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Attendance{})
+	query := r.db.WithContext(ctx).Model(&domain.Attendance{}).Scopes(tenant.Scope(ctx, "attendances"))
+
+	if paginationParams.Cursor != nil {
+		if err := query.Preload("Employee").Scopes(listquery.After(paginationParams, "attendances.date", "attendances.id")).Find(&attendances).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to list all attendances: %w", err)
+		}
+		return attendances, 0, nil
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count all attendances: %w", err)
@@ -101,10 +138,27 @@ func (r *AttendanceRepository) ListByManager(ctx context.Context, managerID uint
 	var attendances []*domain.Attendance
 	var total int64
 
+	team := func(db *gorm.DB) *gorm.DB {
+		return db.
+			Joins("JOIN employees ON attendances.employee_id = employees.id").
+			Where("employees.id IN ("+reportingLineQuery+")", managerID).
+			Scopes(tenant.Scope(ctx, "attendances"), listquery.Scope(paginationParams.Query, domain.AttendanceListSchema))
+	}
+
+	if paginationParams.Cursor != nil {
+		if err := r.db.WithContext(ctx).
+			Model(&domain.Attendance{}).
+			Scopes(team, listquery.After(paginationParams, "attendances.date", "attendances.id")).
+			Preload("Employee").
+			Find(&attendances).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to list attendances by manager: %w", err)
+		}
+		return attendances, 0, nil
+	}
+
 	query := r.db.WithContext(ctx).
 		Table("attendances").
-		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("employees.manager_id = ?", managerID)
+		Scopes(team)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count attendances by manager: %w", err)
@@ -113,12 +167,11 @@ func (r *AttendanceRepository) ListByManager(ctx context.Context, managerID uint
 	offset := (paginationParams.Page - 1) * paginationParams.PageSize
 	if err := r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
-		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("employees.manager_id = ?", managerID).
+		Scopes(team).
 		Preload("Employee").
 		Offset(offset).
 		Limit(paginationParams.PageSize).
-		Order("attendances.date DESC, attendances.clock_in DESC").
+		Order(listquery.Order(paginationParams.Query, domain.AttendanceListSchema, "attendances.date DESC, attendances.clock_in DESC")).
 		Find(&attendances).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to list attendances by manager: %w", err)
 	}
@@ -127,14 +180,14 @@ func (r *AttendanceRepository) ListByManager(ctx context.Context, managerID uint
 }
 
 func (r *AttendanceRepository) Update(ctx context.Context, attendance *domain.Attendance) error {
-	if err := r.db.WithContext(ctx).Save(attendance).Error; err != nil {
+	if err := tenant.Save(ctx, r.db, "attendances", attendance); err != nil {
 		return fmt.Errorf("failed to update attendance with ID %d: %w", attendance.ID, err)
 	}
 	return nil
 }
 
 func (r *AttendanceRepository) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Attendance{}, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "attendances")).Delete(&domain.Attendance{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete attendance with ID %d: %w", id, err)
 	}
 	return nil
@@ -154,6 +207,7 @@ func (r *AttendanceRepository) GetByEmployeeAndDateRange(ctx context.Context, em
 	}
 
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND DATE(date) BETWEEN DATE(?) AND DATE(?)", employeeID, startParsed, endParsed).
 		Preload("Employee").
 		Order("date ASC").
@@ -178,6 +232,7 @@ func (r *AttendanceRepository) GetByDateRange(ctx context.Context, startDate, en
 	}
 
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) BETWEEN DATE(?) AND DATE(?)", startParsed, endParsed).
 		Preload("Employee").
 		Order("date ASC, employee_id ASC").
@@ -193,6 +248,7 @@ func (r *AttendanceRepository) CountByEmployeeAndStatus(ctx context.Context, emp
 
 	if err := r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND status = ?", employeeID, status).
 		Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count attendance by employee and status: %w", err)
@@ -206,6 +262,7 @@ func (r *AttendanceRepository) GetTodayAttendanceByEmployee(ctx context.Context,
 	today := time.Now().Format("2006-01-02")
 
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND DATE(date) = DATE(?)", employeeID, today).
 		Preload("Employee").
 		First(&attendance).Error; err != nil {
@@ -223,6 +280,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) = DATE(?) AND status = ?", today, domain.OnTime).
 		Count(&onTime).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count on-time attendances: %w", err)
@@ -230,6 +288,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) = DATE(?) AND status = ?", today, domain.Late).
 		Count(&late).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count late attendances: %w", err)
@@ -237,6 +296,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) = DATE(?) AND status = ?", today, domain.EarlyLeave).
 		Count(&earlyLeave).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count early leave attendances: %w", err)
@@ -244,6 +304,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) = DATE(?) AND status = ?", today, domain.Absent).
 		Count(&absent).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count absent attendances: %w", err)
@@ -251,6 +312,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("DATE(date) = DATE(?) AND status = ?", today, domain.Leave).
 		Count(&leave).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count leave attendances: %w", err)
@@ -260,6 +322,7 @@ func (r *AttendanceRepository) GetStatistics(ctx context.Context) (onTime, late,
 
 	if err = r.db.WithContext(ctx).
 		Table("employees").
+		Scopes(tenant.Scope(ctx, "employees")).
 		Where("employment_status = ?", true).
 		Count(&totalEmployees).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count total employees: %w", err)
@@ -273,40 +336,45 @@ func (r *AttendanceRepository) GetStatisticsByManager(ctx context.Context, manag
 
 	if err = r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ? AND attendances.status = ?", today, managerID, domain.OnTime).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+") AND attendances.status = ?", today, managerID, domain.OnTime).
 		Count(&onTime).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count on-time attendances by manager: %w", err)
 	}
 
 	if err = r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ? AND attendances.status = ?", today, managerID, domain.Late).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+") AND attendances.status = ?", today, managerID, domain.Late).
 		Count(&late).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count late attendances by manager: %w", err)
 	}
 
 	if err = r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ? AND attendances.status = ?", today, managerID, domain.EarlyLeave).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+") AND attendances.status = ?", today, managerID, domain.EarlyLeave).
 		Count(&earlyLeave).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count early leave attendances by manager: %w", err)
 	}
 
 	if err = r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ? AND attendances.status = ?", today, managerID, domain.Absent).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+") AND attendances.status = ?", today, managerID, domain.Absent).
 		Count(&absent).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count absent attendances by manager: %w", err)
 	}
 
 	if err = r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ? AND attendances.status = ?", today, managerID, domain.Leave).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+") AND attendances.status = ?", today, managerID, domain.Leave).
 		Count(&leave).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count leave attendances by manager: %w", err)
 	}
@@ -315,7 +383,8 @@ func (r *AttendanceRepository) GetStatisticsByManager(ctx context.Context, manag
 
 	if err = r.db.WithContext(ctx).
 		Table("employees").
-		Where("employment_status = ? AND manager_id = ?", true, managerID).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Where("employment_status = ? AND id IN ("+reportingLineQuery+")", true, managerID).
 		Count(&totalEmployees).Error; err != nil {
 		return 0, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to count total employees by manager: %w", err)
 	}
@@ -332,8 +401,9 @@ func (r *AttendanceRepository) GetTodayAttendancesByManager(ctx context.Context,
 	// Build base query
 	query := r.db.WithContext(ctx).
 		Table("attendances").
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ?", today, managerID)
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+")", today, managerID)
 
 	// Count total records
 	if err := query.Count(&total).Error; err != nil {
@@ -344,8 +414,9 @@ func (r *AttendanceRepository) GetTodayAttendancesByManager(ctx context.Context,
 	offset := (paginationParams.Page - 1) * paginationParams.PageSize
 	if err := r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Joins("JOIN employees ON attendances.employee_id = employees.id").
-		Where("DATE(attendances.date) = DATE(?) AND employees.manager_id = ?", today, managerID).
+		Where("DATE(attendances.date) = DATE(?) AND employees.id IN ("+reportingLineQuery+")", today, managerID).
 		Preload("Employee").
 		Offset(offset).
 		Limit(paginationParams.PageSize).
@@ -369,6 +440,7 @@ func (r *AttendanceRepository) GetEmployeeMonthlyStatistics(ctx context.Context,
 	// Count each status for the month
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND date >= ? AND date <= ? AND status = ?", employeeID, startDate, endDate, domain.OnTime).
 		Count(&onTime).Error; err != nil {
 		return 0, 0, 0, 0, 0, fmt.Errorf("failed to count on-time attendances for employee: %w", err)
@@ -376,6 +448,7 @@ func (r *AttendanceRepository) GetEmployeeMonthlyStatistics(ctx context.Context,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND date >= ? AND date <= ? AND status = ?", employeeID, startDate, endDate, domain.Late).
 		Count(&late).Error; err != nil {
 		return 0, 0, 0, 0, 0, fmt.Errorf("failed to count late attendances for employee: %w", err)
@@ -383,6 +456,7 @@ func (r *AttendanceRepository) GetEmployeeMonthlyStatistics(ctx context.Context,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND date >= ? AND date <= ? AND status = ?", employeeID, startDate, endDate, domain.Absent).
 		Count(&absent).Error; err != nil {
 		return 0, 0, 0, 0, 0, fmt.Errorf("failed to count absent attendances for employee: %w", err)
@@ -390,6 +464,7 @@ func (r *AttendanceRepository) GetEmployeeMonthlyStatistics(ctx context.Context,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Where("employee_id = ? AND date >= ? AND date <= ? AND status = ?", employeeID, startDate, endDate, domain.Leave).
 		Count(&leave).Error; err != nil {
 		return 0, 0, 0, 0, 0, fmt.Errorf("failed to count leave attendances for employee: %w", err)
@@ -402,6 +477,7 @@ func (r *AttendanceRepository) GetEmployeeMonthlyStatistics(ctx context.Context,
 
 	if err = r.db.WithContext(ctx).
 		Model(&domain.Attendance{}).
+		Scopes(tenant.Scope(ctx, "attendances")).
 		Select("COALESCE(SUM(work_hours), 0) as total_hours").
 		Where("employee_id = ? AND date >= ? AND date <= ? AND work_hours IS NOT NULL", employeeID, startDate, endDate).
 		Scan(&result).Error; err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/SukaMajuu/hris/apps/backend/domain"
 	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
 	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/utils"
 	"github.com/google/uuid"
 
@@ -89,6 +90,12 @@ func (r *supabaseRepository) RegisterAdminWithForm(ctx context.Context, user *do
 		return fmt.Errorf("error storing user in database: %w", err)
 	}
 
+	if err := createCompanyForAdmin(tx, user, employee); err != nil {
+		tx.Rollback()
+		_ = r.client.Auth.AdminDeleteUser(types.AdminDeleteUserRequest{UserID: supaUserResponse.ID})
+		return fmt.Errorf("error storing company in database: %w", err)
+	}
+
 	employee.UserID = user.ID
 	if err := tx.Create(employee).Error; err != nil {
 		tx.Rollback()
@@ -134,6 +141,8 @@ func (r *supabaseRepository) RegisterEmployeeUser(ctx context.Context, user *dom
 
 	supaUserIDStr := supaUserResponse.ID.String()
 	user.SupabaseUID = &supaUserIDStr
+	employee.CompanyID = tenant.Assign(ctx, employee.CompanyID)
+	user.CompanyID = employee.CompanyID
 
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
@@ -228,6 +237,11 @@ func (r *supabaseRepository) RegisterAdminWithGoogle(ctx context.Context, token
 		return nil, nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if err := createCompanyForAdmin(tx, user, employee); err != nil {
+		tx.Rollback()
+		return nil, nil, fmt.Errorf("failed to create company: %w", err)
+	}
+
 	employee.UserID = user.ID
 
 	if err := tx.Create(employee).Error; err != nil {
@@ -243,6 +257,29 @@ func (r *supabaseRepository) RegisterAdminWithGoogle(ctx context.Context, token
 	return user, employee, nil
 }
 
+// createCompanyForAdmin creates the company a newly registered admin owns and assigns the admin to it.
+func createCompanyForAdmin(tx *gorm.DB, user *domain.User, employee *domain.Employee) error {
+	name := employee.FirstName
+	if employee.LastName != nil && *employee.LastName != "" {
+		name += " " + *employee.LastName
+	}
+
+	company := &domain.Company{
+		Name:        name + "'s Company",
+		OwnerUserID: user.ID,
+	}
+	if err := tx.Create(company).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(user).Update("company_id", company.ID).Error; err != nil {
+		return err
+	}
+
+	user.CompanyID = &company.ID
+	employee.CompanyID = &company.ID
+	return nil
+}
+
 // --- Login Methods ---
 
 func (r *supabaseRepository) LoginWithEmail(ctx context.Context, email, password string) (*domain.User, error) {
@@ -630,6 +667,8 @@ func (r *supabaseRepository) RegisterWithPhone(ctx context.Context, user *domain
 
 	supaUserIDStr := supaUserResponse.ID.String()
 	user.SupabaseUID = &supaUserIDStr
+	employee.CompanyID = tenant.Assign(ctx, employee.CompanyID)
+	user.CompanyID = employee.CompanyID
 
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
@@ -662,3 +701,32 @@ func (r *supabaseRepository) RegisterWithPhone(ctx context.Context, user *domain
 
 	return nil
 }
+
+// deactivatedBanDuration bans a deactivated Supabase account for good.
+const deactivatedBanDuration = 100 * 365 * 24 * time.Hour
+
+func (r *supabaseRepository) DeactivateUser(ctx context.Context, userID uint) error {
+	localUser, err := r.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if localUser.SupabaseUID == nil || *localUser.SupabaseUID == "" {
+		log.Printf("User %d is not linked to a supabase account, nothing to deactivate", userID)
+		return nil
+	}
+
+	supabaseUID, err := uuid.Parse(*localUser.SupabaseUID)
+	if err != nil {
+		return fmt.Errorf("invalid supabase UID for user %d: %w", userID, err)
+	}
+
+	banDuration := types.BanDurationTime(deactivatedBanDuration)
+	_, err = r.client.Auth.AdminUpdateUser(types.AdminUpdateUserRequest{
+		UserID:      supabaseUID,
+		BanDuration: &banDuration,
+	})
+	if err != nil {
+		return fmt.Errorf("error deactivating supabase user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package company
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.CompanyRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, company *domain.Company) error {
+	return r.db.WithContext(ctx).Create(company).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.Company, error) {
+	var company domain.Company
+	if err := r.db.WithContext(ctx).First(&company, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *PostgresRepository) GetByOwnerUserID(ctx context.Context, ownerUserID uint) (*domain.Company, error) {
+	var company domain.Company
+	if err := r.db.WithContext(ctx).Where("owner_user_id = ?", ownerUserID).First(&company).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return &company, nil
+}
@@ -0,0 +1,87 @@
+package custom_field
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.CustomFieldRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	definition.CompanyID = tenant.Assign(ctx, definition.CompanyID)
+	return r.db.WithContext(ctx).Create(definition).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.CustomFieldDefinition, error) {
+	var definition domain.CustomFieldDefinition
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "custom_field_definitions")).
+		First(&definition, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCustomFieldNotFound
+		}
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *PostgresRepository) GetByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error) {
+	var definition domain.CustomFieldDefinition
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "custom_field_definitions")).
+		Where("key = ?", key).
+		First(&definition).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCustomFieldNotFound
+		}
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context) ([]*domain.CustomFieldDefinition, error) {
+	var definitions []*domain.CustomFieldDefinition
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "custom_field_definitions")).
+		Order("sort_order ASC, id ASC").
+		Find(&definitions).Error
+	if err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	return tenant.Save(ctx, r.db, "custom_field_definitions", definition)
+}
+
+// Delete removes the definition and the values employees of the company hold for it.
+func (r *PostgresRepository) Delete(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Employee{}).
+			Scopes(tenant.Scope(ctx, "employees")).
+			Where("custom_fields ->> ? IS NOT NULL", definition.Key).
+			Updates(map[string]interface{}{
+				"custom_fields": gorm.Expr("custom_fields - ?", definition.Key),
+				"updated_at":    time.Now().UTC(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(definition).Error
+	})
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
 	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
@@ -22,16 +24,21 @@ func (r *PostgresRepository) Create(ctx context.Context, document *domain.Docume
 
 func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.Document, error) {
 	var document domain.Document
-	err := r.db.WithContext(ctx).Preload("Employee").First(&document, id).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.ScopeVia(ctx, "documents.employee_id", "employees")).Preload("Employee").First(&document, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &document, nil
 }
 
-func (r *PostgresRepository) GetByEmployeeID(ctx context.Context, employeeID uint) ([]*domain.Document, error) {
+func (r *PostgresRepository) GetByEmployeeID(ctx context.Context, employeeID uint, query *domain.ListQuery) ([]*domain.Document, error) {
 	var documents []*domain.Document
-	err := r.db.WithContext(ctx).Where("employee_id = ?", employeeID).Find(&documents).Error
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.ScopeVia(ctx, "documents.employee_id", "employees")).
+		Where("employee_id = ?", employeeID).
+		Scopes(listquery.Scope(query, domain.DocumentListSchema)).
+		Order(listquery.Order(query, domain.DocumentListSchema, "documents.id ASC")).
+		Find(&documents).Error
 	if err != nil {
 		return nil, err
 	}
@@ -39,5 +46,5 @@ func (r *PostgresRepository) GetByEmployeeID(ctx context.Context, employeeID uin
 }
 
 func (r *PostgresRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&domain.Document{}, id).Error
+	return r.db.WithContext(ctx).Scopes(tenant.ScopeVia(ctx, "documents.employee_id", "employees")).Delete(&domain.Document{}, id).Error
 }
@@ -3,14 +3,38 @@ package employee
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
 	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
+// departmentTreeQuery selects the IDs of the given department and every department below it.
+const departmentTreeQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT id FROM departments WHERE id = ?
+		UNION
+		SELECT d.id FROM departments d JOIN tree t ON d.parent_id = t.id
+	)
+	SELECT id FROM tree`
+
+// reportingLineQuery selects the IDs of every employee reporting to the given manager, directly or
+// through other managers.
+const reportingLineQuery = `
+	WITH RECURSIVE team AS (
+		SELECT id FROM employees WHERE manager_id = ?
+		UNION
+		SELECT e.id FROM employees e JOIN team t ON e.manager_id = t.id
+	)
+	SELECT id FROM team`
+
 type PostgresRepository struct {
 	db *gorm.DB
 }
@@ -20,12 +44,13 @@ func NewPostgresRepository(db *gorm.DB) interfaces.EmployeeRepository {
 }
 
 func (r *PostgresRepository) Create(ctx context.Context, employee *domain.Employee) error {
+	employee.CompanyID = tenant.Assign(ctx, employee.CompanyID)
 	return r.db.WithContext(ctx).Create(employee).Error
 }
 
 func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.WithContext(ctx).Preload("User").Preload("WorkSchedule").Preload("WorkSchedule.Details").Preload("WorkSchedule.Details.Location").First(&employee, id).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Preload("User").Preload("Manager").Preload("Department").Preload("WorkSchedule").Preload("WorkSchedule.Details").Preload("WorkSchedule.Details.Location").First(&employee, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +59,7 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.Empl
 
 func (r *PostgresRepository) GetByUserID(ctx context.Context, userID uint) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("User").Preload("WorkSchedule").Preload("WorkSchedule.Details").Preload("WorkSchedule.Details.Location").First(&employee).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Where("user_id = ?", userID).Preload("User").Preload("WorkSchedule").Preload("WorkSchedule.Details").Preload("WorkSchedule.Details.Location").First(&employee).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +68,7 @@ func (r *PostgresRepository) GetByUserID(ctx context.Context, userID uint) (*dom
 
 func (r *PostgresRepository) GetByEmployeeCode(ctx context.Context, employeeCode string) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.WithContext(ctx).Where("employee_code = ?", employeeCode).Preload("WorkSchedule.Details.Location").First(&employee).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Where("employee_code = ?", employeeCode).Preload("WorkSchedule.Details.Location").First(&employee).Error
 	if err != nil {
 		return nil, err
 	}
@@ -52,47 +77,76 @@ func (r *PostgresRepository) GetByEmployeeCode(ctx context.Context, employeeCode
 
 func (r *PostgresRepository) GetByNIK(ctx context.Context, nik string) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.WithContext(ctx).Where("nik = ?", nik).Preload("WorkSchedule.Details.Location").First(&employee).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Where("nik = ?", nik).Preload("WorkSchedule.Details.Location").First(&employee).Error
 	if err != nil {
 		return nil, err
 	}
 	return &employee, nil
 }
 
+// updateMap returns the columns of an employee written by Update. It is a map of values to bypass
+// GORM's change tracking.
+func updateMap(employee *domain.Employee) (map[string]interface{}, error) {
+	// Custom fields are written as JSON here because the json serializer is not applied to map updates
+	var customFields interface{}
+	if employee.CustomFields != nil {
+		encoded, err := json.Marshal(employee.CustomFields)
+		if err != nil {
+			return nil, err
+		}
+		customFields = string(encoded)
+	}
+
+	return map[string]interface{}{
+		"user_id":                     employee.UserID,
+		"first_name":                  employee.FirstName,
+		"last_name":                   employee.LastName,
+		"employee_code":               employee.EmployeeCode,
+		"branch":                      employee.Branch,
+		"gender":                      employee.Gender,
+		"nik":                         employee.NIK,
+		"place_of_birth":              employee.PlaceOfBirth,
+		"date_of_birth":               employee.DateOfBirth,
+		"last_education":              employee.LastEducation,
+		"grade":                       employee.Grade,
+		"contract_type":               employee.ContractType,
+		"position_name":               employee.PositionName,
+		"employment_status":           employee.EmploymentStatus,
+		"resignation_date":            employee.ResignationDate,
+		"hire_date":                   employee.HireDate,
+		"bank_name":                   employee.BankName,
+		"bank_account_number":         employee.BankAccountNumber,
+		"bank_account_holder_name":    employee.BankAccountHolderName,
+		"base_salary":                 employee.BaseSalary,
+		"tax_status":                  employee.TaxStatus,
+		"profile_photo_url":           employee.ProfilePhotoURL,
+		"work_schedule_id":            employee.WorkScheduleID,
+		"annual_leave_allowance":      employee.AnnualLeaveAllowance,
+		"manager_id":                  employee.ManagerID,
+		"department_id":               employee.DepartmentID,
+		"branch_id":                   employee.BranchID,
+		"position_id":                 employee.PositionID,
+		"absence_type":                employee.AbsenceType,
+		"absence_start_date":          employee.AbsenceStartDate,
+		"absence_end_date":            employee.AbsenceEndDate,
+		"absence_excluded_from_seats": employee.AbsenceExcludedFromSeats,
+		"hide_personal_events":        employee.HidePersonalEvents,
+		"event_digest":                employee.EventDigest,
+		"custom_fields":               customFields,
+		"updated_at":                  time.Now().UTC(),
+	}, nil
+}
+
 func (r *PostgresRepository) Update(ctx context.Context, employee *domain.Employee) error {
-	// Create a map of values to update to bypass GORM's change tracking
-	updateMap := map[string]interface{}{
-		"user_id":                  employee.UserID,
-		"first_name":               employee.FirstName,
-		"last_name":                employee.LastName,
-		"employee_code":            employee.EmployeeCode,
-		"branch":                   employee.Branch,
-		"gender":                   employee.Gender,
-		"nik":                      employee.NIK,
-		"place_of_birth":           employee.PlaceOfBirth,
-		"date_of_birth":            employee.DateOfBirth,
-		"last_education":           employee.LastEducation,
-		"grade":                    employee.Grade,
-		"contract_type":            employee.ContractType,
-		"position_name":            employee.PositionName,
-		"employment_status":        employee.EmploymentStatus,
-		"resignation_date":         employee.ResignationDate,
-		"hire_date":                employee.HireDate,
-		"bank_name":                employee.BankName,
-		"bank_account_number":      employee.BankAccountNumber,
-		"bank_account_holder_name": employee.BankAccountHolderName,
-		"tax_status":               employee.TaxStatus,
-		"profile_photo_url":        employee.ProfilePhotoURL,
-		"work_schedule_id":         employee.WorkScheduleID,
-		"annual_leave_allowance":   employee.AnnualLeaveAllowance,
-		"manager_id":               employee.ManagerID,
-		"updated_at":               time.Now().UTC(),
+	values, err := updateMap(employee)
+	if err != nil {
+		return err
 	}
 
 	// Log the WorkScheduleID value being updated
 	log.Printf("PostgresRepository: Updating employee ID %d with WorkScheduleID: %v", employee.ID, employee.WorkScheduleID)
 
-	result := r.db.WithContext(ctx).Model(&domain.Employee{}).Where("id = ?", employee.ID).Updates(updateMap)
+	result := r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Where("id = ?", employee.ID).Updates(values)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -103,46 +157,126 @@ func (r *PostgresRepository) Update(ctx context.Context, employee *domain.Employ
 	return nil
 }
 
+// BulkUpdate writes the given employees and records the employment events that changed them in a
+// single transaction, so either all of them are written or none is.
+func (r *PostgresRepository) BulkUpdate(ctx context.Context, employees []*domain.Employee, events []*domain.EmploymentEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, employee := range employees {
+			values, err := updateMap(employee)
+			if err != nil {
+				return err
+			}
+			result := tx.Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Where("id = ?", employee.ID).Updates(values)
+			if result.Error != nil {
+				return fmt.Errorf("failed to update employee ID %d: %w", employee.ID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("failed to update employee ID %d: %w", employee.ID, gorm.ErrRecordNotFound)
+			}
+		}
+		for _, event := range events {
+			if err := tx.Create(event).Error; err != nil {
+				return fmt.Errorf("failed to record employment event of employee ID %d: %w", event.EmployeeID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ApplyEmploymentEvent writes the employee and records the event that changed them in a single
+// transaction, so the employment history never disagrees with the employee. A scheduled event being
+// applied is saved over its scheduled row.
+func (r *PostgresRepository) ApplyEmploymentEvent(ctx context.Context, employee *domain.Employee, event *domain.EmploymentEvent) error {
+	values, err := updateMap(employee)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Where("id = ?", employee.ID).Updates(values)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update employee ID %d: %w", employee.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("failed to update employee ID %d: %w", employee.ID, gorm.ErrRecordNotFound)
+		}
+
+		if event.ID == 0 {
+			if err := tx.Create(event).Error; err != nil {
+				return fmt.Errorf("failed to record employment event: %w", err)
+			}
+			return nil
+		}
+		if err := tenant.Save(ctx, tx, "employment_events", event); err != nil {
+			return fmt.Errorf("failed to apply employment event ID %d: %w", event.ID, err)
+		}
+		return nil
+	})
+}
+
 func (r *PostgresRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&domain.Employee{}, id).Error
+	return r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Delete(&domain.Employee{}, id).Error
 }
 
 func (r *PostgresRepository) List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.Employee, int64, error) {
 	var employees []*domain.Employee
 	var totalItems int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Employee{}).Joins("LEFT JOIN users ON employees.user_id = users.id")
+	query := r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Joins("LEFT JOIN users ON employees.user_id = users.id")
 
 	for key, value := range filters {
 		switch key {
 		case "manager_id":
 			query = query.Where("employees.manager_id = ?", value)
+		case "reporting_line":
+			query = query.Where("employees.id IN ("+reportingLineQuery+")", value)
 		case "employment_status":
 			query = query.Where("employees.employment_status = ?", value)
+		case "id_after":
+			query = query.Where("employees.id > ?", value)
 		case "gender":
 			query = query.Where("employees.gender = ?", value)
+		case "department_id":
+			query = query.Where("employees.department_id IN ("+departmentTreeQuery+")", value)
+		case "has_long_term_absence":
+			if value == true {
+				query = query.Where("employees.absence_type IS NOT NULL")
+			} else {
+				query = query.Where("employees.absence_type IS NULL")
+			}
+		case "custom_fields":
+			for fieldKey, fieldValue := range value.(map[string]string) {
+				query = query.Where("employees.custom_fields ->> ? = ?", fieldKey, fieldValue)
+			}
 		case "search":
-
+			// search_text holds the name, code, position and branch and has a trigram index
+			// serving the LIKE. NIKs are matched only when search_nik is set.
 			searchTerm := "%" + value.(string) + "%"
-			query = query.Where(
-				"LOWER(employees.first_name || ' ' || COALESCE(employees.last_name, '')) LIKE LOWER(?) OR "+
-					"users.phone LIKE ? OR "+
-					"LOWER(COALESCE(employees.branch, '')) LIKE LOWER(?) OR "+
-					"LOWER(COALESCE(employees.position_name, '')) LIKE LOWER(?) OR "+
-					"LOWER(COALESCE(employees.grade, '')) LIKE LOWER(?)",
-				searchTerm, searchTerm, searchTerm, searchTerm, searchTerm)
+			condition := "employees.search_text LIKE LOWER(?) OR " +
+				"users.phone LIKE ? OR " +
+				"LOWER(COALESCE(employees.grade, '')) LIKE LOWER(?)"
+			args := []interface{}{searchTerm, searchTerm, searchTerm}
+			if filters["search_nik"] == true {
+				condition += " OR employees.nik LIKE ?"
+				args = append(args, searchTerm)
+			}
+			query = query.Where("("+condition+")", args...)
+		case "search_nik":
 		default:
 			query = query.Where("employees."+key+" = ?", value)
 		}
 	}
 
+	query = query.Scopes(listquery.Scope(pagination.Query, domain.EmployeeListSchema))
+
 	if err := query.Count(&totalItems).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (pagination.Page - 1) * pagination.PageSize
-	err := query.Offset(offset).Limit(pagination.PageSize).Order("employees.id ASC").
+	err := query.Offset(offset).Limit(pagination.PageSize).Order(listquery.Order(pagination.Query, domain.EmployeeListSchema, "employees.id ASC")).
 		Preload("User").
+		Preload("Manager").
+		Preload("Department").
 		Preload("WorkSchedule").
 		Preload("WorkSchedule.Details").
 		Preload("WorkSchedule.Details.Location").
@@ -154,6 +288,170 @@ func (r *PostgresRepository) List(ctx context.Context, filters map[string]interf
 	return employees, totalItems, nil
 }
 
+// employeeBatchSize is the number of employees loaded at a time by ForEachBatch.
+const employeeBatchSize = 500
+
+// ForEachBatch calls fn with every employee matching the List filters, a batch at a time in ID order.
+// Batches are read by ID rather than by offset so employees added meanwhile are neither skipped nor
+// seen twice.
+func (r *PostgresRepository) ForEachBatch(ctx context.Context, filters map[string]interface{}, fn func(employees []*domain.Employee) error) error {
+	var lastID uint
+	for {
+		batchFilters := make(map[string]interface{}, len(filters)+1)
+		for key, value := range filters {
+			batchFilters[key] = value
+		}
+		batchFilters["id_after"] = lastID
+
+		employees, _, err := r.List(ctx, batchFilters, domain.PaginationParams{Page: 1, PageSize: employeeBatchSize})
+		if err != nil {
+			return err
+		}
+		if len(employees) == 0 {
+			return nil
+		}
+		if err := fn(employees); err != nil {
+			return err
+		}
+		if len(employees) < employeeBatchSize {
+			return nil
+		}
+		lastID = employees[len(employees)-1].ID
+	}
+}
+
+// Search returns the employees best matching the query, best first. Words match as prefixes in
+// full-text search, misspelt and variant spellings such as Muhamad for Muhammad match on trigram
+// word similarity, and emails match anywhere. Exact codes rank first, and so do NIKs when the scope
+// matches them, which also matches NIKs by prefix.
+func (r *PostgresRepository) Search(ctx context.Context, query string, scope domain.EmployeeSearchScope, limit int) ([]*domain.EmployeeSearchHit, error) {
+	words := domain.EmployeeSearchWords(query)
+	if len(words) == 0 {
+		return []*domain.EmployeeSearchHit{}, nil
+	}
+	prefixes := make([]string, len(words))
+	for i, word := range words {
+		prefixes[i] = word + ":*"
+	}
+	tsQuery := strings.Join(prefixes, " & ")
+	term := strings.ToLower(strings.TrimSpace(query))
+
+	exactMatch := "LOWER(employees.employee_code) = ?"
+	match := "employees.search_vector @@ to_tsquery('simple', ?) OR ? <% employees.search_text OR LOWER(users.email) LIKE ?"
+	exactArgs := []interface{}{term}
+	matchArgs := []interface{}{tsQuery, term, "%" + term + "%"}
+	if scope.MatchNIK {
+		exactMatch += " OR employees.nik = ?"
+		match += " OR employees.nik LIKE ?"
+		exactArgs = append(exactArgs, term)
+		matchArgs = append(matchArgs, term+"%")
+	}
+
+	rankQuery := r.db.WithContext(ctx).Model(&domain.Employee{}).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Joins("LEFT JOIN users ON users.id = employees.user_id").
+		Select("employees.id, "+
+			"ts_rank(employees.search_vector, to_tsquery('simple', ?)) + "+
+			"word_similarity(?, employees.search_text) + "+
+			"CASE WHEN "+exactMatch+" THEN 1 ELSE 0 END AS rank",
+			append([]interface{}{tsQuery, term}, exactArgs...)...).
+		Where("("+match+")", matchArgs...)
+	if !scope.IncludeInactive {
+		rankQuery = rankQuery.Where("employees.employment_status = ?", true)
+	}
+	if scope.ManagerID != nil {
+		rankQuery = rankQuery.Where("employees.manager_id = ?", *scope.ManagerID)
+	}
+
+	var ranks []struct {
+		ID   uint
+		Rank float64
+	}
+	if err := rankQuery.Order("rank DESC, employees.id ASC").Limit(limit).Scan(&ranks).Error; err != nil {
+		return nil, err
+	}
+	if len(ranks) == 0 {
+		return []*domain.EmployeeSearchHit{}, nil
+	}
+
+	ids := make([]uint, len(ranks))
+	for i, rank := range ranks {
+		ids[i] = rank.ID
+	}
+	var employees []*domain.Employee
+	if err := r.db.WithContext(ctx).Preload("User").Where("id IN ?", ids).Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Employee, len(employees))
+	for _, employee := range employees {
+		byID[employee.ID] = employee
+	}
+
+	hits := make([]*domain.EmployeeSearchHit, 0, len(ranks))
+	for _, rank := range ranks {
+		if employee, ok := byID[rank.ID]; ok {
+			hits = append(hits, &domain.EmployeeSearchHit{Employee: employee, Rank: rank.Rank})
+		}
+	}
+	return hits, nil
+}
+
+// GetReportingLineIDs returns the IDs of every employee reporting to the manager, directly or
+// indirectly.
+func (r *PostgresRepository) GetReportingLineIDs(ctx context.Context, managerID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&domain.Employee{}).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Where("id IN ("+reportingLineQuery+")", managerID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListActiveByIDs returns the active employees among the given IDs.
+func (r *PostgresRepository) ListActiveByIDs(ctx context.Context, ids []uint) ([]*domain.Employee, error) {
+	var employees []*domain.Employee
+	if len(ids) == 0 {
+		return employees, nil
+	}
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Preload("User").
+		Where("id IN ? AND employment_status = ?", ids, true).
+		Order("id ASC").
+		Find(&employees).Error
+	if err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// ListEventDigestSubscribers returns the active employees subscribed to the daily events digest.
+func (r *PostgresRepository) ListEventDigestSubscribers(ctx context.Context) ([]*domain.Employee, error) {
+	var employees []*domain.Employee
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Preload("User").
+		Where("event_digest = ? AND employment_status = ?", true, true).
+		Order("id ASC").
+		Find(&employees).Error
+	if err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+func (r *PostgresRepository) UpdateManager(ctx context.Context, employeeIDs []uint, managerID uint) error {
+	return r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).
+		Where("id IN ?", employeeIDs).
+		Updates(map[string]interface{}{
+			"manager_id": managerID,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
 func (r *PostgresRepository) GetStatisticsWithTrendsByManager(ctx context.Context, managerID uint) (
 	totalEmployees, newEmployees, activeEmployees, resignedEmployees,
 	permanentEmployees, contractEmployees, freelanceEmployees int64,
@@ -161,7 +459,7 @@ func (r *PostgresRepository) GetStatisticsWithTrendsByManager(ctx context.Contex
 	err error,
 ) {
 	newQuery := func() *gorm.DB {
-		return r.db.WithContext(ctx).Model(&domain.Employee{}).Where("manager_id = ?", managerID)
+		return r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Where("id IN ("+reportingLineQuery+")", managerID)
 	}
 
 	err = newQuery().Count(&totalEmployees).Error
@@ -262,7 +560,7 @@ func (r *PostgresRepository) GetStatisticsWithTrendsByManagerAndMonth(ctx contex
 	err error,
 ) {
 	newQuery := func() *gorm.DB {
-		return r.db.WithContext(ctx).Model(&domain.Employee{}).Where("manager_id = ?", managerID)
+		return r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).Where("id IN ("+reportingLineQuery+")", managerID)
 	}
 
 	// Parse month parameter (format: YYYY-MM)
@@ -399,7 +697,8 @@ func (r *PostgresRepository) GetHireDateRange(ctx context.Context, managerID uin
 
 	// Get earliest hire date
 	err = r.db.WithContext(ctx).Model(&domain.Employee{}).
-		Where("manager_id = ? AND hire_date IS NOT NULL", managerID).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Where("id IN ("+reportingLineQuery+") AND hire_date IS NOT NULL", managerID).
 		Select("MIN(hire_date)").
 		Scan(&earliest).Error
 	if err != nil {
@@ -408,7 +707,8 @@ func (r *PostgresRepository) GetHireDateRange(ctx context.Context, managerID uin
 
 	// Get latest hire date
 	err = r.db.WithContext(ctx).Model(&domain.Employee{}).
-		Where("manager_id = ? AND hire_date IS NOT NULL", managerID).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Where("id IN ("+reportingLineQuery+") AND hire_date IS NOT NULL", managerID).
 		Select("MAX(hire_date)").
 		Scan(&latest).Error
 	if err != nil {
@@ -0,0 +1,241 @@
+package employee_duplicate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// candidatePairsQuery blocks the duplicate detection to pairs of employees of the same company with
+// similar names or NIKs or the same phone number, so only those are scored. Phone numbers are
+// compared on their last nine digits to ignore country codes and formatting.
+const candidatePairsQuery = `
+	SELECT a.id AS employee_id, b.id AS duplicate_id, a.company_id AS company_id
+	FROM employees a
+	JOIN employees b ON b.company_id IS NOT DISTINCT FROM a.company_id AND b.id > a.id
+	JOIN users ua ON ua.id = a.user_id
+	JOIN users ub ON ub.id = b.user_id
+	WHERE (
+		similarity(LOWER(a.first_name || ' ' || COALESCE(a.last_name, '')), LOWER(b.first_name || ' ' || COALESCE(b.last_name, ''))) >= 0.4
+		OR (COALESCE(a.nik, '') <> '' AND COALESCE(b.nik, '') <> '' AND similarity(a.nik, b.nik) >= 0.6)
+		OR (COALESCE(ua.phone, '') <> '' AND COALESCE(ub.phone, '') <> ''
+			AND RIGHT(regexp_replace(ua.phone, '\D', '', 'g'), 9) = RIGHT(regexp_replace(ub.phone, '\D', '', 'g'), 9))
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM employee_duplicates d
+		WHERE d.employee_id = a.id AND d.duplicate_id = b.id AND d.status = 'dismissed'
+	)`
+
+// employeeReferences are the columns referencing an employee that follow the survivor of a merge,
+// by table.
+var employeeReferences = []struct{ table, column string }{
+	{"attendances", "employee_id"},
+	{"leave_requests", "employee_id"},
+	{"documents", "employee_id"},
+	{"employment_events", "employee_id"},
+	{"employment_contracts", "employee_id"},
+	{"probations", "employee_id"},
+	{"offboardings", "employee_id"},
+	{"offboarding_tasks", "assignee_id"},
+	{"onboarding_tasks", "employee_id"},
+	{"onboarding_tasks", "assignee_id"},
+	{"profile_change_requests", "employee_id"},
+	{"import_jobs", "created_by"},
+	{"import_job_rows", "employee_id"},
+	{"family_members", "employee_id"},
+	{"emergency_contacts", "employee_id"},
+	{"leave_encashments", "employee_id"},
+	{"leave_staffing_rules", "root_employee_id"},
+}
+
+// userReferences are the columns referencing the user who did something, such as the reviewer of
+// a probation, that follow the survivor's account when the other account is retired by a merge.
+var userReferences = []struct{ table, column string }{
+	{"probation_reviews", "reviewer_id"},
+	{"employment_events", "recorded_by"},
+	{"employment_contracts", "created_by"},
+	{"offboardings", "initiated_by"},
+	{"offboarding_tasks", "completed_by"},
+	{"onboarding_tasks", "completed_by"},
+	{"onboarding_templates", "created_by"},
+	{"leave_staffing_rules", "created_by"},
+	{"leave_encashments", "reviewed_by"},
+	{"profile_change_requests", "reviewed_by"},
+	{"employee_duplicates", "resolved_by"},
+}
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.EmployeeDuplicateRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) ListCandidates(ctx context.Context) ([]*domain.EmployeeDuplicate, error) {
+	query, args := candidatePairsQuery, []interface{}{}
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query += " AND a.company_id = ?"
+		args = append(args, companyID)
+	}
+
+	var pairs []*domain.EmployeeDuplicate
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&pairs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find duplicate candidates: %w", err)
+	}
+	if len(pairs) == 0 {
+		return pairs, nil
+	}
+
+	ids := make([]uint, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		ids = append(ids, pair.EmployeeID, pair.DuplicateID)
+	}
+	var employees []domain.Employee
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "employees")).Preload("User").Where("id IN ?", ids).Find(&employees).Error; err != nil {
+		return nil, fmt.Errorf("failed to load duplicate candidates: %w", err)
+	}
+	byID := make(map[uint]domain.Employee, len(employees))
+	for _, employee := range employees {
+		byID[employee.ID] = employee
+	}
+
+	for _, pair := range pairs {
+		pair.Employee = byID[pair.EmployeeID]
+		pair.Duplicate = byID[pair.DuplicateID]
+	}
+	return pairs, nil
+}
+
+func (r *PostgresRepository) Save(ctx context.Context, duplicate *domain.EmployeeDuplicate) error {
+	return r.db.WithContext(ctx).
+		Omit("Employee", "Duplicate").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "employee_id"}, {Name: "duplicate_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"score", "signals", "detected_at", "updated_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: "employee_duplicates", Name: "status"}, Value: domain.EmployeeDuplicatePending},
+			}},
+		}).
+		Create(duplicate).Error
+}
+
+func (r *PostgresRepository) DeletePendingDetectedBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employee_duplicates")).
+		Where("status = ? AND detected_at < ?", domain.EmployeeDuplicatePending, before).
+		Delete(&domain.EmployeeDuplicate{}).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.EmployeeDuplicate, error) {
+	var duplicate domain.EmployeeDuplicate
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employee_duplicates")).
+		Preload("Employee.User").
+		Preload("Duplicate.User").
+		First(&duplicate, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeDuplicateNotFound
+		}
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, status domain.EmployeeDuplicateStatus, pagination domain.PaginationParams) ([]*domain.EmployeeDuplicate, int64, error) {
+	var duplicates []*domain.EmployeeDuplicate
+	var totalItems int64
+
+	query := r.db.WithContext(ctx).Model(&domain.EmployeeDuplicate{}).
+		Scopes(tenant.Scope(ctx, "employee_duplicates")).
+		Where("status = ?", status)
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if pagination.PageSize > 0 {
+		query = query.Offset((pagination.Page - 1) * pagination.PageSize).Limit(pagination.PageSize)
+	}
+	if err := query.
+		Preload("Employee.User").
+		Preload("Duplicate.User").
+		Order("score DESC, id ASC").
+		Find(&duplicates).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return duplicates, totalItems, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, duplicate *domain.EmployeeDuplicate) error {
+	return tenant.Save(ctx, r.db.Omit("Employee", "Duplicate"), "employee_duplicates", duplicate)
+}
+
+// Merge moves the records of the duplicate to the survivor. Foreign keys are not created by the
+// migration, so nothing cascades: every reference to the duplicate is moved here, and the pairs the
+// duplicate was part of, including the merged one, are deleted with it.
+func (r *PostgresRepository) Merge(ctx context.Context, survivor *domain.Employee, event *domain.EmploymentEvent, duplicateID, retiredUserID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An employee has one attendance a day; on days both were clocked in, the survivor's is kept.
+		if err := tx.Exec(`DELETE FROM attendances d WHERE d.employee_id = ? AND EXISTS (
+			SELECT 1 FROM attendances s WHERE s.employee_id = ? AND s.date = d.date
+		)`, duplicateID, survivor.ID).Error; err != nil {
+			return fmt.Errorf("failed to drop overlapping attendances: %w", err)
+		}
+		// Leave encashments are unique per employee, reason and year; the survivor's are kept and
+		// the others moved with the rest.
+		if err := tx.Exec(`DELETE FROM leave_encashments d WHERE d.employee_id = ? AND EXISTS (
+			SELECT 1 FROM leave_encashments s
+			WHERE s.employee_id = ? AND s.reason = d.reason AND s.year = d.year
+		)`, duplicateID, survivor.ID).Error; err != nil {
+			return fmt.Errorf("failed to drop overlapping leave encashments: %w", err)
+		}
+		for _, ref := range employeeReferences {
+			if err := tx.Table(ref.table).Where(ref.column+" = ?", duplicateID).Update(ref.column, survivor.ID).Error; err != nil {
+				return fmt.Errorf("failed to move %s.%s: %w", ref.table, ref.column, err)
+			}
+		}
+		if err := tx.Table("employees").Where("manager_id = ? AND id <> ?", duplicateID, survivor.ID).Update("manager_id", survivor.ID).Error; err != nil {
+			return fmt.Errorf("failed to move direct reports: %w", err)
+		}
+
+		if retiredUserID != 0 && retiredUserID != survivor.UserID {
+			for _, ref := range userReferences {
+				if err := tx.Table(ref.table).Where(ref.column+" = ?", retiredUserID).Update(ref.column, survivor.UserID).Error; err != nil {
+					return fmt.Errorf("failed to move %s.%s: %w", ref.table, ref.column, err)
+				}
+			}
+			// A user owns at most one leave policy; the survivor's is kept.
+			if err := tx.Exec(`UPDATE leave_policies SET created_by = ? WHERE created_by = ?
+				AND NOT EXISTS (SELECT 1 FROM leave_policies WHERE created_by = ?)`,
+				survivor.UserID, retiredUserID, survivor.UserID).Error; err != nil {
+				return fmt.Errorf("failed to move leave policy: %w", err)
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM employee_duplicates WHERE employee_id = ? OR duplicate_id = ?", duplicateID, duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate pairs: %w", err)
+		}
+		if err := tx.Scopes(tenant.Scope(ctx, "employees")).Delete(&domain.Employee{}, duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate employee: %w", err)
+		}
+		if err := tenant.Save(ctx, tx.Omit(clause.Associations), "employees", survivor); err != nil {
+			return fmt.Errorf("failed to save surviving employee: %w", err)
+		}
+		if event != nil {
+			if err := tx.Omit(clause.Associations).Create(event).Error; err != nil {
+				return fmt.Errorf("failed to record merge event: %w", err)
+			}
+		}
+		return nil
+	})
+}
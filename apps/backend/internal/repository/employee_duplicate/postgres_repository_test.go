@@ -0,0 +1,227 @@
+package employee_duplicate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordedStatement is a statement the repository sent to the database.
+type recordedStatement struct {
+	query string
+	args  []interface{}
+}
+
+// recorder is a database/sql driver that records the statements it is sent, reports one affected
+// row for every statement and answers queries with no rows.
+type recorder struct {
+	mu         sync.Mutex
+	statements []recordedStatement
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return &recorderConn{recorder: r}, nil }
+
+func (r *recorder) record(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	r.statements = append(r.statements, recordedStatement{query: query, args: values})
+}
+
+// find returns the recorded statements starting with prefix.
+func (r *recorder) find(prefix string) []recordedStatement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []recordedStatement
+	for _, statement := range r.statements {
+		if strings.HasPrefix(statement.query, prefix) {
+			found = append(found, statement)
+		}
+	}
+	return found
+}
+
+// index returns the position of the first recorded statement starting with prefix, or -1.
+func (r *recorder) index(prefix string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, statement := range r.statements {
+		if strings.HasPrefix(statement.query, prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+type recorderConn struct{ recorder *recorder }
+
+func (c *recorderConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+func (c *recorderConn) Close() error              { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recorderConn) Commit() error             { return nil }
+func (c *recorderConn) Rollback() error           { return nil }
+
+func (c *recorderConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.recorder.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recorderConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.recorder.record(query, args)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func newRecordedRepository(t *testing.T) (*PostgresRepository, *recorder) {
+	t.Helper()
+	rec := &recorder{}
+	driverName := "employee_duplicate_recorder_" + t.Name()
+	sql.Register(driverName, rec)
+	sqlDB, err := sql.Open(driverName, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	return &PostgresRepository{db: db}, rec
+}
+
+// updateStatement returns the statement moving the references of a "table.column".
+func updateStatement(column string) string {
+	table, name, _ := strings.Cut(column, ".")
+	return fmt.Sprintf(`UPDATE "%s" SET "%s"=$1 WHERE %s = $2`, table, name, name)
+}
+
+func TestPostgresRepository_Merge(t *testing.T) {
+	ctx := tenant.WithCompanyID(context.Background(), 3)
+	survivor := &domain.Employee{ID: 1, UserID: 11, FirstName: "Siti"}
+
+	t.Run("every reference to the duplicate and its account moves to the survivor", func(t *testing.T) {
+		repo, rec := newRecordedRepository(t)
+
+		require.NoError(t, repo.Merge(ctx, survivor, nil, 2, 12))
+
+		employeeColumns := []string{
+			"attendances.employee_id",
+			"leave_requests.employee_id",
+			"leave_encashments.employee_id",
+			"documents.employee_id",
+			"employment_events.employee_id",
+			"employment_contracts.employee_id",
+			"probations.employee_id",
+			"offboardings.employee_id",
+			"offboarding_tasks.assignee_id",
+			"onboarding_tasks.employee_id",
+			"onboarding_tasks.assignee_id",
+			"profile_change_requests.employee_id",
+			"import_jobs.created_by",
+			"import_job_rows.employee_id",
+			"family_members.employee_id",
+			"emergency_contacts.employee_id",
+			"leave_staffing_rules.root_employee_id",
+		}
+		for _, column := range employeeColumns {
+			moved := rec.find(updateStatement(column))
+			if assert.Len(t, moved, 1, column) {
+				assert.Equal(t, []interface{}{int64(1), int64(2)}, moved[0].args, column)
+			}
+		}
+
+		userColumns := []string{
+			"probation_reviews.reviewer_id",
+			"employment_events.recorded_by",
+			"employment_contracts.created_by",
+			"offboardings.initiated_by",
+			"offboarding_tasks.completed_by",
+			"onboarding_tasks.completed_by",
+			"onboarding_templates.created_by",
+			"leave_staffing_rules.created_by",
+			"leave_encashments.reviewed_by",
+			"profile_change_requests.reviewed_by",
+			"employee_duplicates.resolved_by",
+		}
+		for _, column := range userColumns {
+			moved := rec.find(updateStatement(column))
+			if assert.Len(t, moved, 1, column) {
+				assert.Equal(t, []interface{}{int64(11), int64(12)}, moved[0].args, column)
+			}
+		}
+		policies := rec.find("UPDATE leave_policies SET created_by = $1 WHERE created_by = $2")
+		if assert.Len(t, policies, 1) {
+			assert.Equal(t, []interface{}{int64(11), int64(12), int64(11)}, policies[0].args)
+		}
+
+		reports := rec.find(`UPDATE "employees" SET "manager_id"=$1`)
+		if assert.Len(t, reports, 1) {
+			assert.Equal(t, []interface{}{int64(1), int64(2), int64(1)}, reports[0].args)
+		}
+	})
+
+	t.Run("attendances of days the survivor was clocked in are dropped before moving the rest", func(t *testing.T) {
+		repo, rec := newRecordedRepository(t)
+
+		require.NoError(t, repo.Merge(ctx, survivor, nil, 2, 12))
+
+		overlapping := rec.find("DELETE FROM attendances d WHERE d.employee_id = $1")
+		if assert.Len(t, overlapping, 1) {
+			assert.Equal(t, []interface{}{int64(2), int64(1)}, overlapping[0].args)
+		}
+		assert.Less(t, rec.index("DELETE FROM attendances d"), rec.index(updateStatement("attendances.employee_id")))
+	})
+
+	t.Run("the employment event of the merge is recorded with the survivor", func(t *testing.T) {
+		repo, rec := newRecordedRepository(t)
+		event := &domain.EmploymentEvent{EmployeeID: 1, EventType: domain.EmploymentEventMerge}
+
+		require.NoError(t, repo.Merge(ctx, survivor, event, 2, 12))
+
+		assert.Len(t, rec.find(`INSERT INTO "employment_events"`), 1)
+	})
+
+	t.Run("the pairs of the duplicate are deleted so the pair cannot be merged again", func(t *testing.T) {
+		repo, rec := newRecordedRepository(t)
+
+		require.NoError(t, repo.Merge(ctx, survivor, nil, 2, 12))
+
+		pairs := rec.find("DELETE FROM employee_duplicates WHERE employee_id = $1 OR duplicate_id = $2")
+		if assert.Len(t, pairs, 1) {
+			assert.Equal(t, []interface{}{int64(2), int64(2)}, pairs[0].args)
+		}
+		assert.Len(t, rec.find(`DELETE FROM "employees"`), 1)
+	})
+
+	t.Run("the survivor's own account keeps its records", func(t *testing.T) {
+		repo, rec := newRecordedRepository(t)
+
+		require.NoError(t, repo.Merge(ctx, survivor, nil, 2, 11))
+
+		assert.Empty(t, rec.find(`UPDATE "probation_reviews"`))
+		assert.Empty(t, rec.find("UPDATE leave_policies"))
+	})
+}
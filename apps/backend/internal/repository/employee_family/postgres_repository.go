@@ -0,0 +1,104 @@
+package employee_family
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.EmployeeFamilyRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) ListFamilyMembers(ctx context.Context, employeeID uint) ([]*domain.FamilyMember, error) {
+	var members []*domain.FamilyMember
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "family_members")).
+		Where("employee_id = ?", employeeID).
+		Order("id ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *PostgresRepository) CountTaxDependants(ctx context.Context, employeeID uint) (bool, int, error) {
+	var counts struct {
+		Members    int
+		Dependants int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.FamilyMember{}).
+		Scopes(tenant.Scope(ctx, "family_members")).
+		Select("COUNT(*) AS members, COUNT(*) FILTER (WHERE tax_dependant) AS dependants").
+		Where("employee_id = ?", employeeID).
+		Scan(&counts).Error
+	if err != nil {
+		return false, 0, err
+	}
+	return counts.Members > 0, counts.Dependants, nil
+}
+
+func (r *PostgresRepository) ReplaceFamilyMembers(ctx context.Context, employeeID uint, members []*domain.FamilyMember, taxStatus *enums.TaxStatus) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Employee{}).
+			Where("id = ?", employeeID).
+			Update("tax_status", taxStatus).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("employee_id = ?", employeeID).Delete(&domain.FamilyMember{}).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+
+		for _, member := range members {
+			member.ID = 0
+			member.EmployeeID = employeeID
+			member.CompanyID = tenant.Assign(ctx, member.CompanyID)
+		}
+		return tx.Omit(clause.Associations).Create(&members).Error
+	})
+}
+
+func (r *PostgresRepository) ListEmergencyContacts(ctx context.Context, employeeID uint) ([]*domain.EmergencyContact, error) {
+	var contacts []*domain.EmergencyContact
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "emergency_contacts")).
+		Where("employee_id = ?", employeeID).
+		Order("is_primary DESC, id ASC").
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (r *PostgresRepository) ReplaceEmergencyContacts(ctx context.Context, employeeID uint, contacts []*domain.EmergencyContact) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("employee_id = ?", employeeID).Delete(&domain.EmergencyContact{}).Error; err != nil {
+			return err
+		}
+		if len(contacts) == 0 {
+			return nil
+		}
+
+		for _, contact := range contacts {
+			contact.ID = 0
+			contact.EmployeeID = employeeID
+			contact.CompanyID = tenant.Assign(ctx, contact.CompanyID)
+		}
+		return tx.Omit(clause.Associations).Create(&contacts).Error
+	})
+}
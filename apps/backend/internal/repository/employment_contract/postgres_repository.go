@@ -0,0 +1,113 @@
+package employment_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.EmploymentContractRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, contract *domain.EmploymentContract) error {
+	if contract.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, contract.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", contract.EmployeeID, err)
+		}
+		contract.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Omit("Employee").Create(contract).Error
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, contract *domain.EmploymentContract) error {
+	return tenant.Save(ctx, r.db.Omit("Employee"), "employment_contracts", contract)
+}
+
+// Replace closes the active contract, starts the next one, sets the employee's contract type to
+// the next contract's and records the event in a single transaction, so an employee never has two
+// active contracts or a contract type their contracts disagree with.
+func (r *PostgresRepository) Replace(ctx context.Context, active, next *domain.EmploymentContract, event *domain.EmploymentEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tenant.Save(ctx, tx.Omit("Employee"), "employment_contracts", active); err != nil {
+			return fmt.Errorf("failed to close contract ID %d: %w", active.ID, err)
+		}
+		if err := tx.Omit("Employee").Create(next).Error; err != nil {
+			return fmt.Errorf("failed to create contract: %w", err)
+		}
+
+		result := tx.Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).
+			Where("id = ?", next.EmployeeID).
+			Update("contract_type", next.ContractType)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update contract type of employee ID %d: %w", next.EmployeeID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("failed to update contract type of employee ID %d: %w", next.EmployeeID, gorm.ErrRecordNotFound)
+		}
+
+		if err := tx.Create(event).Error; err != nil {
+			return fmt.Errorf("failed to record employment event: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PostgresRepository) GetActiveByEmployee(ctx context.Context, employeeID uint) (*domain.EmploymentContract, error) {
+	var contract domain.EmploymentContract
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_contracts")).
+		Where("employee_id = ? AND status = ?", employeeID, domain.ContractStatusActive).
+		First(&contract).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrContractNotFound
+		}
+		return nil, err
+	}
+	return &contract, nil
+}
+
+func (r *PostgresRepository) ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentContract, error) {
+	var contracts []*domain.EmploymentContract
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_contracts")).
+		Where("employee_id = ?", employeeID).
+		Order("start_date ASC, id ASC").
+		Find(&contracts).Error
+	if err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+// ListActiveEndingBefore returns the active contracts of active employees that end on or before
+// endDate, soonest first, with the employee and their manager.
+func (r *PostgresRepository) ListActiveEndingBefore(ctx context.Context, endDate time.Time) ([]*domain.EmploymentContract, error) {
+	var contracts []*domain.EmploymentContract
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_contracts")).
+		Joins("JOIN employees ON employees.id = employment_contracts.employee_id").
+		Where("employment_contracts.status = ?", domain.ContractStatusActive).
+		Where("employment_contracts.end_date IS NOT NULL AND employment_contracts.end_date <= ?", endDate.Format("2006-01-02")).
+		Where("employees.employment_status = ?", true).
+		Preload("Employee").
+		Preload("Employee.Manager").
+		Order("employment_contracts.end_date ASC, employment_contracts.id ASC").
+		Find(&contracts).Error
+	if err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
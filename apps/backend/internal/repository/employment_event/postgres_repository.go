@@ -0,0 +1,72 @@
+package employment_event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.EmploymentEventRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, event *domain.EmploymentEvent) error {
+	if event.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, event.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", event.EmployeeID, err)
+		}
+		event.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *PostgresRepository) ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentEvent, error) {
+	var events []*domain.EmploymentEvent
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_events")).
+		Where("employee_id = ?", employeeID).
+		Order("effective_date ASC, id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *PostgresRepository) ListByPeriod(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmploymentEvent, error) {
+	var events []*domain.EmploymentEvent
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_events")).
+		Where("effective_date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Where("scheduled = ?", false).
+		Order("effective_date ASC, id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListDue returns the scheduled events effective on or before the given date, oldest first.
+func (r *PostgresRepository) ListDue(ctx context.Context, date time.Time) ([]*domain.EmploymentEvent, error) {
+	var events []*domain.EmploymentEvent
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "employment_events")).
+		Where("scheduled = ? AND effective_date <= ?", true, date.Format("2006-01-02")).
+		Order("effective_date ASC, id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,132 @@
+package import_job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.ImportJobRepository {
+	return &PostgresRepository{db: db}
+}
+
+// Create stores a job together with its rows.
+func (r *PostgresRepository) Create(ctx context.Context, job *domain.ImportJob, rows []*domain.ImportJobRow) error {
+	job.CompanyID = tenant.Assign(ctx, job.CompanyID)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		for _, row := range rows {
+			row.JobID = job.ID
+		}
+		return tx.CreateInBatches(rows, 100).Error
+	})
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "import_jobs")).
+		First(&job, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	return tenant.Save(ctx, r.db, "import_jobs", job)
+}
+
+// List returns the jobs of the company, newest first.
+func (r *PostgresRepository) List(ctx context.Context, pagination domain.PaginationParams) ([]*domain.ImportJob, int64, error) {
+	var jobs []*domain.ImportJob
+	var totalItems int64
+
+	query := r.db.WithContext(ctx).Model(&domain.ImportJob{}).
+		Scopes(tenant.Scope(ctx, "import_jobs"))
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if pagination.PageSize > 0 {
+		query = query.Offset((pagination.Page - 1) * pagination.PageSize).Limit(pagination.PageSize)
+	}
+	if err := query.Order("import_jobs.created_at DESC, import_jobs.id DESC").Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, totalItems, nil
+}
+
+// ListUnfinished returns the jobs of every company that are queued or were interrupted while
+// running, oldest first.
+func (r *PostgresRepository) ListUnfinished(ctx context.Context) ([]*domain.ImportJob, error) {
+	var jobs []*domain.ImportJob
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "import_jobs")).
+		Where("status IN ?", []domain.ImportJobStatus{domain.ImportJobQueued, domain.ImportJobRunning}).
+		Order("created_at ASC, id ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Claim marks a job as running on behalf of the caller until leaseExpiresAt, provided it is queued
+// or running with a lapsed lease. Claiming is a single conditional update, so of several servers
+// claiming the same job only one succeeds; it reports whether the caller did. The claimed job is
+// read back into job.
+func (r *PostgresRepository) Claim(ctx context.Context, job *domain.ImportJob, leaseExpiresAt time.Time) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(job).
+		Scopes(tenant.Scope(ctx, "import_jobs")).
+		Clauses(clause.Returning{}).
+		Where("status = ? OR (status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?))",
+			domain.ImportJobQueued, domain.ImportJobRunning, now).
+		Updates(map[string]interface{}{
+			"status":           domain.ImportJobRunning,
+			"started_at":       gorm.Expr("COALESCE(started_at, ?)", now),
+			"lease_expires_at": leaseExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *PostgresRepository) ListRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error) {
+	var rows []*domain.ImportJobRow
+	err := r.db.WithContext(ctx).Scopes(tenant.ScopeVia(ctx, "import_job_rows.job_id", "import_jobs")).Where("job_id = ?", jobID).Order("row ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *PostgresRepository) ListPendingRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error) {
+	var rows []*domain.ImportJobRow
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.ScopeVia(ctx, "import_job_rows.job_id", "import_jobs")).
+		Where("job_id = ? AND status = ?", jobID, domain.ImportJobRowPending).
+		Order("row ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *PostgresRepository) UpdateRow(ctx context.Context, row *domain.ImportJobRow) error {
+	return tenant.SaveVia(ctx, r.db, "import_job_rows.job_id", "import_jobs", row)
+}
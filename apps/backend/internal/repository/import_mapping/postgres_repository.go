@@ -0,0 +1,73 @@
+package import_mapping
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.ImportMappingRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	profile.CompanyID = tenant.Assign(ctx, profile.CompanyID)
+	return r.db.WithContext(ctx).Create(profile).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.ImportMappingProfile, error) {
+	var profile domain.ImportMappingProfile
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "import_mapping_profiles")).
+		First(&profile, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrImportMappingProfileNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *PostgresRepository) GetByName(ctx context.Context, name string) (*domain.ImportMappingProfile, error) {
+	var profile domain.ImportMappingProfile
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "import_mapping_profiles")).
+		Where("LOWER(name) = LOWER(?)", name).
+		First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrImportMappingProfileNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context) ([]*domain.ImportMappingProfile, error) {
+	var profiles []*domain.ImportMappingProfile
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "import_mapping_profiles")).
+		Order("name ASC").
+		Find(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	return tenant.Save(ctx, r.db, "import_mapping_profiles", profile)
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	return r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "import_mapping_profiles")).Delete(profile).Error
+}
@@ -0,0 +1,118 @@
+package leave_encashment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.LeaveEncashmentRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, encashment *domain.LeaveEncashment) error {
+	return r.db.WithContext(ctx).Create(encashment).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.LeaveEncashment, error) {
+	var encashment domain.LeaveEncashment
+	err := r.db.WithContext(ctx).
+		Joins("JOIN employees ON leave_encashments.employee_id = employees.id").
+		Scopes(tenant.Scope(ctx, "employees")).
+		Preload("Employee").
+		First(&encashment, "leave_encashments.id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLeaveEncashmentNotFound
+		}
+		return nil, err
+	}
+	return &encashment, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, encashment *domain.LeaveEncashment) error {
+	updateMap := map[string]interface{}{
+		"encashed_days": encashment.EncashedDays,
+		"daily_rate":    encashment.DailyRate,
+		"amount":        encashment.Amount,
+		"status":        encashment.Status,
+		"admin_note":    encashment.AdminNote,
+		"reviewed_by":   encashment.ReviewedBy,
+		"finalized_at":  encashment.FinalizedAt,
+		"updated_at":    time.Now().UTC(),
+	}
+
+	result := r.db.WithContext(ctx).Model(&domain.LeaveEncashment{}).Scopes(tenant.ScopeVia(ctx, "leave_encashments.employee_id", "employees")).Where("id = ?", encashment.ID).Updates(updateMap)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrLeaveEncashmentNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.LeaveEncashment, int64, error) {
+	var encashments []*domain.LeaveEncashment
+	var totalItems int64
+
+	query := r.db.WithContext(ctx).Model(&domain.LeaveEncashment{}).
+		Joins("JOIN employees ON leave_encashments.employee_id = employees.id").
+		Scopes(tenant.Scope(ctx, "employees"))
+
+	for key, value := range filters {
+		switch key {
+		case "manager_id":
+			query = query.Where("employees.manager_id = ?", value)
+		default:
+			query = query.Where(fmt.Sprintf("leave_encashments.%s = ?", key), value)
+		}
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pagination.Page - 1) * pagination.PageSize
+	if err := query.Order("leave_encashments.created_at DESC").Offset(offset).Limit(pagination.PageSize).Preload("Employee").Find(&encashments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return encashments, totalItems, nil
+}
+
+func (r *PostgresRepository) Exists(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.LeaveEncashment{}).
+		Scopes(tenant.ScopeVia(ctx, "leave_encashments.employee_id", "employees")).
+		Where("employee_id = ? AND year = ? AND reason = ?", employeeID, year, reason).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresRepository) GetByPeriod(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (*domain.LeaveEncashment, error) {
+	var encashment domain.LeaveEncashment
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.ScopeVia(ctx, "leave_encashments.employee_id", "employees")).
+		Where("employee_id = ? AND year = ? AND reason = ?", employeeID, year, reason).
+		First(&encashment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLeaveEncashmentNotFound
+		}
+		return nil, err
+	}
+	return &encashment, nil
+}
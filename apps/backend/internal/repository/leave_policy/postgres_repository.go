@@ -0,0 +1,78 @@
+package leave_policy
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// chainUsersQuery selects the user IDs of the given employee and every manager above them.
+const chainUsersQuery = `
+	WITH RECURSIVE chain AS (
+		SELECT id, manager_id, user_id FROM employees WHERE id = ?
+		UNION
+		SELECT e.id, e.manager_id, e.user_id FROM employees e JOIN chain c ON e.id = c.manager_id
+	)
+	SELECT user_id FROM chain`
+
+// companyOwnerQuery selects the user ID of the admin owning the given employee's company.
+const companyOwnerQuery = `
+	SELECT c.owner_user_id FROM companies c JOIN employees e ON e.company_id = c.id WHERE e.id = ?`
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.LeavePolicyRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetByCreator(ctx context.Context, createdBy uint) (*domain.LeavePolicy, error) {
+	var policy domain.LeavePolicy
+	err := r.db.WithContext(ctx).Scopes(tenant.ScopeVia(ctx, "leave_policies.created_by", "users")).Where("created_by = ?", createdBy).First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLeavePolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// GetForEmployee returns the policy owned by the admin of the employee's company, falling back to
+// the admin at the top of the employee's reporting line for employees without a company.
+func (r *PostgresRepository) GetForEmployee(ctx context.Context, employeeID uint) (*domain.LeavePolicy, error) {
+	var policy domain.LeavePolicy
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.ScopeVia(ctx, "leave_policies.created_by", "users")).
+		Where("created_by IN ("+companyOwnerQuery+") OR created_by IN ("+chainUsersQuery+")", employeeID, employeeID).
+		Order("id ASC").
+		First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLeavePolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *PostgresRepository) Upsert(ctx context.Context, policy *domain.LeavePolicy) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "created_by"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"sick_certificate_required_after_days",
+			"sick_certificate_grace_days",
+			"sick_certificate_fallback_type",
+			"exclude_long_term_absence_from_seats",
+			"annual_leave_accrues_monthly",
+			"annual_leave_carry_over_cap",
+			"encashment_working_days_per_month",
+			"updated_at",
+		}),
+	}).Create(policy).Error
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
 	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
@@ -19,12 +21,19 @@ func NewPostgresRepository(db *gorm.DB) interfaces.LeaveRequestRepository {
 }
 
 func (r *PostgresRepository) Create(ctx context.Context, leaveRequest *domain.LeaveRequest) error {
+	if leaveRequest.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, leaveRequest.EmployeeID)
+		if err != nil {
+			return err
+		}
+		leaveRequest.CompanyID = companyID
+	}
 	return r.db.WithContext(ctx).Create(leaveRequest).Error
 }
 
 func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.LeaveRequest, error) {
 	var leaveRequest domain.LeaveRequest
-	err := r.db.WithContext(ctx).Preload("Employee").First(&leaveRequest, id).Error
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "leave_requests")).Preload("Employee").First(&leaveRequest, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrLeaveRequestNotFound
@@ -38,14 +47,22 @@ func (r *PostgresRepository) GetByEmployeeID(ctx context.Context, employeeID uin
 	var leaveRequests []*domain.LeaveRequest
 	var totalItems int64
 
-	query := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).Where("employee_id = ?", employeeID)
+	query := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).Scopes(tenant.Scope(ctx, "leave_requests")).Where("employee_id = ?", employeeID).
+		Scopes(listquery.Scope(pagination.Query, domain.LeaveRequestListSchema))
+
+	if pagination.Cursor != nil {
+		if err := query.Scopes(listquery.After(pagination, "leave_requests.created_at", "leave_requests.id")).Preload("Employee").Find(&leaveRequests).Error; err != nil {
+			return nil, 0, err
+		}
+		return leaveRequests, 0, nil
+	}
 
 	if err := query.Count(&totalItems).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (pagination.Page - 1) * pagination.PageSize
-	if err := query.Order("created_at DESC").Offset(offset).Limit(pagination.PageSize).Preload("Employee").Find(&leaveRequests).Error; err != nil {
+	if err := query.Order(listquery.Order(pagination.Query, domain.LeaveRequestListSchema, "created_at DESC")).Offset(offset).Limit(pagination.PageSize).Preload("Employee").Find(&leaveRequests).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -53,11 +70,11 @@ func (r *PostgresRepository) GetByEmployeeID(ctx context.Context, employeeID uin
 }
 
 func (r *PostgresRepository) Update(ctx context.Context, leaveRequest *domain.LeaveRequest) error {
-	return r.db.WithContext(ctx).Save(leaveRequest).Error
+	return tenant.Save(ctx, r.db, "leave_requests", leaveRequest)
 }
 
 func (r *PostgresRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&domain.LeaveRequest{}, id)
+	result := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "leave_requests")).Delete(&domain.LeaveRequest{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -71,7 +88,7 @@ func (r *PostgresRepository) List(ctx context.Context, filters map[string]interf
 	var leaveRequests []*domain.LeaveRequest
 	var totalItems int64
 
-	query := r.db.WithContext(ctx).Model(&domain.LeaveRequest{})
+	query := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).Scopes(tenant.Scope(ctx, "leave_requests"))
 
 	// Apply filters
 	for key, value := range filters {
@@ -86,6 +103,10 @@ func (r *PostgresRepository) List(ctx context.Context, filters map[string]interf
 			query = query.Where("start_date >= ?", value)
 		case "end_date_lte":
 			query = query.Where("end_date <= ?", value)
+		case "start_date_lte":
+			query = query.Where("start_date <= ?", value)
+		case "end_date_gte":
+			query = query.Where("end_date >= ?", value)
 		case "manager_id":
 			// Join with employees table to filter by manager
 			query = query.Joins("JOIN employees ON leave_requests.employee_id = employees.id").
@@ -94,13 +115,21 @@ func (r *PostgresRepository) List(ctx context.Context, filters map[string]interf
 			query = query.Where(fmt.Sprintf("%s = ?", key), value)
 		}
 	}
+	query = query.Scopes(listquery.Scope(pagination.Query, domain.LeaveRequestListSchema))
+
+	if pagination.Cursor != nil {
+		if err := query.Scopes(listquery.After(pagination, "leave_requests.created_at", "leave_requests.id")).Preload("Employee").Find(&leaveRequests).Error; err != nil {
+			return nil, 0, err
+		}
+		return leaveRequests, 0, nil
+	}
 
 	if err := query.Count(&totalItems).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (pagination.Page - 1) * pagination.PageSize
-	if err := query.Order("leave_requests.created_at DESC").Offset(offset).Limit(pagination.PageSize).Preload("Employee").Find(&leaveRequests).Error; err != nil {
+	if err := query.Order(listquery.Order(pagination.Query, domain.LeaveRequestListSchema, "leave_requests.created_at DESC")).Offset(offset).Limit(pagination.PageSize).Preload("Employee").Find(&leaveRequests).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -115,7 +144,7 @@ func (r *PostgresRepository) UpdateStatus(ctx context.Context, id uint, status d
 		updates["admin_note"] = *adminNote
 	}
 
-	result := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).Where("id = ?", id).Updates(updates)
+	result := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).Scopes(tenant.Scope(ctx, "leave_requests")).Where("id = ?", id).Updates(updates)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -130,6 +159,7 @@ func (r *PostgresRepository) HasApprovedLeaveForDate(ctx context.Context, employ
 
 	if err := r.db.WithContext(ctx).
 		Model(&domain.LeaveRequest{}).
+		Scopes(tenant.Scope(ctx, "leave_requests")).
 		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?",
 			employeeID, domain.LeaveStatusApproved, date, date).
 		Count(&count).Error; err != nil {
@@ -143,6 +173,7 @@ func (r *PostgresRepository) HasOverlappingLeaveRequest(ctx context.Context, emp
 	var count int64
 	
 	query := r.db.WithContext(ctx).Model(&domain.LeaveRequest{}).
+		Scopes(tenant.Scope(ctx, "leave_requests")).
 		Where("employee_id = ?", employeeID).
 		Where("status IN (?)", []domain.LeaveStatus{domain.LeaveStatusPending, domain.LeaveStatusApproved}).
 		Where("NOT (end_date < ? OR start_date > ?)", startDate, endDate)
@@ -158,3 +189,20 @@ func (r *PostgresRepository) HasOverlappingLeaveRequest(ctx context.Context, emp
 	
 	return count > 0, nil
 }
+
+// ListCertificatesOverdue returns the non-rejected requests whose medical certificate is still
+// pending after its due date.
+func (r *PostgresRepository) ListCertificatesOverdue(ctx context.Context, asOf time.Time) ([]*domain.LeaveRequest, error) {
+	var leaveRequests []*domain.LeaveRequest
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "leave_requests")).
+		Where("certificate_status = ? AND certificate_due_date < ?", domain.CertificatePending, asOf).
+		Where("status != ?", domain.LeaveStatusRejected).
+		Order("certificate_due_date ASC").
+		Preload("Employee").
+		Find(&leaveRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaveRequests, nil
+}
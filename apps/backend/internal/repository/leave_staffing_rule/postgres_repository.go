@@ -0,0 +1,159 @@
+package leave_staffing_rule
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+// subtreeQuery selects the IDs of every employee below the given root in the reporting tree.
+const subtreeQuery = `
+	WITH RECURSIVE team AS (
+		SELECT id FROM employees WHERE manager_id = ?
+		UNION
+		SELECT e.id FROM employees e JOIN team t ON e.manager_id = t.id
+	)
+	SELECT id FROM team`
+
+// ancestorsQuery selects the IDs of every manager above the given employee.
+const ancestorsQuery = `
+	WITH RECURSIVE chain AS (
+		SELECT id, manager_id FROM employees WHERE id = ?
+		UNION
+		SELECT e.id, e.manager_id FROM employees e JOIN chain c ON e.id = c.manager_id
+	)
+	SELECT manager_id FROM chain WHERE manager_id IS NOT NULL`
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.LeaveStaffingRuleRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, rule *domain.LeaveStaffingRule) error {
+	rule.CompanyID = tenant.Assign(ctx, rule.CompanyID)
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.LeaveStaffingRule, error) {
+	var rule domain.LeaveStaffingRule
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "leave_staffing_rules")).Preload("RootEmployee").First(&rule, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrStaffingRuleNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context) ([]*domain.LeaveStaffingRule, error) {
+	var rules []*domain.LeaveStaffingRule
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "leave_staffing_rules")).
+		Order("id ASC").
+		Preload("RootEmployee").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, rule *domain.LeaveStaffingRule) error {
+	updateMap := map[string]interface{}{
+		"name":               rule.Name,
+		"scope":              rule.Scope,
+		"root_employee_id":   rule.RootEmployeeID,
+		"branch":             rule.Branch,
+		"max_absent":         rule.MaxAbsent,
+		"max_absent_percent": rule.MaxAbsentPercent,
+		"is_active":          rule.IsActive,
+		"updated_at":         time.Now().UTC(),
+	}
+
+	result := r.db.WithContext(ctx).Model(&domain.LeaveStaffingRule{}).Scopes(tenant.Scope(ctx, "leave_staffing_rules")).Where("id = ?", rule.ID).Updates(updateMap)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrStaffingRuleNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "leave_staffing_rules")).Delete(&domain.LeaveStaffingRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrStaffingRuleNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListApplicableToEmployee(ctx context.Context, employee *domain.Employee) ([]*domain.LeaveStaffingRule, error) {
+	var rules []*domain.LeaveStaffingRule
+
+	query := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "leave_staffing_rules")).
+		Where("is_active = ?", true).
+		Where("root_employee_id IS NULL OR root_employee_id IN ("+ancestorsQuery+")", employee.ID)
+
+	if employee.Branch != nil {
+		query = query.Where("branch IS NULL OR branch = ?", *employee.Branch)
+	} else {
+		query = query.Where("branch IS NULL")
+	}
+
+	if err := query.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *PostgresRepository) CountScopeEmployees(ctx context.Context, rule *domain.LeaveStaffingRule) (int64, error) {
+	var count int64
+	if err := r.scopeEmployees(ctx, rule).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PostgresRepository) ListScopeLeaves(ctx context.Context, rule *domain.LeaveStaffingRule, startDate, endDate time.Time, statuses []domain.LeaveStatus) ([]*domain.LeaveRequest, error) {
+	var leaveRequests []*domain.LeaveRequest
+
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "leave_requests")).
+		Where("employee_id IN (?)", r.scopeEmployees(ctx, rule).Select("employees.id")).
+		Where("status IN (?)", statuses).
+		Where("NOT (end_date < ? OR start_date > ?)", startDate, endDate).
+		Order("start_date ASC").
+		Preload("Employee").
+		Find(&leaveRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaveRequests, nil
+}
+
+func (r *PostgresRepository) scopeEmployees(ctx context.Context, rule *domain.LeaveStaffingRule) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&domain.Employee{}).
+		Scopes(tenant.Scope(ctx, "employees")).
+		Where("employees.employment_status = ?", true)
+
+	if rule.RootEmployeeID != nil {
+		query = query.Where("employees.id IN ("+subtreeQuery+")", *rule.RootEmployeeID)
+	}
+	if rule.Branch != nil {
+		query = query.Where("employees.branch = ?", *rule.Branch)
+	}
+	return query
+}
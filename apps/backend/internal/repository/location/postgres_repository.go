@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +18,7 @@ func NewLocationRepository(db *gorm.DB) *locationRepository {
 }
 
 func (r *locationRepository) Create(ctx context.Context, location *domain.Location) (*domain.Location, error) {
+	location.CompanyID = tenant.Assign(ctx, location.CompanyID)
 	err := r.db.WithContext(ctx).Create(location).Error
 	if err != nil {
 		return nil, err
@@ -27,13 +30,14 @@ func (r *locationRepository) List(ctx context.Context, paginationParams domain.P
 	var locations []*domain.Location
 	var totalItems int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Location{}).Where("is_active = ?", true)
+	query := r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("is_active = ?", true).
+		Scopes(listquery.Scope(paginationParams.Query, domain.LocationListSchema))
 	if err := query.Count(&totalItems).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (paginationParams.Page - 1) * paginationParams.PageSize
-	if err := query.Order("id ASC").Offset(offset).Limit(paginationParams.PageSize).Find(&locations).Error; err != nil {
+	if err := query.Order(listquery.Order(paginationParams.Query, domain.LocationListSchema, "locations.id ASC")).Offset(offset).Limit(paginationParams.PageSize).Find(&locations).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -44,13 +48,14 @@ func (r *locationRepository) ListByUser(ctx context.Context, userID uint, pagina
 	var locations []*domain.Location
 	var totalItems int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Location{}).Where("is_active = ? AND created_by = ?", true, userID)
+	query := r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("is_active = ? AND created_by = ?", true, userID).
+		Scopes(listquery.Scope(paginationParams.Query, domain.LocationListSchema))
 	if err := query.Count(&totalItems).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (paginationParams.Page - 1) * paginationParams.PageSize
-	if err := query.Order("id ASC").Offset(offset).Limit(paginationParams.PageSize).Find(&locations).Error; err != nil {
+	if err := query.Order(listquery.Order(paginationParams.Query, domain.LocationListSchema, "locations.id ASC")).Offset(offset).Limit(paginationParams.PageSize).Find(&locations).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -59,7 +64,7 @@ func (r *locationRepository) ListByUser(ctx context.Context, userID uint, pagina
 
 func (r *locationRepository) GetByID(ctx context.Context, id uint) (*domain.Location, error) {
 	var location domain.Location
-	if err := r.db.WithContext(ctx).Where("id = ? AND is_active = ?", id, true).First(&location).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND is_active = ?", id, true).First(&location).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrLocationNotFound
 		}
@@ -70,7 +75,7 @@ func (r *locationRepository) GetByID(ctx context.Context, id uint) (*domain.Loca
 
 func (r *locationRepository) GetByIDAndUser(ctx context.Context, id uint, userID uint) (*domain.Location, error) {
 	var location domain.Location
-	if err := r.db.WithContext(ctx).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&location).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&location).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrLocationNotFound
 		}
@@ -80,39 +85,39 @@ func (r *locationRepository) GetByIDAndUser(ctx context.Context, id uint, userID
 }
 
 func (r *locationRepository) Update(ctx context.Context, id uint, location *domain.Location) (*domain.Location, error) {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Updates(location).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ?", id).Updates(location).Error; err != nil {
 		return nil, err
 	}
 	var updatedLocation domain.Location
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&updatedLocation).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ?", id).First(&updatedLocation).Error; err != nil {
 		return nil, err
 	}
 	return &updatedLocation, nil
 }
 
 func (r *locationRepository) UpdateByUser(ctx context.Context, id uint, userID uint, location *domain.Location) (*domain.Location, error) {
-	if err := r.db.WithContext(ctx).Where("id = ? AND created_by = ?", id, userID).Updates(location).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND created_by = ?", id, userID).Updates(location).Error; err != nil {
 		return nil, err
 	}
 	var updatedLocation domain.Location
-	if err := r.db.WithContext(ctx).Where("id = ? AND created_by = ?", id, userID).First(&updatedLocation).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND created_by = ?", id, userID).First(&updatedLocation).Error; err != nil {
 		return nil, err
 	}
 	return &updatedLocation, nil
 }
 
 func (r *locationRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Model(&domain.Location{}).Where("id = ?", id).Update("is_active", false).Error
+	return r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("id = ?", id).Update("is_active", false).Error
 }
 
 func (r *locationRepository) DeleteByUser(ctx context.Context, id uint, userID uint) error {
-	return r.db.WithContext(ctx).Model(&domain.Location{}).Where("id = ? AND created_by = ?", id, userID).Update("is_active", false).Error
+	return r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND created_by = ?", id, userID).Update("is_active", false).Error
 }
 
 // Exists checks if a location with the given ID exists and is active.
 func (r *locationRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var location domain.Location
-	err := r.db.WithContext(ctx).Model(&domain.Location{}).Where("id = ? AND is_active = ?", id, true).First(&location).Error
+	err := r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND is_active = ?", id, true).First(&location).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return false, nil // Record not found means it doesn't exist
@@ -125,7 +130,7 @@ func (r *locationRepository) Exists(ctx context.Context, id uint) (bool, error)
 // ExistsByUser checks if a location with the given ID exists, is active, and belongs to the specified user.
 func (r *locationRepository) ExistsByUser(ctx context.Context, id uint, userID uint) (bool, error) {
 	var location domain.Location
-	err := r.db.WithContext(ctx).Model(&domain.Location{}).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&location).Error
+	err := r.db.WithContext(ctx).Model(&domain.Location{}).Scopes(tenant.Scope(ctx, "locations")).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&location).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return false, nil // Record not found means it doesn't exist
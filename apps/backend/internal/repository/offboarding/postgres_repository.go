@@ -0,0 +1,102 @@
+package offboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.OffboardingRepository {
+	return &PostgresRepository{db: db}
+}
+
+// Create stores the offboarding together with its exit checklist.
+func (r *PostgresRepository) Create(ctx context.Context, offboarding *domain.Offboarding) error {
+	if offboarding.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, offboarding.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", offboarding.EmployeeID, err)
+		}
+		offboarding.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Omit("Employee", "Tasks.Assignee").Create(offboarding).Error
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, offboarding *domain.Offboarding) error {
+	return tenant.Save(ctx, r.db.Omit("Employee", "Tasks"), "offboardings", offboarding)
+}
+
+func (r *PostgresRepository) GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Offboarding, error) {
+	var offboarding domain.Offboarding
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "offboardings")).
+		Where("employee_id = ?", employeeID).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("due_date ASC, id ASC") }).
+		Preload("Tasks.Assignee").
+		Order("created_at DESC, id DESC").
+		First(&offboarding).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOffboardingNotFound
+		}
+		return nil, err
+	}
+	return &offboarding, nil
+}
+
+func (r *PostgresRepository) GetTaskByID(ctx context.Context, offboardingID, taskID uint) (*domain.OffboardingTask, error) {
+	var task domain.OffboardingTask
+	err := r.db.WithContext(ctx).Scopes(tenant.ScopeVia(ctx, "offboarding_tasks.offboarding_id", "offboardings")).Where("offboarding_id = ?", offboardingID).First(&task, taskID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOffboardingTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *PostgresRepository) UpdateTask(ctx context.Context, task *domain.OffboardingTask) error {
+	return tenant.SaveVia(ctx, r.db.Omit("Assignee"), "offboarding_tasks.offboarding_id", "offboardings", task)
+}
+
+// ListDue returns the scheduled offboardings whose last working day is before the given date,
+// with the leaving employee.
+func (r *PostgresRepository) ListDue(ctx context.Context, date time.Time) ([]*domain.Offboarding, error) {
+	var offboardings []*domain.Offboarding
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "offboardings")).
+		Where("status = ? AND last_working_day < ?", domain.OffboardingScheduled, date.Format("2006-01-02")).
+		Preload("Employee").
+		Order("last_working_day ASC, id ASC").
+		Find(&offboardings).Error
+	if err != nil {
+		return nil, err
+	}
+	return offboardings, nil
+}
+
+func (r *PostgresRepository) ListCompletedBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Offboarding, error) {
+	var offboardings []*domain.Offboarding
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "offboardings")).
+		Where("status = ? AND last_working_day BETWEEN ? AND ?", domain.OffboardingCompleted,
+			startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Preload("Employee").
+		Order("last_working_day ASC, id ASC").
+		Find(&offboardings).Error
+	if err != nil {
+		return nil, err
+	}
+	return offboardings, nil
+}
@@ -0,0 +1,188 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.OnboardingRepository {
+	return &PostgresRepository{db: db}
+}
+
+func orderTemplateTasks(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
+// --- Templates ---
+
+// CreateTemplate stores the template together with its tasks.
+func (r *PostgresRepository) CreateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error {
+	template.CompanyID = tenant.Assign(ctx, template.CompanyID)
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *PostgresRepository) GetTemplateByID(ctx context.Context, id uint) (*domain.OnboardingTemplate, error) {
+	var template domain.OnboardingTemplate
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_templates")).
+		Preload("Tasks", orderTemplateTasks).
+		First(&template, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOnboardingTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *PostgresRepository) ListTemplates(ctx context.Context) ([]*domain.OnboardingTemplate, error) {
+	var templates []*domain.OnboardingTemplate
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_templates")).
+		Preload("Tasks", orderTemplateTasks).
+		Order("name ASC, id ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// UpdateTemplate saves the template and replaces its tasks with the ones it holds. Checklists
+// already created from the template are left as they are.
+func (r *PostgresRepository) UpdateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(tenant.Scope(ctx, "onboarding_templates")).Select("id").First(&domain.OnboardingTemplate{}, template.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&domain.OnboardingTemplateTask{}).Error; err != nil {
+			return fmt.Errorf("failed to delete template tasks: %w", err)
+		}
+		for i := range template.Tasks {
+			template.Tasks[i].ID = 0
+			template.Tasks[i].TemplateID = template.ID
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(template).Error
+	})
+}
+
+// DeleteTemplate removes the template and its tasks. Checklists created from it are kept.
+func (r *PostgresRepository) DeleteTemplate(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.OnboardingTask{}).Where("template_id = ?", id).Update("template_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&domain.OnboardingTemplateTask{}).Error; err != nil {
+			return err
+		}
+		result := tx.Scopes(tenant.Scope(ctx, "onboarding_templates")).Delete(&domain.OnboardingTemplate{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrOnboardingTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// --- Tasks ---
+
+// CreateTasks stores the onboarding checklist of a new hire. The tasks belong to the new hire's
+// company.
+func (r *PostgresRepository) CreateTasks(ctx context.Context, tasks []*domain.OnboardingTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	for _, task := range tasks {
+		if task.CompanyID != nil {
+			continue
+		}
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, task.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", task.EmployeeID, err)
+		}
+		task.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Omit("Employee", "Assignee").Create(&tasks).Error
+}
+
+func (r *PostgresRepository) ListTasksByEmployee(ctx context.Context, employeeID uint) ([]*domain.OnboardingTask, error) {
+	var tasks []*domain.OnboardingTask
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_tasks")).
+		Where("employee_id = ?", employeeID).
+		Preload("Assignee").
+		Order("due_date ASC, sort_order ASC, id ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListOpenTasksByAssignee returns the open onboarding tasks assigned to an employee, with the new
+// hire each task is for.
+func (r *PostgresRepository) ListOpenTasksByAssignee(ctx context.Context, assigneeID uint) ([]*domain.OnboardingTask, error) {
+	var tasks []*domain.OnboardingTask
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_tasks")).
+		Where("assignee_id = ? AND completed_at IS NULL", assigneeID).
+		Preload("Employee").
+		Order("due_date ASC, sort_order ASC, id ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListOpenTasksDueBefore returns the open onboarding tasks of active employees due before the
+// given date, with the new hire and the assignee.
+func (r *PostgresRepository) ListOpenTasksDueBefore(ctx context.Context, date time.Time) ([]*domain.OnboardingTask, error) {
+	var tasks []*domain.OnboardingTask
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_tasks")).
+		Joins("JOIN employees ON employees.id = onboarding_tasks.employee_id AND employees.employment_status = ?", true).
+		Where("onboarding_tasks.completed_at IS NULL AND onboarding_tasks.due_date < ?", date.Format("2006-01-02")).
+		Preload("Employee").
+		Preload("Assignee").
+		Order("onboarding_tasks.due_date ASC, onboarding_tasks.id ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *PostgresRepository) GetTaskByID(ctx context.Context, employeeID, taskID uint) (*domain.OnboardingTask, error) {
+	var task domain.OnboardingTask
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "onboarding_tasks")).
+		Where("employee_id = ?", employeeID).
+		Preload("Assignee").
+		First(&task, taskID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOnboardingTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *PostgresRepository) UpdateTask(ctx context.Context, task *domain.OnboardingTask) error {
+	return tenant.Save(ctx, r.db.Omit("Employee", "Assignee"), "onboarding_tasks", task)
+}
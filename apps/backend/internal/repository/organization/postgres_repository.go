@@ -0,0 +1,238 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.OrganizationRepository {
+	return &PostgresRepository{db: db}
+}
+
+// --- Departments ---
+
+func (r *PostgresRepository) CreateDepartment(ctx context.Context, department *domain.Department) error {
+	department.CompanyID = tenant.Assign(ctx, department.CompanyID)
+	return r.db.WithContext(ctx).Create(department).Error
+}
+
+func (r *PostgresRepository) GetDepartmentByID(ctx context.Context, id uint) (*domain.Department, error) {
+	var department domain.Department
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "departments")).First(&department, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDepartmentNotFound
+		}
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *PostgresRepository) ListDepartments(ctx context.Context) ([]*domain.Department, error) {
+	var departments []*domain.Department
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "departments")).Order("name ASC, id ASC").Find(&departments).Error
+	if err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (r *PostgresRepository) UpdateDepartment(ctx context.Context, department *domain.Department) error {
+	result := r.db.WithContext(ctx).Model(&domain.Department{}).Scopes(tenant.Scope(ctx, "departments")).
+		Where("id = ?", department.ID).
+		Updates(map[string]interface{}{
+			"name":       department.Name,
+			"parent_id":  department.ParentID,
+			"updated_at": time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// DeleteDepartment removes the department and unassigns the former employees still pointing at it.
+func (r *PostgresRepository) DeleteDepartment(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Employee{}).Where("department_id = ?", id).Update("department_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Scopes(tenant.Scope(ctx, "departments")).Delete(&domain.Department{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrDepartmentNotFound
+		}
+		return nil
+	})
+}
+
+// CountActiveEmployeesByDepartment returns the number of active employees per department.
+// Employees without a department are counted under key 0.
+func (r *PostgresRepository) CountActiveEmployeesByDepartment(ctx context.Context) (map[uint]int64, error) {
+	var rows []struct {
+		DepartmentID *uint
+		Count        int64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.Employee{}).Scopes(tenant.Scope(ctx, "employees")).
+		Select("department_id, COUNT(*) AS count").
+		Where("employment_status = ?", true).
+		Group("department_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		var departmentID uint
+		if row.DepartmentID != nil {
+			departmentID = *row.DepartmentID
+		}
+		counts[departmentID] = row.Count
+	}
+	return counts, nil
+}
+
+// --- Branches ---
+
+func (r *PostgresRepository) CreateBranch(ctx context.Context, branch *domain.Branch) error {
+	branch.CompanyID = tenant.Assign(ctx, branch.CompanyID)
+	return r.db.WithContext(ctx).Create(branch).Error
+}
+
+func (r *PostgresRepository) GetBranchByID(ctx context.Context, id uint) (*domain.Branch, error) {
+	var branch domain.Branch
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "branches")).First(&branch, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (r *PostgresRepository) ListBranches(ctx context.Context) ([]*domain.Branch, error) {
+	var branches []*domain.Branch
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "branches")).Order("name ASC, id ASC").Find(&branches).Error
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// UpdateBranch renames the branch and the branch name shown on its employees.
+func (r *PostgresRepository) UpdateBranch(ctx context.Context, branch *domain.Branch) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Branch{}).Scopes(tenant.Scope(ctx, "branches")).
+			Where("id = ?", branch.ID).
+			Updates(map[string]interface{}{
+				"name":       branch.Name,
+				"updated_at": time.Now().UTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrBranchNotFound
+		}
+		return tx.Model(&domain.Employee{}).Where("branch_id = ?", branch.ID).Update("branch", branch.Name).Error
+	})
+}
+
+// DeleteBranch removes the branch and unassigns the former employees still pointing at it. Their
+// branch name is kept for reference.
+func (r *PostgresRepository) DeleteBranch(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Employee{}).Where("branch_id = ?", id).Update("branch_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Scopes(tenant.Scope(ctx, "branches")).Delete(&domain.Branch{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrBranchNotFound
+		}
+		return nil
+	})
+}
+
+// --- Positions ---
+
+func (r *PostgresRepository) CreatePosition(ctx context.Context, position *domain.Position) error {
+	position.CompanyID = tenant.Assign(ctx, position.CompanyID)
+	return r.db.WithContext(ctx).Create(position).Error
+}
+
+func (r *PostgresRepository) GetPositionByID(ctx context.Context, id uint) (*domain.Position, error) {
+	var position domain.Position
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "positions")).First(&position, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPositionNotFound
+		}
+		return nil, err
+	}
+	return &position, nil
+}
+
+func (r *PostgresRepository) ListPositions(ctx context.Context) ([]*domain.Position, error) {
+	var positions []*domain.Position
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "positions")).Order("name ASC, id ASC").Find(&positions).Error
+	if err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// UpdatePosition renames the position and the position name shown on its employees.
+func (r *PostgresRepository) UpdatePosition(ctx context.Context, position *domain.Position) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Position{}).Scopes(tenant.Scope(ctx, "positions")).
+			Where("id = ?", position.ID).
+			Updates(map[string]interface{}{
+				"name":       position.Name,
+				"updated_at": time.Now().UTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPositionNotFound
+		}
+		return tx.Model(&domain.Employee{}).Where("position_id = ?", position.ID).Update("position_name", position.Name).Error
+	})
+}
+
+// DeletePosition removes the position and unassigns the former employees still pointing at it.
+// Their position name is kept for reference.
+func (r *PostgresRepository) DeletePosition(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Employee{}).Where("position_id = ?", id).Update("position_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Scopes(tenant.Scope(ctx, "positions")).Delete(&domain.Position{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPositionNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+package probation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.ProbationRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, probation *domain.Probation) error {
+	if probation.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, probation.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", probation.EmployeeID, err)
+		}
+		probation.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Omit("Employee", "Reviews").Create(probation).Error
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, probation *domain.Probation) error {
+	return tenant.Save(ctx, r.db.Omit("Employee", "Reviews"), "probations", probation)
+}
+
+// GetLatestByEmployee returns the most recent probation of an employee with its reviews.
+func (r *PostgresRepository) GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Probation, error) {
+	var probation domain.Probation
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "probations")).
+		Where("employee_id = ?", employeeID).
+		Preload("Reviews", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		Order("created_at DESC, id DESC").
+		First(&probation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProbationNotFound
+		}
+		return nil, err
+	}
+	return &probation, nil
+}
+
+func (r *PostgresRepository) CreateReview(ctx context.Context, review *domain.ProbationReview) error {
+	return r.db.WithContext(ctx).Create(review).Error
+}
+
+// ListInProgress returns the probations of active employees that have not been concluded yet,
+// by end date, with the employee and their manager.
+func (r *PostgresRepository) ListInProgress(ctx context.Context) ([]*domain.Probation, error) {
+	var probations []*domain.Probation
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "probations")).
+		Joins("JOIN employees ON employees.id = probations.employee_id AND employees.employment_status = ?", true).
+		Where("probations.status = ?", domain.ProbationInProgress).
+		Preload("Employee").
+		Preload("Employee.Manager").
+		Order("probations.end_date ASC, probations.id ASC").
+		Find(&probations).Error
+	if err != nil {
+		return nil, err
+	}
+	return probations, nil
+}
@@ -0,0 +1,104 @@
+package profile_change
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRepository(db *gorm.DB) interfaces.ProfileChangeRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) GetPolicy(ctx context.Context, companyID uint) (*domain.ProfileChangePolicy, error) {
+	var policy domain.ProfileChangePolicy
+	err := r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "profile_change_policies")).Where("company_id = ?", companyID).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProfileChangePolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *PostgresRepository) UpsertPolicy(ctx context.Context, policy *domain.ProfileChangePolicy) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fields", "updated_at"}),
+	}).Create(policy).Error
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, request *domain.ProfileChangeRequest) error {
+	if request.CompanyID == nil {
+		companyID, err := tenant.EmployeeCompanyID(ctx, r.db, request.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get company of employee %d: %w", request.EmployeeID, err)
+		}
+		request.CompanyID = companyID
+	}
+	return r.db.WithContext(ctx).Omit("Employee").Create(request).Error
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uint) (*domain.ProfileChangeRequest, error) {
+	var request domain.ProfileChangeRequest
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "profile_change_requests")).
+		Preload("Employee").
+		First(&request, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProfileChangeNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, request *domain.ProfileChangeRequest) error {
+	return tenant.Save(ctx, r.db.Omit("Employee"), "profile_change_requests", request)
+}
+
+// List returns the requests matching the filters, newest first, with the employee.
+func (r *PostgresRepository) List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.ProfileChangeRequest, int64, error) {
+	var requests []*domain.ProfileChangeRequest
+	var totalItems int64
+
+	query := r.db.WithContext(ctx).Model(&domain.ProfileChangeRequest{}).
+		Scopes(tenant.Scope(ctx, "profile_change_requests"))
+
+	for key, value := range filters {
+		query = query.Where(fmt.Sprintf("profile_change_requests.%s = ?", key), value)
+	}
+
+	if pagination.Cursor != nil {
+		if err := query.Scopes(listquery.After(pagination, "profile_change_requests.created_at", "profile_change_requests.id")).Preload("Employee").Find(&requests).Error; err != nil {
+			return nil, 0, err
+		}
+		return requests, 0, nil
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if pagination.PageSize > 0 {
+		query = query.Offset((pagination.Page - 1) * pagination.PageSize).Limit(pagination.PageSize)
+	}
+	if err := query.Order("profile_change_requests.created_at DESC, profile_change_requests.id DESC").Preload("Employee").Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return requests, totalItems, nil
+}
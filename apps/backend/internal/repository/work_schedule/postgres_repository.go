@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"gorm.io/gorm"
 )
 
@@ -24,6 +25,7 @@ func (r *WorkScheduleRepository) CreateWithDetails(ctx context.Context, workSche
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. Create the main WorkSchedule record (ensure it's active)
 		workSchedule.IsActive = true
+		workSchedule.CompanyID = tenant.Assign(ctx, workSchedule.CompanyID)
 		if err := tx.Create(workSchedule).Error; err != nil {
 			return fmt.Errorf("failed to create work schedule: %w", err)
 		}
@@ -45,6 +47,7 @@ func (r *WorkScheduleRepository) GetByIDAndUser(ctx context.Context, id uint, us
 	var workSchedule domain.WorkSchedule
 	// Preload Details yang aktif dan their associated Location, hanya untuk work schedule yang aktif dan dimiliki user
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ? AND created_by = ?", true, userID).
 		Preload("Details", "is_active = ?", true).
 		Preload("Details.Location").
@@ -62,6 +65,7 @@ func (r *WorkScheduleRepository) GetByIDWithAllDetailsByUser(ctx context.Context
 	var workSchedule domain.WorkSchedule
 	// Preload semua Details (aktif dan tidak aktif) beserta associated Location, hanya untuk work schedule yang aktif dan dimiliki user
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ? AND created_by = ?", true, userID).
 		Preload("Details").
 		Preload("Details.Location").
@@ -79,6 +83,7 @@ func (r *WorkScheduleRepository) GetByIDWithDetails(ctx context.Context, id uint
 	var workSchedule domain.WorkSchedule
 	// Preload Details yang aktif dan their associated Location, hanya untuk work schedule yang aktif
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ?", true).
 		Preload("Details", "is_active = ?", true).
 		Preload("Details.Location").
@@ -96,6 +101,7 @@ func (r *WorkScheduleRepository) GetByIDWithAllDetails(ctx context.Context, id u
 	var workSchedule domain.WorkSchedule
 	// Preload semua Details (aktif dan tidak aktif) beserta associated Location, hanya untuk work schedule yang aktif
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ?", true).
 		Preload("Details").
 		Preload("Details.Location").
@@ -113,7 +119,7 @@ func (r *WorkScheduleRepository) UpdateByUser(ctx context.Context, id uint, user
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Check if work schedule exists and is owned by user
 		var existingSchedule domain.WorkSchedule
-		if err := tx.Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&existingSchedule).Error; err != nil {
+		if err := tx.Scopes(tenant.Scope(ctx, "work_schedules")).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&existingSchedule).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return fmt.Errorf("work schedule with ID %d not found, not active, or not owned by user", id)
 			}
@@ -148,6 +154,13 @@ func (r *WorkScheduleRepository) UpdateByUser(ctx context.Context, id uint, user
 // UpdateWithDetails memperbarui jadwal kerja beserta detailnya
 func (r *WorkScheduleRepository) UpdateWithDetails(ctx context.Context, workSchedule *domain.WorkSchedule, details []*domain.WorkScheduleDetail, deletedDetailIDs []uint) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(tenant.Scope(ctx, "work_schedules")).First(&domain.WorkSchedule{}, workSchedule.ID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("work schedule with ID %d not found", workSchedule.ID)
+			}
+			return fmt.Errorf("failed to check work schedule existence: %w", err)
+		}
+
 		// Update WorkSchedule utama
 		if err := tx.Save(workSchedule).Error; err != nil {
 			return fmt.Errorf("failed to update work schedule: %w", err)
@@ -175,6 +188,7 @@ func (r *WorkScheduleRepository) UpdateWithDetails(ctx context.Context, workSche
 func (r *WorkScheduleRepository) GetDetailsByScheduleID(ctx context.Context, scheduleID uint) ([]*domain.WorkScheduleDetail, error) {
 	var details []*domain.WorkScheduleDetail
 	if err := r.db.WithContext(ctx).
+		Scopes(tenant.ScopeVia(ctx, "work_schedule_details.work_schedule_id", "work_schedules")).
 		Preload("Location").
 		Where("work_schedule_id = ? AND is_active = ?", scheduleID, true).
 		Find(&details).Error; err != nil {
@@ -188,7 +202,7 @@ func (r *WorkScheduleRepository) DeleteByUser(ctx context.Context, id uint, user
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// First, check if the work schedule exists, is still active, and is owned by user
 		var workSchedule domain.WorkSchedule
-		if err := tx.Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&workSchedule).Error; err != nil {
+		if err := tx.Scopes(tenant.Scope(ctx, "work_schedules")).Where("id = ? AND is_active = ? AND created_by = ?", id, true, userID).First(&workSchedule).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return fmt.Errorf("work schedule with ID %d not found, already deleted, or not owned by user", id)
 			}
@@ -216,7 +230,7 @@ func (r *WorkScheduleRepository) DeleteWithDetails(ctx context.Context, id uint)
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// First, check if the work schedule exists and is still active
 		var workSchedule domain.WorkSchedule
-		if err := tx.Where("id = ? AND is_active = ?", id, true).First(&workSchedule).Error; err != nil {
+		if err := tx.Scopes(tenant.Scope(ctx, "work_schedules")).Where("id = ? AND is_active = ?", id, true).First(&workSchedule).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return fmt.Errorf("work schedule with ID %d not found or already deleted", id)
 			}
@@ -248,6 +262,7 @@ func (r *WorkScheduleRepository) ListByUser(ctx context.Context, userID uint, pa
 
 	// Count total active items owned by user only
 	err := r.db.WithContext(ctx).Model(&domain.WorkSchedule{}).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ? AND created_by = ?", true, userID).
 		Count(&totalItems).Error
 	if err != nil {
@@ -256,6 +271,7 @@ func (r *WorkScheduleRepository) ListByUser(ctx context.Context, userID uint, pa
 
 	// Retrieve paginated active items owned by user with active details preloaded, ordered by ID
 	err = r.db.WithContext(ctx).Model(&domain.WorkSchedule{}).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ? AND created_by = ?", true, userID).
 		Preload("Details", "is_active = ?", true).
 		Preload("Details.Location"). // Preload location for each detail
@@ -280,6 +296,7 @@ func (r *WorkScheduleRepository) ListWithPagination(ctx context.Context, paginat
 
 	// Count total active items only
 	err := r.db.WithContext(ctx).Model(&domain.WorkSchedule{}).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ?", true).
 		Count(&totalItems).Error
 	if err != nil {
@@ -288,6 +305,7 @@ func (r *WorkScheduleRepository) ListWithPagination(ctx context.Context, paginat
 
 	// Retrieve paginated active items with active details preloaded, ordered by ID
 	err = r.db.WithContext(ctx).Model(&domain.WorkSchedule{}).
+		Scopes(tenant.Scope(ctx, "work_schedules")).
 		Where("is_active = ?", true).
 		Preload("Details", "is_active = ?", true).
 		Preload("Details.Location"). // Preload location for each detail
@@ -307,6 +325,7 @@ func (r *WorkScheduleRepository) ListWithPagination(ctx context.Context, paginat
 func (r *WorkScheduleRepository) IsDetailConfigurationUnique(ctx context.Context, scheduleID uint, workTypeDetail string, days []domain.Days, excludeDetailID uint) (bool, error) {
 	var count int64
 	query := r.db.WithContext(ctx).Model(&domain.WorkScheduleDetail{}).
+		Scopes(tenant.ScopeVia(ctx, "work_schedule_details.work_schedule_id", "work_schedules")).
 		Where("work_schedule_id = ?", scheduleID).
 		Where("work_type_detail = ?", workTypeDetail)
 
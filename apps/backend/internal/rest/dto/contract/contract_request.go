@@ -0,0 +1,120 @@
+package contract
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type CreateContractRequest struct {
+	ContractType string  `json:"contract_type" binding:"required,oneof=permanent contract freelance"`
+	StartDate    string  `json:"start_date" binding:"required"`
+	EndDate      *string `json:"end_date,omitempty"`
+	DocumentURL  *string `json:"document_url,omitempty" binding:"omitempty,url,max=255"`
+}
+
+// RenewContractRequest starts a new fixed-term contract after the active one. The start date
+// defaults to the day after the active contract ends.
+type RenewContractRequest struct {
+	StartDate   *string `json:"start_date,omitempty"`
+	EndDate     string  `json:"end_date" binding:"required"`
+	DocumentURL *string `json:"document_url,omitempty" binding:"omitempty,url,max=255"`
+	Reason      *string `json:"reason,omitempty"`
+}
+
+// ConvertContractRequest makes a fixed-term employee permanent. The start date defaults to the day
+// after the active contract ends.
+type ConvertContractRequest struct {
+	StartDate   *string `json:"start_date,omitempty"`
+	DocumentURL *string `json:"document_url,omitempty" binding:"omitempty,url,max=255"`
+	Reason      *string `json:"reason,omitempty"`
+}
+
+type UpcomingExpiriesQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+func parseDate(field, value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s format. Please use YYYY-MM-DD. Value: %s", field, value)
+	}
+	return date, nil
+}
+
+func parseOptionalDate(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := parseDate(field, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func (r *CreateContractRequest) ToDomain(employeeID, createdBy uint) (*domain.EmploymentContract, error) {
+	startDate, err := parseDate("start_date", r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseOptionalDate("end_date", r.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EmploymentContract{
+		EmployeeID:   employeeID,
+		ContractType: enums.ContractType(r.ContractType),
+		StartDate:    startDate,
+		EndDate:      endDate,
+		DocumentURL:  r.DocumentURL,
+		CreatedBy:    &createdBy,
+	}, nil
+}
+
+// ToDomain returns the new contract. Its start date is left zero when the request has none.
+func (r *RenewContractRequest) ToDomain(employeeID, createdBy uint) (*domain.EmploymentContract, error) {
+	startDate, err := parseOptionalDate("start_date", r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseDate("end_date", r.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &domain.EmploymentContract{
+		EmployeeID:   employeeID,
+		ContractType: enums.Contract,
+		EndDate:      &endDate,
+		DocumentURL:  r.DocumentURL,
+		CreatedBy:    &createdBy,
+	}
+	if startDate != nil {
+		contract.StartDate = *startDate
+	}
+	return contract, nil
+}
+
+// ToDomain returns the new permanent contract. Its start date is left zero when the request has
+// none.
+func (r *ConvertContractRequest) ToDomain(employeeID, createdBy uint) (*domain.EmploymentContract, error) {
+	startDate, err := parseOptionalDate("start_date", r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &domain.EmploymentContract{
+		EmployeeID:   employeeID,
+		ContractType: enums.Permanent,
+		DocumentURL:  r.DocumentURL,
+		CreatedBy:    &createdBy,
+	}
+	if startDate != nil {
+		contract.StartDate = *startDate
+	}
+	return contract, nil
+}
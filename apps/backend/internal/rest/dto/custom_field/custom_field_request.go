@@ -0,0 +1,39 @@
+package custom_field
+
+import (
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// CustomFieldRequest creates or updates a custom employee field. The key and type of an existing
+// field cannot be changed.
+type CustomFieldRequest struct {
+	Key        string   `json:"key" binding:"required,max=100"`
+	Label      string   `json:"label" binding:"required,max=255"`
+	Type       string   `json:"type" binding:"required,oneof=text number date select file"`
+	Visibility string   `json:"visibility" binding:"omitempty,oneof=admin_only employee_editable"`
+	Required   bool     `json:"required"`
+	Options    []string `json:"options,omitempty" binding:"omitempty,dive,required"`
+	Pattern    *string  `json:"pattern,omitempty" binding:"omitempty,max=255"`
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	SortOrder  int      `json:"sort_order"`
+}
+
+func (r *CustomFieldRequest) ToDomain() *domain.CustomFieldDefinition {
+	visibility := domain.CustomFieldAdminOnly
+	if r.Visibility != "" {
+		visibility = domain.CustomFieldVisibility(r.Visibility)
+	}
+	return &domain.CustomFieldDefinition{
+		Key:        r.Key,
+		Label:      r.Label,
+		Type:       domain.CustomFieldType(r.Type),
+		Visibility: visibility,
+		Required:   r.Required,
+		Options:    r.Options,
+		Pattern:    r.Pattern,
+		Min:        r.Min,
+		Max:        r.Max,
+		SortOrder:  r.SortOrder,
+	}
+}
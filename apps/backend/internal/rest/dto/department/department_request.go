@@ -0,0 +1,11 @@
+package department
+
+type CreateDepartmentRequest struct {
+	Name     string `json:"name" binding:"required" validate:"required,min=1,max=255"`
+	ParentID *uint  `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+type UpdateDepartmentRequest struct {
+	Name     string `json:"name" binding:"required" validate:"required,min=1,max=255"`
+	ParentID *uint  `json:"parent_id" binding:"omitempty,min=1"`
+}
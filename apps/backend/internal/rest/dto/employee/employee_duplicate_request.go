@@ -0,0 +1,15 @@
+package employee
+
+type EmployeeDuplicateQueryDTO struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=10"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending dismissed"`
+}
+
+// MergeEmployeeDuplicateRequestDTO merges a duplicate pair into SurvivorID, one of the pair. The
+// survivor keeps its own user account unless KeepDuplicateAccount moves the duplicate's account
+// to it; the account left over is deactivated.
+type MergeEmployeeDuplicateRequestDTO struct {
+	SurvivorID           uint `json:"survivor_id" binding:"required"`
+	KeepDuplicateAccount bool `json:"keep_duplicate_account"`
+}
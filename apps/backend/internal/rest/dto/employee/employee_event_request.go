@@ -0,0 +1,13 @@
+package employee
+
+// EmployeeEventQueryDTO selects how many days ahead of today the events feed looks.
+type EmployeeEventQueryDTO struct {
+	Days *int `form:"days" binding:"omitempty,min=0,max=90"`
+}
+
+// EventPreferencesRequestDTO changes the events feed preferences of the current user. Omitted
+// preferences are left unchanged.
+type EventPreferencesRequestDTO struct {
+	HidePersonalEvents *bool `json:"hide_personal_events,omitempty"`
+	EventDigest        *bool `json:"event_digest,omitempty"`
+}
@@ -0,0 +1,85 @@
+package employee
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type FamilyMemberRequestDTO struct {
+	Name                           string  `json:"name" binding:"required,max=255"`
+	Relationship                   string  `json:"relationship" binding:"required,oneof=spouse child parent parent_in_law sibling other"`
+	NIK                            *string `json:"nik,omitempty" binding:"omitempty,len=16,numeric"`
+	DateOfBirth                    *string `json:"date_of_birth,omitempty"`
+	TaxDependant                   bool    `json:"tax_dependant"`
+	BPJSKesehatan                  bool    `json:"bpjs_kesehatan"`
+	BPJSKetenagakerjaanBeneficiary bool    `json:"bpjs_ketenagakerjaan_beneficiary"`
+}
+
+// ReplaceFamilyMembersRequestDTO replaces the whole family of an employee. TaxStatus, when given,
+// is set together with the family so the dependants can be corrected in one step; otherwise the
+// current tax status has to match the dependants.
+type ReplaceFamilyMembersRequestDTO struct {
+	TaxStatus *string                  `json:"tax_status,omitempty" binding:"omitempty,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3 K/I/0 K/I/1 K/I/2 K/I/3"`
+	Members   []FamilyMemberRequestDTO `json:"members" binding:"omitempty,dive"`
+}
+
+type EmergencyContactRequestDTO struct {
+	Name         string  `json:"name" binding:"required,max=255"`
+	Relationship string  `json:"relationship" binding:"required,max=100"`
+	Phone        string  `json:"phone" binding:"required,max=20"`
+	Address      *string `json:"address,omitempty"`
+	IsPrimary    bool    `json:"is_primary"`
+}
+
+// ReplaceEmergencyContactsRequestDTO replaces the emergency contacts of an employee.
+type ReplaceEmergencyContactsRequestDTO struct {
+	Contacts []EmergencyContactRequestDTO `json:"contacts" binding:"omitempty,dive"`
+}
+
+// ToDomain returns the family members and the tax status to set, nil when the request keeps the
+// current one.
+func (r *ReplaceFamilyMembersRequestDTO) ToDomain() ([]*domain.FamilyMember, *enums.TaxStatus, error) {
+	members := make([]*domain.FamilyMember, len(r.Members))
+	for i, reqMember := range r.Members {
+		member := &domain.FamilyMember{
+			Name:                           reqMember.Name,
+			Relationship:                   domain.FamilyRelationship(reqMember.Relationship),
+			NIK:                            reqMember.NIK,
+			TaxDependant:                   reqMember.TaxDependant,
+			BPJSKesehatan:                  reqMember.BPJSKesehatan,
+			BPJSKetenagakerjaanBeneficiary: reqMember.BPJSKetenagakerjaanBeneficiary,
+		}
+		if reqMember.DateOfBirth != nil && *reqMember.DateOfBirth != "" {
+			dateOfBirth, err := time.Parse("2006-01-02", *reqMember.DateOfBirth)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid date_of_birth format for member %d. Please use YYYY-MM-DD. Value: %s", i+1, *reqMember.DateOfBirth)
+			}
+			member.DateOfBirth = &dateOfBirth
+		}
+		members[i] = member
+	}
+
+	var taxStatus *enums.TaxStatus
+	if r.TaxStatus != nil {
+		status := enums.TaxStatus(*r.TaxStatus)
+		taxStatus = &status
+	}
+	return members, taxStatus, nil
+}
+
+func (r *ReplaceEmergencyContactsRequestDTO) ToDomain() []*domain.EmergencyContact {
+	contacts := make([]*domain.EmergencyContact, len(r.Contacts))
+	for i, reqContact := range r.Contacts {
+		contacts[i] = &domain.EmergencyContact{
+			Name:         reqContact.Name,
+			Relationship: reqContact.Relationship,
+			Phone:        reqContact.Phone,
+			Address:      reqContact.Address,
+			IsPrimary:    reqContact.IsPrimary,
+		}
+	}
+	return contacts
+}
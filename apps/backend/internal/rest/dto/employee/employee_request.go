@@ -17,6 +17,40 @@ type ListEmployeesRequestQuery struct {
 	Status   *string `form:"status" binding:"omitempty,oneof=active inactive"`
 	Search   *string `form:"search" binding:"omitempty"`
 	Gender   *string `form:"gender" binding:"omitempty,oneof=Male Female"`
+
+	DepartmentID *uint `form:"department_id" binding:"omitempty,min=1"`
+	BranchID     *uint `form:"branch_id" binding:"omitempty,min=1"`
+	PositionID   *uint `form:"position_id" binding:"omitempty,min=1"`
+
+	// CustomFields filters on custom field values, given as a JSON object of keys and values.
+	CustomFields map[string]string `form:"custom_fields" binding:"omitempty"`
+}
+
+// SearchEmployeesRequestQuery is a typeahead search over the name, code, position, branch and
+// email of employees, and their NIK for admins. Resigned and inactive employees are left out unless include_inactive is set.
+type SearchEmployeesRequestQuery struct {
+	Query           string `form:"q" binding:"required,min=2,max=100"`
+	Limit           int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	IncludeInactive bool   `form:"include_inactive"`
+}
+
+// ExportEmployeesRequestQuery filters an export the way ListEmployeesRequestQuery filters the
+// list; the page is ignored. Columns is a comma-separated list of export column keys.
+type ExportEmployeesRequestQuery struct {
+	ListEmployeesRequestQuery
+	Format  string `form:"format" binding:"omitempty,oneof=csv xlsx pdf"`
+	Columns string `form:"columns" binding:"omitempty"`
+}
+
+// ColumnKeys returns the keys of the requested columns.
+func (q *ExportEmployeesRequestQuery) ColumnKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(q.Columns, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 type CreateEmployeeRequestDTO struct {
@@ -27,7 +61,7 @@ type CreateEmployeeRequestDTO struct {
 	UserID                uint                  `form:"user_id,omitempty" binding:"omitempty"`
 	FirstName             string                `form:"first_name" binding:"required"`
 	LastName              *string               `form:"last_name,omitempty"`
-	PositionName          string                `form:"position_name" binding:"required"`
+	PositionName          string                `form:"position_name" binding:"required_without=PositionID"`
 	EmploymentStatus      *bool                 `form:"employment_status,omitempty"`
 	EmployeeCode          *string               `form:"employee_code,omitempty" binding:"omitempty,alphanum,max=50"`
 	Branch                *string               `form:"branch,omitempty"`
@@ -43,28 +77,101 @@ type CreateEmployeeRequestDTO struct {
 	BankName              *string               `form:"bank_name,omitempty"`
 	BankAccountNumber     *string               `form:"bank_account_number,omitempty"`
 	BankAccountHolderName *string               `form:"bank_account_holder_name,omitempty"`
+	BaseSalary            *float64              `form:"base_salary,omitempty" binding:"omitempty,gte=0"`
 	TaxStatus             *enums.TaxStatus      `form:"tax_status,omitempty"`
 	ProfilePhotoURL       *string               `form:"profile_photo_url,omitempty"`
 
+	DepartmentID *uint `form:"department_id,omitempty"`
+	BranchID     *uint `form:"branch_id,omitempty"`
+	PositionID   *uint `form:"position_id,omitempty"`
+
+	// ManagerID is the employee's supervisor. It defaults to the creating admin when omitted.
+	ManagerID *uint `form:"manager_id,omitempty" binding:"omitempty,min=1"`
+
+	// CustomFields holds the values of the company's custom fields as a JSON object keyed by field key.
+	CustomFields map[string]interface{} `form:"custom_fields,omitempty"`
+
 	PhotoFile *multipart.FileHeader `form:"photo_file,omitempty"`
 }
 
+type ReassignManagerRequestDTO struct {
+	ManagerID uint `json:"manager_id" binding:"required,min=1"`
+}
+
+type BulkReassignManagerRequestDTO struct {
+	EmployeeIDs []uint `json:"employee_ids" binding:"required,min=1,dive,min=1"`
+	ManagerID   uint   `json:"manager_id" binding:"required,min=1"`
+}
+
+// RecordEmploymentEventRequestDTO records a change in an employee's employment and applies it to
+// the employee. Hires and resignations are recorded automatically and cannot be sent here.
+type RecordEmploymentEventRequestDTO struct {
+	EventType     string              `json:"event_type" binding:"required,oneof=promotion demotion transfer contract_renewal salary_change"`
+	EffectiveDate string              `json:"effective_date" binding:"required"`
+	Reason        *string             `json:"reason,omitempty"`
+	PositionID    *uint               `json:"position_id,omitempty" binding:"omitempty,min=1"`
+	PositionName  *string             `json:"position_name,omitempty"`
+	Grade         *string             `json:"grade,omitempty"`
+	BranchID      *uint               `json:"branch_id,omitempty" binding:"omitempty,min=1"`
+	Branch        *string             `json:"branch,omitempty"`
+	DepartmentID  *uint               `json:"department_id,omitempty" binding:"omitempty,min=1"`
+	ContractType  *enums.ContractType `json:"contract_type,omitempty" binding:"omitempty,oneof=permanent contract freelance"`
+	BaseSalary    *float64            `json:"base_salary,omitempty" binding:"omitempty,gte=0"`
+}
+
+type TurnoverReportRequestQuery struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+}
+
+// MapEmploymentEventDTOToDomain splits the request into the event and the change to apply to the
+// employee.
+func MapEmploymentEventDTOToDomain(employeeID uint, recordedBy uint, reqDTO *RecordEmploymentEventRequestDTO) (*domain.EmploymentEvent, *domain.Employee, error) {
+	effectiveDate, err := time.Parse("2006-01-02", reqDTO.EffectiveDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid effective_date format. Please use YYYY-MM-DD. Value: %s", reqDTO.EffectiveDate)
+	}
+
+	event := &domain.EmploymentEvent{
+		EmployeeID:    employeeID,
+		EventType:     domain.EmploymentEventType(reqDTO.EventType),
+		EffectiveDate: effectiveDate,
+		Reason:        reqDTO.Reason,
+		RecordedBy:    &recordedBy,
+	}
+
+	change := &domain.Employee{
+		ID:           employeeID,
+		PositionID:   reqDTO.PositionID,
+		Grade:        reqDTO.Grade,
+		BranchID:     reqDTO.BranchID,
+		Branch:       reqDTO.Branch,
+		DepartmentID: reqDTO.DepartmentID,
+		ContractType: reqDTO.ContractType,
+		BaseSalary:   reqDTO.BaseSalary,
+	}
+	if reqDTO.PositionName != nil {
+		change.PositionName = strings.TrimSpace(*reqDTO.PositionName)
+	}
+
+	return event, change, nil
+}
+
+// UpdateEmployeeRequestDTO changes an employee's personal and account details. The position,
+// grade, branch, department, contract type and base salary are part of the employment history and
+// change only through RecordEmploymentEventRequestDTO.
 type UpdateEmployeeRequestDTO struct {
 	Email                 *string               `form:"email,omitempty" binding:"omitempty,email"`
 	Phone                 *string               `form:"phone,omitempty" binding:"omitempty,e164"`
 	FirstName             *string               `form:"first_name,omitempty"`
 	LastName              *string               `form:"last_name,omitempty"`
-	PositionName          *string               `form:"position_name,omitempty"`
 	EmploymentStatus      *bool                 `form:"employment_status,omitempty"`
 	EmployeeCode          *string               `form:"employee_code,omitempty" binding:"omitempty,alphanum,max=50"`
-	Branch                *string               `form:"branch,omitempty"`
 	Gender                *enums.Gender         `form:"gender,omitempty" binding:"omitempty"`
 	NIK                   *string               `form:"nik,omitempty" binding:"omitempty,numeric"`
 	PlaceOfBirth          *string               `form:"place_of_birth,omitempty"`
 	DateOfBirth           *string               `form:"date_of_birth,omitempty"`
 	LastEducation         *enums.EducationLevel `form:"last_education,omitempty"`
-	Grade                 *string               `form:"grade,omitempty"`
-	ContractType          *enums.ContractType   `form:"contract_type,omitempty"`
 	ResignationDate       *string               `form:"resignation_date,omitempty"`
 	HireDate              *string               `form:"hire_date,omitempty"`
 	BankName              *string               `form:"bank_name,omitempty"`
@@ -74,6 +181,10 @@ type UpdateEmployeeRequestDTO struct {
 	WorkScheduleID        *uint                 `form:"work_schedule_id,omitempty"`
 	ProfilePhotoURL       *string               `form:"profile_photo_url,omitempty"`
 
+	// CustomFields changes the given custom fields, as a JSON object keyed by field key. A null
+	// value clears the field; fields left out keep their value.
+	CustomFields map[string]interface{} `form:"custom_fields,omitempty"`
+
 	PhotoFile *multipart.FileHeader `form:"photo_file,omitempty"`
 }
 
@@ -81,8 +192,39 @@ type UploadEmployeePhotoRequestDTO struct {
 	File *multipart.FileHeader `form:"file" binding:"required"`
 }
 
+// BulkImportEmployeesRequestDTO uploads an employee import file. The default create mode adds new
+// employees. The update mode changes existing employees matched on match_by, and the upsert mode
+// also creates the employees no existing record matches. A dry run validates the file and returns
+// the changes it would make without applying them. A file exported from another HR system is
+// read through the saved column mapping profile mapping_profile_id.
 type BulkImportEmployeesRequestDTO struct {
-	File *multipart.FileHeader `form:"file" binding:"required"`
+	File             *multipart.FileHeader `form:"file" binding:"required"`
+	Mode             string                `form:"mode" binding:"omitempty,oneof=create update upsert"`
+	MatchBy          string                `form:"match_by" binding:"omitempty,oneof=employee_code nik"`
+	DryRun           bool                  `form:"dry_run"`
+	MappingProfileID *uint                 `form:"mapping_profile_id"`
+}
+
+const (
+	ImportModeCreate = "create"
+	ImportModeUpdate = "update"
+	ImportModeUpsert = "upsert"
+)
+
+// ImportMode returns the mode of the import, create when none is given.
+func (r *BulkImportEmployeesRequestDTO) ImportMode() string {
+	if r.Mode == "" {
+		return ImportModeCreate
+	}
+	return r.Mode
+}
+
+// ImportMatchBy returns the key rows are matched on, the employee code when none is given.
+func (r *BulkImportEmployeesRequestDTO) ImportMatchBy() string {
+	if r.MatchBy == "" {
+		return "employee_code"
+	}
+	return r.MatchBy
 }
 
 type BulkImportEmployeeData struct {
@@ -124,11 +266,36 @@ type BulkImportFailedRow struct {
 }
 
 type BulkImportError struct {
+	Row     int    `json:"row,omitempty"`
 	Field   string `json:"field"`
 	Message string `json:"message"`
 	Value   string `json:"value,omitempty"`
 }
 
+type BulkImportFieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// BulkImportRowPreview is what an update or upsert import does with a row.
+type BulkImportRowPreview struct {
+	Row        int                              `json:"row"`
+	Action     string                           `json:"action"`
+	EmployeeID *uint                            `json:"employee_id,omitempty"`
+	Changes    map[string]BulkImportFieldChange `json:"changes,omitempty"`
+}
+
+type BulkUpsertResult struct {
+	DryRun         bool                   `json:"dry_run"`
+	Applied        bool                   `json:"applied"`
+	UpdatedCount   int                    `json:"updated_count"`
+	CreatedCount   int                    `json:"created_count"`
+	UnchangedCount int                    `json:"unchanged_count"`
+	ErrorCount     int                    `json:"error_count"`
+	Rows           []BulkImportRowPreview `json:"rows"`
+	FailedRows     []BulkImportFailedRow  `json:"failed_rows"`
+}
+
 func MapCreateDTOToDomain(reqDTO *CreateEmployeeRequestDTO) (*domain.Employee, error) {
 	userDomain := domain.User{
 		Email:    reqDTO.Email,
@@ -154,8 +321,14 @@ func MapCreateDTOToDomain(reqDTO *CreateEmployeeRequestDTO) (*domain.Employee, e
 		BankName:              reqDTO.BankName,
 		BankAccountNumber:     reqDTO.BankAccountNumber,
 		BankAccountHolderName: reqDTO.BankAccountHolderName,
+		BaseSalary:            reqDTO.BaseSalary,
 		TaxStatus:             reqDTO.TaxStatus,
 		ProfilePhotoURL:       reqDTO.ProfilePhotoURL,
+		DepartmentID:          reqDTO.DepartmentID,
+		BranchID:              reqDTO.BranchID,
+		PositionID:            reqDTO.PositionID,
+		ManagerID:             reqDTO.ManagerID,
+		CustomFields:          reqDTO.CustomFields,
 	}
 
 	if err := parseDatesForCreate(reqDTO, employeeDomain); err != nil {
@@ -223,18 +396,12 @@ func MapUpdateDTOToDomain(employeeID uint, reqDTO *UpdateEmployeeRequestDTO) (*d
 	if reqDTO.LastName != nil {
 		employeeUpdatePayload.LastName = reqDTO.LastName
 	}
-	if reqDTO.PositionName != nil {
-		employeeUpdatePayload.PositionName = *reqDTO.PositionName
-	}
 	if reqDTO.EmploymentStatus != nil {
 		employeeUpdatePayload.EmploymentStatus = *reqDTO.EmploymentStatus
 	}
 	if reqDTO.EmployeeCode != nil {
 		employeeUpdatePayload.EmployeeCode = reqDTO.EmployeeCode
 	}
-	if reqDTO.Branch != nil {
-		employeeUpdatePayload.Branch = reqDTO.Branch
-	}
 	if reqDTO.Gender != nil {
 		employeeUpdatePayload.Gender = reqDTO.Gender
 	}
@@ -254,12 +421,6 @@ func MapUpdateDTOToDomain(employeeID uint, reqDTO *UpdateEmployeeRequestDTO) (*d
 	if reqDTO.LastEducation != nil {
 		employeeUpdatePayload.LastEducation = reqDTO.LastEducation
 	}
-	if reqDTO.Grade != nil {
-		employeeUpdatePayload.Grade = reqDTO.Grade
-	}
-	if reqDTO.ContractType != nil {
-		employeeUpdatePayload.ContractType = reqDTO.ContractType
-	}
 	if reqDTO.ResignationDate != nil && *reqDTO.ResignationDate != "" {
 		parsedDate, err := time.Parse("2006-01-02", *reqDTO.ResignationDate)
 		if err != nil {
@@ -295,18 +456,30 @@ func MapUpdateDTOToDomain(employeeID uint, reqDTO *UpdateEmployeeRequestDTO) (*d
 	if reqDTO.ProfilePhotoURL != nil {
 		employeeUpdatePayload.ProfilePhotoURL = reqDTO.ProfilePhotoURL
 	}
+	employeeUpdatePayload.CustomFields = reqDTO.CustomFields
 	return employeeUpdatePayload, nil
 }
 
 func ParseEmployeeFromRecord(headers, record []string, rowNum int) (*domain.Employee, []BulkImportError) {
-	var errors []BulkImportError
-
 	fieldMap := createFieldMap(headers, record)
 
 	if requiredErrors := validateRequiredFields(fieldMap, rowNum); len(requiredErrors) > 0 {
 		return nil, requiredErrors
 	}
 
+	return parseEmployeeFields(fieldMap, rowNum)
+}
+
+// ParseEmployeeChangesFromRecord parses a row of an update or upsert import. No column is
+// required, as a blank cell leaves the field of an existing employee unchanged; rows creating an
+// employee are checked for the required fields when they are imported.
+func ParseEmployeeChangesFromRecord(headers, record []string, rowNum int) (*domain.Employee, []BulkImportError) {
+	return parseEmployeeFields(createFieldMap(headers, record), rowNum)
+}
+
+func parseEmployeeFields(fieldMap map[string]string, rowNum int) (*domain.Employee, []BulkImportError) {
+	var errors []BulkImportError
+
 	employee := createBasicEmployee(fieldMap)
 
 	parseOptionalStringFields(employee, fieldMap)
@@ -320,6 +493,7 @@ func ParseEmployeeFromRecord(headers, record []string, rowNum int) (*domain.Empl
 	}
 
 	parseBankFields(employee, fieldMap)
+	parseCustomFields(employee, fieldMap)
 
 	if len(errors) > 0 {
 		return nil, errors
@@ -506,6 +680,22 @@ func parseBankFields(employee *domain.Employee, fieldMap map[string]string) {
 	}
 }
 
+// customFieldColumnPrefix marks the import columns holding custom field values, such as
+// custom_blood_type for the blood_type field. The values are validated when the row is imported.
+const customFieldColumnPrefix = "custom_"
+
+func parseCustomFields(employee *domain.Employee, fieldMap map[string]string) {
+	for column, value := range fieldMap {
+		if !strings.HasPrefix(column, customFieldColumnPrefix) || value == "" {
+			continue
+		}
+		if employee.CustomFields == nil {
+			employee.CustomFields = make(map[string]interface{})
+		}
+		employee.CustomFields[strings.TrimPrefix(column, customFieldColumnPrefix)] = value
+	}
+}
+
 func isValidEnum(value string, validValues []string) bool {
 	for _, valid := range validValues {
 		if value == valid {
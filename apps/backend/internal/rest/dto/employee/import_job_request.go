@@ -0,0 +1,6 @@
+package employee
+
+type ImportJobQueryDTO struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=10"`
+}
@@ -0,0 +1,50 @@
+package employee
+
+import (
+	"mime/multipart"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// ImportMappingProfileRequest creates or updates a saved column mapping of a legacy HR system's
+// export.
+type ImportMappingProfileRequest struct {
+	Name     string                       `json:"name" binding:"required,max=255"`
+	Mappings []ImportColumnMappingRequest `json:"mappings" binding:"required,min=1,dive"`
+}
+
+// ImportColumnMappingRequest maps a source column to an import field. DateFormat is written with
+// YYYY, YY, MMMM, MMM, MM, M, DD and D, such as DD/MM/YYYY.
+type ImportColumnMappingRequest struct {
+	SourceHeader string            `json:"source_header" binding:"required,max=255"`
+	Field        string            `json:"field" binding:"required,max=100"`
+	DateFormat   string            `json:"date_format,omitempty" binding:"omitempty,max=50"`
+	ValueMap     map[string]string `json:"value_map,omitempty"`
+}
+
+func (r *ImportMappingProfileRequest) ToDomain() *domain.ImportMappingProfile {
+	mappings := make([]domain.ImportColumnMapping, len(r.Mappings))
+	for i, mapping := range r.Mappings {
+		mappings[i] = domain.ImportColumnMapping{
+			SourceHeader: mapping.SourceHeader,
+			Field:        mapping.Field,
+			DateFormat:   mapping.DateFormat,
+			ValueMap:     mapping.ValueMap,
+		}
+	}
+	return &domain.ImportMappingProfile{
+		Name:     r.Name,
+		Mappings: mappings,
+	}
+}
+
+// DetectImportMappingRequestDTO uploads a sample of a legacy HR system's export to suggest a
+// mapping for.
+type DetectImportMappingRequestDTO struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// ImportTemplateQuery picks the file format of the import template, CSV when none is given.
+type ImportTemplateQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
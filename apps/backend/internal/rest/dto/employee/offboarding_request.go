@@ -0,0 +1,75 @@
+package employee
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type OffboardingTaskRequestDTO struct {
+	Title      string  `json:"title" binding:"required,max=255"`
+	AssigneeID *uint   `json:"assignee_id,omitempty" binding:"omitempty,min=1"`
+	DueDate    *string `json:"due_date,omitempty"`
+}
+
+// StartOffboardingRequestDTO schedules the departure of an employee. Without tasks the default exit
+// checklist is used. The notice date defaults to today.
+type StartOffboardingRequestDTO struct {
+	ResignationType string                      `json:"resignation_type" binding:"required,oneof=voluntary termination end_of_contract"`
+	ExitReason      string                      `json:"exit_reason" binding:"required,oneof=career_growth compensation work_life_balance management relocation personal performance misconduct restructuring contract_ended other"`
+	Notes           *string                     `json:"notes,omitempty"`
+	NoticeDate      *string                     `json:"notice_date,omitempty"`
+	LastWorkingDay  string                      `json:"last_working_day" binding:"required"`
+	Tasks           []OffboardingTaskRequestDTO `json:"tasks,omitempty" binding:"omitempty,dive"`
+}
+
+type UpdateOffboardingTaskRequestDTO struct {
+	Completed *bool `json:"completed" binding:"required"`
+}
+
+type ExitReasonReportRequestQuery struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+}
+
+func MapStartOffboardingDTOToDomain(employeeID uint, initiatedBy uint, reqDTO *StartOffboardingRequestDTO) (*domain.Offboarding, error) {
+	lastWorkingDay, err := time.Parse("2006-01-02", reqDTO.LastWorkingDay)
+	if err != nil {
+		return nil, fmt.Errorf("invalid last_working_day format. Please use YYYY-MM-DD. Value: %s", reqDTO.LastWorkingDay)
+	}
+
+	offboarding := &domain.Offboarding{
+		EmployeeID:      employeeID,
+		ResignationType: domain.ResignationType(reqDTO.ResignationType),
+		ExitReason:      domain.ExitReason(reqDTO.ExitReason),
+		Notes:           reqDTO.Notes,
+		LastWorkingDay:  lastWorkingDay,
+		InitiatedBy:     initiatedBy,
+	}
+
+	if reqDTO.NoticeDate != nil && *reqDTO.NoticeDate != "" {
+		noticeDate, err := time.Parse("2006-01-02", *reqDTO.NoticeDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notice_date format. Please use YYYY-MM-DD. Value: %s", *reqDTO.NoticeDate)
+		}
+		offboarding.NoticeDate = noticeDate
+	}
+
+	for _, taskDTO := range reqDTO.Tasks {
+		task := domain.OffboardingTask{
+			Title:      taskDTO.Title,
+			AssigneeID: taskDTO.AssigneeID,
+		}
+		if taskDTO.DueDate != nil && *taskDTO.DueDate != "" {
+			dueDate, err := time.Parse("2006-01-02", *taskDTO.DueDate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid due_date format. Please use YYYY-MM-DD. Value: %s", *taskDTO.DueDate)
+			}
+			task.DueDate = dueDate
+		}
+		offboarding.Tasks = append(offboarding.Tasks, task)
+	}
+
+	return offboarding, nil
+}
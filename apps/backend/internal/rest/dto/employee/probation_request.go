@@ -0,0 +1,73 @@
+package employee
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// UpdateProbationRequestDTO corrects the dates of a probation that is still in progress.
+type UpdateProbationRequestDTO struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+// ReviewProbationRequestDTO concludes or extends a probation. An extension needs the new end date;
+// a termination schedules the offboarding with the last working day, which defaults to the end of
+// the probation.
+type ReviewProbationRequestDTO struct {
+	Outcome        string  `json:"outcome" binding:"required,oneof=confirm extend terminate"`
+	Rating         *int    `json:"rating,omitempty" binding:"omitempty,min=1,max=5"`
+	Comments       *string `json:"comments,omitempty"`
+	ExtendTo       *string `json:"extend_to,omitempty" binding:"required_if=Outcome extend"`
+	LastWorkingDay *string `json:"last_working_day,omitempty"`
+}
+
+func parseProbationDate(field, value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s format. Please use YYYY-MM-DD. Value: %s", field, value)
+	}
+	return date, nil
+}
+
+func (r *UpdateProbationRequestDTO) Dates() (startDate, endDate time.Time, err error) {
+	if startDate, err = parseProbationDate("start_date", r.StartDate); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if endDate, err = parseProbationDate("end_date", r.EndDate); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return startDate, endDate, nil
+}
+
+// MapReviewProbationDTOToDomain returns the review and, for a termination, the requested last
+// working day.
+func MapReviewProbationDTOToDomain(reviewerID uint, reqDTO *ReviewProbationRequestDTO) (*domain.ProbationReview, *time.Time, error) {
+	review := &domain.ProbationReview{
+		ReviewerID: reviewerID,
+		Outcome:    domain.ProbationOutcome(reqDTO.Outcome),
+		Rating:     reqDTO.Rating,
+		Comments:   reqDTO.Comments,
+	}
+
+	if review.Outcome == domain.ProbationOutcomeExtend {
+		extendTo, err := parseProbationDate("extend_to", *reqDTO.ExtendTo)
+		if err != nil {
+			return nil, nil, err
+		}
+		review.NewEndDate = &extendTo
+	}
+
+	var lastWorkingDay *time.Time
+	if review.Outcome == domain.ProbationOutcomeTerminate && reqDTO.LastWorkingDay != nil && *reqDTO.LastWorkingDay != "" {
+		date, err := parseProbationDate("last_working_day", *reqDTO.LastWorkingDay)
+		if err != nil {
+			return nil, nil, err
+		}
+		lastWorkingDay = &date
+	}
+
+	return review, lastWorkingDay, nil
+}
@@ -0,0 +1,65 @@
+package employee
+
+import (
+	"fmt"
+	"mime/multipart"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// SubmitProfileChangeRequestDTO requests changes of sensitive profile fields. Changes is a JSON
+// object keyed by field name; a null value clears the field.
+type SubmitProfileChangeRequestDTO struct {
+	Changes  map[string]*string    `form:"changes" json:"changes" binding:"required"`
+	Reason   *string               `form:"reason" json:"reason,omitempty"`
+	Document *multipart.FileHeader `form:"document" json:"-"`
+}
+
+// ReviewProfileChangeRequestDTO approves or rejects a pending profile change request.
+type ReviewProfileChangeRequestDTO struct {
+	Action string  `json:"action" binding:"required,oneof=approve reject"`
+	Note   *string `json:"note,omitempty"`
+}
+
+type ProfileChangePolicyRequestDTO struct {
+	Fields []string `json:"fields" binding:"required"`
+}
+
+type ProfileChangeQueryDTO struct {
+	Page       int     `form:"page" binding:"omitempty,min=1"`
+	PageSize   int     `form:"page_size" binding:"omitempty,min=10"`
+	EmployeeID *uint   `form:"employee_id" binding:"omitempty"`
+	Status     *string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+}
+
+func toProfileField(name string) (domain.ProfileField, error) {
+	field := domain.ProfileField(name)
+	if !field.IsValid() {
+		return "", fmt.Errorf("%w: unknown field %s", domain.ErrInvalidProfileChange, name)
+	}
+	return field, nil
+}
+
+func (r *SubmitProfileChangeRequestDTO) ToDomain() (map[domain.ProfileField]*string, error) {
+	changes := make(map[domain.ProfileField]*string, len(r.Changes))
+	for name, value := range r.Changes {
+		field, err := toProfileField(name)
+		if err != nil {
+			return nil, err
+		}
+		changes[field] = value
+	}
+	return changes, nil
+}
+
+func (r *ProfileChangePolicyRequestDTO) ToDomain() ([]domain.ProfileField, error) {
+	fields := make([]domain.ProfileField, 0, len(r.Fields))
+	for _, name := range r.Fields {
+		field, err := toProfileField(name)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
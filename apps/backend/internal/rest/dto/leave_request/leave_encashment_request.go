@@ -0,0 +1,24 @@
+package leave_request
+
+import "github.com/SukaMajuu/hris/apps/backend/domain"
+
+type LeaveEncashmentQueryDTO struct {
+	Page       int     `form:"page" binding:"omitempty,min=1"`
+	PageSize   int     `form:"page_size" binding:"omitempty,min=10"`
+	EmployeeID *uint   `form:"employee_id" binding:"omitempty"`
+	Year       *int    `form:"year" binding:"omitempty"`
+	Reason     *string `form:"reason" binding:"omitempty,oneof=year_end resignation"`
+	Status     *string `form:"status" binding:"omitempty,oneof=draft finalized cancelled"`
+}
+
+// ReviewLeaveEncashmentDTO adjusts a draft encashment before it is finalized.
+type ReviewLeaveEncashmentDTO struct {
+	EncashedDays *int     `json:"encashed_days,omitempty" binding:"omitempty,min=0"`
+	DailyRate    *float64 `json:"daily_rate,omitempty" binding:"omitempty,gte=0"`
+	AdminNote    *string  `json:"admin_note,omitempty" binding:"omitempty,max=255"`
+}
+
+type UpdateLeaveEncashmentStatusDTO struct {
+	Status    domain.EncashmentStatus `json:"status" binding:"required,oneof=finalized cancelled"`
+	AdminNote *string                 `json:"admin_note,omitempty" binding:"omitempty,max=255"`
+}
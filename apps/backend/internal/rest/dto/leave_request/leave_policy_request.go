@@ -0,0 +1,44 @@
+package leave_request
+
+import (
+	"mime/multipart"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type LeavePolicyRequestDTO struct {
+	SickCertificateRequiredAfterDays *uint  `json:"sick_certificate_required_after_days" binding:"required"`
+	SickCertificateGraceDays         *uint  `json:"sick_certificate_grace_days" binding:"required"`
+	SickCertificateFallbackType      string `json:"sick_certificate_fallback_type" binding:"required,oneof=annual_leave unpaid_leave"`
+	ExcludeLongTermAbsenceFromSeats  bool   `json:"exclude_long_term_absence_from_seats"`
+	AnnualLeaveAccruesMonthly        bool   `json:"annual_leave_accrues_monthly"`
+	AnnualLeaveCarryOverCap          *uint  `json:"annual_leave_carry_over_cap,omitempty"`
+	EncashmentWorkingDaysPerMonth    *uint  `json:"encashment_working_days_per_month,omitempty" binding:"omitempty,min=1,max=31"`
+}
+
+func (dto *LeavePolicyRequestDTO) ToDomain(createdBy uint) *domain.LeavePolicy {
+	defaults := domain.DefaultLeavePolicy()
+	policy := &domain.LeavePolicy{
+		SickCertificateRequiredAfterDays: *dto.SickCertificateRequiredAfterDays,
+		SickCertificateGraceDays:         *dto.SickCertificateGraceDays,
+		SickCertificateFallbackType:      enums.LeaveType(dto.SickCertificateFallbackType),
+		ExcludeLongTermAbsenceFromSeats:  dto.ExcludeLongTermAbsenceFromSeats,
+		AnnualLeaveAccruesMonthly:        dto.AnnualLeaveAccruesMonthly,
+		AnnualLeaveCarryOverCap:          defaults.AnnualLeaveCarryOverCap,
+		EncashmentWorkingDaysPerMonth:    defaults.EncashmentWorkingDaysPerMonth,
+		CreatedBy:                        createdBy,
+	}
+
+	if dto.AnnualLeaveCarryOverCap != nil {
+		policy.AnnualLeaveCarryOverCap = *dto.AnnualLeaveCarryOverCap
+	}
+	if dto.EncashmentWorkingDaysPerMonth != nil {
+		policy.EncashmentWorkingDaysPerMonth = *dto.EncashmentWorkingDaysPerMonth
+	}
+	return policy
+}
+
+type UploadCertificateDTO struct {
+	CertificateFile *multipart.FileHeader `form:"certificate" binding:"required"`
+}
@@ -16,21 +16,30 @@ type LeaveRequestQueryDTO struct {
 	LeaveType  *string `form:"leave_type" binding:"omitempty"`
 }
 
+// LeaveBalanceProjectionQueryDTO selects how far ahead to project. Months defaults to the rest of the year.
+type LeaveBalanceProjectionQueryDTO struct {
+	Months         int  `form:"months" binding:"omitempty,min=1,max=24"`
+	IncludePending bool `form:"include_pending"`
+}
+
 type CreateLeaveRequestDTO struct {
 	LeaveType      enums.LeaveType       `form:"leave_type" binding:"required"`
 	StartDate      string                `form:"start_date" binding:"required"`
 	EndDate        string                `form:"end_date" binding:"required"`
 	EmployeeNote   *string               `form:"employee_note,omitempty"`
 	AttachmentFile *multipart.FileHeader `form:"attachment,omitempty"`
+	PreviewBalance bool                  `form:"preview_balance"`
 }
 
 type CreateLeaveRequestForEmployeeDTO struct {
-	EmployeeID     uint                  `form:"employee_id" binding:"required"`
-	LeaveType      enums.LeaveType       `form:"leave_type" binding:"required"`
-	StartDate      string                `form:"start_date" binding:"required"`
-	EndDate        string                `form:"end_date" binding:"required"`
-	EmployeeNote   *string               `form:"employee_note,omitempty"`
-	AttachmentFile *multipart.FileHeader `form:"attachment,omitempty"`
+	EmployeeID            uint                  `form:"employee_id" binding:"required"`
+	LeaveType             enums.LeaveType       `form:"leave_type" binding:"required"`
+	StartDate             string                `form:"start_date" binding:"required"`
+	EndDate               string                `form:"end_date" binding:"required"`
+	EmployeeNote          *string               `form:"employee_note,omitempty"`
+	AttachmentFile        *multipart.FileHeader `form:"attachment,omitempty"`
+	OverrideStaffingRules bool                  `form:"override_staffing_rules"`
+	PreviewBalance        bool                  `form:"preview_balance"`
 }
 
 type UpdateLeaveRequestDTO struct {
@@ -42,8 +51,9 @@ type UpdateLeaveRequestDTO struct {
 }
 
 type UpdateLeaveRequestStatusDTO struct {
-	Status    domain.LeaveStatus `json:"status" binding:"required,oneof='Waiting Approval' Approved Rejected"`
-	AdminNote *string            `json:"admin_note,omitempty"`
+	Status                domain.LeaveStatus `json:"status" binding:"required,oneof='Waiting Approval' Approved Rejected"`
+	AdminNote             *string            `json:"admin_note,omitempty"`
+	OverrideStaffingRules bool               `json:"override_staffing_rules"`
 }
 
 func (dto *CreateLeaveRequestDTO) ToDomain(employeeID uint) (*domain.LeaveRequest, error) {
@@ -0,0 +1,45 @@
+package leave_request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+type LongTermAbsenceRequestDTO struct {
+	AbsenceType string `json:"absence_type" binding:"required,oneof=sabbatical suspension extended_leave"`
+	StartDate   string `json:"start_date" binding:"required"`
+	EndDate     string `json:"end_date" binding:"required"`
+}
+
+// ToDomain returns an employee carrying only the absence fields to apply.
+func (dto *LongTermAbsenceRequestDTO) ToDomain(employeeID uint) (*domain.Employee, error) {
+	startDate, err := time.Parse("2006-01-02", dto.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", dto.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("start date cannot be after end date")
+	}
+
+	absenceType := enums.LongTermAbsenceType(dto.AbsenceType)
+	return &domain.Employee{
+		ID:               employeeID,
+		AbsenceType:      &absenceType,
+		AbsenceStartDate: &startDate,
+		AbsenceEndDate:   &endDate,
+	}, nil
+}
+
+type UnpaidDaysQueryDTO struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+}
@@ -0,0 +1,39 @@
+package leave_request
+
+import (
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type StaffingRuleRequestDTO struct {
+	Name             string   `json:"name" binding:"required,max=255"`
+	Scope            string   `json:"scope" binding:"required,oneof=manager_subtree branch"`
+	RootEmployeeID   *uint    `json:"root_employee_id,omitempty"`
+	Branch           *string  `json:"branch,omitempty"`
+	MaxAbsent        *uint    `json:"max_absent,omitempty"`
+	MaxAbsentPercent *float64 `json:"max_absent_percent,omitempty" binding:"omitempty,gt=0,lte=100"`
+	IsActive         *bool    `json:"is_active,omitempty"`
+}
+
+// ToDomain maps the request to a staffing rule. Manager subtree rules without an explicit
+// root cover the team of the admin, so defaultRootEmployeeID is used instead. Branch rules
+// without a root cover the whole branch.
+func (dto *StaffingRuleRequestDTO) ToDomain(createdBy uint, defaultRootEmployeeID uint) *domain.LeaveStaffingRule {
+	rule := &domain.LeaveStaffingRule{
+		Name:             dto.Name,
+		Scope:            domain.StaffingRuleScope(dto.Scope),
+		RootEmployeeID:   dto.RootEmployeeID,
+		Branch:           dto.Branch,
+		MaxAbsent:        dto.MaxAbsent,
+		MaxAbsentPercent: dto.MaxAbsentPercent,
+		IsActive:         true,
+		CreatedBy:        createdBy,
+	}
+
+	if rule.RootEmployeeID == nil && rule.Scope == domain.StaffingRuleScopeManagerSubtree {
+		rule.RootEmployeeID = &defaultRootEmployeeID
+	}
+	if dto.IsActive != nil {
+		rule.IsActive = *dto.IsActive
+	}
+	return rule
+}
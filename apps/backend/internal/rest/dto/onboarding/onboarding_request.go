@@ -0,0 +1,45 @@
+package onboarding
+
+import (
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+type TemplateTaskRequest struct {
+	Title       string  `json:"title" binding:"required,max=255"`
+	Description *string `json:"description,omitempty"`
+	Owner       string  `json:"owner" binding:"required,oneof=hr manager employee"`
+	DueDays     int     `json:"due_days" binding:"min=-30,max=365"`
+}
+
+// TemplateRequest creates or replaces an onboarding template. A template without a department and
+// position applies to every new hire no more specific template applies to.
+type TemplateRequest struct {
+	Name         string                `json:"name" binding:"required,max=255"`
+	DepartmentID *uint                 `json:"department_id,omitempty" binding:"omitempty,min=1"`
+	PositionID   *uint                 `json:"position_id,omitempty" binding:"omitempty,min=1"`
+	Tasks        []TemplateTaskRequest `json:"tasks" binding:"required,min=1,dive"`
+}
+
+type UpdateTaskRequest struct {
+	Completed *bool `json:"completed" binding:"required"`
+}
+
+func (r *TemplateRequest) ToDomain(createdBy uint) *domain.OnboardingTemplate {
+	template := &domain.OnboardingTemplate{
+		Name:         r.Name,
+		DepartmentID: r.DepartmentID,
+		PositionID:   r.PositionID,
+		CreatedBy:    createdBy,
+		Tasks:        make([]domain.OnboardingTemplateTask, len(r.Tasks)),
+	}
+	for i, task := range r.Tasks {
+		template.Tasks[i] = domain.OnboardingTemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Owner:       domain.OnboardingOwner(task.Owner),
+			DueDays:     task.DueDays,
+			SortOrder:   i + 1,
+		}
+	}
+	return template
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	domainAttendanceDTO "github.com/SukaMajuu/hris/apps/backend/domain/dto/attendance"
 	attendanceDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/attendance"
 	attendanceUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/attendance"
 	employeeUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
@@ -165,6 +166,14 @@ func (h *AttendanceHandler) ListAttendances(c *gin.Context) {
 		response.BadRequest(c, "Invalid query parameters", err)
 		return
 	}
+	listQuery, invalid := bindListQuery(c, domain.AttendanceListSchema, domainAttendanceDTO.AttendanceResponseDTO{})
+	if invalid {
+		return
+	}
+	cursor, invalid := bindCursor(c, listQuery)
+	if invalid {
+		return
+	}
 
 	userIDCtx, exists := c.Get("userID")
 	if !exists {
@@ -186,6 +195,8 @@ func (h *AttendanceHandler) ListAttendances(c *gin.Context) {
 	paginationParams := domain.PaginationParams{
 		Page:     queryDTO.Page,
 		PageSize: queryDTO.PageSize,
+		Query:    listQuery,
+		Cursor:   cursor,
 	}
 
 	if paginationParams.Page == 0 {
@@ -201,7 +212,7 @@ func (h *AttendanceHandler) ListAttendances(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Attendances retrieved successfully", attendances)
+	respondList(c, "Attendances retrieved successfully", attendances, listQuery)
 }
 
 func (h *AttendanceHandler) ListAttendancesByEmployee(c *gin.Context) {
@@ -218,10 +229,20 @@ func (h *AttendanceHandler) ListAttendancesByEmployee(c *gin.Context) {
 		response.BadRequest(c, "Invalid query parameters", err)
 		return
 	}
+	listQuery, invalid := bindListQuery(c, domain.AttendanceListSchema, domainAttendanceDTO.AttendanceResponseDTO{})
+	if invalid {
+		return
+	}
+	cursor, invalid := bindCursor(c, listQuery)
+	if invalid {
+		return
+	}
 	
 	paginationParams := domain.PaginationParams{
 		Page:     queryDTO.Page,
 		PageSize: queryDTO.PageSize,
+		Query:    listQuery,
+		Cursor:   cursor,
 	}
 
 	if paginationParams.Page == 0 {
@@ -237,7 +258,7 @@ func (h *AttendanceHandler) ListAttendancesByEmployee(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Employee attendances retrieved successfully", attendances)
+	respondList(c, "Employee attendances retrieved successfully", attendances, listQuery)
 }
 
 func (h *AttendanceHandler) UpdateAttendance(c *gin.Context) {
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	contractDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/contract"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/contract"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ContractHandler struct {
+	contractUseCase *contract.ContractUseCase
+}
+
+func NewContractHandler(contractUseCase *contract.ContractUseCase) *ContractHandler {
+	return &ContractHandler{
+		contractUseCase: contractUseCase,
+	}
+}
+
+func handleContractError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrEmployeeNotFound) {
+		response.NotFound(c, "Employee not found", err)
+	} else if errors.Is(err, domain.ErrContractNotFound) {
+		response.NotFound(c, "Employee has no active contract", err)
+	} else if errors.Is(err, domain.ErrActiveContractExists) {
+		response.Conflict(c, "Employee already has an active contract, renew or convert it instead", err)
+	} else if errors.Is(err, domain.ErrContractNotRenewable) || errors.Is(err, domain.ErrInvalidContract) {
+		response.BadRequest(c, err.Error(), err)
+	} else {
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *ContractHandler) CreateContract(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var req contractDTO.CreateContractRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	newContract, err := req.ToDomain(uint(id), userID)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.contractUseCase.CreateContract(c.Request.Context(), newContract)
+	if err != nil {
+		handleContractError(c, err)
+		return
+	}
+
+	response.Created(c, "Contract created successfully", result)
+}
+
+func (h *ContractHandler) ListContracts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	contracts, err := h.contractUseCase.ListContracts(c.Request.Context(), uint(id))
+	if err != nil {
+		handleContractError(c, err)
+		return
+	}
+
+	response.OK(c, "Contracts retrieved successfully", contracts)
+}
+
+func (h *ContractHandler) RenewContract(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var req contractDTO.RenewContractRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	next, err := req.ToDomain(uint(id), userID)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.contractUseCase.RenewContract(c.Request.Context(), next, req.Reason)
+	if err != nil {
+		handleContractError(c, err)
+		return
+	}
+
+	response.Created(c, "Contract renewed successfully", result)
+}
+
+func (h *ContractHandler) ConvertToPermanent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var req contractDTO.ConvertContractRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	next, err := req.ToDomain(uint(id), userID)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.contractUseCase.ConvertToPermanent(c.Request.Context(), next, req.Reason)
+	if err != nil {
+		handleContractError(c, err)
+		return
+	}
+
+	response.Created(c, "Contract converted to permanent successfully", result)
+}
+
+func (h *ContractHandler) ListUpcomingExpiries(c *gin.Context) {
+	var query contractDTO.UpcomingExpiriesQuery
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+	if query.Days == 0 {
+		query.Days = domain.ContractExpiryReminderDays[0]
+	}
+
+	expiries, err := h.contractUseCase.ListUpcomingExpiries(c.Request.Context(), query.Days)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Upcoming contract expiries retrieved successfully", expiries)
+}
@@ -2,8 +2,14 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	attendanceUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/attendance"
+	contractUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/contract"
+	employeeUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
+	leaveRequestUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/leave_request"
+	onboardingUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/onboarding"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/subscription"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -12,12 +18,20 @@ import (
 type CronHandler struct {
 	subscriptionUC *subscription.SubscriptionUseCase
 	attendanceUC   *attendanceUseCase.AttendanceUseCase
+	leaveRequestUC *leaveRequestUseCase.LeaveRequestUseCase
+	contractUC     *contractUseCase.ContractUseCase
+	employeeUC     *employeeUseCase.EmployeeUseCase
+	onboardingUC   *onboardingUseCase.OnboardingUseCase
 }
 
-func NewCronHandler(subscriptionUC *subscription.SubscriptionUseCase, attendanceUC *attendanceUseCase.AttendanceUseCase) *CronHandler {
+func NewCronHandler(subscriptionUC *subscription.SubscriptionUseCase, attendanceUC *attendanceUseCase.AttendanceUseCase, leaveRequestUC *leaveRequestUseCase.LeaveRequestUseCase, contractUC *contractUseCase.ContractUseCase, employeeUC *employeeUseCase.EmployeeUseCase, onboardingUC *onboardingUseCase.OnboardingUseCase) *CronHandler {
 	return &CronHandler{
 		subscriptionUC: subscriptionUC,
 		attendanceUC:   attendanceUC,
+		leaveRequestUC: leaveRequestUC,
+		contractUC:     contractUC,
+		employeeUC:     employeeUC,
+		onboardingUC:   onboardingUC,
 	}
 }
 
@@ -80,3 +94,121 @@ func (h *CronHandler) ProcessDailyAbsentCheck(c *gin.Context) {
 
 	response.OK(c, "Daily absent check completed", nil)
 }
+
+func (h *CronHandler) ProcessMissingCertificates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.leaveRequestUC.ProcessMissingCertificates(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process missing medical certificates", err)
+		return
+	}
+
+	response.OK(c, "Missing medical certificates processed", result)
+}
+
+func (h *CronHandler) ProcessLeaveEncashment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	year := time.Now().Year() - 1
+	if yearParam := c.Query("year"); yearParam != "" {
+		parsedYear, err := strconv.Atoi(yearParam)
+		if err != nil {
+			response.BadRequest(c, "Invalid year parameter", err)
+			return
+		}
+		year = parsedYear
+	}
+
+	result, err := h.leaveRequestUC.ProcessYearEndEncashment(ctx, year)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process leave encashment", err)
+		return
+	}
+
+	response.OK(c, "Leave encashment processed", result)
+}
+
+func (h *CronHandler) ProcessContractExpiryReminders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.contractUC.ProcessContractExpiryReminders(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process contract expiry reminders", err)
+		return
+	}
+
+	response.OK(c, "Contract expiry reminders processed", result)
+}
+
+func (h *CronHandler) ProcessDueOffboardings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.employeeUC.ProcessDueOffboardings(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process due offboardings", err)
+		return
+	}
+
+	response.OK(c, "Due offboardings processed", result)
+}
+
+func (h *CronHandler) ProcessEmploymentEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.employeeUC.ApplyDueEmploymentEvents(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to apply due employment events", err)
+		return
+	}
+
+	response.OK(c, "Due employment events applied", result)
+}
+
+func (h *CronHandler) ProcessOnboardingReminders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.onboardingUC.ProcessOnboardingReminders(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process onboarding reminders", err)
+		return
+	}
+
+	response.OK(c, "Onboarding reminders processed", result)
+}
+
+func (h *CronHandler) ProcessProbationReviewReminders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.employeeUC.ProcessProbationReviewReminders(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process probation review reminders", err)
+		return
+	}
+
+	response.OK(c, "Probation review reminders processed", result)
+}
+
+func (h *CronHandler) ProcessEmployeeDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.employeeUC.DetectEmployeeDuplicates(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process employee duplicates", err)
+		return
+	}
+
+	response.OK(c, "Employee duplicates processed", result)
+}
+
+func (h *CronHandler) ProcessEmployeeEventDigest(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.employeeUC.ProcessEmployeeEventDigest(ctx)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to process employee event digests", err)
+		return
+	}
+
+	response.OK(c, "Employee event digests processed", result)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	customFieldDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/custom_field"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/custom_field"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	customFieldUseCase *custom_field.CustomFieldUseCase
+}
+
+func NewCustomFieldHandler(customFieldUseCase *custom_field.CustomFieldUseCase) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldUseCase: customFieldUseCase,
+	}
+}
+
+func handleCustomFieldError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrCustomFieldNotFound) {
+		response.NotFound(c, "Custom field not found", err)
+	} else if errors.Is(err, domain.ErrCustomFieldExists) {
+		response.Conflict(c, err.Error(), err)
+	} else if errors.Is(err, domain.ErrInvalidCustomField) {
+		response.BadRequest(c, err.Error(), err)
+	} else {
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *CustomFieldHandler) CreateCustomField(c *gin.Context) {
+	var req customFieldDTO.CustomFieldRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	field, err := h.customFieldUseCase.Create(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		handleCustomFieldError(c, err)
+		return
+	}
+
+	response.Created(c, "Custom field created successfully", field)
+}
+
+func (h *CustomFieldHandler) ListCustomFields(c *gin.Context) {
+	fields, err := h.customFieldUseCase.List(c.Request.Context())
+	if err != nil {
+		handleCustomFieldError(c, err)
+		return
+	}
+
+	response.OK(c, "Custom fields retrieved successfully", fields)
+}
+
+func (h *CustomFieldHandler) GetCustomField(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid custom field ID format", err)
+		return
+	}
+
+	field, err := h.customFieldUseCase.Get(c.Request.Context(), uint(id))
+	if err != nil {
+		handleCustomFieldError(c, err)
+		return
+	}
+
+	response.OK(c, "Custom field retrieved successfully", field)
+}
+
+func (h *CustomFieldHandler) UpdateCustomField(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid custom field ID format", err)
+		return
+	}
+
+	var req customFieldDTO.CustomFieldRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	definition := req.ToDomain()
+	definition.ID = uint(id)
+
+	field, err := h.customFieldUseCase.Update(c.Request.Context(), definition)
+	if err != nil {
+		handleCustomFieldError(c, err)
+		return
+	}
+
+	response.OK(c, "Custom field updated successfully", field)
+}
+
+func (h *CustomFieldHandler) DeleteCustomField(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid custom field ID format", err)
+		return
+	}
+
+	if err := h.customFieldUseCase.Delete(c.Request.Context(), uint(id)); err != nil {
+		handleCustomFieldError(c, err)
+		return
+	}
+
+	response.OK(c, "Custom field deleted successfully", nil)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/SukaMajuu/hris/apps/backend/domain"
 	respDocumentDTO "github.com/SukaMajuu/hris/apps/backend/domain/dto/document"
 	reqDocumentDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/document"
 	documentUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/document"
@@ -62,9 +63,14 @@ func (h *DocumentHandler) GetDocuments(c *gin.Context) {
 		return
 	}
 
+	listQuery, invalid := bindListQuery(c, domain.DocumentListSchema, respDocumentDTO.DocumentResponseDTO{})
+	if invalid {
+		return
+	}
+
 	log.Printf("DocumentHandler: Getting documents for user ID: %d", userID)
 
-	documents, err := h.documentUseCase.GetDocumentsByUserID(c.Request.Context(), userID)
+	documents, err := h.documentUseCase.GetDocumentsByUserID(c.Request.Context(), userID, listQuery)
 	if err != nil {
 		log.Printf("DocumentHandler: Error getting documents for user ID %d: %v", userID, err)
 		response.InternalServerError(c, fmt.Errorf("failed to retrieve user documents"))
@@ -76,7 +82,7 @@ func (h *DocumentHandler) GetDocuments(c *gin.Context) {
 	respDTOs := respDocumentDTO.ToDocumentResponseDTOList(documents)
 
 	log.Printf("DocumentHandler: Returning %d documents as response", len(respDTOs))
-	response.Success(c, http.StatusOK, "Your documents retrieved successfully", respDTOs)
+	respondList(c, "Your documents retrieved successfully", respDTOs, listQuery)
 }
 
 func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
@@ -136,9 +142,14 @@ func (h *DocumentHandler) GetDocumentsByEmployee(c *gin.Context) {
 		return
 	}
 
+	listQuery, invalid := bindListQuery(c, domain.DocumentListSchema, respDocumentDTO.DocumentResponseDTO{})
+	if invalid {
+		return
+	}
+
 	log.Printf("DocumentHandler: Getting documents for employee ID: %d", employeeID)
 
-	documents, err := h.documentUseCase.GetDocumentsByEmployeeID(c.Request.Context(), employeeID)
+	documents, err := h.documentUseCase.GetDocumentsByEmployeeID(c.Request.Context(), employeeID, listQuery)
 	if err != nil {
 		log.Printf("DocumentHandler: Error getting documents for employee ID %d: %v", employeeID, err)
 		response.InternalServerError(c, fmt.Errorf("failed to retrieve employee documents"))
@@ -150,7 +161,7 @@ func (h *DocumentHandler) GetDocumentsByEmployee(c *gin.Context) {
 	respDTOs := respDocumentDTO.ToDocumentResponseDTOList(documents)
 
 	log.Printf("DocumentHandler: Returning %d documents as response", len(respDTOs))
-	response.Success(c, http.StatusOK, "Employee documents retrieved successfully", respDTOs)
+	respondList(c, "Employee documents retrieved successfully", respDTOs, listQuery)
 }
 
 func (h *DocumentHandler) getUserIDFromContext(c *gin.Context) (uint, error) {
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleEmployeeDuplicateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeDuplicateNotFound):
+		response.NotFound(c, "Employee duplicate not found", err)
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrEmployeeDuplicateDismissed):
+		response.Conflict(c, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidEmployeeMerge):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *EmployeeHandler) ListEmployeeDuplicates(c *gin.Context) {
+	var query employeeDTO.EmployeeDuplicateQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	status := domain.EmployeeDuplicatePending
+	if query.Status != "" {
+		status = domain.EmployeeDuplicateStatus(query.Status)
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	if paginationParams.Page <= 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize <= 0 {
+		paginationParams.PageSize = 10
+	}
+
+	result, err := h.employeeUseCase.ListEmployeeDuplicates(c.Request.Context(), status, paginationParams)
+	if err != nil {
+		handleEmployeeDuplicateError(c, err)
+		return
+	}
+
+	response.OK(c, "Employee duplicates retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) DetectEmployeeDuplicates(c *gin.Context) {
+	result, err := h.employeeUseCase.DetectEmployeeDuplicates(c.Request.Context())
+	if err != nil {
+		handleEmployeeDuplicateError(c, err)
+		return
+	}
+
+	response.OK(c, "Employee duplicate detection completed", result)
+}
+
+func (h *EmployeeHandler) DismissEmployeeDuplicate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("duplicate_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee duplicate ID format", err)
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.DismissEmployeeDuplicate(c.Request.Context(), uint(id), userID)
+	if err != nil {
+		handleEmployeeDuplicateError(c, err)
+		return
+	}
+
+	response.OK(c, "Employee duplicate dismissed successfully", result)
+}
+
+func (h *EmployeeHandler) MergeEmployeeDuplicate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("duplicate_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee duplicate ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.MergeEmployeeDuplicateRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.MergeEmployeeDuplicate(c.Request.Context(), uint(id), reqDTO.SurvivorID, reqDTO.KeepDuplicateAccount, userID)
+	if err != nil {
+		handleEmployeeDuplicateError(c, err)
+		return
+	}
+
+	response.OK(c, "Employee duplicates merged successfully", result)
+}
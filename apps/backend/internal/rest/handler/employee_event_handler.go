@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleEmployeeEventError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrTeamEventsDenied):
+		response.Forbidden(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+// ListTeamEvents lists the upcoming events of a manager's team. Besides admins, only the manager
+// can list them.
+func (h *EmployeeHandler) ListTeamEvents(c *gin.Context) {
+	var query employeeDTO.EmployeeEventQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+	managerID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+	if c.Param("id") != "" && !isAdmin(c) {
+		userID, ok := currentUserID(c)
+		if !ok {
+			return
+		}
+		currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+		if err != nil {
+			handleEmployeeEventError(c, err)
+			return
+		}
+		if currentEmployee.ID != managerID {
+			handleEmployeeEventError(c, domain.ErrTeamEventsDenied)
+			return
+		}
+	}
+
+	days := domain.DefaultEmployeeEventDays
+	if query.Days != nil {
+		days = *query.Days
+	}
+
+	result, err := h.employeeUseCase.ListTeamEvents(c.Request.Context(), managerID, days)
+	if err != nil {
+		handleEmployeeEventError(c, err)
+		return
+	}
+
+	response.OK(c, "Team events retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) UpdateMyEventPreferences(c *gin.Context) {
+	var reqDTO employeeDTO.EventPreferencesRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+	employeeID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.UpdateEventPreferences(c.Request.Context(), employeeID, reqDTO.HidePersonalEvents, reqDTO.EventDigest)
+	if err != nil {
+		handleEmployeeEventError(c, err)
+		return
+	}
+
+	response.OK(c, "Event preferences updated successfully", result)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/export"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// pdfRosterColumns are the columns of a PDF export when none are chosen, as every column of an
+// employee does not fit on a page.
+var pdfRosterColumns = []string{"employee_code", "first_name", "last_name", "position_name", "department", "branch", "email", "phone"}
+
+func (h *EmployeeHandler) ListExportColumns(c *gin.Context) {
+	columns, err := h.employeeUseCase.ExportColumns(c.Request.Context(), isAdmin(c))
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Export columns retrieved successfully", columns)
+}
+
+// ExportEmployees writes the employees matching the list filters to a CSV, XLSX or PDF file. The
+// file is streamed while the employees are read, so an error after the first rows were sent can
+// only cut the download short.
+func (h *EmployeeHandler) ExportEmployees(c *gin.Context) {
+	var queryDTO employeeDTO.ExportEmployeesRequestQuery
+	if bindAndValidateQuery(c, &queryDTO) {
+		return
+	}
+
+	format := export.Format(queryDTO.Format)
+	if format == "" {
+		format = export.FormatCSV
+	}
+	keys := queryDTO.ColumnKeys()
+	if len(keys) == 0 && format == export.FormatPDF {
+		keys = pdfRosterColumns
+	}
+
+	columns, err := h.employeeUseCase.ResolveExportColumns(c.Request.Context(), keys, isAdmin(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidExportColumn) {
+			response.BadRequest(c, err.Error(), err)
+			return
+		}
+		response.InternalServerError(c, err)
+		return
+	}
+	filters := h.buildFilters(&queryDTO.ListEmployeesRequestQuery)
+	filters["search_nik"] = true
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="employees-%s.%s"`, time.Now().Format("20060102"), format))
+
+	writer, err := export.NewWriter(format, c.Writer, "Employees")
+	if err == nil {
+		err = writer.WriteHeader(headers)
+	}
+	if err == nil {
+		err = h.employeeUseCase.ExportEmployees(c.Request.Context(), filters, columns, writer.WriteRow)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		log.Printf("EmployeeHandler: Error exporting employees: %v", err)
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		response.InternalServerError(c, fmt.Errorf("failed to export employees"))
+	}
+}
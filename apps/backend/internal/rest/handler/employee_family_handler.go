@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleEmployeeFamilyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrInvalidFamilyMember),
+		errors.Is(err, domain.ErrInvalidEmergencyContact),
+		errors.Is(err, domain.ErrTaxDependantMismatch):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+// targetEmployeeID returns the employee a request is about: the one in the path, or the current
+// user's own employee record on the /me routes.
+func (h *EmployeeHandler) targetEmployeeID(c *gin.Context) (uint, bool) {
+	if idStr := c.Param("id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			response.BadRequest(c, "Invalid employee ID format", err)
+			return 0, false
+		}
+		return uint(id), true
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return 0, false
+	}
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		handleEmployeeFamilyError(c, err)
+		return 0, false
+	}
+	return currentEmployee.ID, true
+}
+
+func (h *EmployeeHandler) GetFamily(c *gin.Context) {
+	employeeID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.GetFamily(c.Request.Context(), employeeID)
+	if err != nil {
+		handleEmployeeFamilyError(c, err)
+		return
+	}
+
+	response.OK(c, "Family members retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) ReplaceFamily(c *gin.Context) {
+	employeeID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	var reqDTO employeeDTO.ReplaceFamilyMembersRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	members, taxStatus, err := reqDTO.ToDomain()
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.employeeUseCase.ReplaceFamily(c.Request.Context(), employeeID, members, taxStatus)
+	if err != nil {
+		handleEmployeeFamilyError(c, err)
+		return
+	}
+
+	response.OK(c, "Family members updated successfully", result)
+}
+
+func (h *EmployeeHandler) GetEmergencyContacts(c *gin.Context) {
+	employeeID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.GetEmergencyContacts(c.Request.Context(), employeeID)
+	if err != nil {
+		handleEmployeeFamilyError(c, err)
+		return
+	}
+
+	response.OK(c, "Emergency contacts retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) ReplaceEmergencyContacts(c *gin.Context) {
+	employeeID, ok := h.targetEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	var reqDTO employeeDTO.ReplaceEmergencyContactsRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	result, err := h.employeeUseCase.ReplaceEmergencyContacts(c.Request.Context(), employeeID, reqDTO.ToDomain())
+	if err != nil {
+		handleEmployeeFamilyError(c, err)
+		return
+	}
+
+	response.OK(c, "Emergency contacts updated successfully", result)
+}
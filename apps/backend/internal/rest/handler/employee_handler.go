@@ -2,6 +2,7 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
@@ -46,28 +47,19 @@ func (h *EmployeeHandler) ListEmployees(c *gin.Context) {
 	if bindAndValidateQuery(c, &queryDTO) {
 		return
 	}
-
-	userIDCtx, exists := c.Get("userID")
-	if !exists {
-		response.Unauthorized(c, "User ID not found in context", fmt.Errorf("missing userID in context"))
-		return
+	schema := domain.EmployeeListSchema
+	if !isAdmin(c) {
+		schema = schema.WithoutAdminOnly()
 	}
-	currentUserID, ok := userIDCtx.(uint)
-	if !ok {
-		response.InternalServerError(c, fmt.Errorf("invalid user ID type in context"))
-		return
-	}
-
-	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), currentUserID)
-	if err != nil {
-		log.Printf("EmployeeHandler: Error getting current employee for UserID %d: %v", currentUserID, err)
-		response.InternalServerError(c, fmt.Errorf("failed to get current employee information: %w", err))
+	listQuery, invalid := bindListQuery(c, schema, domainEmployeeDTO.EmployeeResponseDTO{})
+	if invalid {
 		return
 	}
 
 	paginationParams := domain.PaginationParams{
 		Page:     queryDTO.Page,
 		PageSize: queryDTO.PageSize,
+		Query:    listQuery,
 	}
 
 	if paginationParams.Page == 0 {
@@ -77,9 +69,15 @@ func (h *EmployeeHandler) ListEmployees(c *gin.Context) {
 		paginationParams.PageSize = 10
 	}
 
-	filters := h.buildFilters(&queryDTO, currentEmployee.ID)
+	filters := h.buildFilters(&queryDTO)
+	if restrictToTeam(c, filters, h.employeeUseCase.GetEmployeeByUserID) {
+		return
+	}
+	if isAdmin(c) {
+		filters["search_nik"] = true
+	}
 
-	log.Printf("EmployeeHandler: Listing employees for manager ID %d with DTO: %+v, Parsed Filters: %+v, Pagination: %+v", currentEmployee.ID, queryDTO, filters, paginationParams)
+	log.Printf("EmployeeHandler: Listing employees with DTO: %+v, Parsed Filters: %+v, Pagination: %+v", queryDTO, filters, paginationParams)
 
 	employeeData, err := h.employeeUseCase.List(c.Request.Context(), filters, paginationParams)
 	if err != nil {
@@ -87,11 +85,17 @@ func (h *EmployeeHandler) ListEmployees(c *gin.Context) {
 		response.InternalServerError(c, fmt.Errorf("failed to retrieve employees list"))
 		return
 	}
+	if !isAdmin(c) {
+		if err := h.employeeUseCase.HideAdminOnlyCustomFieldValues(c.Request.Context(), employeeData.Items...); err != nil {
+			response.InternalServerError(c, fmt.Errorf("failed to retrieve employees list"))
+			return
+		}
+	}
 
-	response.Success(c, http.StatusOK, "Employees retrieved successfully", employeeData)
+	respondList(c, "Employees retrieved successfully", employeeData, listQuery)
 }
 
-func (h *EmployeeHandler) buildFilters(queryDTO *employeeDTO.ListEmployeesRequestQuery, managerID uint) map[string]interface{} {
+func (h *EmployeeHandler) buildFilters(queryDTO *employeeDTO.ListEmployeesRequestQuery) map[string]interface{} {
 	filters := make(map[string]interface{})
 
 	if queryDTO.Status != nil {
@@ -103,8 +107,18 @@ func (h *EmployeeHandler) buildFilters(queryDTO *employeeDTO.ListEmployeesReques
 	if queryDTO.Gender != nil && *queryDTO.Gender != "" {
 		filters["gender"] = *queryDTO.Gender
 	}
-
-	filters["manager_id"] = managerID
+	if queryDTO.DepartmentID != nil {
+		filters["department_id"] = *queryDTO.DepartmentID
+	}
+	if queryDTO.BranchID != nil {
+		filters["branch_id"] = *queryDTO.BranchID
+	}
+	if queryDTO.PositionID != nil {
+		filters["position_id"] = *queryDTO.PositionID
+	}
+	if len(queryDTO.CustomFields) > 0 {
+		filters["custom_fields"] = queryDTO.CustomFields
+	}
 
 	return filters
 }
@@ -163,11 +177,20 @@ func (h *EmployeeHandler) handleCreateEmployeeError(c *gin.Context, err error) {
 	log.Printf("EmployeeHandler: Error creating employee from use case: %v", err)
 	if errors.Is(err, domain.ErrUserAlreadyExists) || errors.Is(err, domain.ErrEmailAlreadyExists) {
 		response.Error(c, http.StatusConflict, "Failed to create employee: user or email already exists.", err)
+	} else if isOrganizationNotFound(err) || errors.Is(err, domain.ErrManagerNotFound) || errors.Is(err, domain.ErrManagerResigned) || errors.Is(err, domain.ErrInvalidCustomFieldValue) {
+		response.BadRequest(c, err.Error(), err)
 	} else {
 		response.InternalServerError(c, fmt.Errorf("failed to create employee: %w", err))
 	}
 }
 
+// isOrganizationNotFound reports whether err is caused by an unknown department, branch or position.
+func isOrganizationNotFound(err error) bool {
+	return errors.Is(err, domain.ErrDepartmentNotFound) ||
+		errors.Is(err, domain.ErrBranchNotFound) ||
+		errors.Is(err, domain.ErrPositionNotFound)
+}
+
 func (h *EmployeeHandler) handlePhotoUploadForNewEmployee(c *gin.Context, createdEmployee *domain.Employee, photoFile *multipart.FileHeader) *domain.Employee {
 	log.Printf("EmployeeHandler: Uploading photo for newly created employee ID: %d", createdEmployee.ID)
 
@@ -200,6 +223,12 @@ func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 		response.InternalServerError(c, fmt.Errorf("failed to retrieve employee"))
 		return
 	}
+	if !isAdmin(c) {
+		if err := h.employeeUseCase.HideAdminOnlyCustomFieldValues(c.Request.Context(), employeeDTO); err != nil {
+			response.InternalServerError(c, fmt.Errorf("failed to retrieve employee"))
+			return
+		}
+	}
 
 	response.Success(c, http.StatusOK, "Employee retrieved successfully", employeeDTO)
 }
@@ -250,6 +279,10 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 			response.NotFound(c, "Employee not found for update", err)
 			return
 		}
+		if isOrganizationNotFound(err) || errors.Is(err, domain.ErrInvalidCustomFieldValue) || errors.Is(err, domain.ErrTaxDependantMismatch) {
+			response.BadRequest(c, err.Error(), err)
+			return
+		}
 		log.Printf("EmployeeHandler: Error updating employee from use case: %v", err)
 		response.InternalServerError(c, fmt.Errorf("failed to update employee: %w", err))
 		return
@@ -294,6 +327,108 @@ func (h *EmployeeHandler) ResignEmployee(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Employee resigned successfully", nil)
 }
 
+func (h *EmployeeHandler) handleReassignManagerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrManagerNotFound), errors.Is(err, domain.ErrManagerResigned), errors.Is(err, domain.ErrManagerCycle):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		log.Printf("EmployeeHandler: Error reassigning manager from use case: %v", err)
+		response.InternalServerError(c, fmt.Errorf("failed to reassign manager: %w", err))
+	}
+}
+
+func (h *EmployeeHandler) ReassignManager(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.ReassignManagerRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	updatedEmployee, err := h.employeeUseCase.ReassignManager(c.Request.Context(), uint(id), reqDTO.ManagerID)
+	if err != nil {
+		h.handleReassignManagerError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Manager reassigned successfully", domainEmployeeDTO.ToEmployeeResponseDTO(updatedEmployee))
+}
+
+func (h *EmployeeHandler) BulkReassignManager(c *gin.Context) {
+	var reqDTO employeeDTO.BulkReassignManagerRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	if err := h.employeeUseCase.BulkReassignManager(c.Request.Context(), reqDTO.EmployeeIDs, reqDTO.ManagerID); err != nil {
+		h.handleReassignManagerError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Managers reassigned successfully", nil)
+}
+
+// listReportsOfCurrentUser lists employees below the current user in the reporting line, using
+// listFn to choose between direct reports and the whole subtree.
+func (h *EmployeeHandler) listReportsOfCurrentUser(c *gin.Context, listFn func(ctx context.Context, managerID uint, filters map[string]interface{}, paginationParams domain.PaginationParams) (*domainEmployeeDTO.EmployeeListResponseData, error)) {
+	var queryDTO employeeDTO.ListEmployeesRequestQuery
+	if bindAndValidateQuery(c, &queryDTO) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", fmt.Errorf("missing userID in context"))
+		return
+	}
+	currentUserID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, fmt.Errorf("invalid user ID type in context"))
+		return
+	}
+
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), currentUserID)
+	if err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to get current employee information: %w", err))
+		return
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     queryDTO.Page,
+		PageSize: queryDTO.PageSize,
+	}
+	if paginationParams.Page == 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize == 0 {
+		paginationParams.PageSize = 10
+	}
+
+	employeeData, err := listFn(c.Request.Context(), currentEmployee.ID, h.buildFilters(&queryDTO), paginationParams)
+	if err != nil {
+		log.Printf("EmployeeHandler: Error listing reports of employee %d: %v", currentEmployee.ID, err)
+		response.InternalServerError(c, fmt.Errorf("failed to retrieve employees list"))
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Employees retrieved successfully", employeeData)
+}
+
+func (h *EmployeeHandler) ListMyDirectReports(c *gin.Context) {
+	h.listReportsOfCurrentUser(c, h.employeeUseCase.ListDirectReports)
+}
+
+func (h *EmployeeHandler) ListMyReportingLine(c *gin.Context) {
+	h.listReportsOfCurrentUser(c, h.employeeUseCase.ListReportingLine)
+}
+
 var allowedPhotoMimeTypes = []string{
 	"image/jpeg",
 	"image/jpg",
@@ -444,16 +579,119 @@ func (h *EmployeeHandler) GetHireDateRange(c *gin.Context) {
 }
 
 func (h *EmployeeHandler) BulkImportEmployees(c *gin.Context) {
+	reqDTO, creatorEmployeeID, ok := h.bindBulkImport(c)
+	if !ok {
+		return
+	}
+
+	if reqDTO.ImportMode() != employeeDTO.ImportModeCreate || reqDTO.DryRun {
+		h.upsertImportEmployees(c, reqDTO, creatorEmployeeID)
+		return
+	}
+
+	parseRow, ok := h.importRowParserFor(c, reqDTO, employeeDTO.ParseEmployeeFromRecord)
+	if !ok {
+		return
+	}
+
+	employees, parseErrors, err := h.parseImportFile(reqDTO.File, parseRow)
+	if err != nil {
+		log.Printf("EmployeeHandler: Error parsing import file: %v", err)
+		response.BadRequest(c, fmt.Sprintf("Failed to parse file: %v", err), err)
+		return
+	}
+
+	if len(parseErrors) > 0 {
+		response.BadRequest(c, "File contains validation errors", fmt.Errorf("file contains %d validation errors", len(parseErrors)))
+		return
+	}
+
+	if len(employees) == 0 {
+		response.BadRequest(c, "No valid employee data found in file", nil)
+		return
+	}
+
+	successfulIDs, importErrors := h.employeeUseCase.BulkImportWithTransaction(c.Request.Context(), employees, creatorEmployeeID)
+
+	result := h.buildBulkImportResult(successfulIDs, importErrors)
+
+	if len(importErrors) > 0 {
+		response.Success(c, http.StatusPartialContent, fmt.Sprintf("Import completed with %d successes and %d errors", len(successfulIDs), len(importErrors)), result)
+	} else {
+		response.Success(c, http.StatusCreated, fmt.Sprintf("Successfully imported %d employees", len(successfulIDs)), result)
+	}
+}
+
+func (h *EmployeeHandler) upsertImportEmployees(c *gin.Context, reqDTO *employeeDTO.BulkImportEmployeesRequestDTO, creatorEmployeeID uint) {
+	result, ok := h.runImport(c, reqDTO, creatorEmployeeID)
+	if !ok {
+		return
+	}
+
+	switch {
+	case result.DryRun:
+		response.OK(c, fmt.Sprintf("Import preview: %d to update, %d to create, %d unchanged and %d errors", result.UpdatedCount, result.CreatedCount, result.UnchangedCount, result.ErrorCount), result)
+	case !result.Applied:
+		response.ErrorWithData(c, http.StatusUnprocessableEntity, "File contains validation errors, nothing was imported", fmt.Errorf("file contains %d validation errors", result.ErrorCount), result)
+	case result.ErrorCount > 0:
+		response.Success(c, http.StatusPartialContent, fmt.Sprintf("Import completed with %d updated, %d created and %d errors", result.UpdatedCount, result.CreatedCount, result.ErrorCount), result)
+	default:
+		response.OK(c, fmt.Sprintf("Successfully imported %d updated and %d created employees", result.UpdatedCount, result.CreatedCount), result)
+	}
+}
+
+// BulkImportErrorReport validates an import file like a dry run and returns its errors as a CSV
+// file.
+func (h *EmployeeHandler) BulkImportErrorReport(c *gin.Context) {
+	reqDTO, creatorEmployeeID, ok := h.bindBulkImport(c)
+	if !ok {
+		return
+	}
+	reqDTO.DryRun = true
+
+	result, ok := h.runImport(c, reqDTO, creatorEmployeeID)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"row", "field", "value", "message"}); err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to write error report: %w", err))
+		return
+	}
+	for _, failedRow := range result.FailedRows {
+		for _, importErr := range failedRow.Errors {
+			record := []string{strconv.Itoa(failedRow.Row), importErr.Field, importErr.Value, importErr.Message}
+			if err := writer.Write(record); err != nil {
+				response.InternalServerError(c, fmt.Errorf("failed to write error report: %w", err))
+				return
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to write error report: %w", err))
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="employee-import-errors.csv"`)
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// bindBulkImport binds an import request and returns it with the employee of the importing user,
+// responding with an error and returning false when it is invalid.
+func (h *EmployeeHandler) bindBulkImport(c *gin.Context) (*employeeDTO.BulkImportEmployeesRequestDTO, uint, bool) {
 	var reqDTO employeeDTO.BulkImportEmployeesRequestDTO
 	if err := c.ShouldBind(&reqDTO); err != nil {
 		log.Printf("EmployeeHandler: Error binding bulk import request: %v", err)
 		response.BadRequest(c, "Invalid request format", err)
-		return
+		return nil, 0, false
 	}
 
 	if reqDTO.File == nil {
 		response.BadRequest(c, "File is required", fmt.Errorf("no file provided"))
-		return
+		return nil, 0, false
 	}
 
 	mimeType := reqDTO.File.Header.Get("Content-Type")
@@ -461,52 +699,77 @@ func (h *EmployeeHandler) BulkImportEmployees(c *gin.Context) {
 
 	if !h.isValidImportFileType(mimeType) {
 		response.BadRequest(c, fmt.Sprintf("File type not allowed. Detected: %s. Allowed types: CSV, Excel", mimeType), nil)
-		return
+		return nil, 0, false
 	}
 
 	userIDCtx, exists := c.Get("userID")
 	if !exists {
 		response.Unauthorized(c, "User ID not found in context", fmt.Errorf("missing userID in context"))
-		return
+		return nil, 0, false
 	}
 	creatorUserID, ok := userIDCtx.(uint)
 	if !ok {
 		response.InternalServerError(c, fmt.Errorf("invalid user ID type in context"))
-		return
+		return nil, 0, false
 	}
 
 	creatorEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), creatorUserID)
 	if err != nil {
 		response.InternalServerError(c, fmt.Errorf("failed to get creator employee information: %w", err))
-		return
+		return nil, 0, false
 	}
 
-	employees, parseErrors, err := h.parseImportFile(reqDTO.File)
+	return &reqDTO, creatorEmployee.ID, true
+}
+
+// runImport parses an import file and previews or applies it in the mode of the request. Rows that
+// cannot be parsed are reported as failed rows without looking at the rest of the file. It
+// responds with an error and returns false when the file cannot be imported at all.
+func (h *EmployeeHandler) runImport(c *gin.Context, reqDTO *employeeDTO.BulkImportEmployeesRequestDTO, creatorEmployeeID uint) (*employeeDTO.BulkUpsertResult, bool) {
+	mode := reqDTO.ImportMode()
+
+	parseRecord := employeeDTO.ParseEmployeeFromRecord
+	if mode != employeeDTO.ImportModeCreate {
+		parseRecord = employeeDTO.ParseEmployeeChangesFromRecord
+	}
+	parseRow, ok := h.importRowParserFor(c, reqDTO, parseRecord)
+	if !ok {
+		return nil, false
+	}
+
+	employees, parseErrors, err := h.parseImportFile(reqDTO.File, parseRow)
 	if err != nil {
 		log.Printf("EmployeeHandler: Error parsing import file: %v", err)
 		response.BadRequest(c, fmt.Sprintf("Failed to parse file: %v", err), err)
-		return
+		return nil, false
 	}
 
 	if len(parseErrors) > 0 {
-		response.BadRequest(c, "File contains validation errors", fmt.Errorf("file contains %d validation errors", len(parseErrors)))
-		return
+		result := h.buildBulkUpsertResult(&employeeUseCase.EmployeeUpsertResult{DryRun: reqDTO.DryRun})
+		result.FailedRows = groupImportErrorsByRow(parseErrors)
+		result.ErrorCount = len(parseErrors)
+		return &result, true
 	}
 
 	if len(employees) == 0 {
 		response.BadRequest(c, "No valid employee data found in file", nil)
-		return
+		return nil, false
 	}
 
-	successfulIDs, importErrors := h.employeeUseCase.BulkImportWithTransaction(c.Request.Context(), employees, creatorEmployee.ID)
-
-	result := h.buildBulkImportResult(successfulIDs, importErrors)
-
-	if len(importErrors) > 0 {
-		response.Success(c, http.StatusPartialContent, fmt.Sprintf("Import completed with %d successes and %d errors", len(successfulIDs), len(importErrors)), result)
+	var upsertResult *employeeUseCase.EmployeeUpsertResult
+	if mode == employeeDTO.ImportModeCreate {
+		upsertResult = h.employeeUseCase.PreviewBulkImport(c.Request.Context(), employees)
 	} else {
-		response.Success(c, http.StatusCreated, fmt.Sprintf("Successfully imported %d employees", len(successfulIDs)), result)
+		upsertResult, err = h.employeeUseCase.BulkUpsert(c.Request.Context(), employees, reqDTO.ImportMatchBy(), mode == employeeDTO.ImportModeUpsert, reqDTO.DryRun, creatorEmployeeID)
+		if err != nil {
+			log.Printf("EmployeeHandler: Error running %s import: %v", mode, err)
+			response.InternalServerError(c, err)
+			return nil, false
+		}
 	}
+
+	result := h.buildBulkUpsertResult(upsertResult)
+	return &result, true
 }
 
 func (h *EmployeeHandler) ValidateUniqueField(c *gin.Context) {
@@ -601,7 +864,78 @@ func (h *EmployeeHandler) buildBulkImportResult(successfulIDs []uint, importErro
 	return result
 }
 
-func (h *EmployeeHandler) parseImportFile(file *multipart.FileHeader) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
+func (h *EmployeeHandler) buildBulkUpsertResult(upsertResult *employeeUseCase.EmployeeUpsertResult) employeeDTO.BulkUpsertResult {
+	result := employeeDTO.BulkUpsertResult{
+		DryRun:         upsertResult.DryRun,
+		Applied:        upsertResult.Applied,
+		UpdatedCount:   upsertResult.Count(employeeUseCase.ImportActionUpdate),
+		CreatedCount:   upsertResult.Count(employeeUseCase.ImportActionCreate),
+		UnchangedCount: upsertResult.Count(employeeUseCase.ImportActionUnchanged),
+		ErrorCount:     len(upsertResult.Errors),
+		Rows:           []employeeDTO.BulkImportRowPreview{},
+		FailedRows:     []employeeDTO.BulkImportFailedRow{},
+	}
+
+	for _, row := range upsertResult.Rows {
+		preview := employeeDTO.BulkImportRowPreview{
+			Row:        row.Row,
+			Action:     row.Action,
+			EmployeeID: row.EmployeeID,
+		}
+		if len(row.Changes) > 0 {
+			preview.Changes = make(map[string]employeeDTO.BulkImportFieldChange, len(row.Changes))
+			for column, change := range row.Changes {
+				preview.Changes[column] = employeeDTO.BulkImportFieldChange{From: change.From, To: change.To}
+			}
+		}
+		result.Rows = append(result.Rows, preview)
+	}
+
+	importErrors := make([]employeeDTO.BulkImportError, 0, len(upsertResult.Errors))
+	for _, importErr := range upsertResult.Errors {
+		importErrors = append(importErrors, employeeDTO.BulkImportError{
+			Row:     importErr.Row,
+			Field:   importErr.Field,
+			Message: importErr.Message,
+			Value:   importErr.Value,
+		})
+	}
+	result.FailedRows = groupImportErrorsByRow(importErrors)
+
+	return result
+}
+
+// groupImportErrorsByRow returns a failed row for every row with errors, in the order the rows
+// first appear.
+func groupImportErrorsByRow(importErrors []employeeDTO.BulkImportError) []employeeDTO.BulkImportFailedRow {
+	failedRows := []employeeDTO.BulkImportFailedRow{}
+	indexByRow := make(map[int]int)
+	for _, importErr := range importErrors {
+		index, ok := indexByRow[importErr.Row]
+		if !ok {
+			index = len(failedRows)
+			indexByRow[importErr.Row] = index
+			failedRows = append(failedRows, employeeDTO.BulkImportFailedRow{Row: importErr.Row})
+		}
+		failedRows[index].Errors = append(failedRows[index].Errors, importErr)
+	}
+	return failedRows
+}
+
+// withImportRow sets the row of parse errors that do not carry one.
+func withImportRow(importErrors []employeeDTO.BulkImportError, rowNum int) []employeeDTO.BulkImportError {
+	for i := range importErrors {
+		if importErrors[i].Row == 0 {
+			importErrors[i].Row = rowNum
+		}
+	}
+	return importErrors
+}
+
+// importRowParser turns a row of an import file into an employee.
+type importRowParser func(headers, record []string, rowNum int) (*domain.Employee, []employeeDTO.BulkImportError)
+
+func (h *EmployeeHandler) parseImportFile(file *multipart.FileHeader, parseRow importRowParser) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
 	src, err := file.Open()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open file: %w", err)
@@ -618,9 +952,9 @@ func (h *EmployeeHandler) parseImportFile(file *multipart.FileHeader) ([]*domain
 	mimeType := file.Header.Get("Content-Type")
 
 	if strings.Contains(mimeType, "csv") {
-		employees, parseErrors, err = h.parseCSVFile(src)
+		employees, parseErrors, err = h.parseCSVFile(src, parseRow)
 	} else if strings.Contains(mimeType, "excel") || strings.Contains(mimeType, "spreadsheet") {
-		employees, parseErrors, err = h.parseExcelFile(src)
+		employees, parseErrors, err = h.parseExcelFile(src, parseRow)
 	} else {
 		return nil, nil, fmt.Errorf("unsupported file type: %s", mimeType)
 	}
@@ -632,7 +966,7 @@ func (h *EmployeeHandler) parseImportFile(file *multipart.FileHeader) ([]*domain
 	return employees, parseErrors, nil
 }
 
-func (h *EmployeeHandler) parseCSVFile(src io.Reader) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
+func (h *EmployeeHandler) parseCSVFile(src io.Reader, parseRow importRowParser) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
 	reader := csv.NewReader(src)
 	records, err := reader.ReadAll()
 	if err != nil {
@@ -652,15 +986,16 @@ func (h *EmployeeHandler) parseCSVFile(src io.Reader) ([]*domain.Employee, []emp
 
 		if len(record) != len(headers) {
 			parseErrors = append(parseErrors, employeeDTO.BulkImportError{
+				Row:     rowNum,
 				Field:   "row",
 				Message: fmt.Sprintf("Row %d has %d columns, expected %d", rowNum, len(record), len(headers)),
 			})
 			continue
 		}
 
-		employee, rowErrors := employeeDTO.ParseEmployeeFromRecord(headers, record, rowNum)
+		employee, rowErrors := parseRow(headers, record, rowNum)
 		if len(rowErrors) > 0 {
-			parseErrors = append(parseErrors, rowErrors...)
+			parseErrors = append(parseErrors, withImportRow(rowErrors, rowNum)...)
 			continue
 		}
 
@@ -670,7 +1005,7 @@ func (h *EmployeeHandler) parseCSVFile(src io.Reader) ([]*domain.Employee, []emp
 	return employees, parseErrors, nil
 }
 
-func (h *EmployeeHandler) parseExcelFile(src io.Reader) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
+func (h *EmployeeHandler) parseExcelFile(src io.Reader, parseRow importRowParser) ([]*domain.Employee, []employeeDTO.BulkImportError, error) {
 	content, err := io.ReadAll(src)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read Excel file: %w", err)
@@ -711,9 +1046,9 @@ func (h *EmployeeHandler) parseExcelFile(src io.Reader) ([]*domain.Employee, []e
 			row = append(row, "")
 		}
 
-		employee, rowErrors := employeeDTO.ParseEmployeeFromRecord(headers, row, rowNum)
+		employee, rowErrors := parseRow(headers, row, rowNum)
 		if len(rowErrors) > 0 {
-			parseErrors = append(parseErrors, rowErrors...)
+			parseErrors = append(parseErrors, withImportRow(rowErrors, rowNum)...)
 			continue
 		}
 
@@ -742,6 +1077,11 @@ func (h *EmployeeHandler) GetCurrentUserProfile(c *gin.Context) {
 		return
 	}
 
+	if err := h.employeeUseCase.HideAdminOnlyCustomFields(c.Request.Context(), employee); err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to get current user profile: %w", err))
+		return
+	}
+
 	respDTO := domainEmployeeDTO.ToEmployeeResponseDTO(employee)
 	response.Success(c, http.StatusOK, "Current user profile retrieved successfully", respDTO)
 }
@@ -799,9 +1139,31 @@ func (h *EmployeeHandler) UpdateCurrentUserProfile(c *gin.Context) {
 		return
 	}
 
+	if err := h.employeeUseCase.CheckSelfEditableProfileFields(c.Request.Context(), currentEmployee, updatePayload); err != nil {
+		if errors.Is(err, domain.ErrProfileChangeRequiresApproval) {
+			response.Forbidden(c, err.Error(), err)
+			return
+		}
+		response.InternalServerError(c, fmt.Errorf("failed to update current user profile: %w", err))
+		return
+	}
+
+	if err := h.employeeUseCase.CheckSelfEditableCustomFields(c.Request.Context(), updatePayload.CustomFields); err != nil {
+		if errors.Is(err, domain.ErrCustomFieldNotEditable) {
+			response.Forbidden(c, err.Error(), err)
+			return
+		}
+		response.InternalServerError(c, fmt.Errorf("failed to update current user profile: %w", err))
+		return
+	}
+
 	// Update the employee
 	updatedEmployee, err := h.employeeUseCase.Update(c.Request.Context(), updatePayload)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCustomFieldValue) || errors.Is(err, domain.ErrTaxDependantMismatch) {
+			response.BadRequest(c, err.Error(), err)
+			return
+		}
 		log.Printf("EmployeeHandler: Error updating current user profile for EmployeeID %d: %v", currentEmployee.ID, err)
 		response.InternalServerError(c, fmt.Errorf("failed to update current user profile: %w", err))
 		return
@@ -821,6 +1183,11 @@ func (h *EmployeeHandler) UpdateCurrentUserProfile(c *gin.Context) {
 		}
 	}
 
+	if err := h.employeeUseCase.HideAdminOnlyCustomFields(c.Request.Context(), updatedEmployee); err != nil {
+		log.Printf("EmployeeHandler: Warning - failed to hide admin-only custom fields: %v", err)
+		updatedEmployee.CustomFields = nil
+	}
+
 	respDTO := domainEmployeeDTO.ToEmployeeResponseDTO(updatedEmployee)
 	response.Success(c, http.StatusOK, "Current user profile updated successfully", respDTO)
 }
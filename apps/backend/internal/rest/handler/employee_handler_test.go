@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	employeeUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// customFieldDefinitions are an admin-only and an employee-editable custom field.
+var customFieldDefinitions = []*domain.CustomFieldDefinition{
+	{Key: "salary_band", Label: "Salary Band", Type: domain.CustomFieldText, Visibility: domain.CustomFieldAdminOnly},
+	{Key: "shirt_size", Label: "Shirt Size", Type: domain.CustomFieldText, Visibility: domain.CustomFieldEmployeeEditable},
+}
+
+func newCustomFieldEmployee() *domain.Employee {
+	return &domain.Employee{
+		ID:           7,
+		FirstName:    "Siti",
+		CustomFields: map[string]interface{}{"salary_band": "B2", "shirt_size": "M"},
+	}
+}
+
+// newEmployeeRouter serves the employee read endpoints to a user with the given role.
+func newEmployeeRouter(role enums.UserRole) (*gin.Engine, *mocks.EmployeeRepository) {
+	gin.SetMode(gin.TestMode)
+	employeeRepo := new(mocks.EmployeeRepository)
+	customFieldRepo := new(mocks.CustomFieldRepository)
+	customFieldRepo.On("List", mock.Anything).Return(customFieldDefinitions, nil)
+	useCase := employeeUseCase.NewEmployeeUseCase(employeeRepo, nil, nil, nil, nil).
+		WithDependencies(employeeUseCase.Dependencies{CustomFieldRepo: customFieldRepo})
+	h := NewEmployeeHandler(useCase)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uint(11))
+		c.Set("userRole", role)
+	})
+	router.GET("/employees", h.ListEmployees)
+	router.GET("/employees/export", h.ExportEmployees)
+	router.GET("/employees/export/columns", h.ListExportColumns)
+	router.GET("/employees/search", h.SearchEmployees)
+	router.GET("/employees/:id/events", h.ListTeamEvents)
+	router.GET("/employees/:id", h.GetEmployeeByID)
+	return router, employeeRepo
+}
+
+func serve(router *gin.Engine, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func TestEmployeeHandler_ListEmployees_HidesAdminOnlyCustomFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     enums.UserRole
+		expected map[string]interface{}
+	}{
+		{name: "admin", role: enums.RoleAdmin, expected: map[string]interface{}{"salary_band": "B2", "shirt_size": "M"}},
+		{name: "manager", role: enums.RoleUser, expected: map[string]interface{}{"shirt_size": "M"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, employeeRepo := newEmployeeRouter(tt.role)
+			employeeRepo.On("GetByUserID", mock.Anything, uint(11)).Return(&domain.Employee{ID: 3}, nil)
+			employeeRepo.On("List", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*domain.Employee{newCustomFieldEmployee()}, int64(1), nil)
+
+			recorder := serve(router, "/employees")
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var body struct {
+				Data struct {
+					Items []struct {
+						CustomFields map[string]interface{} `json:"custom_fields"`
+					} `json:"items"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			require.Len(t, body.Data.Items, 1)
+			assert.Equal(t, tt.expected, body.Data.Items[0].CustomFields)
+		})
+	}
+}
+
+func TestEmployeeHandler_GetEmployeeByID_HidesAdminOnlyCustomFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     enums.UserRole
+		expected map[string]interface{}
+	}{
+		{name: "admin", role: enums.RoleAdmin, expected: map[string]interface{}{"salary_band": "B2", "shirt_size": "M"}},
+		{name: "not an admin", role: enums.RoleUser, expected: map[string]interface{}{"shirt_size": "M"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, employeeRepo := newEmployeeRouter(tt.role)
+			employeeRepo.On("GetByID", mock.Anything, uint(7)).Return(newCustomFieldEmployee(), nil)
+
+			recorder := serve(router, "/employees/7")
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var body struct {
+				Data struct {
+					CustomFields map[string]interface{} `json:"custom_fields"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.Equal(t, tt.expected, body.Data.CustomFields)
+		})
+	}
+}
+
+func TestEmployeeHandler_ExportEmployees_HidesAdminOnlyCustomFields(t *testing.T) {
+	t.Run("admin exports admin-only custom fields", func(t *testing.T) {
+		router, employeeRepo := newEmployeeRouter(enums.RoleAdmin)
+		employeeRepo.On("ForEachBatch", mock.Anything, mock.Anything).
+			Return([]*domain.Employee{newCustomFieldEmployee()}, nil)
+
+		recorder := serve(router, "/employees/export?columns=first_name,custom_salary_band,custom_shirt_size")
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		records, err := csv.NewReader(recorder.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"First Name", "Salary Band", "Shirt Size"}, {"Siti", "B2", "M"}}, records)
+	})
+
+	t.Run("admin-only custom fields are not export columns for others", func(t *testing.T) {
+		router, employeeRepo := newEmployeeRouter(enums.RoleUser)
+
+		recorder := serve(router, "/employees/export/columns")
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"custom_shirt_size"`)
+		assert.NotContains(t, recorder.Body.String(), `"custom_salary_band"`)
+
+		recorder = serve(router, "/employees/export?columns=first_name,custom_salary_band")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		employeeRepo.AssertNotCalled(t, "ForEachBatch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmployeeHandler_ListEmployees_AdminOnlyListFields(t *testing.T) {
+	for _, query := range []string{"filter[nik]=3201010101010001", "filter[base_salary][gte]=10000000", "sort=-base_salary"} {
+		t.Run(query, func(t *testing.T) {
+			router, employeeRepo := newEmployeeRouter(enums.RoleUser)
+
+			recorder := serve(router, "/employees?"+query)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			employeeRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("admins can filter on them", func(t *testing.T) {
+		router, employeeRepo := newEmployeeRouter(enums.RoleAdmin)
+		employeeRepo.On("List", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Employee{}, int64(0), nil)
+
+		recorder := serve(router, "/employees?filter[base_salary][gte]=10000000&sort=-base_salary")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestEmployeeHandler_SearchEmployees_Scope(t *testing.T) {
+	nik := "3174011403900001"
+	managerID := uint(3)
+	tests := []struct {
+		name          string
+		role          enums.UserRole
+		expectedScope domain.EmployeeSearchScope
+		expectedNIK   *string
+	}{
+		{name: "admin", role: enums.RoleAdmin, expectedScope: domain.EmployeeSearchScope{MatchNIK: true}, expectedNIK: &nik},
+		{name: "manager", role: enums.RoleUser, expectedScope: domain.EmployeeSearchScope{ManagerID: &managerID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, employeeRepo := newEmployeeRouter(tt.role)
+			employeeRepo.On("GetByUserID", mock.Anything, uint(11)).Return(&domain.Employee{ID: 3}, nil).Maybe()
+			employeeRepo.On("Search", mock.Anything, "siti", tt.expectedScope, 10).
+				Return([]*domain.EmployeeSearchHit{{Employee: &domain.Employee{ID: 7, FirstName: "Siti", NIK: &nik}}}, nil)
+
+			recorder := serve(router, "/employees/search?q=siti")
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var body struct {
+				Data struct {
+					Items []struct {
+						NIK *string `json:"nik"`
+					} `json:"items"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			require.Len(t, body.Data.Items, 1)
+			assert.Equal(t, tt.expectedNIK, body.Data.Items[0].NIK)
+			employeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeHandler_ListEmployees_SearchMatchesNIKOnlyForAdmins(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      enums.UserRole
+		searchNIK bool
+	}{
+		{name: "admin", role: enums.RoleAdmin, searchNIK: true},
+		{name: "manager", role: enums.RoleUser, searchNIK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, employeeRepo := newEmployeeRouter(tt.role)
+			employeeRepo.On("GetByUserID", mock.Anything, uint(11)).Return(&domain.Employee{ID: 3}, nil).Maybe()
+			employeeRepo.On("List", mock.Anything, mock.MatchedBy(func(filters map[string]interface{}) bool {
+				_, searchNIK := filters["search_nik"]
+				return filters["search"] == "3174" && searchNIK == tt.searchNIK
+			}), mock.Anything).Return([]*domain.Employee{}, int64(0), nil)
+
+			recorder := serve(router, "/employees?search=3174")
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			employeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeHandler_ListTeamEvents_OtherManager(t *testing.T) {
+	router, employeeRepo := newEmployeeRouter(enums.RoleUser)
+	employeeRepo.On("GetByUserID", mock.Anything, uint(11)).Return(&domain.Employee{ID: 3}, nil)
+
+	recorder := serve(router, "/employees/9/events")
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	employeeRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchEmployees is the typeahead employee search. It returns at most limit employees, 10 by
+// default, ranked by how well they match. Admins search the whole company, NIKs included; other
+// users search their direct reports.
+func (h *EmployeeHandler) SearchEmployees(c *gin.Context) {
+	var queryDTO employeeDTO.SearchEmployeesRequestQuery
+	if bindAndValidateQuery(c, &queryDTO) {
+		return
+	}
+	if queryDTO.Limit == 0 {
+		queryDTO.Limit = 10
+	}
+
+	scope := domain.EmployeeSearchScope{IncludeInactive: queryDTO.IncludeInactive, MatchNIK: isAdmin(c)}
+	filters := make(map[string]interface{})
+	if restrictToTeam(c, filters, h.employeeUseCase.GetEmployeeByUserID) {
+		return
+	}
+	if managerID, ok := filters["manager_id"].(uint); ok {
+		scope.ManagerID = &managerID
+	}
+
+	result, err := h.employeeUseCase.SearchEmployees(c.Request.Context(), queryDTO.Query, scope, queryDTO.Limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmployeeSearchTimeout) {
+			response.Error(c, http.StatusGatewayTimeout, err.Error(), err)
+			return
+		}
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Employees searched successfully", result)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *EmployeeHandler) RecordEmploymentEvent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.RecordEmploymentEventRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", fmt.Errorf("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, fmt.Errorf("invalid user ID type in context"))
+		return
+	}
+
+	event, change, err := employeeDTO.MapEmploymentEventDTOToDomain(uint(id), userID, &reqDTO)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	recordedEvent, err := h.employeeUseCase.RecordEmploymentEvent(c.Request.Context(), event, change)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmployeeNotFound) {
+			response.NotFound(c, "Employee not found", err)
+		} else if errors.Is(err, domain.ErrInvalidEmploymentEvent) || isOrganizationNotFound(err) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, "Employment event recorded successfully", recordedEvent)
+}
+
+func (h *EmployeeHandler) ListEmploymentHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	history, err := h.employeeUseCase.ListEmploymentHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrEmployeeNotFound) {
+			response.NotFound(c, "Employee not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Employment history retrieved successfully", history)
+}
+
+func (h *EmployeeHandler) GetTenureReport(c *gin.Context) {
+	asOf := time.Now()
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		parsed, err := time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			response.BadRequest(c, "Invalid as_of date format, expected YYYY-MM-DD", err)
+			return
+		}
+		asOf = parsed
+	}
+
+	report, err := h.employeeUseCase.GetTenureReport(c.Request.Context(), asOf)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenure report retrieved successfully", report)
+}
+
+func (h *EmployeeHandler) GetTurnoverReport(c *gin.Context) {
+	var query employeeDTO.TurnoverReportRequestQuery
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", query.StartDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid start date format, expected YYYY-MM-DD", err)
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", query.EndDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid end date format, expected YYYY-MM-DD", err)
+		return
+	}
+	if startDate.After(endDate) {
+		response.BadRequest(c, "Start date cannot be after end date", errors.New("invalid date range"))
+		return
+	}
+
+	report, err := h.employeeUseCase.GetTurnoverReport(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Turnover report retrieved successfully", report)
+}
@@ -1,16 +1,47 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/listquery"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/validation"
 	"github.com/gin-gonic/gin"
 )
 
+// isAdmin reports whether the authenticated user is an admin of their company.
+func isAdmin(c *gin.Context) bool {
+	role, _ := c.Get("userRole")
+	return role == enums.RoleAdmin
+}
+
+// restrictToTeam limits a company-wide list to the direct reports of the authenticated user unless
+// the user is an admin. It responds with an error and returns true when the user's employee record
+// cannot be found.
+func restrictToTeam(c *gin.Context, filters map[string]interface{}, getEmployeeByUserID func(context.Context, uint) (*domain.Employee, error)) bool {
+	if isAdmin(c) {
+		return false
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return true
+	}
+	currentEmployee, err := getEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to get current employee information: %w", err))
+		return true
+	}
+
+	filters["manager_id"] = currentEmployee.ID
+	return false
+}
+
 func bindAndValidate(c *gin.Context, dto interface{}) bool {
 	if dto == nil {
 		response.BadRequest(c, domain.ErrRequestBodyRequired.Error(), nil)
@@ -78,3 +109,50 @@ func bindAndValidateQuery(c *gin.Context, dto interface{}) bool {
 	}
 	return false
 }
+
+// bindListQuery parses the filters, sorts and field selection of a list request against the
+// list's schema and the response DTO of its items. It responds with an error and returns true
+// when the query is invalid.
+func bindListQuery(c *gin.Context, schema domain.ListSchema, item interface{}) (*domain.ListQuery, bool) {
+	query, err := listquery.Parse(c.Request.URL.Query(), schema, item)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return nil, true
+	}
+	return query, false
+}
+
+// bindCursor reads the cursor of a list request. A cursor parameter, empty for the first page,
+// switches the list from pages to cursors, which keep their own order and so cannot be sorted. It
+// responds with an error and returns true when the cursor is invalid or comes with a sort, even
+// for lists without a list query, rather than ignoring the sort.
+func bindCursor(c *gin.Context, query *domain.ListQuery) (*domain.Cursor, bool) {
+	token, ok := c.GetQuery("cursor")
+	if !ok {
+		return nil, false
+	}
+	if sort, sorted := c.GetQuery("sort"); (sorted && sort != "") || (query != nil && len(query.Sorts) > 0) {
+		response.BadRequest(c, "cursor pagination cannot be combined with sort", domain.ErrInvalidCursor)
+		return nil, true
+	}
+	cursor, err := domain.DecodeCursor(token)
+	if err != nil {
+		response.BadRequest(c, domain.ErrInvalidCursor.Error(), err)
+		return nil, true
+	}
+	return cursor, false
+}
+
+// respondList responds with a list, keeping only the fields the list query selected.
+func respondList(c *gin.Context, message string, data interface{}, query *domain.ListQuery) {
+	var fields []string
+	if query != nil {
+		fields = query.Fields
+	}
+	selected, err := listquery.SelectFields(data, fields)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+	response.OK(c, message, selected)
+}
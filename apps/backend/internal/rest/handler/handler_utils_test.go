@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sorted := &domain.ListQuery{Sorts: []domain.QuerySort{{Field: "date", Desc: true}}}
+	next := domain.Cursor{ID: 9}
+
+	tests := []struct {
+		name           string
+		query          string
+		listQuery      *domain.ListQuery
+		expectedCursor *domain.Cursor
+		expectedStatus int
+	}{
+		{name: "no cursor", query: "sort=-date", listQuery: sorted, expectedStatus: http.StatusOK},
+		{name: "first page", query: "cursor=", expectedCursor: &domain.Cursor{}, expectedStatus: http.StatusOK},
+		{name: "next page", query: "cursor=" + next.Encode(), expectedCursor: &next, expectedStatus: http.StatusOK},
+		{name: "malformed cursor", query: "cursor=not-a-cursor", expectedStatus: http.StatusBadRequest},
+		{name: "cursor with a sort of the list query", query: "cursor=&sort=-date", listQuery: sorted, expectedStatus: http.StatusBadRequest},
+		{name: "cursor with a sort on a list without a list query", query: "cursor=&sort=-created_at", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+			cursor, invalid := bindCursor(c, tt.listQuery)
+
+			assert.Equal(t, tt.expectedStatus != http.StatusOK, invalid)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedCursor, cursor)
+		})
+	}
+}
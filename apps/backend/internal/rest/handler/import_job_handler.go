@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleImportJobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrImportJobNotFound):
+		response.NotFound(c, "Import job not found", err)
+	case errors.Is(err, domain.ErrImportJobNotFinished):
+		response.Conflict(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+// SubmitImportJob queues a create import to run in the background. The file is validated before
+// the job is queued, and is rejected with its errors the way a bulk import is.
+func (h *EmployeeHandler) SubmitImportJob(c *gin.Context) {
+	reqDTO, creatorEmployeeID, ok := h.bindBulkImport(c)
+	if !ok {
+		return
+	}
+	if reqDTO.ImportMode() != employeeDTO.ImportModeCreate || reqDTO.DryRun {
+		response.BadRequest(c, "Import jobs only create employees, use the bulk import for updates and previews", nil)
+		return
+	}
+
+	parseRow, ok := h.importRowParserFor(c, reqDTO, employeeDTO.ParseEmployeeFromRecord)
+	if !ok {
+		return
+	}
+
+	employees, parseErrors, err := h.parseImportFile(reqDTO.File, parseRow)
+	if err != nil {
+		log.Printf("EmployeeHandler: Error parsing import file: %v", err)
+		response.BadRequest(c, fmt.Sprintf("Failed to parse file: %v", err), err)
+		return
+	}
+
+	if len(parseErrors) > 0 {
+		result := employeeDTO.BulkImportResult{
+			ErrorCount: len(parseErrors),
+			FailedRows: groupImportErrorsByRow(parseErrors),
+		}
+		response.ErrorWithData(c, http.StatusBadRequest, "File contains validation errors", fmt.Errorf("file contains %d validation errors", len(parseErrors)), result)
+		return
+	}
+
+	if len(employees) == 0 {
+		response.BadRequest(c, "No valid employee data found in file", nil)
+		return
+	}
+
+	job, importErrors, err := h.employeeUseCase.SubmitImportJob(c.Request.Context(), employees, reqDTO.File.Filename, creatorEmployeeID)
+	if err != nil {
+		handleImportJobError(c, err)
+		return
+	}
+	if len(importErrors) > 0 {
+		result := h.buildBulkImportResult(nil, importErrors)
+		response.ErrorWithData(c, http.StatusUnprocessableEntity, "File contains validation errors, no import job was queued", fmt.Errorf("file contains %d validation errors", len(importErrors)), result)
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, "Import job queued successfully", job)
+}
+
+func (h *EmployeeHandler) ListImportJobs(c *gin.Context) {
+	var query employeeDTO.ImportJobQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	if paginationParams.Page <= 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize <= 0 {
+		paginationParams.PageSize = 10
+	}
+
+	result, err := h.employeeUseCase.ListImportJobs(c.Request.Context(), paginationParams)
+	if err != nil {
+		handleImportJobError(c, err)
+		return
+	}
+
+	response.OK(c, "Import jobs retrieved successfully", result)
+}
+
+// GetImportJob returns the status and progress of an import job.
+func (h *EmployeeHandler) GetImportJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("job_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid import job ID format", err)
+		return
+	}
+
+	result, err := h.employeeUseCase.GetImportJob(c.Request.Context(), uint(id))
+	if err != nil {
+		handleImportJobError(c, err)
+		return
+	}
+
+	response.OK(c, "Import job retrieved successfully", result)
+}
+
+// DownloadImportJobResults returns the result of every row of a finished import job as a CSV file,
+// with a line for every error of a failed row.
+func (h *EmployeeHandler) DownloadImportJobResults(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("job_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid import job ID format", err)
+		return
+	}
+
+	rows, err := h.employeeUseCase.GetImportJobResults(c.Request.Context(), uint(id))
+	if err != nil {
+		handleImportJobError(c, err)
+		return
+	}
+
+	records := [][]string{{"row", "status", "employee_id", "field", "value", "message"}}
+	for _, row := range rows {
+		employeeID := ""
+		if row.EmployeeID != nil {
+			employeeID = strconv.FormatUint(uint64(*row.EmployeeID), 10)
+		}
+		if len(row.Errors) == 0 {
+			records = append(records, []string{strconv.Itoa(row.Row), string(row.Status), employeeID, "", "", ""})
+			continue
+		}
+		for _, rowErr := range row.Errors {
+			records = append(records, []string{strconv.Itoa(row.Row), string(row.Status), employeeID, rowErr.Field, rowErr.Value, rowErr.Message})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to write import job results: %w", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="employee-import-%d-results.csv"`, id))
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/export"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// importMappingSampleRows is the number of rows of a sample file read to detect a mapping.
+const importMappingSampleRows = 20
+
+func handleImportMappingError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrImportMappingProfileNotFound) {
+		response.NotFound(c, "Import mapping profile not found", err)
+	} else if errors.Is(err, domain.ErrImportMappingProfileExists) {
+		response.Conflict(c, err.Error(), err)
+	} else if errors.Is(err, domain.ErrInvalidImportMapping) {
+		response.BadRequest(c, err.Error(), err)
+	} else {
+		response.InternalServerError(c, err)
+	}
+}
+
+// ListImportFields returns the columns of an employee import file with their type, whether they
+// are required and their allowed values.
+func (h *EmployeeHandler) ListImportFields(c *gin.Context) {
+	fields, err := h.employeeUseCase.ImportTemplateFields(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Import fields retrieved successfully", fields)
+}
+
+// DownloadImportTemplate returns an empty employee import file with a header for every standard
+// and custom field.
+func (h *EmployeeHandler) DownloadImportTemplate(c *gin.Context) {
+	var queryDTO employeeDTO.ImportTemplateQuery
+	if bindAndValidateQuery(c, &queryDTO) {
+		return
+	}
+
+	fields, err := h.employeeUseCase.ImportTemplateFields(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+	headers := make([]string, len(fields))
+	for i, field := range fields {
+		headers[i] = field.Key
+	}
+
+	format := export.Format(queryDTO.Format)
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	var buf bytes.Buffer
+	writer, err := export.NewWriter(format, &buf, "Employees")
+	if err == nil {
+		err = writer.WriteHeader(headers)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		response.InternalServerError(c, fmt.Errorf("failed to write import template: %w", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="employee-import-template.%s"`, format))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// DetectImportMapping suggests a column mapping for a sample of a legacy HR system's export.
+func (h *EmployeeHandler) DetectImportMapping(c *gin.Context) {
+	var reqDTO employeeDTO.DetectImportMappingRequestDTO
+	if err := c.ShouldBind(&reqDTO); err != nil {
+		response.BadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	mimeType := reqDTO.File.Header.Get("Content-Type")
+	if !h.isValidImportFileType(mimeType) {
+		response.BadRequest(c, fmt.Sprintf("File type not allowed. Detected: %s. Allowed types: CSV, Excel", mimeType), nil)
+		return
+	}
+
+	rows, err := readImportRows(reqDTO.File, importMappingSampleRows+1)
+	if err != nil {
+		log.Printf("EmployeeHandler: Error reading sample import file: %v", err)
+		response.BadRequest(c, fmt.Sprintf("Failed to parse file: %v", err), err)
+		return
+	}
+	if len(rows) == 0 {
+		response.BadRequest(c, "File contains no header row", nil)
+		return
+	}
+
+	detection, err := h.employeeUseCase.DetectImportMapping(c.Request.Context(), rows[0], rows[1:])
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Import mapping detected successfully", detection)
+}
+
+func (h *EmployeeHandler) CreateImportMappingProfile(c *gin.Context) {
+	var req employeeDTO.ImportMappingProfileRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	profile, err := h.employeeUseCase.CreateImportMappingProfile(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		handleImportMappingError(c, err)
+		return
+	}
+
+	response.Created(c, "Import mapping profile created successfully", profile)
+}
+
+func (h *EmployeeHandler) ListImportMappingProfiles(c *gin.Context) {
+	profiles, err := h.employeeUseCase.ListImportMappingProfiles(c.Request.Context())
+	if err != nil {
+		handleImportMappingError(c, err)
+		return
+	}
+
+	response.OK(c, "Import mapping profiles retrieved successfully", profiles)
+}
+
+func (h *EmployeeHandler) GetImportMappingProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("mapping_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid import mapping profile ID format", err)
+		return
+	}
+
+	profile, err := h.employeeUseCase.GetImportMappingProfile(c.Request.Context(), uint(id))
+	if err != nil {
+		handleImportMappingError(c, err)
+		return
+	}
+
+	response.OK(c, "Import mapping profile retrieved successfully", profile)
+}
+
+func (h *EmployeeHandler) UpdateImportMappingProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("mapping_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid import mapping profile ID format", err)
+		return
+	}
+
+	var req employeeDTO.ImportMappingProfileRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	update := req.ToDomain()
+	update.ID = uint(id)
+
+	profile, err := h.employeeUseCase.UpdateImportMappingProfile(c.Request.Context(), update)
+	if err != nil {
+		handleImportMappingError(c, err)
+		return
+	}
+
+	response.OK(c, "Import mapping profile updated successfully", profile)
+}
+
+func (h *EmployeeHandler) DeleteImportMappingProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("mapping_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid import mapping profile ID format", err)
+		return
+	}
+
+	if err := h.employeeUseCase.DeleteImportMappingProfile(c.Request.Context(), uint(id)); err != nil {
+		handleImportMappingError(c, err)
+		return
+	}
+
+	response.OK(c, "Import mapping profile deleted successfully", nil)
+}
+
+// importRowParserFor returns the row parser of an import request, which reads the file through
+// the request's mapping profile when it names one. It responds with an error and returns false
+// when the profile cannot be loaded.
+func (h *EmployeeHandler) importRowParserFor(c *gin.Context, reqDTO *employeeDTO.BulkImportEmployeesRequestDTO, parseRow importRowParser) (importRowParser, bool) {
+	if reqDTO.MappingProfileID == nil {
+		return parseRow, true
+	}
+
+	profile, err := h.employeeUseCase.ImportMappingProfile(c.Request.Context(), *reqDTO.MappingProfileID)
+	if err != nil {
+		handleImportMappingError(c, err)
+		return nil, false
+	}
+	return withImportMapping(profile, parseRow), true
+}
+
+// withImportMapping converts each row through a mapping profile before parsing it. Values the
+// profile cannot convert are reported as errors of the row.
+func withImportMapping(profile *domain.ImportMappingProfile, parseRow importRowParser) importRowParser {
+	return func(headers, record []string, rowNum int) (*domain.Employee, []employeeDTO.BulkImportError) {
+		mappedHeaders, mappedRecord, valueErrors := profile.MapRecord(headers, record)
+		if len(valueErrors) > 0 {
+			importErrors := make([]employeeDTO.BulkImportError, len(valueErrors))
+			for i, valueErr := range valueErrors {
+				importErrors[i] = employeeDTO.BulkImportError{
+					Row:     rowNum,
+					Field:   valueErr.Field,
+					Message: valueErr.Message,
+					Value:   valueErr.Value,
+				}
+			}
+			return nil, importErrors
+		}
+		return parseRow(mappedHeaders, mappedRecord, rowNum)
+	}
+}
+
+// readImportRows reads up to limit rows of a CSV or Excel file, the header included.
+func readImportRows(file *multipart.FileHeader, limit int) ([][]string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close file: %v", closeErr)
+		}
+	}()
+
+	mimeType := file.Header.Get("Content-Type")
+	var rows [][]string
+	if strings.Contains(mimeType, "csv") {
+		reader := csv.NewReader(src)
+		reader.FieldsPerRecord = -1
+		for len(rows) < limit {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			rows = append(rows, record)
+		}
+		return rows, nil
+	}
+
+	if !strings.Contains(mimeType, "excel") && !strings.Contains(mimeType, "spreadsheet") {
+		return nil, fmt.Errorf("unsupported file type: %s", mimeType)
+	}
+
+	f, err := excelize.OpenReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close Excel file: %v", closeErr)
+		}
+	}()
+
+	sheetName := f.GetSheetName(0)
+	if sheetName == "" {
+		return nil, fmt.Errorf("excel file contains no sheets")
+	}
+	sheetRows, err := f.Rows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from Excel: %w", err)
+	}
+	defer func() {
+		if closeErr := sheetRows.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close Excel rows: %v", closeErr)
+		}
+	}()
+	for len(rows) < limit && sheetRows.Next() {
+		row, err := sheetRows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows from Excel: %w", err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -3,9 +3,12 @@ package handler
 import (
 	"errors"
 	"log"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	domainLeaveRequestDTO "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
 	leaveRequestDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/leave_request"
 	leaveRequestUseCase "github.com/SukaMajuu/hris/apps/backend/internal/usecase/leave_request"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
@@ -46,6 +49,11 @@ func (h *LeaveRequestHandler) CreateLeaveRequest(c *gin.Context) {
 		return
 	}
 
+	var preview *domainLeaveRequestDTO.LeaveBalancePreviewDTO
+	if req.PreviewBalance {
+		preview = h.previewBalance(c, domainLeaveRequest)
+	}
+
 	createdLeaveRequest, err := h.leaveRequestUseCase.Create(c.Request.Context(), domainLeaveRequest, req.AttachmentFile)
 	if err != nil {
 		if errors.Is(err, domain.ErrEmployeeNotFound) {
@@ -58,6 +66,8 @@ func (h *LeaveRequestHandler) CreateLeaveRequest(c *gin.Context) {
 		return
 	}
 
+	createdLeaveRequest.BalancePreview = preview
+
 	response.Created(c, "Leave request created successfully", createdLeaveRequest)
 }
 
@@ -87,9 +97,17 @@ func (h *LeaveRequestHandler) CreateLeaveRequestForEmployee(c *gin.Context) {
 		return
 	}
 
-	createdLeaveRequest, err := h.leaveRequestUseCase.CreateForEmployee(c.Request.Context(), domainLeaveRequest, req.AttachmentFile)
+	var preview *domainLeaveRequestDTO.LeaveBalancePreviewDTO
+	if req.PreviewBalance {
+		preview = h.previewBalance(c, domainLeaveRequest)
+	}
+
+	createdLeaveRequest, err := h.leaveRequestUseCase.CreateForEmployee(c.Request.Context(), domainLeaveRequest, req.AttachmentFile, req.OverrideStaffingRules)
 	if err != nil {
-		if errors.Is(err, domain.ErrEmployeeNotFound) {
+		var violation *leaveRequestUseCase.StaffingRuleViolationError
+		if errors.As(err, &violation) {
+			response.ErrorWithData(c, http.StatusConflict, "Approving this leave would breach a staffing rule. Resubmit with override_staffing_rules to proceed", err, violation.Conflicts)
+		} else if errors.Is(err, domain.ErrEmployeeNotFound) {
 			response.NotFound(c, "Employee not found", err)
 		} else if errors.Is(err, domain.ErrOverlappingLeaveRequest) {
 			response.Conflict(c, "Employee already has a pending or approved leave request for overlapping dates", err)
@@ -99,6 +117,8 @@ func (h *LeaveRequestHandler) CreateLeaveRequestForEmployee(c *gin.Context) {
 		return
 	}
 
+	createdLeaveRequest.BalancePreview = preview
+
 	response.Created(c, "Leave request created successfully for employee", createdLeaveRequest)
 }
 
@@ -128,21 +148,12 @@ func (h *LeaveRequestHandler) ListLeaveRequests(c *gin.Context) {
 	if bindAndValidateQuery(c, &query) {
 		return
 	}
-
-	userIDCtx, exists := c.Get("userID")
-	if !exists {
-		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+	listQuery, invalid := bindListQuery(c, domain.LeaveRequestListSchema, domainLeaveRequestDTO.LeaveRequestResponseDTO{})
+	if invalid {
 		return
 	}
-	currentUserID, ok := userIDCtx.(uint)
-	if !ok {
-		response.InternalServerError(c, errors.New("invalid user ID type in context"))
-		return
-	}
-
-	currentEmployee, err := h.leaveRequestUseCase.GetEmployeeByUserID(c.Request.Context(), currentUserID)
-	if err != nil {
-		response.InternalServerError(c, err)
+	cursor, invalid := bindCursor(c, listQuery)
+	if invalid {
 		return
 	}
 
@@ -156,12 +167,15 @@ func (h *LeaveRequestHandler) ListLeaveRequests(c *gin.Context) {
 	if query.LeaveType != nil {
 		filters["leave_type"] = *query.LeaveType
 	}
-
-	filters["manager_id"] = currentEmployee.ID
+	if restrictToTeam(c, filters, h.leaveRequestUseCase.GetEmployeeByUserID) {
+		return
+	}
 
 	paginationParams := domain.PaginationParams{
 		Page:     query.Page,
 		PageSize: query.PageSize,
+		Query:    listQuery,
+		Cursor:   cursor,
 	}
 
 	if paginationParams.Page <= 0 {
@@ -177,7 +191,7 @@ func (h *LeaveRequestHandler) ListLeaveRequests(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, "Leave requests retrieved successfully", leaveRequests)
+	respondList(c, "Leave requests retrieved successfully", leaveRequests, listQuery)
 }
 
 func (h *LeaveRequestHandler) GetMyLeaveRequests(c *gin.Context) {
@@ -185,6 +199,14 @@ func (h *LeaveRequestHandler) GetMyLeaveRequests(c *gin.Context) {
 	if bindAndValidateQuery(c, &query) {
 		return
 	}
+	listQuery, invalid := bindListQuery(c, domain.LeaveRequestListSchema, domainLeaveRequestDTO.LeaveRequestResponseDTO{})
+	if invalid {
+		return
+	}
+	cursor, invalid := bindCursor(c, listQuery)
+	if invalid {
+		return
+	}
 
 	userIDCtx, exists := c.Get("userID")
 	if !exists {
@@ -207,6 +229,8 @@ func (h *LeaveRequestHandler) GetMyLeaveRequests(c *gin.Context) {
 	pagination := domain.PaginationParams{
 		Page:     query.Page,
 		PageSize: query.PageSize,
+		Query:    listQuery,
+		Cursor:   cursor,
 	}
 
 	leaveRequests, err := h.leaveRequestUseCase.GetByEmployeeUserID(c.Request.Context(), userID, filters, pagination)
@@ -219,7 +243,7 @@ func (h *LeaveRequestHandler) GetMyLeaveRequests(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, "My leave requests retrieved successfully", leaveRequests)
+	respondList(c, "My leave requests retrieved successfully", leaveRequests, listQuery)
 }
 
 func (h *LeaveRequestHandler) UpdateLeaveRequest(c *gin.Context) {
@@ -349,9 +373,12 @@ func (h *LeaveRequestHandler) UpdateLeaveRequestStatus(c *gin.Context) {
 
 	log.Printf("Leave request status update by admin user ID: %d", userID)
 
-	updatedLeaveRequest, err := h.leaveRequestUseCase.UpdateStatus(c.Request.Context(), uint(id), req.Status, req.AdminNote)
+	updatedLeaveRequest, err := h.leaveRequestUseCase.UpdateStatus(c.Request.Context(), uint(id), req.Status, req.AdminNote, req.OverrideStaffingRules)
 	if err != nil {
-		if errors.Is(err, domain.ErrLeaveRequestNotFound) {
+		var violation *leaveRequestUseCase.StaffingRuleViolationError
+		if errors.As(err, &violation) {
+			response.ErrorWithData(c, http.StatusConflict, "Approving this leave would breach a staffing rule. Resubmit with override_staffing_rules to proceed", err, violation.Conflicts)
+		} else if errors.Is(err, domain.ErrLeaveRequestNotFound) {
 			response.NotFound(c, "Leave request not found", err)
 		} else {
 			response.InternalServerError(c, err)
@@ -361,3 +388,518 @@ func (h *LeaveRequestHandler) UpdateLeaveRequestStatus(c *gin.Context) {
 
 	response.OK(c, "Leave request status updated successfully", updatedLeaveRequest)
 }
+
+func (h *LeaveRequestHandler) CreateStaffingRule(c *gin.Context) {
+	var req leaveRequestDTO.StaffingRuleRequestDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	currentEmployee, err := h.leaveRequestUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	createdRule, err := h.leaveRequestUseCase.CreateStaffingRule(c.Request.Context(), req.ToDomain(userID, currentEmployee.ID))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStaffingRule) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, "Staffing rule created successfully", createdRule)
+}
+
+func (h *LeaveRequestHandler) ListStaffingRules(c *gin.Context) {
+	rules, err := h.leaveRequestUseCase.ListStaffingRules(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Staffing rules retrieved successfully", rules)
+}
+
+func (h *LeaveRequestHandler) UpdateStaffingRule(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid staffing rule ID format", err)
+		return
+	}
+
+	var req leaveRequestDTO.StaffingRuleRequestDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	currentEmployee, err := h.leaveRequestUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	updatedRule, err := h.leaveRequestUseCase.UpdateStaffingRule(c.Request.Context(), uint(id), req.ToDomain(userID, currentEmployee.ID))
+	if err != nil {
+		if errors.Is(err, domain.ErrStaffingRuleNotFound) {
+			response.NotFound(c, "Staffing rule not found", err)
+		} else if errors.Is(err, domain.ErrInvalidStaffingRule) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Staffing rule updated successfully", updatedRule)
+}
+
+func (h *LeaveRequestHandler) DeleteStaffingRule(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid staffing rule ID format", err)
+		return
+	}
+
+	if err := h.leaveRequestUseCase.DeleteStaffingRule(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrStaffingRuleNotFound) {
+			response.NotFound(c, "Staffing rule not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Staffing rule deleted successfully", nil)
+}
+
+func (h *LeaveRequestHandler) UploadCertificate(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid leave request ID format", err)
+		return
+	}
+
+	var req leaveRequestDTO.UploadCertificateDTO
+	if err := c.ShouldBind(&req); err != nil {
+		response.BadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	employee, err := h.leaveRequestUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	updatedLeaveRequest, err := h.leaveRequestUseCase.UploadCertificate(c.Request.Context(), uint(id), employee.ID, req.CertificateFile)
+	if err != nil {
+		if errors.Is(err, domain.ErrLeaveRequestNotFound) {
+			response.NotFound(c, "Leave request not found", err)
+		} else if errors.Is(err, domain.ErrCertificateNotExpected) || errors.Is(err, domain.ErrCertificateDeadlinePast) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Medical certificate uploaded successfully", updatedLeaveRequest)
+}
+
+func (h *LeaveRequestHandler) GetLeavePolicy(c *gin.Context) {
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	policy, err := h.leaveRequestUseCase.GetLeavePolicy(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Leave policy retrieved successfully", policy)
+}
+
+func (h *LeaveRequestHandler) UpdateLeavePolicy(c *gin.Context) {
+	var req leaveRequestDTO.LeavePolicyRequestDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	policy, err := h.leaveRequestUseCase.UpdateLeavePolicy(c.Request.Context(), req.ToDomain(userID))
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Leave policy updated successfully", policy)
+}
+
+func (h *LeaveRequestHandler) GetUnpaidDays(c *gin.Context) {
+	var query leaveRequestDTO.UnpaidDaysQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", query.StartDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid start date format, expected YYYY-MM-DD", err)
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", query.EndDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid end date format, expected YYYY-MM-DD", err)
+		return
+	}
+
+	report, err := h.leaveRequestUseCase.GetUnpaidDays(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPeriod) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Unpaid days retrieved successfully", report)
+}
+
+func (h *LeaveRequestHandler) ListLongTermAbsences(c *gin.Context) {
+	absences, err := h.leaveRequestUseCase.ListLongTermAbsences(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Long-term absences retrieved successfully", absences)
+}
+
+func (h *LeaveRequestHandler) SetLongTermAbsence(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employee_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var req leaveRequestDTO.LongTermAbsenceRequestDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	absence, err := req.ToDomain(uint(employeeID))
+	if err != nil {
+		response.BadRequest(c, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.leaveRequestUseCase.SetLongTermAbsence(c.Request.Context(), absence)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmployeeNotFound) {
+			response.NotFound(c, "Employee not found", err)
+		} else if errors.Is(err, domain.ErrInvalidLongTermAbsence) {
+			response.BadRequest(c, err.Error(), err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Long-term absence saved successfully", result)
+}
+
+func (h *LeaveRequestHandler) ClearLongTermAbsence(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employee_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	if err := h.leaveRequestUseCase.ClearLongTermAbsence(c.Request.Context(), uint(employeeID)); err != nil {
+		if errors.Is(err, domain.ErrEmployeeNotFound) {
+			response.NotFound(c, "Employee not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Long-term absence cleared successfully", nil)
+}
+
+func (h *LeaveRequestHandler) ListLeaveEncashments(c *gin.Context) {
+	var query leaveRequestDTO.LeaveEncashmentQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if query.EmployeeID != nil {
+		filters["employee_id"] = *query.EmployeeID
+	}
+	if query.Year != nil {
+		filters["year"] = *query.Year
+	}
+	if query.Reason != nil {
+		filters["reason"] = *query.Reason
+	}
+	if query.Status != nil {
+		filters["status"] = *query.Status
+	}
+	if restrictToTeam(c, filters, h.leaveRequestUseCase.GetEmployeeByUserID) {
+		return
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+
+	if paginationParams.Page <= 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize <= 0 {
+		paginationParams.PageSize = 10
+	}
+
+	encashments, err := h.leaveRequestUseCase.ListEncashments(c.Request.Context(), filters, paginationParams)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Leave encashments retrieved successfully", encashments)
+}
+
+func (h *LeaveRequestHandler) GetLeaveEncashment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid leave encashment ID format", err)
+		return
+	}
+
+	// Like the list, non-admins only see the encashments of their direct reports.
+	filters := make(map[string]interface{})
+	if restrictToTeam(c, filters, h.leaveRequestUseCase.GetEmployeeByUserID) {
+		return
+	}
+	var managerID *uint
+	if teamManagerID, ok := filters["manager_id"].(uint); ok {
+		managerID = &teamManagerID
+	}
+
+	encashment, err := h.leaveRequestUseCase.GetEncashmentByID(c.Request.Context(), uint(id), managerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrLeaveEncashmentNotFound) {
+			response.NotFound(c, "Leave encashment not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Leave encashment retrieved successfully", encashment)
+}
+
+func (h *LeaveRequestHandler) ReviewLeaveEncashment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid leave encashment ID format", err)
+		return
+	}
+
+	var req leaveRequestDTO.ReviewLeaveEncashmentDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	encashment, err := h.leaveRequestUseCase.ReviewEncashment(c.Request.Context(), uint(id), userID, req.EncashedDays, req.DailyRate, req.AdminNote)
+	if err != nil {
+		if errors.Is(err, domain.ErrLeaveEncashmentNotFound) {
+			response.NotFound(c, "Leave encashment not found", err)
+		} else if errors.Is(err, domain.ErrEncashmentNotDraft) {
+			response.Conflict(c, "Only draft leave encashments can be reviewed", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Leave encashment updated successfully", encashment)
+}
+
+func (h *LeaveRequestHandler) UpdateLeaveEncashmentStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid leave encashment ID format", err)
+		return
+	}
+
+	var req leaveRequestDTO.UpdateLeaveEncashmentStatusDTO
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	encashment, err := h.leaveRequestUseCase.UpdateEncashmentStatus(c.Request.Context(), uint(id), userID, req.Status, req.AdminNote)
+	if err != nil {
+		if errors.Is(err, domain.ErrLeaveEncashmentNotFound) {
+			response.NotFound(c, "Leave encashment not found", err)
+		} else if errors.Is(err, domain.ErrEncashmentNotDraft) {
+			response.Conflict(c, "Only draft leave encashments can be finalized or cancelled", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Leave encashment status updated successfully", encashment)
+}
+
+// previewBalance returns the balance the employee would have once the leave request is approved.
+// It is taken before the request is created, so the preview shows the balance the request was
+// submitted against. A failed preview is logged instead of failing the request.
+func (h *LeaveRequestHandler) previewBalance(c *gin.Context, leaveRequest *domain.LeaveRequest) *domainLeaveRequestDTO.LeaveBalancePreviewDTO {
+	preview, err := h.leaveRequestUseCase.PreviewLeaveBalance(c.Request.Context(), leaveRequest)
+	if err != nil {
+		log.Printf("Warning: failed to preview leave balance for employee ID %d: %v", leaveRequest.EmployeeID, err)
+		return nil
+	}
+	return preview
+}
+
+func (h *LeaveRequestHandler) GetMyLeaveBalanceProjection(c *gin.Context) {
+	var query leaveRequestDTO.LeaveBalanceProjectionQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return
+	}
+
+	employee, err := h.leaveRequestUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmployeeNotFound) {
+			response.NotFound(c, "Employee not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	projection, err := h.leaveRequestUseCase.ProjectLeaveBalance(c.Request.Context(), employee.ID, query.Months, query.IncludePending)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Leave balance projection retrieved successfully", projection)
+}
+
+func (h *LeaveRequestHandler) GetLeaveBalanceProjection(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employee_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var query leaveRequestDTO.LeaveBalanceProjectionQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	projection, err := h.leaveRequestUseCase.ProjectLeaveBalance(c.Request.Context(), uint(employeeID), query.Months, query.IncludePending)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Leave balance projection retrieved successfully", projection)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv" // Added for Atoi conversion
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
+	domainLocationDTO "github.com/SukaMajuu/hris/apps/backend/domain/dto/location"
 	locationDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/check-clock/location"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/location"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
@@ -63,6 +64,10 @@ func (h *LocationHandler) ListLocations(c *gin.Context) {
 	if bindAndValidateQuery(c, &queryDTO) {
 		return
 	}
+	listQuery, invalid := bindListQuery(c, domain.LocationListSchema, domainLocationDTO.LocationResponseDTO{})
+	if invalid {
+		return
+	}
 
 	// Get userID from context (set by auth middleware)
 	userIDCtx, exists := c.Get("userID")
@@ -79,6 +84,7 @@ func (h *LocationHandler) ListLocations(c *gin.Context) {
 	paginationParams := domain.PaginationParams{
 		Page:     queryDTO.Page,
 		PageSize: queryDTO.PageSize,
+		Query:    listQuery,
 	}
 
 	if paginationParams.Page == 0 {
@@ -94,7 +100,7 @@ func (h *LocationHandler) ListLocations(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, "Successfully retrieved locations", locationsData)
+	respondList(c, "Successfully retrieved locations", locationsData, listQuery)
 }
 
 func (h *LocationHandler) GetLocationByID(c *gin.Context) {
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleOffboardingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrOffboardingNotFound):
+		response.NotFound(c, "Employee has no offboarding", err)
+	case errors.Is(err, domain.ErrOffboardingTaskNotFound):
+		response.NotFound(c, "Offboarding task not found", err)
+	case errors.Is(err, domain.ErrOffboardingExists):
+		response.Conflict(c, "Employee already has a scheduled offboarding", err)
+	case errors.Is(err, domain.ErrOffboardingNotScheduled):
+		response.Conflict(c, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidOffboarding):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *EmployeeHandler) StartOffboarding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.StartOffboardingRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	offboarding, err := employeeDTO.MapStartOffboardingDTOToDomain(uint(id), userID, &reqDTO)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.employeeUseCase.StartOffboarding(c.Request.Context(), offboarding)
+	if err != nil {
+		handleOffboardingError(c, err)
+		return
+	}
+
+	response.Created(c, "Offboarding scheduled successfully", result)
+}
+
+func (h *EmployeeHandler) GetOffboarding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	result, err := h.employeeUseCase.GetOffboarding(c.Request.Context(), uint(id))
+	if err != nil {
+		handleOffboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Offboarding retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) CancelOffboarding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	result, err := h.employeeUseCase.CancelOffboarding(c.Request.Context(), uint(id))
+	if err != nil {
+		handleOffboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Offboarding cancelled successfully", result)
+}
+
+func (h *EmployeeHandler) UpdateOffboardingTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid task ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.UpdateOffboardingTaskRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.employeeUseCase.UpdateOffboardingTask(c.Request.Context(), uint(id), uint(taskID), *reqDTO.Completed, userID)
+	if err != nil {
+		handleOffboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Offboarding task updated successfully", task)
+}
+
+func (h *EmployeeHandler) GetFinalSettlement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	settlement, err := h.employeeUseCase.GetFinalSettlement(c.Request.Context(), uint(id))
+	if err != nil {
+		handleOffboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Final settlement retrieved successfully", settlement)
+}
+
+func (h *EmployeeHandler) GetExitReasonReport(c *gin.Context) {
+	var query employeeDTO.ExitReasonReportRequestQuery
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", query.StartDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid start date format, expected YYYY-MM-DD", err)
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", query.EndDate)
+	if err != nil {
+		response.BadRequest(c, "Invalid end date format, expected YYYY-MM-DD", err)
+		return
+	}
+	if startDate.After(endDate) {
+		response.BadRequest(c, "Start date cannot be after end date", errors.New("invalid date range"))
+		return
+	}
+
+	report, err := h.employeeUseCase.GetExitReasonReport(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Exit reason report retrieved successfully", report)
+}
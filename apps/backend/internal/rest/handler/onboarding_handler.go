@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	onboardingDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/onboarding"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/onboarding"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type OnboardingHandler struct {
+	onboardingUseCase *onboarding.OnboardingUseCase
+}
+
+func NewOnboardingHandler(onboardingUseCase *onboarding.OnboardingUseCase) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingUseCase: onboardingUseCase,
+	}
+}
+
+func handleOnboardingError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrEmployeeNotFound) {
+		response.NotFound(c, "Employee not found", err)
+	} else if errors.Is(err, domain.ErrOnboardingTemplateNotFound) {
+		response.NotFound(c, "Onboarding template not found", err)
+	} else if errors.Is(err, domain.ErrOnboardingTaskNotFound) {
+		response.NotFound(c, "Onboarding task not found", err)
+	} else if errors.Is(err, domain.ErrOnboardingTaskDenied) {
+		response.Forbidden(c, err.Error(), err)
+	} else if errors.Is(err, domain.ErrOnboardingTemplateExists) {
+		response.Conflict(c, err.Error(), err)
+	} else if errors.Is(err, domain.ErrInvalidOnboardingTemplate) {
+		response.BadRequest(c, err.Error(), err)
+	} else {
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *OnboardingHandler) CreateTemplate(c *gin.Context) {
+	var req onboardingDTO.TemplateRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.onboardingUseCase.CreateTemplate(c.Request.Context(), req.ToDomain(userID))
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.Created(c, "Onboarding template created successfully", template)
+}
+
+func (h *OnboardingHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.onboardingUseCase.ListTemplates(c.Request.Context())
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding templates retrieved successfully", templates)
+}
+
+func (h *OnboardingHandler) GetTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid onboarding template ID format", err)
+		return
+	}
+
+	template, err := h.onboardingUseCase.GetTemplate(c.Request.Context(), uint(id))
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding template retrieved successfully", template)
+}
+
+func (h *OnboardingHandler) UpdateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid onboarding template ID format", err)
+		return
+	}
+
+	var req onboardingDTO.TemplateRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	template := req.ToDomain(userID)
+	template.ID = uint(id)
+
+	updated, err := h.onboardingUseCase.UpdateTemplate(c.Request.Context(), template)
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding template updated successfully", updated)
+}
+
+func (h *OnboardingHandler) DeleteTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid onboarding template ID format", err)
+		return
+	}
+
+	if err := h.onboardingUseCase.DeleteTemplate(c.Request.Context(), uint(id)); err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding template deleted successfully", nil)
+}
+
+func (h *OnboardingHandler) GetChecklist(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	checklist, err := h.onboardingUseCase.GetChecklist(c.Request.Context(), uint(id))
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding checklist retrieved successfully", checklist)
+}
+
+func (h *OnboardingHandler) ListMyTasks(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.onboardingUseCase.ListMyTasks(c.Request.Context(), userID)
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding tasks retrieved successfully", tasks)
+}
+
+func (h *OnboardingHandler) UpdateTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid task ID format", err)
+		return
+	}
+
+	var req onboardingDTO.UpdateTaskRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.onboardingUseCase.UpdateTask(c.Request.Context(), uint(id), uint(taskID), *req.Completed, userID)
+	if err != nil {
+		handleOnboardingError(c, err)
+		return
+	}
+
+	response.OK(c, "Onboarding task updated successfully", task)
+}
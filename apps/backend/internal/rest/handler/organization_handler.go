@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	branchDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/branch"
+	departmentDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/department"
+	positionDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/position"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/organization"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	organizationUseCase *organization.OrganizationUseCase
+}
+
+func NewOrganizationHandler(organizationUseCase *organization.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+func currentUserID(c *gin.Context) (uint, bool) {
+	userIDCtx, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User ID not found in context", errors.New("missing userID in context"))
+		return 0, false
+	}
+	userID, ok := userIDCtx.(uint)
+	if !ok {
+		response.InternalServerError(c, errors.New("invalid user ID type in context"))
+		return 0, false
+	}
+	return userID, true
+}
+
+// --- Departments ---
+
+func (h *OrganizationHandler) CreateDepartment(c *gin.Context) {
+	var req departmentDTO.CreateDepartmentRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	department, err := h.organizationUseCase.CreateDepartment(c.Request.Context(), &domain.Department{
+		Name:      req.Name,
+		ParentID:  req.ParentID,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrDepartmentNotFound) {
+			response.BadRequest(c, "Parent department not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, "Department created successfully", department)
+}
+
+func (h *OrganizationHandler) ListDepartments(c *gin.Context) {
+	departments, err := h.organizationUseCase.ListDepartments(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Departments retrieved successfully", departments)
+}
+
+func (h *OrganizationHandler) GetDepartmentByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid department ID format", err)
+		return
+	}
+
+	department, err := h.organizationUseCase.GetDepartmentByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrDepartmentNotFound) {
+			response.NotFound(c, "Department not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Department retrieved successfully", department)
+}
+
+func (h *OrganizationHandler) UpdateDepartment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid department ID format", err)
+		return
+	}
+
+	var req departmentDTO.UpdateDepartmentRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	department, err := h.organizationUseCase.UpdateDepartment(c.Request.Context(), &domain.Department{
+		ID:       uint(id),
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrDepartmentCycle) {
+			response.BadRequest(c, "A department cannot be moved below itself or one of its sub-departments", err)
+		} else if errors.Is(err, domain.ErrDepartmentNotFound) {
+			response.NotFound(c, "Department not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Department updated successfully", department)
+}
+
+func (h *OrganizationHandler) DeleteDepartment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid department ID format", err)
+		return
+	}
+
+	if err := h.organizationUseCase.DeleteDepartment(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrDepartmentInUse) {
+			response.Conflict(c, "Department still has sub-departments or active employees", err)
+		} else if errors.Is(err, domain.ErrDepartmentNotFound) {
+			response.NotFound(c, "Department not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Department deleted successfully", nil)
+}
+
+func (h *OrganizationHandler) GetOrgChart(c *gin.Context) {
+	chart, err := h.organizationUseCase.GetOrgChart(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Org chart retrieved successfully", chart)
+}
+
+// --- Branches ---
+
+func (h *OrganizationHandler) CreateBranch(c *gin.Context) {
+	var req branchDTO.CreateBranchRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	branch, err := h.organizationUseCase.CreateBranch(c.Request.Context(), &domain.Branch{
+		Name:      req.Name,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.Created(c, "Branch created successfully", branch)
+}
+
+func (h *OrganizationHandler) ListBranches(c *gin.Context) {
+	branches, err := h.organizationUseCase.ListBranches(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Branches retrieved successfully", branches)
+}
+
+func (h *OrganizationHandler) UpdateBranch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid branch ID format", err)
+		return
+	}
+
+	var req branchDTO.UpdateBranchRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	branch, err := h.organizationUseCase.UpdateBranch(c.Request.Context(), &domain.Branch{
+		ID:   uint(id),
+		Name: req.Name,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrBranchNotFound) {
+			response.NotFound(c, "Branch not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Branch updated successfully", branch)
+}
+
+func (h *OrganizationHandler) DeleteBranch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid branch ID format", err)
+		return
+	}
+
+	if err := h.organizationUseCase.DeleteBranch(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrBranchInUse) {
+			response.Conflict(c, "Branch is still assigned to active employees", err)
+		} else if errors.Is(err, domain.ErrBranchNotFound) {
+			response.NotFound(c, "Branch not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Branch deleted successfully", nil)
+}
+
+// --- Positions ---
+
+func (h *OrganizationHandler) CreatePosition(c *gin.Context) {
+	var req positionDTO.CreatePositionRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	position, err := h.organizationUseCase.CreatePosition(c.Request.Context(), &domain.Position{
+		Name:      req.Name,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.Created(c, "Position created successfully", position)
+}
+
+func (h *OrganizationHandler) ListPositions(c *gin.Context) {
+	positions, err := h.organizationUseCase.ListPositions(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Positions retrieved successfully", positions)
+}
+
+func (h *OrganizationHandler) UpdatePosition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid position ID format", err)
+		return
+	}
+
+	var req positionDTO.UpdatePositionRequest
+	if bindAndValidate(c, &req) {
+		return
+	}
+
+	position, err := h.organizationUseCase.UpdatePosition(c.Request.Context(), &domain.Position{
+		ID:   uint(id),
+		Name: req.Name,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrPositionNotFound) {
+			response.NotFound(c, "Position not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Position updated successfully", position)
+}
+
+func (h *OrganizationHandler) DeletePosition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid position ID format", err)
+		return
+	}
+
+	if err := h.organizationUseCase.DeletePosition(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrPositionInUse) {
+			response.Conflict(c, "Position is still assigned to active employees", err)
+		} else if errors.Is(err, domain.ErrPositionNotFound) {
+			response.NotFound(c, "Position not found", err)
+		} else {
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "Position deleted successfully", nil)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func handleProbationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrProbationNotFound):
+		response.NotFound(c, "Employee has no probation", err)
+	case errors.Is(err, domain.ErrProbationSelfReview),
+		errors.Is(err, domain.ErrProbationReviewDenied):
+		response.Forbidden(c, err.Error(), err)
+	case errors.Is(err, domain.ErrProbationNotInProgress):
+		response.Conflict(c, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidProbation):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		handleOffboardingError(c, err)
+	}
+}
+
+func (h *EmployeeHandler) GetProbation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	result, err := h.employeeUseCase.GetProbation(c.Request.Context(), uint(id))
+	if err != nil {
+		handleProbationError(c, err)
+		return
+	}
+
+	response.OK(c, "Probation retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) UpdateProbation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.UpdateProbationRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	startDate, endDate, err := reqDTO.Dates()
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.employeeUseCase.UpdateProbation(c.Request.Context(), uint(id), startDate, endDate)
+	if err != nil {
+		handleProbationError(c, err)
+		return
+	}
+
+	response.OK(c, "Probation updated successfully", result)
+}
+
+func (h *EmployeeHandler) ReviewProbation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid employee ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.ReviewProbationRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	review, lastWorkingDay, err := employeeDTO.MapReviewProbationDTOToDomain(userID, &reqDTO)
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	result, err := h.employeeUseCase.ReviewProbation(c.Request.Context(), uint(id), review, lastWorkingDay)
+	if err != nil {
+		handleProbationError(c, err)
+		return
+	}
+
+	response.Created(c, "Probation review recorded successfully", result)
+}
+
+func (h *EmployeeHandler) GetProbationReport(c *gin.Context) {
+	report, err := h.employeeUseCase.GetProbationReport(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+
+	response.OK(c, "Probation report retrieved successfully", report)
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	employeeDTO "github.com/SukaMajuu/hris/apps/backend/internal/rest/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+var allowedProfileChangeDocumentMimeTypes = []string{
+	"application/pdf",
+	"image/jpeg",
+	"image/png",
+}
+
+func handleProfileChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrEmployeeNotFound):
+		response.NotFound(c, "Employee not found", err)
+	case errors.Is(err, domain.ErrProfileChangeNotFound):
+		response.NotFound(c, "Profile change request not found", err)
+	case errors.Is(err, domain.ErrCompanyNotFound):
+		response.NotFound(c, "Company not found", err)
+	case errors.Is(err, domain.ErrProfileChangeSelfReview):
+		response.Forbidden(c, err.Error(), err)
+	case errors.Is(err, domain.ErrProfileChangeNotPending),
+		errors.Is(err, domain.ErrProfileChangeAlreadyPending):
+		response.Conflict(c, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidProfileChange),
+		errors.Is(err, domain.ErrTaxDependantMismatch):
+		response.BadRequest(c, err.Error(), err)
+	default:
+		response.InternalServerError(c, err)
+	}
+}
+
+func (h *EmployeeHandler) GetProfileChangePolicy(c *gin.Context) {
+	policy, err := h.employeeUseCase.GetProfileChangePolicy(c.Request.Context())
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change policy retrieved successfully", policy)
+}
+
+func (h *EmployeeHandler) UpdateProfileChangePolicy(c *gin.Context) {
+	var reqDTO employeeDTO.ProfileChangePolicyRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	fields, err := reqDTO.ToDomain()
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	policy, err := h.employeeUseCase.UpdateProfileChangePolicy(c.Request.Context(), fields)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change policy updated successfully", policy)
+}
+
+func (h *EmployeeHandler) ListProfileChanges(c *gin.Context) {
+	var query employeeDTO.ProfileChangeQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+	cursor, invalid := bindCursor(c, nil)
+	if invalid {
+		return
+	}
+
+	filters := make(map[string]interface{})
+	if query.EmployeeID != nil {
+		filters["employee_id"] = *query.EmployeeID
+	}
+	if query.Status != nil {
+		filters["status"] = *query.Status
+	}
+	// Only admins see the requests of the whole company; everyone else sees their own.
+	if !isAdmin(c) {
+		currentEmployee, ok := h.currentEmployee(c)
+		if !ok {
+			return
+		}
+		filters["employee_id"] = currentEmployee.ID
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   cursor,
+	}
+	if paginationParams.Page <= 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize <= 0 {
+		paginationParams.PageSize = 10
+	}
+
+	result, err := h.employeeUseCase.ListProfileChanges(c.Request.Context(), filters, paginationParams)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change requests retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) GetProfileChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid profile change request ID format", err)
+		return
+	}
+
+	result, err := h.employeeUseCase.GetProfileChange(c.Request.Context(), uint(id))
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+	if !isAdmin(c) {
+		currentEmployee, ok := h.currentEmployee(c)
+		if !ok {
+			return
+		}
+		if result.EmployeeID != currentEmployee.ID {
+			handleProfileChangeError(c, domain.ErrProfileChangeNotFound)
+			return
+		}
+	}
+
+	response.OK(c, "Profile change request retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) ReviewProfileChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid profile change request ID format", err)
+		return
+	}
+
+	var reqDTO employeeDTO.ReviewProfileChangeRequestDTO
+	if bindAndValidate(c, &reqDTO) {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.employeeUseCase.ReviewProfileChange(c.Request.Context(), uint(id), userID, reqDTO.Action == "approve", reqDTO.Note)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change request reviewed successfully", result)
+}
+
+func (h *EmployeeHandler) ListMyProfileChanges(c *gin.Context) {
+	var query employeeDTO.ProfileChangeQueryDTO
+	if bindAndValidateQuery(c, &query) {
+		return
+	}
+	cursor, invalid := bindCursor(c, nil)
+	if invalid {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   cursor,
+	}
+	if paginationParams.Page <= 0 {
+		paginationParams.Page = 1
+	}
+	if paginationParams.PageSize <= 0 {
+		paginationParams.PageSize = 10
+	}
+
+	result, err := h.employeeUseCase.ListMyProfileChanges(c.Request.Context(), currentEmployee.ID, paginationParams)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change requests retrieved successfully", result)
+}
+
+func (h *EmployeeHandler) SubmitProfileChange(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var reqDTO employeeDTO.SubmitProfileChangeRequestDTO
+	if err := c.ShouldBind(&reqDTO); err != nil {
+		response.BadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if reqDTO.Document != nil {
+		mimeType := reqDTO.Document.Header.Get("Content-Type")
+		allowed := false
+		for _, allowedType := range allowedProfileChangeDocumentMimeTypes {
+			if strings.EqualFold(mimeType, allowedType) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			response.BadRequest(c, "Invalid document type. Only PDF, JPEG and PNG are allowed", nil)
+			return
+		}
+	}
+
+	changes, err := reqDTO.ToDomain()
+	if err != nil {
+		response.BadRequest(c, err.Error(), err)
+		return
+	}
+
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	result, err := h.employeeUseCase.SubmitProfileChange(c.Request.Context(), currentEmployee.ID, changes, reqDTO.Reason, reqDTO.Document)
+	if err != nil {
+		log.Printf("EmployeeHandler: Error submitting profile change for EmployeeID %d: %v", currentEmployee.ID, err)
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.Created(c, "Profile change request submitted successfully", result)
+}
+
+func (h *EmployeeHandler) CancelProfileChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("change_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "Invalid profile change request ID format", err)
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	result, err := h.employeeUseCase.CancelProfileChange(c.Request.Context(), uint(id), currentEmployee.ID)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return
+	}
+
+	response.OK(c, "Profile change request cancelled successfully", result)
+}
+
+// currentEmployee returns the employee record of the authenticated user. It responds with an error
+// and returns false when it cannot be found.
+func (h *EmployeeHandler) currentEmployee(c *gin.Context) (*domain.Employee, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, false
+	}
+	currentEmployee, err := h.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+	if err != nil {
+		handleProfileChangeError(c, err)
+		return nil, false
+	}
+	return currentEmployee, true
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/auth"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"github.com/gin-gonic/gin"
 )
 
@@ -43,8 +45,9 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		// Check if user is an employee and if they have resigned
-		employee, err := m.employeeUseCase.GetEmployeeByUserID(c.Request.Context(), userID)
+		// Check if user is an employee and if they have resigned. The company of the user is not
+		// known yet, so the lookup runs across companies.
+		employee, err := m.employeeUseCase.GetEmployeeByUserID(tenant.AcrossCompanies(c.Request.Context()), userID)
 		if err == nil && !employee.EmploymentStatus {
 			// Employee exists and has resigned
 			c.JSON(http.StatusForbidden, gin.H{
@@ -53,11 +56,45 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		// If error occurred (employee not found), continue - allows admin users to access
+
+		// Scope every repository query of this request to the user's company. Users without a
+		// company are turned away rather than given unscoped access.
+		if err != nil || employee.CompanyID == nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Access denied. Your account does not belong to a company.",
+			})
+			c.Abort()
+			return
+		}
+		companyID := *employee.CompanyID
+		c.Request = c.Request.WithContext(tenant.WithCompanyID(c.Request.Context(), companyID))
 
 		c.Set("userID", userID)
 		c.Set("userRole", role)
+		c.Set("companyID", companyID)
+
+		c.Next()
+	}
+}
 
+// RequireAdmin lets through only the admins of their company. It has to run after Authenticate.
+func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if role, _ := c.Get("userRole"); role != enums.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied. Only company admins can do this."})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AcrossCompanies marks the requests of routes that are not made on behalf of a single company,
+// such as signing in, webhooks and cron jobs, so their queries are not scoped to one.
+func AcrossCompanies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.AcrossCompanies(c.Request.Context()))
 		c.Next()
 	}
 }
@@ -5,10 +5,14 @@ import (
 	"github.com/SukaMajuu/hris/apps/backend/internal/rest/middleware"
 	attendance "github.com/SukaMajuu/hris/apps/backend/internal/usecase/attendance"
 	auth "github.com/SukaMajuu/hris/apps/backend/internal/usecase/auth"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/contract"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/custom_field"
 	document "github.com/SukaMajuu/hris/apps/backend/internal/usecase/document"
 	employee "github.com/SukaMajuu/hris/apps/backend/internal/usecase/employee"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/leave_request"
 	location "github.com/SukaMajuu/hris/apps/backend/internal/usecase/location"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/onboarding"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/organization"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/subscription"
 	work_Schedule "github.com/SukaMajuu/hris/apps/backend/internal/usecase/work_schedule"
 
@@ -27,6 +31,10 @@ type Router struct {
 	leaveRequestHandler *handler.LeaveRequestHandler
 	attendanceHandler   *handler.AttendanceHandler
 	cronHandler         *handler.CronHandler
+	organizationHandler *handler.OrganizationHandler
+	contractHandler     *handler.ContractHandler
+	onboardingHandler   *handler.OnboardingHandler
+	customFieldHandler  *handler.CustomFieldHandler
 }
 
 func NewRouter(
@@ -37,6 +45,10 @@ func NewRouter(
 	leaveRequestUC *leave_request.LeaveRequestUseCase,
 	workScheduleUC *work_Schedule.WorkScheduleUseCase,
 	documentUC *document.DocumentUseCase,
+	organizationUC *organization.OrganizationUseCase,
+	contractUC *contract.ContractUseCase,
+	onboardingUC *onboarding.OnboardingUseCase,
+	customFieldUC *custom_field.CustomFieldUseCase,
 	subscriptionUC *subscription.SubscriptionUseCase,
 	midtransSubscriptionUC *subscription.MidtransSubscriptionUseCase,
 ) *Router {
@@ -48,7 +60,11 @@ func NewRouter(
 	locationHandler := handler.NewLocationHandler(locationUC)
 	documentHandler := handler.NewDocumentHandler(documentUC)
 	subscriptionHandler := handler.NewSubscriptionHandlerWithMidtrans(subscriptionUC, midtransSubscriptionUC)
-	cronHandler := handler.NewCronHandler(subscriptionUC, attendanceUC)
+	cronHandler := handler.NewCronHandler(subscriptionUC, attendanceUC, leaveRequestUC, contractUC, employeeUC, onboardingUC)
+	organizationHandler := handler.NewOrganizationHandler(organizationUC)
+	contractHandler := handler.NewContractHandler(contractUC)
+	onboardingHandler := handler.NewOnboardingHandler(onboardingUC)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldUC)
 
 	return &Router{
 		authHandler:         authHandler,
@@ -61,6 +77,10 @@ func NewRouter(
 		leaveRequestHandler: leaveRequestHandler,
 		attendanceHandler:   attendanceHandler,
 		cronHandler:         cronHandler,
+		organizationHandler: organizationHandler,
+		contractHandler:     contractHandler,
+		onboardingHandler:   onboardingHandler,
+		customFieldHandler:  customFieldHandler,
 	}
 }
 
@@ -80,6 +100,7 @@ func (r *Router) Setup() *gin.Engine {
 	v1 := router.Group("/v1")
 	{
 		auth := v1.Group("/auth")
+		auth.Use(middleware.AcrossCompanies())
 		{
 			auth.POST("/register", r.authHandler.Register)
 			auth.POST("/login", r.authHandler.Login)
@@ -105,19 +126,134 @@ func (r *Router) Setup() *gin.Engine {
 				employee.GET("", r.employeeHandler.ListEmployees)
 				employee.GET("/statistics", r.employeeHandler.GetEmployeeStatistics)
 				employee.GET("/hire-date-range", r.employeeHandler.GetHireDateRange)
+				employee.GET("/reports/tenure", r.employeeHandler.GetTenureReport)
+				employee.GET("/reports/turnover", r.employeeHandler.GetTurnoverReport)
+				employee.GET("/reports/exit-reasons", r.employeeHandler.GetExitReasonReport)
+				employee.GET("/reports/probation", r.employeeHandler.GetProbationReport)
+				employee.GET("/search", r.employeeHandler.SearchEmployees)
+				employee.GET("/export", r.authMiddleware.RequireAdmin(), r.employeeHandler.ExportEmployees)
+				employee.GET("/export/columns", r.authMiddleware.RequireAdmin(), r.employeeHandler.ListExportColumns)
 				employee.GET("/validate-unique", r.employeeHandler.ValidateUniqueField)
 				employee.GET("/me", r.employeeHandler.GetCurrentUserProfile)
 				employee.PATCH("/me", r.employeeHandler.UpdateCurrentUserProfile)
+				employee.GET("/me/direct-reports", r.employeeHandler.ListMyDirectReports)
+				employee.GET("/me/reporting-line", r.employeeHandler.ListMyReportingLine)
+				employee.GET("/me/profile-changes", r.employeeHandler.ListMyProfileChanges)
+				employee.POST("/me/profile-changes", r.employeeHandler.SubmitProfileChange)
+				employee.POST("/me/profile-changes/:change_id/cancel", r.employeeHandler.CancelProfileChange)
+				employee.GET("/me/family", r.employeeHandler.GetFamily)
+				employee.GET("/me/emergency-contacts", r.employeeHandler.GetEmergencyContacts)
+				employee.PUT("/me/emergency-contacts", r.employeeHandler.ReplaceEmergencyContacts)
+				employee.GET("/me/events", r.employeeHandler.ListTeamEvents)
+				employee.PUT("/me/event-preferences", r.employeeHandler.UpdateMyEventPreferences)
+				employee.POST("/reassign-manager", r.employeeHandler.BulkReassignManager)
+				employee.GET("/duplicates", r.authMiddleware.RequireAdmin(), r.employeeHandler.ListEmployeeDuplicates)
+				employee.POST("/duplicates/detect", r.authMiddleware.RequireAdmin(), r.employeeHandler.DetectEmployeeDuplicates)
+				employee.POST("/duplicates/:duplicate_id/dismiss", r.authMiddleware.RequireAdmin(), r.employeeHandler.DismissEmployeeDuplicate)
+				employee.POST("/duplicates/:duplicate_id/merge", r.authMiddleware.RequireAdmin(), r.employeeHandler.MergeEmployeeDuplicate)
 				employee.GET("/:id", r.employeeHandler.GetEmployeeByID)
 				employee.POST("", r.employeeHandler.CreateEmployee)
 				employee.POST("/bulk-import", r.employeeHandler.BulkImportEmployees)
+				employee.POST("/bulk-import/error-report", r.employeeHandler.BulkImportErrorReport)
+				employee.GET("/bulk-import/template", r.employeeHandler.DownloadImportTemplate)
+				employee.GET("/bulk-import/fields", r.employeeHandler.ListImportFields)
+				employee.POST("/bulk-import/mappings/detect", r.employeeHandler.DetectImportMapping)
+				employee.GET("/bulk-import/mappings", r.employeeHandler.ListImportMappingProfiles)
+				employee.POST("/bulk-import/mappings", r.employeeHandler.CreateImportMappingProfile)
+				employee.GET("/bulk-import/mappings/:mapping_id", r.employeeHandler.GetImportMappingProfile)
+				employee.PUT("/bulk-import/mappings/:mapping_id", r.employeeHandler.UpdateImportMappingProfile)
+				employee.DELETE("/bulk-import/mappings/:mapping_id", r.employeeHandler.DeleteImportMappingProfile)
+				employee.GET("/import-jobs", r.employeeHandler.ListImportJobs)
+				employee.POST("/import-jobs", r.employeeHandler.SubmitImportJob)
+				employee.GET("/import-jobs/:job_id", r.employeeHandler.GetImportJob)
+				employee.GET("/import-jobs/:job_id/results", r.employeeHandler.DownloadImportJobResults)
 				employee.PATCH("/:id", r.employeeHandler.UpdateEmployee)
+				employee.PUT("/:id/manager", r.employeeHandler.ReassignManager)
+				employee.POST("/:id/employment-events", r.authMiddleware.RequireAdmin(), r.employeeHandler.RecordEmploymentEvent)
+				employee.GET("/:id/employment-events", r.employeeHandler.ListEmploymentHistory)
+				employee.POST("/:id/contracts", r.contractHandler.CreateContract)
+				employee.GET("/:id/contracts", r.contractHandler.ListContracts)
+				employee.POST("/:id/contracts/renew", r.contractHandler.RenewContract)
+				employee.POST("/:id/contracts/convert", r.contractHandler.ConvertToPermanent)
+				employee.POST("/:id/offboarding", r.authMiddleware.RequireAdmin(), r.employeeHandler.StartOffboarding)
+				employee.GET("/:id/offboarding", r.authMiddleware.RequireAdmin(), r.employeeHandler.GetOffboarding)
+				employee.POST("/:id/offboarding/cancel", r.authMiddleware.RequireAdmin(), r.employeeHandler.CancelOffboarding)
+				employee.PATCH("/:id/offboarding/tasks/:task_id", r.authMiddleware.RequireAdmin(), r.employeeHandler.UpdateOffboardingTask)
+				employee.GET("/:id/offboarding/final-settlement", r.authMiddleware.RequireAdmin(), r.employeeHandler.GetFinalSettlement)
+				employee.GET("/:id/onboarding", r.onboardingHandler.GetChecklist)
+				employee.PATCH("/:id/onboarding/tasks/:task_id", r.onboardingHandler.UpdateTask)
+				employee.GET("/:id/probation", r.employeeHandler.GetProbation)
+				employee.PUT("/:id/probation", r.employeeHandler.UpdateProbation)
+				employee.POST("/:id/probation/review", r.employeeHandler.ReviewProbation)
+				employee.GET("/:id/family", r.authMiddleware.RequireAdmin(), r.employeeHandler.GetFamily)
+				employee.PUT("/:id/family", r.authMiddleware.RequireAdmin(), r.employeeHandler.ReplaceFamily)
+				employee.GET("/:id/emergency-contacts", r.authMiddleware.RequireAdmin(), r.employeeHandler.GetEmergencyContacts)
+				employee.PUT("/:id/emergency-contacts", r.authMiddleware.RequireAdmin(), r.employeeHandler.ReplaceEmergencyContacts)
+				employee.GET("/:id/events", r.employeeHandler.ListTeamEvents)
 				employee.PATCH("/:id/status", r.employeeHandler.ResignEmployee) // Employee document routes nested under employee routes
 				employee.POST("/:id/reset-password", r.employeeHandler.ResetEmployeePassword)
 				employee.POST("/:id/documents", r.documentHandler.UploadDocumentForEmployee)
 				employee.GET("/:id/documents", r.documentHandler.GetDocumentsByEmployee)
 			}
 
+			departments := api.Group("/departments")
+			{
+				departments.POST("", r.organizationHandler.CreateDepartment)
+				departments.GET("", r.organizationHandler.ListDepartments)
+				departments.GET("/:id", r.organizationHandler.GetDepartmentByID)
+				departments.PUT("/:id", r.organizationHandler.UpdateDepartment)
+				departments.DELETE("/:id", r.organizationHandler.DeleteDepartment)
+			}
+
+			branches := api.Group("/branches")
+			{
+				branches.POST("", r.organizationHandler.CreateBranch)
+				branches.GET("", r.organizationHandler.ListBranches)
+				branches.PUT("/:id", r.organizationHandler.UpdateBranch)
+				branches.DELETE("/:id", r.organizationHandler.DeleteBranch)
+			}
+
+			positions := api.Group("/positions")
+			{
+				positions.POST("", r.organizationHandler.CreatePosition)
+				positions.GET("", r.organizationHandler.ListPositions)
+				positions.PUT("/:id", r.organizationHandler.UpdatePosition)
+				positions.DELETE("/:id", r.organizationHandler.DeletePosition)
+			}
+
+			profileChanges := api.Group("/profile-changes")
+			{
+				profileChanges.GET("", r.employeeHandler.ListProfileChanges)
+				profileChanges.GET("/policy", r.employeeHandler.GetProfileChangePolicy)
+				profileChanges.PUT("/policy", r.authMiddleware.RequireAdmin(), r.employeeHandler.UpdateProfileChangePolicy)
+				profileChanges.GET("/:id", r.employeeHandler.GetProfileChange)
+				profileChanges.POST("/:id/review", r.authMiddleware.RequireAdmin(), r.employeeHandler.ReviewProfileChange)
+			}
+
+			api.GET("/org-chart", r.organizationHandler.GetOrgChart)
+
+			api.GET("/contracts/upcoming-expiries", r.contractHandler.ListUpcomingExpiries)
+
+			onboardingTemplates := api.Group("/onboarding-templates")
+			{
+				onboardingTemplates.POST("", r.onboardingHandler.CreateTemplate)
+				onboardingTemplates.GET("", r.onboardingHandler.ListTemplates)
+				onboardingTemplates.GET("/:id", r.onboardingHandler.GetTemplate)
+				onboardingTemplates.PUT("/:id", r.onboardingHandler.UpdateTemplate)
+				onboardingTemplates.DELETE("/:id", r.onboardingHandler.DeleteTemplate)
+			}
+
+			api.GET("/onboarding/my-tasks", r.onboardingHandler.ListMyTasks)
+
+			customFields := api.Group("/custom-fields")
+			{
+				customFields.POST("", r.customFieldHandler.CreateCustomField)
+				customFields.GET("", r.customFieldHandler.ListCustomFields)
+				customFields.GET("/:id", r.customFieldHandler.GetCustomField)
+				customFields.PUT("/:id", r.customFieldHandler.UpdateCustomField)
+				customFields.DELETE("/:id", r.customFieldHandler.DeleteCustomField)
+			}
+
 			locations := api.Group("/locations")
 			{
 				locations.POST("", r.locationHandler.CreateLocation)
@@ -165,14 +301,48 @@ func (r *Router) Setup() *gin.Engine {
 				// Employee routes (can access their own leave requests)
 				leaveRequests.POST("", r.leaveRequestHandler.CreateLeaveRequest)
 				leaveRequests.GET("/my", r.leaveRequestHandler.GetMyLeaveRequests)
+				leaveRequests.GET("/my/balance-projection", r.leaveRequestHandler.GetMyLeaveBalanceProjection)
 				leaveRequests.GET("/:id", r.leaveRequestHandler.GetLeaveRequestByID)
 				leaveRequests.PUT("/:id", r.leaveRequestHandler.UpdateLeaveRequest)
 				leaveRequests.DELETE("/:id", r.leaveRequestHandler.DeleteLeaveRequest)
+				leaveRequests.POST("/:id/certificate", r.leaveRequestHandler.UploadCertificate)
 
 				// Admin routes (can access all leave requests and update status)
 				leaveRequests.GET("", r.leaveRequestHandler.ListLeaveRequests)
 				leaveRequests.POST("/admin", r.leaveRequestHandler.CreateLeaveRequestForEmployee)
 				leaveRequests.PATCH("/:id/status", r.leaveRequestHandler.UpdateLeaveRequestStatus)
+				leaveRequests.GET("/unpaid-days", r.authMiddleware.RequireAdmin(), r.leaveRequestHandler.GetUnpaidDays)
+				leaveRequests.GET("/balance-projection/:employee_id", r.leaveRequestHandler.GetLeaveBalanceProjection)
+			}
+
+			longTermAbsences := api.Group("/long-term-absences")
+			longTermAbsences.Use(r.authMiddleware.RequireAdmin())
+			{
+				longTermAbsences.GET("", r.leaveRequestHandler.ListLongTermAbsences)
+				longTermAbsences.PUT("/:employee_id", r.leaveRequestHandler.SetLongTermAbsence)
+				longTermAbsences.DELETE("/:employee_id", r.leaveRequestHandler.ClearLongTermAbsence)
+			}
+
+			leaveEncashments := api.Group("/leave-encashments")
+			{
+				leaveEncashments.GET("", r.leaveRequestHandler.ListLeaveEncashments)
+				leaveEncashments.GET("/:id", r.leaveRequestHandler.GetLeaveEncashment)
+				leaveEncashments.PUT("/:id", r.authMiddleware.RequireAdmin(), r.leaveRequestHandler.ReviewLeaveEncashment)
+				leaveEncashments.PATCH("/:id/status", r.authMiddleware.RequireAdmin(), r.leaveRequestHandler.UpdateLeaveEncashmentStatus)
+			}
+
+			leavePolicy := api.Group("/leave-policy")
+			{
+				leavePolicy.GET("", r.leaveRequestHandler.GetLeavePolicy)
+				leavePolicy.PUT("", r.leaveRequestHandler.UpdateLeavePolicy)
+			}
+
+			staffingRules := api.Group("/leave-staffing-rules")
+			{
+				staffingRules.POST("", r.leaveRequestHandler.CreateStaffingRule)
+				staffingRules.GET("", r.leaveRequestHandler.ListStaffingRules)
+				staffingRules.PUT("/:id", r.leaveRequestHandler.UpdateStaffingRule)
+				staffingRules.DELETE("/:id", r.leaveRequestHandler.DeleteStaffingRule)
 			}
 
 			subscription := api.Group("/subscription")
@@ -201,6 +371,7 @@ func (r *Router) Setup() *gin.Engine {
 		}
 
 		webhooks := v1.Group("/webhooks")
+		webhooks.Use(middleware.AcrossCompanies())
 		{
 			webhooks.POST("/xendit", r.subscriptionHandler.ProcessWebhook)
 			webhooks.POST("/tripay", r.subscriptionHandler.ProcessTripayWebhook)
@@ -211,13 +382,22 @@ func (r *Router) Setup() *gin.Engine {
 
 		// Cron job endpoints (secured with API key)
 		cron := v1.Group("/cron")
-		cron.Use(middleware.CronAPIKeyAuth())
+		cron.Use(middleware.CronAPIKeyAuth(), middleware.AcrossCompanies())
 		{
 			cron.POST("/check-trial-expiry", r.cronHandler.CheckTrialExpiry)
 			cron.POST("/send-trial-warnings", r.cronHandler.SendTrialWarnings)
 			cron.POST("/process-auto-renewals", r.cronHandler.ProcessAutoRenewals)
 			cron.POST("/update-usage-stats", r.cronHandler.UpdateUsageStatistics)
 			cron.POST("/process-daily-absent-check", r.cronHandler.ProcessDailyAbsentCheck)
+			cron.POST("/process-missing-certificates", r.cronHandler.ProcessMissingCertificates)
+			cron.POST("/process-leave-encashment", r.cronHandler.ProcessLeaveEncashment)
+			cron.POST("/process-contract-expiry-reminders", r.cronHandler.ProcessContractExpiryReminders)
+			cron.POST("/process-offboardings", r.cronHandler.ProcessDueOffboardings)
+			cron.POST("/process-employment-events", r.cronHandler.ProcessEmploymentEvents)
+			cron.POST("/process-onboarding-reminders", r.cronHandler.ProcessOnboardingReminders)
+			cron.POST("/process-probation-reminders", r.cronHandler.ProcessProbationReviewReminders)
+			cron.POST("/process-employee-duplicates", r.cronHandler.ProcessEmployeeDuplicates)
+			cron.POST("/process-event-digest", r.cronHandler.ProcessEmployeeEventDigest)
 		}
 	}
 
@@ -39,6 +39,12 @@ func (uc *AttendanceUseCase) ProcessDailyAbsentCheck(ctx context.Context) error
 			continue
 		}
 
+		if employee.IsOnLongTermAbsence(todayParsed) {
+			// Employee is not expected to work during a long-term absence
+			log.Printf("📋 Employee %d is on long-term absence for today", employee.ID)
+			continue
+		}
+
 		// Check if employee has approved leave request for today
 		// NOTE: Only APPROVED leaves prevent absent marking - pending leaves will still result in absent status
 		hasApprovedLeave, err := uc.hasApprovedLeaveForDate(ctx, employee.ID, todayParsed)
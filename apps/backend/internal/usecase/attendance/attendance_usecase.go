@@ -378,22 +378,7 @@ func (uc *AttendanceUseCase) List(ctx context.Context, paginationParams domain.P
 		return nil, fmt.Errorf("failed to list attendances: %w", err)
 	}
 
-	responseDTOs := make([]*responseAttendance.AttendanceResponseDTO, len(attendances))
-	for i, attendance := range attendances {
-		responseDTOs[i] = responseAttendance.NewAttendanceResponseDTO(attendance)
-	}
-
-	return &responseAttendance.AttendanceListResponseData{
-		Items: responseDTOs,
-		Pagination: domain.Pagination{
-			TotalItems:  totalItems,
-			TotalPages:  int(math.Ceil(float64(totalItems) / float64(paginationParams.PageSize))),
-			CurrentPage: paginationParams.Page,
-			PageSize:    paginationParams.PageSize,
-			HasNextPage: paginationParams.Page*paginationParams.PageSize < int(totalItems),
-			HasPrevPage: paginationParams.Page > 1,
-		},
-	}, nil
+	return newAttendanceListResponse(attendances, totalItems, paginationParams), nil
 }
 
 func (uc *AttendanceUseCase) ListByEmployee(ctx context.Context, employeeID uint, paginationParams domain.PaginationParams) (*responseAttendance.AttendanceListResponseData, error) {
@@ -411,22 +396,7 @@ func (uc *AttendanceUseCase) ListByEmployee(ctx context.Context, employeeID uint
 		return nil, fmt.Errorf("failed to list attendances for employee %d: %w", employeeID, err)
 	}
 
-	responseDTOs := make([]*responseAttendance.AttendanceResponseDTO, len(attendances))
-	for i, attendance := range attendances {
-		responseDTOs[i] = responseAttendance.NewAttendanceResponseDTO(attendance)
-	}
-
-	return &responseAttendance.AttendanceListResponseData{
-		Items: responseDTOs,
-		Pagination: domain.Pagination{
-			TotalItems:  totalItems,
-			TotalPages:  int(math.Ceil(float64(totalItems) / float64(paginationParams.PageSize))),
-			CurrentPage: paginationParams.Page,
-			PageSize:    paginationParams.PageSize,
-			HasNextPage: paginationParams.Page*paginationParams.PageSize < int(totalItems),
-			HasPrevPage: paginationParams.Page > 1,
-		},
-	}, nil
+	return newAttendanceListResponse(attendances, totalItems, paginationParams), nil
 }
 
 func (uc *AttendanceUseCase) Update(ctx context.Context, id uint, reqDTO *dtoAttendance.UpdateAttendanceRequestDTO) (*responseAttendance.AttendanceResponseDTO, error) {
@@ -666,20 +636,35 @@ func (uc *AttendanceUseCase) ListByManager(ctx context.Context, managerID uint,
 		return nil, fmt.Errorf("failed to list attendances by manager: %w", err)
 	}
 
-	responseDTOs := make([]*responseAttendance.AttendanceResponseDTO, len(attendances))
-	for i, attendance := range attendances {
-		responseDTOs[i] = responseAttendance.NewAttendanceResponseDTO(attendance)
-	}
+	return newAttendanceListResponse(attendances, totalItems, paginationParams), nil
+}
 
-	return &responseAttendance.AttendanceListResponseData{
-		Items: responseDTOs,
-		Pagination: domain.Pagination{
+// newAttendanceListResponse builds a page of attendances, trimming the extra attendance fetched for
+// lists paginated by cursor.
+func newAttendanceListResponse(attendances []*domain.Attendance, totalItems int64, paginationParams domain.PaginationParams) *responseAttendance.AttendanceListResponseData {
+	var pagination domain.Pagination
+	if paginationParams.Cursor != nil {
+		attendances, pagination = domain.NewCursorPage(attendances, paginationParams, func(attendance *domain.Attendance) domain.Cursor {
+			return domain.Cursor{Date: attendance.Date, ID: attendance.ID}
+		})
+	} else {
+		pagination = domain.Pagination{
 			TotalItems:  totalItems,
 			TotalPages:  int(math.Ceil(float64(totalItems) / float64(paginationParams.PageSize))),
 			CurrentPage: paginationParams.Page,
 			PageSize:    paginationParams.PageSize,
 			HasNextPage: paginationParams.Page*paginationParams.PageSize < int(totalItems),
 			HasPrevPage: paginationParams.Page > 1,
-		},
-	}, nil
+		}
+	}
+
+	responseDTOs := make([]*responseAttendance.AttendanceResponseDTO, len(attendances))
+	for i, attendance := range attendances {
+		responseDTOs[i] = responseAttendance.NewAttendanceResponseDTO(attendance)
+	}
+
+	return &responseAttendance.AttendanceListResponseData{
+		Items:      responseDTOs,
+		Pagination: pagination,
+	}
 }
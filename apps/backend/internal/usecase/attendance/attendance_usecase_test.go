@@ -313,6 +313,67 @@ func TestAttendanceUseCase_List(t *testing.T) {
 	}
 }
 
+func TestAttendanceUseCase_ListByManager_Cursor(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// The repository fetches one attendance more than the page size when another page follows
+	mockAttendances := []*domain.Attendance{
+		{ID: 9, EmployeeID: 1, Date: day, Status: domain.OnTime},
+		{ID: 7, EmployeeID: 2, Date: day, Status: domain.OnTime},
+		{ID: 8, EmployeeID: 1, Date: day.AddDate(0, 0, -1), Status: domain.OnTime},
+	}
+
+	tests := []struct {
+		name               string
+		paginationParams   domain.PaginationParams
+		attendances        []*domain.Attendance
+		expectedItems      int
+		expectedNextCursor *domain.Cursor
+		expectedPrevPage   bool
+	}{
+		{
+			name:               "first page with another page following",
+			paginationParams:   domain.PaginationParams{PageSize: 2, Cursor: &domain.Cursor{}},
+			attendances:        mockAttendances,
+			expectedItems:      2,
+			expectedNextCursor: &domain.Cursor{Date: day, ID: 7},
+		},
+		{
+			name:             "last page",
+			paginationParams: domain.PaginationParams{PageSize: 2, Cursor: &domain.Cursor{Date: day, ID: 7}},
+			attendances:      mockAttendances[2:],
+			expectedItems:    1,
+			expectedPrevPage: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendanceRepo := &mocks.AttendanceRepository{}
+			attendanceRepo.On("ListByManager", ctx, uint(1), tt.paginationParams).Return(tt.attendances, int64(0), nil)
+
+			uc := NewAttendanceUseCase(attendanceRepo, &mocks.EmployeeRepository{}, &mocks.WorkScheduleRepository{}, &mocks.LeaveRequestRepository{})
+			result, err := uc.ListByManager(ctx, 1, tt.paginationParams)
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Items, tt.expectedItems)
+			assert.Equal(t, tt.expectedItems == 2, result.Pagination.HasNextPage)
+			assert.Equal(t, tt.expectedPrevPage, result.Pagination.HasPrevPage)
+			if tt.expectedNextCursor == nil {
+				assert.Empty(t, result.Pagination.NextCursor)
+			} else {
+				cursor, err := domain.DecodeCursor(result.Pagination.NextCursor)
+				assert.NoError(t, err)
+				assert.True(t, tt.expectedNextCursor.Date.Equal(cursor.Date))
+				assert.Equal(t, tt.expectedNextCursor.ID, cursor.ID)
+			}
+
+			attendanceRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAttendanceUseCase_ListByEmployee(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -0,0 +1,348 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtocontract "github.com/SukaMajuu/hris/apps/backend/domain/dto/contract"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"gorm.io/gorm"
+)
+
+type ContractUseCase struct {
+	contractRepo interfaces.EmploymentContractRepository
+	employeeRepo interfaces.EmployeeRepository
+	companyRepo  interfaces.CompanyRepository
+	authRepo     interfaces.AuthRepository
+	notifier     interfaces.EmploymentNotifier
+}
+
+func NewContractUseCase(
+	contractRepo interfaces.EmploymentContractRepository,
+	employeeRepo interfaces.EmployeeRepository,
+	companyRepo interfaces.CompanyRepository,
+	authRepo interfaces.AuthRepository,
+	notifier interfaces.EmploymentNotifier,
+) *ContractUseCase {
+	return &ContractUseCase{
+		contractRepo: contractRepo,
+		employeeRepo: employeeRepo,
+		companyRepo:  companyRepo,
+		authRepo:     authRepo,
+		notifier:     notifier,
+	}
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func employeeName(employee *domain.Employee) string {
+	name := employee.FirstName
+	if employee.LastName != nil {
+		name += " " + *employee.LastName
+	}
+	return name
+}
+
+// getActiveEmployee returns the employee a contract is recorded for.
+func (uc *ContractUseCase) getActiveEmployee(ctx context.Context, employeeID uint) (*domain.Employee, error) {
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+	if !employee.EmploymentStatus {
+		return nil, fmt.Errorf("%w: employee has resigned", domain.ErrInvalidContract)
+	}
+	return employee, nil
+}
+
+// validateContract checks the dates of a contract against its type: fixed-term contracts need an
+// end date, permanent ones cannot have one.
+func validateContract(contract *domain.EmploymentContract) error {
+	if contract.StartDate.IsZero() {
+		return fmt.Errorf("%w: start date is required", domain.ErrInvalidContract)
+	}
+
+	switch contract.ContractType {
+	case enums.Permanent:
+		if contract.EndDate != nil {
+			return fmt.Errorf("%w: a permanent contract has no end date", domain.ErrInvalidContract)
+		}
+	case enums.Contract:
+		if contract.EndDate == nil {
+			return fmt.Errorf("%w: a fixed-term contract needs an end date", domain.ErrInvalidContract)
+		}
+	case enums.Freelance:
+	default:
+		return fmt.Errorf("%w: unknown contract type %s", domain.ErrInvalidContract, contract.ContractType)
+	}
+
+	if contract.EndDate != nil && !contract.EndDate.After(contract.StartDate) {
+		return fmt.Errorf("%w: end date must be after the start date", domain.ErrInvalidContract)
+	}
+	return nil
+}
+
+// updateEmployeeContractType keeps the contract type on the employee in line with their active
+// contract.
+func (uc *ContractUseCase) updateEmployeeContractType(ctx context.Context, employee *domain.Employee, contractType enums.ContractType) error {
+	if employee.ContractType != nil && *employee.ContractType == contractType {
+		return nil
+	}
+	employee.ContractType = &contractType
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		return fmt.Errorf("failed to update contract type of employee ID %d: %w", employee.ID, err)
+	}
+	return nil
+}
+
+// CreateContract records the current contract of an employee that has no active contract yet.
+func (uc *ContractUseCase) CreateContract(ctx context.Context, contract *domain.EmploymentContract) (*dtocontract.ContractResponseDTO, error) {
+	log.Printf("ContractUseCase: CreateContract called for employee ID %d, type: %s", contract.EmployeeID, contract.ContractType)
+
+	employee, err := uc.getActiveEmployee(ctx, contract.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateContract(contract); err != nil {
+		return nil, err
+	}
+
+	_, err = uc.contractRepo.GetActiveByEmployee(ctx, contract.EmployeeID)
+	if err == nil {
+		return nil, domain.ErrActiveContractExists
+	}
+	if !errors.Is(err, domain.ErrContractNotFound) {
+		return nil, fmt.Errorf("failed to get active contract: %w", err)
+	}
+
+	contract.CompanyID = employee.CompanyID
+	contract.Status = domain.ContractStatusActive
+	if err := uc.contractRepo.Create(ctx, contract); err != nil {
+		return nil, fmt.Errorf("failed to create contract: %w", err)
+	}
+
+	if err := uc.updateEmployeeContractType(ctx, employee, contract.ContractType); err != nil {
+		return nil, err
+	}
+
+	return dtocontract.ToContractResponseDTO(contract, today()), nil
+}
+
+func (uc *ContractUseCase) ListContracts(ctx context.Context, employeeID uint) ([]*dtocontract.ContractResponseDTO, error) {
+	if _, err := uc.employeeRepo.GetByID(ctx, employeeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	contracts, err := uc.contractRepo.ListByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+	return dtocontract.ToContractResponseDTOList(contracts, today()), nil
+}
+
+// RenewContract follows the active fixed-term contract of an employee with a new one.
+func (uc *ContractUseCase) RenewContract(ctx context.Context, next *domain.EmploymentContract, reason *string) (*dtocontract.ContractResponseDTO, error) {
+	log.Printf("ContractUseCase: RenewContract called for employee ID %d", next.EmployeeID)
+	return uc.replaceActiveContract(ctx, next, domain.ContractStatusRenewed, domain.EmploymentEventContractRenewal, reason)
+}
+
+// ConvertToPermanent follows the active fixed-term contract of an employee with a permanent one.
+func (uc *ContractUseCase) ConvertToPermanent(ctx context.Context, next *domain.EmploymentContract, reason *string) (*dtocontract.ContractResponseDTO, error) {
+	log.Printf("ContractUseCase: ConvertToPermanent called for employee ID %d", next.EmployeeID)
+	return uc.replaceActiveContract(ctx, next, domain.ContractStatusConverted, domain.EmploymentEventContractConversion, reason)
+}
+
+// replaceActiveContract closes the active fixed-term contract of an employee with the given status,
+// starts the next contract and adds the change to the employee's employment history, all in one
+// transaction. The next contract starts the day after the active one ends unless it has a start
+// date of its own; a next contract starting earlier ends the active one the day before it starts,
+// so the two never overlap.
+func (uc *ContractUseCase) replaceActiveContract(ctx context.Context, next *domain.EmploymentContract, closedStatus domain.EmploymentContractStatus, eventType domain.EmploymentEventType, reason *string) (*dtocontract.ContractResponseDTO, error) {
+	employee, err := uc.getActiveEmployee(ctx, next.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := uc.contractRepo.GetActiveByEmployee(ctx, next.EmployeeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrContractNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get active contract: %w", err)
+	}
+	if active.ContractType != enums.Contract || active.EndDate == nil {
+		return nil, domain.ErrContractNotRenewable
+	}
+
+	if next.StartDate.IsZero() {
+		next.StartDate = active.EndDate.AddDate(0, 0, 1)
+	}
+	if err := validateContract(next); err != nil {
+		return nil, err
+	}
+	if !next.StartDate.After(active.StartDate) {
+		return nil, fmt.Errorf("%w: the new contract must start after the current one starts", domain.ErrInvalidContract)
+	}
+	if !next.StartDate.After(*active.EndDate) {
+		endDate := next.StartDate.AddDate(0, 0, -1)
+		active.EndDate = &endDate
+	}
+
+	active.Status = closedStatus
+	next.CompanyID = employee.CompanyID
+	next.Status = domain.ContractStatusActive
+
+	previousContractType := active.ContractType
+	newContractType := next.ContractType
+	event := &domain.EmploymentEvent{
+		CompanyID:            employee.CompanyID,
+		EmployeeID:           employee.ID,
+		EventType:            eventType,
+		EffectiveDate:        next.StartDate,
+		Reason:               reason,
+		PreviousContractType: &previousContractType,
+		NewContractType:      &newContractType,
+		RecordedBy:           next.CreatedBy,
+	}
+	if err := uc.contractRepo.Replace(ctx, active, next, event); err != nil {
+		return nil, fmt.Errorf("failed to replace contract ID %d: %w", active.ID, err)
+	}
+	employee.ContractType = &newContractType
+
+	log.Printf("ContractUseCase: Contract ID %d of employee ID %d %s, new contract ID %d", active.ID, employee.ID, closedStatus, next.ID)
+	return dtocontract.ToContractResponseDTO(next, today()), nil
+}
+
+// ListUpcomingExpiries lists the active fixed-term contracts ending within the given number of
+// days, including those that have already ended without being renewed or converted.
+func (uc *ContractUseCase) ListUpcomingExpiries(ctx context.Context, days int) ([]*dtocontract.UpcomingExpiryDTO, error) {
+	now := today()
+	contracts, err := uc.contractRepo.ListActiveEndingBefore(ctx, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring contracts: %w", err)
+	}
+
+	expiries := make([]*dtocontract.UpcomingExpiryDTO, 0, len(contracts))
+	for _, contract := range contracts {
+		expiry := &dtocontract.UpcomingExpiryDTO{
+			ContractID:    contract.ID,
+			EmployeeID:    contract.EmployeeID,
+			EmployeeName:  employeeName(&contract.Employee),
+			EmployeeCode:  contract.Employee.EmployeeCode,
+			PositionName:  contract.Employee.PositionName,
+			StartDate:     contract.StartDate.Format("2006-01-02"),
+			EndDate:       contract.EndDate.Format("2006-01-02"),
+			DaysRemaining: *contract.DaysRemaining(now),
+			DocumentURL:   contract.DocumentURL,
+		}
+		if contract.Employee.Manager != nil {
+			managerName := employeeName(contract.Employee.Manager)
+			expiry.ManagerName = &managerName
+		}
+		expiries = append(expiries, expiry)
+	}
+	return expiries, nil
+}
+
+// reminderThreshold returns the reminder that is due for a contract ending in daysLeft days: the
+// closest of the reminder days that has been reached.
+func reminderThreshold(daysLeft int) (int, bool) {
+	threshold, due := 0, false
+	for _, days := range domain.ContractExpiryReminderDays {
+		if daysLeft <= days && (!due || days < threshold) {
+			threshold, due = days, true
+		}
+	}
+	return threshold, due
+}
+
+// reminderRecipients returns the users to remind about a contract: the owner of the employee's
+// company, who acts as HR, and the employee's manager.
+func (uc *ContractUseCase) reminderRecipients(ctx context.Context, contract *domain.EmploymentContract) []*domain.User {
+	var userIDs []uint
+	if contract.CompanyID != nil {
+		company, err := uc.companyRepo.GetByID(ctx, *contract.CompanyID)
+		if err != nil {
+			log.Printf("ContractUseCase: Warning - failed to get company ID %d: %v", *contract.CompanyID, err)
+		} else {
+			userIDs = append(userIDs, company.OwnerUserID)
+		}
+	}
+	if manager := contract.Employee.Manager; manager != nil && (len(userIDs) == 0 || manager.UserID != userIDs[0]) {
+		userIDs = append(userIDs, manager.UserID)
+	}
+
+	recipients := make([]*domain.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := uc.authRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Printf("ContractUseCase: Warning - failed to get user ID %d: %v", userID, err)
+			continue
+		}
+		recipients = append(recipients, user)
+	}
+	return recipients
+}
+
+// ProcessContractExpiryReminders reminds HR and managers of fixed-term contracts ending in 30, 14
+// and 7 days. Each reminder is sent once per contract; a contract that is only picked up after a
+// reminder day has passed gets the closest reminder that is due.
+func (uc *ContractUseCase) ProcessContractExpiryReminders(ctx context.Context) (*dtocontract.ContractReminderResultDTO, error) {
+	now := today()
+	log.Printf("ContractUseCase: Processing contract expiry reminders as of %s", now.Format("2006-01-02"))
+
+	contracts, err := uc.contractRepo.ListActiveEndingBefore(ctx, now.AddDate(0, 0, domain.ContractExpiryReminderDays[0]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring contracts: %w", err)
+	}
+
+	result := &dtocontract.ContractReminderResultDTO{ProcessedDate: now.Format("2006-01-02")}
+	for _, contract := range contracts {
+		daysLeft := *contract.DaysRemaining(now)
+		if daysLeft < 0 {
+			continue
+		}
+		result.Checked++
+
+		threshold, due := reminderThreshold(daysLeft)
+		if !due || (contract.LastReminderDays != nil && *contract.LastReminderDays <= threshold) {
+			continue
+		}
+
+		sent := 0
+		for _, recipient := range uc.reminderRecipients(ctx, contract) {
+			if err := uc.notifier.SendContractExpiryReminder(ctx, recipient, contract, daysLeft); err != nil {
+				log.Printf("ContractUseCase: Warning - failed to send contract expiry reminder to %s: %v", recipient.Email, err)
+				continue
+			}
+			sent++
+		}
+		if sent == 0 {
+			result.Failed++
+			continue
+		}
+
+		contract.LastReminderDays = &threshold
+		if err := uc.contractRepo.Update(ctx, contract); err != nil {
+			log.Printf("ContractUseCase: Warning - failed to record reminder for contract ID %d: %v", contract.ID, err)
+		}
+		result.Reminded++
+	}
+
+	log.Printf("ContractUseCase: Sent %d contract expiry reminders for %d expiring contracts", result.Reminded, result.Checked)
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package contract
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func datePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestContractUseCase_ReplaceActiveContract(t *testing.T) {
+	ctx := context.Background()
+	contractStart := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	contractEnd := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	newEnd := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	fixedTerm := func() *domain.EmploymentContract {
+		return &domain.EmploymentContract{
+			ID:           10,
+			EmployeeID:   1,
+			ContractType: enums.Contract,
+			StartDate:    contractStart,
+			EndDate:      datePtr(contractEnd),
+			Status:       domain.ContractStatusActive,
+		}
+	}
+
+	tests := []struct {
+		name              string
+		convert           bool
+		active            *domain.EmploymentContract
+		activeErr         error
+		next              *domain.EmploymentContract
+		expectSave        bool
+		expectedStatus    domain.EmploymentContractStatus
+		expectedEvent     domain.EmploymentEventType
+		expectedStartDate string
+		expectedEndDate   string
+		expectedError     error
+	}{
+		{
+			name:              "renewal starts the day after the current contract",
+			active:            fixedTerm(),
+			next:              &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Contract, EndDate: datePtr(newEnd)},
+			expectSave:        true,
+			expectedStatus:    domain.ContractStatusRenewed,
+			expectedEvent:     domain.EmploymentEventContractRenewal,
+			expectedStartDate: "2025-07-01",
+			expectedEndDate:   "2025-06-30",
+		},
+		{
+			name:              "conversion to permanent",
+			convert:           true,
+			active:            fixedTerm(),
+			next:              &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Permanent},
+			expectSave:        true,
+			expectedStatus:    domain.ContractStatusConverted,
+			expectedEvent:     domain.EmploymentEventContractConversion,
+			expectedStartDate: "2025-07-01",
+			expectedEndDate:   "2025-06-30",
+		},
+		{
+			name:              "conversion before the current contract ends ends it the day before",
+			convert:           true,
+			active:            fixedTerm(),
+			next:              &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Permanent, StartDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			expectSave:        true,
+			expectedStatus:    domain.ContractStatusConverted,
+			expectedEvent:     domain.EmploymentEventContractConversion,
+			expectedStartDate: "2025-03-01",
+			expectedEndDate:   "2025-02-28",
+		},
+		{
+			name:          "renewal starting with the current contract overlaps it",
+			active:        fixedTerm(),
+			next:          &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Contract, StartDate: contractStart, EndDate: datePtr(newEnd)},
+			expectedError: domain.ErrInvalidContract,
+		},
+		{
+			name:          "renewal ending before it starts",
+			active:        fixedTerm(),
+			next:          &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Contract, EndDate: datePtr(contractEnd)},
+			expectedError: domain.ErrInvalidContract,
+		},
+		{
+			name:          "permanent contract cannot be renewed",
+			active:        &domain.EmploymentContract{ID: 10, EmployeeID: 1, ContractType: enums.Permanent, StartDate: contractStart, Status: domain.ContractStatusActive},
+			next:          &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Contract, EndDate: datePtr(newEnd)},
+			expectedError: domain.ErrContractNotRenewable,
+		},
+		{
+			name:          "no active contract",
+			activeErr:     domain.ErrContractNotFound,
+			next:          &domain.EmploymentContract{EmployeeID: 1, ContractType: enums.Contract, EndDate: datePtr(newEnd)},
+			expectedError: domain.ErrContractNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContractRepo := new(mocks.EmploymentContractRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+
+			contractType := enums.Contract
+			employee := &domain.Employee{ID: 1, FirstName: "Budi", ContractType: &contractType, EmploymentStatus: true}
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil).Once()
+			if tt.activeErr != nil {
+				mockContractRepo.On("GetActiveByEmployee", ctx, uint(1)).Return(nil, tt.activeErr).Once()
+			} else {
+				mockContractRepo.On("GetActiveByEmployee", ctx, uint(1)).Return(tt.active, nil).Once()
+			}
+
+			var event *domain.EmploymentEvent
+			if tt.expectSave {
+				mockContractRepo.On("Replace", ctx, tt.active, tt.next, mock.AnythingOfType("*domain.EmploymentEvent")).
+					Run(func(args mock.Arguments) { event = args.Get(3).(*domain.EmploymentEvent) }).
+					Return(nil).Once()
+			}
+
+			uc := NewContractUseCase(mockContractRepo, mockEmployeeRepo, new(mocks.CompanyRepository), new(mocks.AuthRepository), new(mocks.EmploymentNotifier))
+			var (
+				result interface{}
+				err    error
+			)
+			if tt.convert {
+				result, err = uc.ConvertToPermanent(ctx, tt.next, nil)
+			} else {
+				result, err = uc.RenewContract(ctx, tt.next, nil)
+			}
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				mockContractRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, tt.expectedStatus, tt.active.Status)
+				assert.Equal(t, domain.ContractStatusActive, tt.next.Status)
+				assert.Equal(t, tt.expectedStartDate, tt.next.StartDate.Format("2006-01-02"))
+				assert.Equal(t, tt.expectedEndDate, tt.active.EndDate.Format("2006-01-02"))
+				assert.True(t, tt.next.StartDate.After(*tt.active.EndDate))
+				assert.Equal(t, tt.next.ContractType, *employee.ContractType)
+				if assert.NotNil(t, event) {
+					assert.Equal(t, tt.expectedEvent, event.EventType)
+					assert.Equal(t, enums.Contract, *event.PreviousContractType)
+					assert.Equal(t, tt.next.ContractType, *event.NewContractType)
+				}
+			}
+			mockContractRepo.AssertExpectations(t)
+			mockEmployeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestContractUseCase_ProcessContractExpiryReminders(t *testing.T) {
+	ctx := context.Background()
+	now := today()
+
+	owner := &domain.User{ID: 100, Email: "hr@example.com"}
+	manager := &domain.User{ID: 200, Email: "manager@example.com"}
+
+	contractEnding := func(id uint, daysLeft int, lastReminderDays *int, managerUserID uint) *domain.EmploymentContract {
+		return &domain.EmploymentContract{
+			ID:               id,
+			CompanyID:        uintPtr(1),
+			EmployeeID:       id,
+			Employee:         domain.Employee{ID: id, FirstName: "Employee", Manager: &domain.Employee{UserID: managerUserID}},
+			ContractType:     enums.Contract,
+			StartDate:        now.AddDate(-1, 0, 0),
+			EndDate:          datePtr(now.AddDate(0, 0, daysLeft)),
+			Status:           domain.ContractStatusActive,
+			LastReminderDays: lastReminderDays,
+		}
+	}
+
+	firstReminder := contractEnding(1, 30, nil, 200)
+	alreadyReminded := contractEnding(2, 20, intPtr(30), 200)
+	secondReminder := contractEnding(3, 13, intPtr(30), 100)
+	lastReminderSent := contractEnding(4, 5, intPtr(7), 200)
+	lateFirstReminder := contractEnding(5, 3, nil, 200)
+	ended := contractEnding(6, -2, intPtr(7), 200)
+
+	mockContractRepo := new(mocks.EmploymentContractRepository)
+	mockCompanyRepo := new(mocks.CompanyRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockNotifier := new(mocks.EmploymentNotifier)
+
+	mockContractRepo.On("ListActiveEndingBefore", ctx, now.AddDate(0, 0, 30)).Return([]*domain.EmploymentContract{
+		firstReminder, alreadyReminded, secondReminder, lastReminderSent, lateFirstReminder, ended,
+	}, nil).Once()
+	mockCompanyRepo.On("GetByID", ctx, uint(1)).Return(&domain.Company{ID: 1, OwnerUserID: 100}, nil)
+	mockAuthRepo.On("GetUserByID", ctx, uint(100)).Return(owner, nil)
+	mockAuthRepo.On("GetUserByID", ctx, uint(200)).Return(manager, nil)
+
+	mockNotifier.On("SendContractExpiryReminder", ctx, owner, firstReminder, 30).Return(nil).Once()
+	mockNotifier.On("SendContractExpiryReminder", ctx, manager, firstReminder, 30).Return(nil).Once()
+	mockNotifier.On("SendContractExpiryReminder", ctx, owner, secondReminder, 13).Return(nil).Once()
+	mockNotifier.On("SendContractExpiryReminder", ctx, owner, lateFirstReminder, 3).Return(nil).Once()
+	mockNotifier.On("SendContractExpiryReminder", ctx, manager, lateFirstReminder, 3).Return(nil).Once()
+	mockContractRepo.On("Update", ctx, firstReminder).Return(nil).Once()
+	mockContractRepo.On("Update", ctx, secondReminder).Return(nil).Once()
+	mockContractRepo.On("Update", ctx, lateFirstReminder).Return(nil).Once()
+
+	uc := NewContractUseCase(mockContractRepo, new(mocks.EmployeeRepository), mockCompanyRepo, mockAuthRepo, mockNotifier)
+	result, err := uc.ProcessContractExpiryReminders(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Checked)
+	assert.Equal(t, 3, result.Reminded)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, 30, *firstReminder.LastReminderDays)
+	assert.Equal(t, 14, *secondReminder.LastReminderDays)
+	assert.Equal(t, 7, *lateFirstReminder.LastReminderDays)
+	assert.Equal(t, 30, *alreadyReminded.LastReminderDays)
+	mockContractRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
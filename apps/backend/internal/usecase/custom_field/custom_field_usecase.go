@@ -0,0 +1,104 @@
+package custom_field
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtocustomfield "github.com/SukaMajuu/hris/apps/backend/domain/dto/custom_field"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+)
+
+type CustomFieldUseCase struct {
+	customFieldRepo interfaces.CustomFieldRepository
+}
+
+func NewCustomFieldUseCase(customFieldRepo interfaces.CustomFieldRepository) *CustomFieldUseCase {
+	return &CustomFieldUseCase{
+		customFieldRepo: customFieldRepo,
+	}
+}
+
+func (uc *CustomFieldUseCase) Create(ctx context.Context, definition *domain.CustomFieldDefinition) (*dtocustomfield.CustomFieldResponseDTO, error) {
+	log.Printf("CustomFieldUseCase: Create called with key %s", definition.Key)
+
+	if err := definition.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := uc.customFieldRepo.GetByKey(ctx, definition.Key); err == nil {
+		return nil, domain.ErrCustomFieldExists
+	} else if !errors.Is(err, domain.ErrCustomFieldNotFound) {
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+
+	if err := uc.customFieldRepo.Create(ctx, definition); err != nil {
+		return nil, fmt.Errorf("failed to create custom field: %w", err)
+	}
+	return dtocustomfield.ToCustomFieldResponseDTO(definition), nil
+}
+
+func (uc *CustomFieldUseCase) List(ctx context.Context) ([]*dtocustomfield.CustomFieldResponseDTO, error) {
+	definitions, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	return dtocustomfield.ToCustomFieldResponseDTOList(definitions), nil
+}
+
+func (uc *CustomFieldUseCase) Get(ctx context.Context, id uint) (*dtocustomfield.CustomFieldResponseDTO, error) {
+	definition, err := uc.customFieldRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+	return dtocustomfield.ToCustomFieldResponseDTO(definition), nil
+}
+
+// Update changes the label, visibility and validation of a custom field. Its key and type are
+// fixed because employees already hold values under them. Values already stored are not
+// revalidated.
+func (uc *CustomFieldUseCase) Update(ctx context.Context, definition *domain.CustomFieldDefinition) (*dtocustomfield.CustomFieldResponseDTO, error) {
+	log.Printf("CustomFieldUseCase: Update called for ID %d", definition.ID)
+
+	existing, err := uc.customFieldRepo.GetByID(ctx, definition.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+	if definition.Key != existing.Key {
+		return nil, fmt.Errorf("%w: the key of a field cannot be changed", domain.ErrInvalidCustomField)
+	}
+	if definition.Type != existing.Type {
+		return nil, fmt.Errorf("%w: the type of a field cannot be changed", domain.ErrInvalidCustomField)
+	}
+	if err := definition.Check(); err != nil {
+		return nil, err
+	}
+
+	existing.Label = definition.Label
+	existing.Visibility = definition.Visibility
+	existing.Required = definition.Required
+	existing.Options = definition.Options
+	existing.Pattern = definition.Pattern
+	existing.Min = definition.Min
+	existing.Max = definition.Max
+	existing.SortOrder = definition.SortOrder
+	if err := uc.customFieldRepo.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update custom field: %w", err)
+	}
+	return dtocustomfield.ToCustomFieldResponseDTO(existing), nil
+}
+
+// Delete removes a custom field together with the values employees hold for it.
+func (uc *CustomFieldUseCase) Delete(ctx context.Context, id uint) error {
+	log.Printf("CustomFieldUseCase: Delete called for ID %d", id)
+
+	definition, err := uc.customFieldRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get custom field: %w", err)
+	}
+	if err := uc.customFieldRepo.Delete(ctx, definition); err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+	return nil
+}
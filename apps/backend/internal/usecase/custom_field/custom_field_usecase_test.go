@@ -0,0 +1,102 @@
+package custom_field
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldUseCase_Create(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		definition  *domain.CustomFieldDefinition
+		setupMocks  func(mockRepo *mocks.CustomFieldRepository, definition *domain.CustomFieldDefinition)
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			definition: &domain.CustomFieldDefinition{Key: "blood_type", Label: "Blood type", Type: domain.CustomFieldSelect, Options: []string{"A", "B", "AB", "O"}},
+			setupMocks: func(mockRepo *mocks.CustomFieldRepository, definition *domain.CustomFieldDefinition) {
+				mockRepo.On("GetByKey", ctx, "blood_type").Return(nil, domain.ErrCustomFieldNotFound).Once()
+				mockRepo.On("Create", ctx, definition).Return(nil).Once()
+			},
+		},
+		{
+			name:       "key already used",
+			definition: &domain.CustomFieldDefinition{Key: "shirt_size", Label: "Shirt size", Type: domain.CustomFieldText},
+			setupMocks: func(mockRepo *mocks.CustomFieldRepository, definition *domain.CustomFieldDefinition) {
+				mockRepo.On("GetByKey", ctx, "shirt_size").Return(&domain.CustomFieldDefinition{ID: 1, Key: "shirt_size"}, nil).Once()
+			},
+			expectedErr: domain.ErrCustomFieldExists,
+		},
+		{
+			name:        "select without options",
+			definition:  &domain.CustomFieldDefinition{Key: "blood_type", Label: "Blood type", Type: domain.CustomFieldSelect},
+			setupMocks:  func(*mocks.CustomFieldRepository, *domain.CustomFieldDefinition) {},
+			expectedErr: domain.ErrInvalidCustomField,
+		},
+		{
+			name:        "invalid key",
+			definition:  &domain.CustomFieldDefinition{Key: "BPJS Number", Label: "BPJS number", Type: domain.CustomFieldText},
+			setupMocks:  func(*mocks.CustomFieldRepository, *domain.CustomFieldDefinition) {},
+			expectedErr: domain.ErrInvalidCustomField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.CustomFieldRepository)
+			uc := NewCustomFieldUseCase(mockRepo)
+			tt.setupMocks(mockRepo, tt.definition)
+
+			result, err := uc.Create(ctx, tt.definition)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.definition.Key, result.Key)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCustomFieldUseCase_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("changes label and visibility", func(t *testing.T) {
+		mockRepo := new(mocks.CustomFieldRepository)
+		uc := NewCustomFieldUseCase(mockRepo)
+		existing := &domain.CustomFieldDefinition{ID: 1, Key: "npwp", Label: "NPWP", Type: domain.CustomFieldText, Visibility: domain.CustomFieldAdminOnly}
+
+		mockRepo.On("GetByID", ctx, uint(1)).Return(existing, nil).Once()
+		mockRepo.On("Update", ctx, existing).Return(nil).Once()
+
+		result, err := uc.Update(ctx, &domain.CustomFieldDefinition{ID: 1, Key: "npwp", Label: "NPWP number", Type: domain.CustomFieldText, Visibility: domain.CustomFieldEmployeeEditable})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "NPWP number", result.Label)
+		assert.Equal(t, domain.CustomFieldEmployeeEditable, existing.Visibility)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("type cannot change", func(t *testing.T) {
+		mockRepo := new(mocks.CustomFieldRepository)
+		uc := NewCustomFieldUseCase(mockRepo)
+
+		mockRepo.On("GetByID", ctx, uint(1)).Return(&domain.CustomFieldDefinition{ID: 1, Key: "npwp", Type: domain.CustomFieldText}, nil).Once()
+
+		result, err := uc.Update(ctx, &domain.CustomFieldDefinition{ID: 1, Key: "npwp", Label: "NPWP", Type: domain.CustomFieldNumber})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCustomField)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
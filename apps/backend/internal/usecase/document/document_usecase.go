@@ -102,17 +102,17 @@ func (uc *DocumentUseCase) uploadDocumentToStorage(ctx context.Context, employee
 	return document, nil
 }
 
-func (uc *DocumentUseCase) GetDocumentsByEmployeeID(ctx context.Context, employeeID uint) ([]*domain.Document, error) {
-	return uc.documentRepo.GetByEmployeeID(ctx, employeeID)
+func (uc *DocumentUseCase) GetDocumentsByEmployeeID(ctx context.Context, employeeID uint, query *domain.ListQuery) ([]*domain.Document, error) {
+	return uc.documentRepo.GetByEmployeeID(ctx, employeeID, query)
 }
 
-func (uc *DocumentUseCase) GetDocumentsByUserID(ctx context.Context, userID uint) ([]*domain.Document, error) {
+func (uc *DocumentUseCase) GetDocumentsByUserID(ctx context.Context, userID uint, query *domain.ListQuery) ([]*domain.Document, error) {
 	employee, err := uc.employeeRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get employee for user ID %d: %w", userID, err)
 	}
 
-	documents, err := uc.documentRepo.GetByEmployeeID(ctx, employee.ID)
+	documents, err := uc.documentRepo.GetByEmployeeID(ctx, employee.ID, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get documents for employee ID %d: %w", employee.ID, err)
 	}
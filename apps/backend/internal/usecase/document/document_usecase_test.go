@@ -131,10 +131,10 @@ func TestGetDocumentsByEmployeeID(t *testing.T) {
 				mockSupabaseClient,
 			)
 
-			mockDocumentRepo.On("GetByEmployeeID", mock.Anything, tt.employeeID).
+			mockDocumentRepo.On("GetByEmployeeID", mock.Anything, tt.employeeID, (*domain.ListQuery)(nil)).
 				Return(tt.mockDocuments, tt.repositoryError)
 
-			documents, err := uc.GetDocumentsByEmployeeID(context.Background(), tt.employeeID)
+			documents, err := uc.GetDocumentsByEmployeeID(context.Background(), tt.employeeID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -391,11 +391,11 @@ func TestGetDocumentsByUserID(t *testing.T) {
 				Return(tt.mockEmployee, tt.employeeRepoError).Maybe()
 
 			if tt.employeeRepoError == nil && tt.mockEmployee != nil {
-				mockDocumentRepo.On("GetByEmployeeID", mock.Anything, tt.mockEmployee.ID).
+				mockDocumentRepo.On("GetByEmployeeID", mock.Anything, tt.mockEmployee.ID, (*domain.ListQuery)(nil)).
 					Return(tt.mockDocuments, tt.documentRepoError).Maybe()
 			}
 
-			documents, err := uc.GetDocumentsByUserID(context.Background(), tt.userID)
+			documents, err := uc.GetDocumentsByUserID(context.Background(), tt.userID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
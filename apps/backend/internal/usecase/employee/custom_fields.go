@@ -0,0 +1,187 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+)
+
+// customFieldDefinitions returns the custom fields of the company keyed by their key.
+func (uc *EmployeeUseCase) customFieldDefinitions(ctx context.Context) (map[string]*domain.CustomFieldDefinition, error) {
+	definitions := make(map[string]*domain.CustomFieldDefinition)
+	if uc.customFieldRepo == nil {
+		return definitions, nil
+	}
+
+	list, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	for _, definition := range list {
+		definitions[definition.Key] = definition
+	}
+	return definitions, nil
+}
+
+// mergeCustomFields validates the changed custom field values against their definitions and
+// applies them to current. A nil value clears the field. When creating, every required field must
+// be given a value.
+func mergeCustomFields(definitions map[string]*domain.CustomFieldDefinition, current, changes map[string]interface{}, creating bool) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(current)+len(changes))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range changes {
+		definition, ok := definitions[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown custom field %s", domain.ErrInvalidCustomFieldValue, key)
+		}
+		if value == nil || value == "" {
+			if definition.Required {
+				return nil, fmt.Errorf("%w: %s is required", domain.ErrInvalidCustomFieldValue, key)
+			}
+			delete(merged, key)
+			continue
+		}
+		normalized, err := definition.Validate(value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = normalized
+	}
+
+	if creating {
+		for key, definition := range definitions {
+			if _, ok := merged[key]; definition.Required && !ok {
+				return nil, fmt.Errorf("%w: %s is required", domain.ErrInvalidCustomFieldValue, key)
+			}
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+// applyCustomFields validates the custom field values of an employee being created, or the
+// changes to them when updating, and stores the result in employee.CustomFields.
+func (uc *EmployeeUseCase) applyCustomFields(ctx context.Context, employee *domain.Employee, current map[string]interface{}, creating bool) error {
+	if !creating && len(employee.CustomFields) == 0 {
+		employee.CustomFields = current
+		return nil
+	}
+
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	merged, err := mergeCustomFields(definitions, current, employee.CustomFields, creating)
+	if err != nil {
+		return err
+	}
+	employee.CustomFields = merged
+	return nil
+}
+
+// CheckSelfEditableCustomFields reports an error when an employee editing their own profile
+// changes a custom field that only admins may edit.
+func (uc *EmployeeUseCase) CheckSelfEditableCustomFields(ctx context.Context, changes map[string]interface{}) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	for key := range changes {
+		if definition, ok := definitions[key]; ok && definition.Visibility != domain.CustomFieldEmployeeEditable {
+			return fmt.Errorf("%w: %s", domain.ErrCustomFieldNotEditable, key)
+		}
+	}
+	return nil
+}
+
+// HideAdminOnlyCustomFields removes the custom fields only admins may see from an employee's own
+// profile.
+func (uc *EmployeeUseCase) HideAdminOnlyCustomFields(ctx context.Context, employee *domain.Employee) error {
+	if len(employee.CustomFields) == 0 {
+		return nil
+	}
+
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	employee.CustomFields = visibleCustomFields(definitions, employee.CustomFields)
+	return nil
+}
+
+// HideAdminOnlyCustomFieldValues removes the custom fields only admins may see from employees read
+// by a user who is not an admin.
+func (uc *EmployeeUseCase) HideAdminOnlyCustomFieldValues(ctx context.Context, employees ...*dtoemployee.EmployeeResponseDTO) error {
+	var definitions map[string]*domain.CustomFieldDefinition
+	for _, employee := range employees {
+		if len(employee.CustomFields) == 0 {
+			continue
+		}
+		if definitions == nil {
+			var err error
+			if definitions, err = uc.customFieldDefinitions(ctx); err != nil {
+				return err
+			}
+		}
+		employee.CustomFields = visibleCustomFields(definitions, employee.CustomFields)
+	}
+	return nil
+}
+
+// visibleCustomFields returns the values of the custom fields employees may see.
+func visibleCustomFields(definitions map[string]*domain.CustomFieldDefinition, values map[string]interface{}) map[string]interface{} {
+	visible := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if definition, ok := definitions[key]; ok && definition.Visibility == domain.CustomFieldEmployeeEditable {
+			visible[key] = value
+		}
+	}
+	return visible
+}
+
+// customFieldImportErrors validates the custom field columns of an imported row.
+func customFieldImportErrors(definitions map[string]*domain.CustomFieldDefinition, employee *domain.Employee, rowNum int) []EmployeeImportError {
+	merged, err := mergeCustomFields(definitions, nil, employee.CustomFields, true)
+	if err != nil {
+		return []EmployeeImportError{{
+			Row:      rowNum,
+			Field:    "custom_fields",
+			Message:  err.Error(),
+			Employee: employee,
+		}}
+	}
+	employee.CustomFields = merged
+	return nil
+}
+
+// customFieldColumn returns the import and export column of a custom field.
+func customFieldColumn(key string) string {
+	return domain.ImportCustomFieldPrefix + key
+}
+
+// customFieldString formats a custom field value for an import or export file.
+func customFieldString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,194 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+)
+
+// DetectEmployeeDuplicates scores the candidate duplicate pairs of employees and flags those
+// reaching domain.EmployeeDuplicateThreshold for review. Pending pairs that no longer reach it
+// are dropped, and dismissed pairs are not flagged again.
+func (uc *EmployeeUseCase) DetectEmployeeDuplicates(ctx context.Context) (*dtoemployee.DuplicateDetectionResultDTO, error) {
+	if uc.duplicateRepo == nil {
+		return nil, fmt.Errorf("duplicate detection is not configured")
+	}
+
+	detectedAt := time.Now()
+	candidates, err := uc.duplicateRepo.ListCandidates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate candidates: %w", err)
+	}
+
+	result := &dtoemployee.DuplicateDetectionResultDTO{}
+	for _, candidate := range candidates {
+		result.Scored++
+		score, signals := domain.ScoreEmployeeDuplicate(&candidate.Employee, &candidate.Duplicate)
+		if score < domain.EmployeeDuplicateThreshold {
+			continue
+		}
+
+		candidate.Score = score
+		candidate.Signals = signals
+		candidate.Status = domain.EmployeeDuplicatePending
+		candidate.DetectedAt = detectedAt
+		if err := uc.duplicateRepo.Save(ctx, candidate); err != nil {
+			return nil, fmt.Errorf("failed to flag employees %d and %d as duplicates: %w", candidate.EmployeeID, candidate.DuplicateID, err)
+		}
+		result.Flagged++
+	}
+
+	if err := uc.duplicateRepo.DeletePendingDetectedBefore(ctx, detectedAt); err != nil {
+		return nil, fmt.Errorf("failed to drop stale duplicate pairs: %w", err)
+	}
+
+	log.Printf("EmployeeUseCase: Flagged %d of %d candidate duplicate pairs", result.Flagged, result.Scored)
+	return result, nil
+}
+
+// ListEmployeeDuplicates returns the duplicate pairs of the company with the given status, most
+// likely duplicates first.
+func (uc *EmployeeUseCase) ListEmployeeDuplicates(ctx context.Context, status domain.EmployeeDuplicateStatus, paginationParams domain.PaginationParams) (*dtoemployee.EmployeeDuplicateListResponseData, error) {
+	if uc.duplicateRepo == nil {
+		return &dtoemployee.EmployeeDuplicateListResponseData{Items: []*dtoemployee.EmployeeDuplicateResponseDTO{}}, nil
+	}
+
+	duplicates, totalItems, err := uc.duplicateRepo.List(ctx, status, paginationParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employee duplicates: %w", err)
+	}
+
+	totalPages := uc.calculateTotalPages(totalItems, paginationParams.PageSize)
+	return &dtoemployee.EmployeeDuplicateListResponseData{
+		Items: dtoemployee.ToEmployeeDuplicateResponseDTOList(duplicates),
+		Pagination: domain.Pagination{
+			TotalItems:  totalItems,
+			TotalPages:  totalPages,
+			CurrentPage: paginationParams.Page,
+			PageSize:    paginationParams.PageSize,
+			HasNextPage: paginationParams.Page < totalPages,
+			HasPrevPage: paginationParams.Page > 1 && paginationParams.Page <= totalPages,
+		},
+	}, nil
+}
+
+func (uc *EmployeeUseCase) getPendingDuplicate(ctx context.Context, id uint) (*domain.EmployeeDuplicate, error) {
+	if uc.duplicateRepo == nil {
+		return nil, domain.ErrEmployeeDuplicateNotFound
+	}
+	duplicate, err := uc.duplicateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate.Status != domain.EmployeeDuplicatePending {
+		return nil, domain.ErrEmployeeDuplicateDismissed
+	}
+	return duplicate, nil
+}
+
+// DismissEmployeeDuplicate marks a pair as two different people, so it is not flagged again.
+func (uc *EmployeeUseCase) DismissEmployeeDuplicate(ctx context.Context, id, reviewerID uint) (*dtoemployee.EmployeeDuplicateResponseDTO, error) {
+	duplicate, err := uc.getPendingDuplicate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	duplicate.Status = domain.EmployeeDuplicateDismissed
+	duplicate.ResolvedBy = &reviewerID
+	duplicate.ResolvedAt = &now
+	if err := uc.duplicateRepo.Update(ctx, duplicate); err != nil {
+		return nil, fmt.Errorf("failed to dismiss employee duplicate ID %d: %w", id, err)
+	}
+
+	return dtoemployee.ToEmployeeDuplicateResponseDTO(duplicate), nil
+}
+
+// MergeEmployeeDuplicate merges a duplicate pair into the surviving employee. The survivor takes
+// over the attendance, leave, documents and other records of the duplicate and fills its empty
+// fields from it, then the duplicate is deleted. Employment values taken from the duplicate are
+// recorded as a merge event in the survivor's employment history. The survivor keeps its own user account unless
+// keepDuplicateAccount moves the duplicate's account to it; the account left over is deactivated.
+func (uc *EmployeeUseCase) MergeEmployeeDuplicate(ctx context.Context, id, survivorID uint, keepDuplicateAccount bool, reviewerID uint) (*dtoemployee.EmployeeResponseDTO, error) {
+	duplicate, err := uc.getPendingDuplicate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if survivorID != duplicate.EmployeeID && survivorID != duplicate.DuplicateID {
+		return nil, domain.ErrInvalidEmployeeMerge
+	}
+
+	survivor, merged := &duplicate.Employee, &duplicate.Duplicate
+	if survivorID == duplicate.DuplicateID {
+		survivor, merged = merged, survivor
+	}
+
+	survivor.MergeFrom(merged)
+	event := mergeEmploymentEvent(survivor, merged)
+	retiredUserID := merged.UserID
+	if keepDuplicateAccount {
+		retiredUserID = survivor.UserID
+		survivor.UserID = merged.UserID
+		survivor.User = merged.User
+	}
+
+	if err := uc.duplicateRepo.Merge(ctx, survivor, event, merged.ID, retiredUserID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee ID %d into employee ID %d: %w", merged.ID, survivor.ID, err)
+	}
+
+	if uc.authRepo != nil {
+		if err := uc.authRepo.DeactivateUser(ctx, retiredUserID); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to deactivate user ID %d after merge: %v", retiredUserID, err)
+		}
+	}
+
+	log.Printf("EmployeeUseCase: User %d merged employee ID %d into employee ID %d", reviewerID, merged.ID, survivor.ID)
+	return dtoemployee.ToEmployeeResponseDTO(survivor), nil
+}
+
+// mergeEmploymentEvent applies to the survivor of a merge the employment values it lacks and the
+// duplicate has, its position, grade, branch, department, contract type and base salary, and
+// returns the event recording them, or nil when the duplicate adds none.
+func mergeEmploymentEvent(survivor, merged *domain.Employee) *domain.EmploymentEvent {
+	fill := &domain.Employee{}
+	if survivor.PositionName == "" {
+		fill.PositionName = merged.PositionName
+		fill.PositionID = merged.PositionID
+	}
+	if (survivor.Grade == nil || *survivor.Grade == "") && merged.Grade != nil && *merged.Grade != "" {
+		fill.Grade = merged.Grade
+	}
+	if (survivor.Branch == nil || *survivor.Branch == "") && merged.Branch != nil && *merged.Branch != "" {
+		fill.Branch = merged.Branch
+		fill.BranchID = merged.BranchID
+	}
+	if survivor.DepartmentID == nil {
+		fill.DepartmentID = merged.DepartmentID
+	}
+	if survivor.ContractType == nil {
+		fill.ContractType = merged.ContractType
+	}
+	if survivor.BaseSalary == nil {
+		fill.BaseSalary = merged.BaseSalary
+	}
+
+	change := splitEmploymentChange(survivor, fill)
+	if change == nil {
+		return nil
+	}
+	reason := fmt.Sprintf("Merged from duplicate employee ID %d", merged.ID)
+	event := &domain.EmploymentEvent{
+		CompanyID:     survivor.CompanyID,
+		EmployeeID:    survivor.ID,
+		EventType:     domain.EmploymentEventMerge,
+		EffectiveDate: startOfDay(time.Now()),
+		Reason:        &reason,
+	}
+	setNewValues(event, change)
+	applyEmploymentValues(survivor, event)
+	return event
+}
@@ -34,11 +34,27 @@ const (
 )
 
 type EmployeeUseCase struct {
-	employeeRepo   interfaces.EmployeeRepository
-	authRepo       interfaces.AuthRepository
-	paymentRepo    interfaces.PaymentRepository
-	supabaseClient *supa.Client
-	db             *gorm.DB
+	employeeRepo      interfaces.EmployeeRepository
+	authRepo          interfaces.AuthRepository
+	paymentRepo       interfaces.PaymentRepository
+	supabaseClient    *supa.Client
+	db                *gorm.DB
+	leaveEncashmentUC interfaces.LeaveEncashmentUseCase
+	companyRepo       interfaces.CompanyRepository
+	organizationRepo  interfaces.OrganizationRepository
+
+	employmentEventRepo interfaces.EmploymentEventRepository
+	contractRepo        interfaces.EmploymentContractRepository
+	offboardingRepo     interfaces.OffboardingRepository
+	onboardingUC        interfaces.OnboardingUseCase
+	probationRepo       interfaces.ProbationRepository
+	notifier            interfaces.EmploymentNotifier
+	customFieldRepo     interfaces.CustomFieldRepository
+	profileChangeRepo   interfaces.ProfileChangeRepository
+	importJobRepo       interfaces.ImportJobRepository
+	importMappingRepo   interfaces.ImportMappingRepository
+	duplicateRepo       interfaces.EmployeeDuplicateRepository
+	familyRepo          interfaces.EmployeeFamilyRepository
 }
 
 func NewEmployeeUseCase(
@@ -57,6 +73,48 @@ func NewEmployeeUseCase(
 	}
 }
 
+// Dependencies are the optional collaborators of the employee use case. The features backed by a
+// collaborator left nil are turned off.
+type Dependencies struct {
+	LeaveEncashmentUC interfaces.LeaveEncashmentUseCase
+	CompanyRepo       interfaces.CompanyRepository
+	OrganizationRepo  interfaces.OrganizationRepository
+
+	EmploymentEventRepo interfaces.EmploymentEventRepository
+	ContractRepo        interfaces.EmploymentContractRepository
+	OffboardingRepo     interfaces.OffboardingRepository
+	OnboardingUC        interfaces.OnboardingUseCase
+	ProbationRepo       interfaces.ProbationRepository
+	Notifier            interfaces.EmploymentNotifier
+	CustomFieldRepo     interfaces.CustomFieldRepository
+	ProfileChangeRepo   interfaces.ProfileChangeRepository
+	ImportJobRepo       interfaces.ImportJobRepository
+	ImportMappingRepo   interfaces.ImportMappingRepository
+	DuplicateRepo       interfaces.EmployeeDuplicateRepository
+	FamilyRepo          interfaces.EmployeeFamilyRepository
+}
+
+// WithDependencies sets the optional collaborators of the use case and returns it.
+func (uc *EmployeeUseCase) WithDependencies(deps Dependencies) *EmployeeUseCase {
+	uc.leaveEncashmentUC = deps.LeaveEncashmentUC
+	uc.companyRepo = deps.CompanyRepo
+	uc.organizationRepo = deps.OrganizationRepo
+
+	uc.employmentEventRepo = deps.EmploymentEventRepo
+	uc.contractRepo = deps.ContractRepo
+	uc.offboardingRepo = deps.OffboardingRepo
+	uc.onboardingUC = deps.OnboardingUC
+	uc.probationRepo = deps.ProbationRepo
+	uc.notifier = deps.Notifier
+	uc.customFieldRepo = deps.CustomFieldRepo
+	uc.profileChangeRepo = deps.ProfileChangeRepo
+	uc.importJobRepo = deps.ImportJobRepo
+	uc.importMappingRepo = deps.ImportMappingRepo
+	uc.duplicateRepo = deps.DuplicateRepo
+	uc.familyRepo = deps.FamilyRepo
+	return uc
+}
+
 func (uc *EmployeeUseCase) List(ctx context.Context, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoemployee.EmployeeListResponseData, error) {
 	domainEmployees, totalItems, err := uc.employeeRepo.List(ctx, filters, paginationParams)
 	if err != nil {
@@ -107,7 +165,22 @@ func (uc *EmployeeUseCase) Create(ctx context.Context, employee *domain.Employee
 		return nil, err
 	}
 
-	employee.ManagerID = &creatorEmployeeID
+	if employee.ManagerID == nil {
+		employee.ManagerID = &creatorEmployeeID
+	} else if _, err := uc.getActiveManager(ctx, *employee.ManagerID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyOrganization(ctx, employee); err != nil {
+		return nil, err
+	}
+	employee.DepartmentID = nonZeroID(employee.DepartmentID)
+	employee.BranchID = nonZeroID(employee.BranchID)
+	employee.PositionID = nonZeroID(employee.PositionID)
+
+	if err := uc.applyCustomFields(ctx, employee, nil, true); err != nil {
+		return nil, err
+	}
 
 	if employee.User.Password == "" {
 		employee.User.Password = defaultPassword
@@ -119,6 +192,10 @@ func (uc *EmployeeUseCase) Create(ctx context.Context, employee *domain.Employee
 		return nil, fmt.Errorf("failed to create employee and user: %w", err)
 	}
 
+	uc.recordEvent(ctx, hireEvent(employee))
+	uc.startProbation(ctx, employee)
+	uc.startOnboarding(ctx, employee)
+
 	if err := uc.updateSubscriptionEmployeeCount(ctx, creatorEmployeeID); err != nil {
 		log.Printf("EmployeeUseCase: Warning - failed to update subscription employee count: %v", err)
 
@@ -194,6 +271,16 @@ func (uc *EmployeeUseCase) getCurrentEmployeeCount(ctx context.Context, adminUse
 		return 0, fmt.Errorf("failed to get admin employee: %w", err)
 	}
 
+	if adminEmployee.CompanyID != nil {
+		totalCount, err := uc.countCompanyEmployees(ctx, *adminEmployee.CompanyID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count company employees: %w", err)
+		}
+
+		log.Printf("EmployeeUseCase: Total employee count for company %d (including admin): %d", *adminEmployee.CompanyID, totalCount)
+		return totalCount, nil
+	}
+
 	totalCount, err := uc.countAllEmployeesRecursively(ctx, adminEmployee.ID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count all employees recursively: %w", err)
@@ -205,6 +292,36 @@ func (uc *EmployeeUseCase) getCurrentEmployeeCount(ctx context.Context, adminUse
 	return totalCount, nil
 }
 
+// countCompanyEmployees counts the active employees of a company, leaving out those on a
+// seat-exempt long-term absence.
+func (uc *EmployeeUseCase) countCompanyEmployees(ctx context.Context, companyID uint) (int, error) {
+	_, activeCount, err := uc.employeeRepo.List(ctx, map[string]interface{}{
+		"company_id":        companyID,
+		"employment_status": true,
+	}, domain.PaginationParams{Page: 1, PageSize: 1})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count employees of company %d: %w", companyID, err)
+	}
+
+	absentEmployees, _, err := uc.employeeRepo.List(ctx, map[string]interface{}{
+		"company_id":            companyID,
+		"employment_status":     true,
+		"has_long_term_absence": true,
+	}, domain.PaginationParams{Page: 1, PageSize: 1000})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list employees on long-term absence of company %d: %w", companyID, err)
+	}
+
+	totalCount := int(activeCount)
+	now := time.Now()
+	for _, employee := range absentEmployees {
+		if employee.IsExcludedFromSeats(now) {
+			totalCount--
+		}
+	}
+	return totalCount, nil
+}
+
 func (uc *EmployeeUseCase) countAllEmployeesRecursively(ctx context.Context, managerID uint) (int, error) {
 
 	filters := map[string]interface{}{
@@ -231,7 +348,13 @@ func (uc *EmployeeUseCase) countAllEmployeesRecursively(ctx context.Context, man
 			return 0, fmt.Errorf("failed to get direct employees for manager %d: %w", managerID, err)
 		}
 
+		now := time.Now()
 		for _, subordinate := range subordinates {
+			// Employees on a seat-exempt long-term absence are not billed, but their reports still are
+			if subordinate.IsExcludedFromSeats(now) {
+				totalCount--
+			}
+
 			subCount, err := uc.countAllEmployeesRecursively(ctx, subordinate.ID)
 			if err != nil {
 				return 0, fmt.Errorf("failed to count employees under subordinate %d: %w", subordinate.ID, err)
@@ -289,6 +412,14 @@ func (uc *EmployeeUseCase) findAdminUserID(ctx context.Context, employee *domain
 		return employee.User.ID, nil
 	}
 
+	if employee.CompanyID != nil && uc.companyRepo != nil {
+		company, err := uc.companyRepo.GetByID(ctx, *employee.CompanyID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get company: %w", err)
+		}
+		return company.OwnerUserID, nil
+	}
+
 	if employee.ManagerID == nil {
 		return 0, fmt.Errorf("employee has no manager and is not admin")
 	}
@@ -315,6 +446,15 @@ func (uc *EmployeeUseCase) GetByID(ctx context.Context, id uint) (*dtoemployee.E
 
 	employeeDTO := dtoemployee.ToEmployeeResponseDTO(employee)
 
+	if uc.employmentEventRepo != nil {
+		events, err := uc.employmentEventRepo.ListByEmployee(ctx, id)
+		if err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to get employment history of employee ID %d: %v", id, err)
+		} else {
+			employeeDTO.EmploymentHistory = dtoemployee.ToEmploymentEventResponseDTOList(events)
+		}
+	}
+
 	log.Printf("EmployeeUseCase: Successfully retrieved employee with ID %d", id)
 	return employeeDTO, nil
 }
@@ -406,7 +546,20 @@ func (uc *EmployeeUseCase) Update(ctx context.Context, employee *domain.Employee
 		return nil, domain.ErrEmployeeNotFound
 	}
 
+	if err := uc.applyOrganization(ctx, employee); err != nil {
+		return nil, err
+	}
+	if err := uc.applyCustomFields(ctx, employee, existingEmployee.CustomFields, false); err != nil {
+		return nil, err
+	}
+
 	uc.updateEmployeeFields(existingEmployee, employee)
+	existingEmployee.CustomFields = employee.CustomFields
+	if employee.TaxStatus != nil {
+		if err := uc.checkTaxDependants(ctx, existingEmployee); err != nil {
+			return nil, err
+		}
+	}
 
 	if employee.User.Email != "" || employee.User.Phone != "" {
 		if existingEmployee.User.ID == 0 && existingEmployee.UserID != 0 {
@@ -507,6 +660,9 @@ func (uc *EmployeeUseCase) updateEmployeeFields(existing *domain.Employee, updat
 	if update.BankAccountHolderName != nil {
 		existing.BankAccountHolderName = update.BankAccountHolderName
 	}
+	if update.BaseSalary != nil {
+		existing.BaseSalary = update.BaseSalary
+	}
 	if update.TaxStatus != nil {
 		existing.TaxStatus = update.TaxStatus
 	}
@@ -532,6 +688,15 @@ func (uc *EmployeeUseCase) updateEmployeeFields(existing *domain.Employee, updat
 	if update.PositionName != "" {
 		existing.PositionName = update.PositionName
 	}
+	if update.DepartmentID != nil {
+		existing.DepartmentID = nonZeroID(update.DepartmentID)
+	}
+	if update.BranchID != nil {
+		existing.BranchID = nonZeroID(update.BranchID)
+	}
+	if update.PositionID != nil {
+		existing.PositionID = nonZeroID(update.PositionID)
+	}
 	if update.User.Email != "" {
 		log.Printf("EmployeeUseCase: Updating User Email for UserID %d to %s", existing.UserID, update.User.Email)
 		existing.User.Email = update.User.Email
@@ -542,6 +707,43 @@ func (uc *EmployeeUseCase) updateEmployeeFields(existing *domain.Employee, updat
 	}
 }
 
+// applyOrganization checks that the department, branch and position the employee is assigned to
+// exist in the company, and copies the branch and position names onto the employee.
+// An ID of 0 stands for removing the assignment and is not looked up.
+func (uc *EmployeeUseCase) applyOrganization(ctx context.Context, employee *domain.Employee) error {
+	if uc.organizationRepo == nil {
+		return nil
+	}
+
+	if employee.DepartmentID != nil && *employee.DepartmentID != 0 {
+		if _, err := uc.organizationRepo.GetDepartmentByID(ctx, *employee.DepartmentID); err != nil {
+			return fmt.Errorf("failed to get department: %w", err)
+		}
+	}
+	if employee.BranchID != nil && *employee.BranchID != 0 {
+		branch, err := uc.organizationRepo.GetBranchByID(ctx, *employee.BranchID)
+		if err != nil {
+			return fmt.Errorf("failed to get branch: %w", err)
+		}
+		employee.Branch = &branch.Name
+	}
+	if employee.PositionID != nil && *employee.PositionID != 0 {
+		position, err := uc.organizationRepo.GetPositionByID(ctx, *employee.PositionID)
+		if err != nil {
+			return fmt.Errorf("failed to get position: %w", err)
+		}
+		employee.PositionName = position.Name
+	}
+	return nil
+}
+
+func nonZeroID(id *uint) *uint {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
+
 func (uc *EmployeeUseCase) BulkImport(ctx context.Context, employees []*domain.Employee, creatorEmployeeID uint) ([]uint, []EmployeeImportError) {
 	log.Printf("EmployeeUseCase: BulkImport called for %d employees by creator %d", len(employees), creatorEmployeeID)
 
@@ -563,10 +765,29 @@ func (uc *EmployeeUseCase) BulkImport(ctx context.Context, employees []*domain.E
 	var successfulIDs []uint
 	var errors []EmployeeImportError
 
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		for i := range employees {
+			errors = append(errors, EmployeeImportError{
+				Row:     i + 2,
+				Field:   "custom_fields",
+				Message: err.Error(),
+			})
+		}
+		return []uint{}, errors
+	}
+
 	for i, employee := range employees {
 		log.Printf("EmployeeUseCase: Processing employee %d/%d: %s", i+1, len(employees), employee.FirstName)
 
-		employee.ManagerID = &creatorEmployeeID
+		if customFieldErrors := customFieldImportErrors(definitions, employee, i+2); len(customFieldErrors) > 0 {
+			errors = append(errors, customFieldErrors...)
+			continue
+		}
+
+		if employee.ManagerID == nil {
+			employee.ManagerID = &creatorEmployeeID
+		}
 
 		if employee.User.Password == "" {
 			employee.User.Password = defaultPassword
@@ -580,6 +801,9 @@ func (uc *EmployeeUseCase) BulkImport(ctx context.Context, employees []*domain.E
 			continue
 		}
 
+		uc.recordEvent(ctx, hireEvent(employee))
+		uc.startProbation(ctx, employee)
+		uc.startOnboarding(ctx, employee)
 		successfulIDs = append(successfulIDs, employee.ID)
 		log.Printf("EmployeeUseCase: Successfully created employee %s with ID %d", employee.FirstName, employee.ID)
 	}
@@ -684,7 +908,9 @@ func (uc *EmployeeUseCase) BulkImportWithTransaction(ctx context.Context, employ
 	for i, employee := range employees {
 		log.Printf("EmployeeUseCase: Processing employee %d/%d: %s", i+1, len(employees), employee.FirstName)
 
-		employee.ManagerID = &creatorEmployeeID
+		if employee.ManagerID == nil {
+			employee.ManagerID = &creatorEmployeeID
+		}
 
 		if employee.User.Password == "" {
 			employee.User.Password = defaultPassword
@@ -709,6 +935,9 @@ func (uc *EmployeeUseCase) BulkImportWithTransaction(ctx context.Context, employ
 			break
 		}
 
+		uc.recordEvent(ctx, hireEvent(employee))
+		uc.startProbation(ctx, employee)
+		uc.startOnboarding(ctx, employee)
 		successfulIDs = append(successfulIDs, employee.ID)
 		log.Printf("EmployeeUseCase: Successfully created employee %s with ID %d", employee.FirstName, employee.ID)
 	}
@@ -731,6 +960,11 @@ func (uc *EmployeeUseCase) BulkImportWithTransaction(ctx context.Context, employ
 func (uc *EmployeeUseCase) preValidateEmployees(ctx context.Context, employees []*domain.Employee) []EmployeeImportError {
 	var validationErrors []EmployeeImportError
 
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return []EmployeeImportError{{Row: 1, Field: "custom_fields", Message: err.Error()}}
+	}
+
 	for i, employee := range employees {
 
 		if employee.User.Password == "" {
@@ -745,6 +979,9 @@ func (uc *EmployeeUseCase) preValidateEmployees(ctx context.Context, employees [
 
 		dbErrors := uc.checkExistingRecords(ctx, employee, i+2)
 		validationErrors = append(validationErrors, dbErrors...)
+
+		customFieldErrors := customFieldImportErrors(definitions, employee, i+2)
+		validationErrors = append(validationErrors, customFieldErrors...)
 	}
 
 	return validationErrors
@@ -1039,6 +1276,8 @@ func (uc *EmployeeUseCase) convertToImportError(err error, employee *domain.Empl
 	}
 }
 
+// Resign ends the employment of an employee immediately. A scheduled offboarding of the employee
+// is completed with today as the last working day.
 func (uc *EmployeeUseCase) Resign(ctx context.Context, id uint) error {
 	log.Printf("EmployeeUseCase: Resign called for employee ID: %d", id)
 
@@ -1052,27 +1291,119 @@ func (uc *EmployeeUseCase) Resign(ctx context.Context, id uint) error {
 		return fmt.Errorf("failed to get employee by ID %d: %w", id, err)
 	}
 
-	employee.EmploymentStatus = false
 	now := time.Now()
-	employee.ResignationDate = &now
+	var reason *string
+	var offboarding *domain.Offboarding
+	if uc.offboardingRepo != nil {
+		offboarding, err = uc.offboardingRepo.GetLatestByEmployee(ctx, id)
+		if err != nil && !errors.Is(err, domain.ErrOffboardingNotFound) {
+			return fmt.Errorf("failed to get offboarding of employee ID %d: %w", id, err)
+		}
+		if offboarding != nil && offboarding.Status == domain.OffboardingScheduled {
+			reason = offboarding.Notes
+		} else {
+			offboarding = nil
+		}
+	}
 
-	err = uc.employeeRepo.Update(ctx, employee)
+	if err := uc.completeResignation(ctx, employee, now, reason); err != nil {
+		return err
+	}
+
+	if offboarding != nil {
+		offboarding.LastWorkingDay = now
+		offboarding.Status = domain.OffboardingCompleted
+		offboarding.CompletedAt = &now
+		if err := uc.offboardingRepo.Update(ctx, offboarding); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to complete offboarding ID %d: %v", offboarding.ID, err)
+		}
+	}
+
+	log.Printf("EmployeeUseCase: Successfully resigned employee with ID %d", id)
+	return nil
+}
+
+// getActiveManager returns the employee that is about to become someone's manager.
+func (uc *EmployeeUseCase) getActiveManager(ctx context.Context, managerID uint) (*domain.Employee, error) {
+	manager, err := uc.employeeRepo.GetByID(ctx, managerID)
 	if err != nil {
-		log.Printf("EmployeeUseCase: Error updating employee resignation status in repository: %v", err)
-		return fmt.Errorf("failed to update employee resignation status: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrManagerNotFound
+		}
+		return nil, fmt.Errorf("failed to get manager ID %d: %w", managerID, err)
+	}
+	if !manager.EmploymentStatus {
+		return nil, domain.ErrManagerResigned
+	}
+	return manager, nil
+}
+
+// ReassignManager moves a single employee to a new manager.
+func (uc *EmployeeUseCase) ReassignManager(ctx context.Context, employeeID uint, managerID uint) (*domain.Employee, error) {
+	if err := uc.BulkReassignManager(ctx, []uint{employeeID}, managerID); err != nil {
+		return nil, err
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh employee ID %d: %w", employeeID, err)
+	}
+	return employee, nil
+}
+
+// BulkReassignManager moves the employees to a new manager. The whole batch is rejected when the
+// manager is one of the employees or reports to one of them, as that would create a loop in the
+// reporting line.
+func (uc *EmployeeUseCase) BulkReassignManager(ctx context.Context, employeeIDs []uint, managerID uint) error {
+	log.Printf("EmployeeUseCase: BulkReassignManager called for %d employees to manager ID %d", len(employeeIDs), managerID)
+
+	if _, err := uc.getActiveManager(ctx, managerID); err != nil {
+		return err
 	}
 
-	if employee.ManagerID != nil {
-		if err := uc.updateSubscriptionEmployeeCount(ctx, *employee.ManagerID); err != nil {
-			log.Printf("EmployeeUseCase: Warning - failed to update subscription employee count after resignation: %v", err)
+	for _, employeeID := range employeeIDs {
+		if employeeID == managerID {
+			return domain.ErrManagerCycle
+		}
 
+		if _, err := uc.employeeRepo.GetByID(ctx, employeeID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrEmployeeNotFound
+			}
+			return fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+		}
+
+		reportIDs, err := uc.employeeRepo.GetReportingLineIDs(ctx, employeeID)
+		if err != nil {
+			return fmt.Errorf("failed to get reporting line of employee ID %d: %w", employeeID, err)
+		}
+		for _, reportID := range reportIDs {
+			if reportID == managerID {
+				return domain.ErrManagerCycle
+			}
 		}
 	}
 
-	log.Printf("EmployeeUseCase: Successfully resigned employee with ID %d", id)
+	if err := uc.employeeRepo.UpdateManager(ctx, employeeIDs, managerID); err != nil {
+		return fmt.Errorf("failed to reassign manager: %w", err)
+	}
+
+	log.Printf("EmployeeUseCase: Reassigned %d employees to manager ID %d", len(employeeIDs), managerID)
 	return nil
 }
 
+// ListDirectReports lists the employees reporting directly to the manager.
+func (uc *EmployeeUseCase) ListDirectReports(ctx context.Context, managerID uint, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoemployee.EmployeeListResponseData, error) {
+	filters["manager_id"] = managerID
+	return uc.List(ctx, filters, paginationParams)
+}
+
+// ListReportingLine lists everyone below the manager in the reporting line, at any depth.
+func (uc *EmployeeUseCase) ListReportingLine(ctx context.Context, managerID uint, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoemployee.EmployeeListResponseData, error) {
+	filters["reporting_line"] = managerID
+	return uc.List(ctx, filters, paginationParams)
+}
+
 func (uc *EmployeeUseCase) GetStatisticsByManager(ctx context.Context, managerID uint) (*dtoemployee.EmployeeStatisticsResponseDTO, error) {
 	log.Printf("EmployeeUseCase: GetStatisticsByManager called for manager ID: %d", managerID)
 
@@ -12,6 +12,7 @@ import (
 	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
 	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	supa "github.com/supabase-community/supabase-go"
@@ -1253,6 +1254,10 @@ func TestEmployeeUseCase_Resign(t *testing.T) {
 					}).
 					Return(tt.mockUpdateError).Once()
 			}
+			if tt.mockGetError == nil && tt.mockUpdateError == nil {
+				mockAuthRepo.On("RevokeAllUserRefreshTokens", ctx, tt.mockEmployee.UserID).Return(nil).Once()
+				mockAuthRepo.On("DeactivateUser", ctx, tt.mockEmployee.UserID).Return(nil).Once()
+			}
 
 			err := uc.Resign(ctx, tt.inputID)
 
@@ -1267,6 +1272,86 @@ func TestEmployeeUseCase_Resign(t *testing.T) {
 			}
 
 			mockEmployeeRepo.AssertExpectations(t)
+			mockAuthRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeUseCase_BulkReassignManager(t *testing.T) {
+	ctx := context.Background()
+	managerID := uint(10)
+
+	activeManager := &domain.Employee{ID: managerID, FirstName: "Maria", EmploymentStatus: true}
+	resignedManager := &domain.Employee{ID: managerID, FirstName: "Maria", EmploymentStatus: false}
+
+	tests := []struct {
+		name           string
+		employeeIDs    []uint
+		mockManager    *domain.Employee
+		mockManagerErr error
+		reportingLines map[uint][]uint
+		expectUpdate   bool
+		checkErrorIs   error
+	}{
+		{
+			name:           "reassign to an unrelated manager",
+			employeeIDs:    []uint{1, 2},
+			mockManager:    activeManager,
+			reportingLines: map[uint][]uint{1: {3}, 2: {}},
+			expectUpdate:   true,
+		},
+		{
+			name:           "manager not found",
+			employeeIDs:    []uint{1},
+			mockManagerErr: gorm.ErrRecordNotFound,
+			checkErrorIs:   domain.ErrManagerNotFound,
+		},
+		{
+			name:         "manager has resigned",
+			employeeIDs:  []uint{1},
+			mockManager:  resignedManager,
+			checkErrorIs: domain.ErrManagerResigned,
+		},
+		{
+			name:         "employee cannot manage themselves",
+			employeeIDs:  []uint{managerID},
+			mockManager:  activeManager,
+			checkErrorIs: domain.ErrManagerCycle,
+		},
+		{
+			name:           "manager reports to one of the employees",
+			employeeIDs:    []uint{1, 2},
+			mockManager:    activeManager,
+			reportingLines: map[uint][]uint{1: {}, 2: {5, managerID}},
+			checkErrorIs:   domain.ErrManagerCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockAuthRepo := new(mocks.AuthRepository)
+			mockXenditRepo := new(mocks.XenditRepository)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, mockAuthRepo, mockXenditRepo, &supa.Client{}, &gorm.DB{})
+
+			mockEmployeeRepo.On("GetByID", ctx, managerID).Return(tt.mockManager, tt.mockManagerErr).Once()
+			for employeeID, reportIDs := range tt.reportingLines {
+				mockEmployeeRepo.On("GetByID", ctx, employeeID).Return(&domain.Employee{ID: employeeID}, nil).Maybe()
+				mockEmployeeRepo.On("GetReportingLineIDs", ctx, employeeID).Return(reportIDs, nil).Maybe()
+			}
+			if tt.expectUpdate {
+				mockEmployeeRepo.On("UpdateManager", ctx, tt.employeeIDs, managerID).Return(nil).Once()
+			}
+
+			err := uc.BulkReassignManager(ctx, tt.employeeIDs, managerID)
+
+			if tt.checkErrorIs != nil {
+				assert.ErrorIs(t, err, tt.checkErrorIs)
+				mockEmployeeRepo.AssertNotCalled(t, "UpdateManager", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockEmployeeRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -1560,6 +1645,55 @@ func TestEmployeeUseCase_GetCurrentEmployeeCount(t *testing.T) {
 			expectedCount: 3, // Admin + 2 subordinates
 			description:   "Should count admin plus all direct subordinates in flat hierarchy",
 		},
+		{
+			name: "subordinate on seat-exempt long-term absence is not counted",
+			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockAuthRepo *mocks.AuthRepository) {
+				mockAuthRepo.On("GetUserByID", ctx, adminUserID).Return(adminUser, nil)
+				mockEmployeeRepo.On("GetByUserID", ctx, adminUserID).Return(adminEmployee, nil)
+
+				sabbatical := enums.Sabbatical
+				absenceStart := time.Now().AddDate(0, -1, 0)
+				absenceEnd := time.Now().AddDate(0, 2, 0)
+				onSabbatical := &domain.Employee{
+					ID:                       2,
+					ManagerID:                &adminEmployeeID,
+					AbsenceType:              &sabbatical,
+					AbsenceStartDate:         &absenceStart,
+					AbsenceEndDate:           &absenceEnd,
+					AbsenceExcludedFromSeats: true,
+				}
+				working := &domain.Employee{ID: 3, ManagerID: &adminEmployeeID}
+				subordinates := []*domain.Employee{onSabbatical, working}
+
+				mockEmployeeRepo.On("List", ctx,
+					map[string]interface{}{
+						"manager_id":        adminEmployeeID,
+						"employment_status": true,
+					},
+					domain.PaginationParams{Page: 1, PageSize: 1}).
+					Return([]*domain.Employee{}, int64(2), nil)
+
+				mockEmployeeRepo.On("List", ctx,
+					map[string]interface{}{
+						"manager_id":        adminEmployeeID,
+						"employment_status": true,
+					},
+					domain.PaginationParams{Page: 1, PageSize: 2}).
+					Return(subordinates, int64(2), nil)
+
+				for _, sub := range subordinates {
+					mockEmployeeRepo.On("List", ctx,
+						map[string]interface{}{
+							"manager_id":        sub.ID,
+							"employment_status": true,
+						},
+						domain.PaginationParams{Page: 1, PageSize: 1}).
+						Return([]*domain.Employee{}, int64(0), nil)
+				}
+			},
+			expectedCount: 2, // Admin + 1 working subordinate
+			description:   "Should skip employees whose long-term absence is excluded from seats",
+		},
 		{
 			name: "admin with multi-level hierarchy",
 			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockAuthRepo *mocks.AuthRepository) {
@@ -1668,6 +1802,39 @@ func TestEmployeeUseCase_GetCurrentEmployeeCount(t *testing.T) {
 			expectedCount: 2, // Admin + 1 active employee (inactive employees excluded)
 			description:   "Should only count active employees (employment_status: true)",
 		},
+		{
+			name: "admin with a company - counts the whole company",
+			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockAuthRepo *mocks.AuthRepository) {
+				companyID := uint(7)
+				companyAdmin := &domain.Employee{ID: adminEmployeeID, UserID: adminUserID, User: *adminUser, CompanyID: &companyID}
+				mockAuthRepo.On("GetUserByID", ctx, adminUserID).Return(adminUser, nil)
+				mockEmployeeRepo.On("GetByUserID", ctx, adminUserID).Return(companyAdmin, nil)
+
+				// Admin plus 3 employees, wherever they sit in the reporting lines
+				mockEmployeeRepo.On("List", ctx,
+					map[string]interface{}{
+						"company_id":        companyID,
+						"employment_status": true,
+					},
+					domain.PaginationParams{Page: 1, PageSize: 1}).
+					Return([]*domain.Employee{}, int64(4), nil)
+
+				// One of them is on a seat-exempt sabbatical
+				sabbatical := enums.Sabbatical
+				start := time.Now().AddDate(0, -1, 0)
+				end := time.Now().AddDate(0, 1, 0)
+				mockEmployeeRepo.On("List", ctx,
+					map[string]interface{}{
+						"company_id":            companyID,
+						"employment_status":     true,
+						"has_long_term_absence": true,
+					},
+					domain.PaginationParams{Page: 1, PageSize: 1000}).
+					Return([]*domain.Employee{{ID: 4, AbsenceType: &sabbatical, AbsenceStartDate: &start, AbsenceEndDate: &end, AbsenceExcludedFromSeats: true}}, int64(1), nil)
+			},
+			expectedCount: 3, // Admin + 2 billable employees
+			description:   "Should count active company employees without walking the manager tree",
+		},
 		{
 			name: "non-admin user should return error",
 			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockAuthRepo *mocks.AuthRepository) {
@@ -1711,3 +1878,1682 @@ func TestEmployeeUseCase_GetCurrentEmployeeCount(t *testing.T) {
 		})
 	}
 }
+
+func TestEmployeeUseCase_RecordEmploymentEvent(t *testing.T) {
+	ctx := context.Background()
+	effectiveDate := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	newEmployee := func() *domain.Employee {
+		return &domain.Employee{
+			ID:               1,
+			FirstName:        "John",
+			PositionName:     "Engineer",
+			Grade:            stringPtr("G3"),
+			EmploymentStatus: true,
+		}
+	}
+
+	futureDate := startOfDay(time.Now()).AddDate(0, 1, 0)
+
+	tests := []struct {
+		name           string
+		eventType      domain.EmploymentEventType
+		effectiveDate  time.Time
+		change         *domain.Employee
+		employee       *domain.Employee
+		expectSave     bool
+		expectSchedule bool
+		checkErrorIs   error
+		checkEvent     func(t *testing.T, event *domain.EmploymentEvent, employee *domain.Employee)
+	}{
+		{
+			name:       "promotion records previous and new position",
+			eventType:  domain.EmploymentEventPromotion,
+			change:     &domain.Employee{ID: 1, PositionName: "Senior Engineer", Grade: stringPtr("G4")},
+			employee:   newEmployee(),
+			expectSave: true,
+			checkEvent: func(t *testing.T, event *domain.EmploymentEvent, employee *domain.Employee) {
+				assert.Equal(t, "Engineer", *event.PreviousPositionName)
+				assert.Equal(t, "Senior Engineer", *event.NewPositionName)
+				assert.Equal(t, "G3", *event.PreviousGrade)
+				assert.Equal(t, "G4", *event.NewGrade)
+				assert.Nil(t, event.PreviousBaseSalary)
+				assert.False(t, event.Scheduled)
+				assert.Equal(t, "Senior Engineer", employee.PositionName)
+				assert.Equal(t, "G4", *employee.Grade)
+			},
+		},
+		{
+			name:           "future promotion is scheduled without changing the employee",
+			eventType:      domain.EmploymentEventPromotion,
+			effectiveDate:  futureDate,
+			change:         &domain.Employee{ID: 1, PositionName: "Senior Engineer"},
+			employee:       newEmployee(),
+			expectSchedule: true,
+			checkEvent: func(t *testing.T, event *domain.EmploymentEvent, employee *domain.Employee) {
+				assert.True(t, event.Scheduled)
+				assert.Nil(t, event.PreviousPositionName)
+				assert.Equal(t, "Senior Engineer", *event.NewPositionName)
+				assert.Equal(t, "Engineer", employee.PositionName)
+			},
+		},
+		{
+			name:         "transfer without a branch or department",
+			eventType:    domain.EmploymentEventTransfer,
+			change:       &domain.Employee{ID: 1, Grade: stringPtr("G4")},
+			employee:     newEmployee(),
+			checkErrorIs: domain.ErrInvalidEmploymentEvent,
+		},
+		{
+			name:         "hire cannot be recorded manually",
+			eventType:    domain.EmploymentEventHire,
+			change:       &domain.Employee{ID: 1},
+			employee:     newEmployee(),
+			checkErrorIs: domain.ErrInvalidEmploymentEvent,
+		},
+		{
+			name:         "resigned employee",
+			eventType:    domain.EmploymentEventSalaryChange,
+			change:       &domain.Employee{ID: 1},
+			employee:     &domain.Employee{ID: 1, EmploymentStatus: false},
+			checkErrorIs: domain.ErrInvalidEmploymentEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockEventRepo := new(mocks.EmploymentEventRepository)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{EmploymentEventRepo: mockEventRepo})
+
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(tt.employee, nil).Once()
+			if tt.expectSave {
+				mockEmployeeRepo.On("ApplyEmploymentEvent", ctx, tt.employee, mock.AnythingOfType("*domain.EmploymentEvent")).Return(nil).Once()
+			}
+			if tt.expectSchedule {
+				mockEventRepo.On("Create", ctx, mock.AnythingOfType("*domain.EmploymentEvent")).Return(nil).Once()
+			}
+
+			eventDate := effectiveDate
+			if !tt.effectiveDate.IsZero() {
+				eventDate = tt.effectiveDate
+			}
+			event := &domain.EmploymentEvent{EmployeeID: 1, EventType: tt.eventType, EffectiveDate: eventDate}
+			result, err := uc.RecordEmploymentEvent(ctx, event, tt.change)
+
+			if tt.checkErrorIs != nil {
+				assert.ErrorIs(t, err, tt.checkErrorIs)
+				assert.Nil(t, result)
+				mockEmployeeRepo.AssertNotCalled(t, "ApplyEmploymentEvent", mock.Anything, mock.Anything, mock.Anything)
+				mockEventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, string(tt.eventType), result.EventType)
+				assert.Equal(t, eventDate.Format("2006-01-02"), result.EffectiveDate)
+				tt.checkEvent(t, event, tt.employee)
+			}
+			if tt.expectSchedule {
+				mockEmployeeRepo.AssertNotCalled(t, "ApplyEmploymentEvent", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockEmployeeRepo.AssertExpectations(t)
+			mockEventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeUseCase_GetTurnoverReport(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	employees := []*domain.Employee{
+		{ID: 1, HireDate: date(2020, time.May, 1), EmploymentStatus: true},
+		{ID: 2, HireDate: date(2021, time.May, 1), EmploymentStatus: true},
+		{ID: 3, HireDate: date(2022, time.May, 1), ResignationDate: date(2025, time.March, 15)},
+		{ID: 4, HireDate: date(2023, time.May, 1), ResignationDate: date(2024, time.December, 1)},
+		{ID: 5, HireDate: date(2025, time.February, 1), EmploymentStatus: true},
+	}
+	events := []*domain.EmploymentEvent{
+		{EmployeeID: 5, EventType: domain.EmploymentEventHire},
+		{EmployeeID: 3, EventType: domain.EmploymentEventResignation},
+		{EmployeeID: 1, EventType: domain.EmploymentEventPromotion},
+		{EmployeeID: 2, EventType: domain.EmploymentEventTransfer},
+	}
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockEventRepo := new(mocks.EmploymentEventRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{EmploymentEventRepo: mockEventRepo})
+
+	mockEmployeeRepo.On("ForEachBatch", ctx, map[string]interface{}{}).
+		Return(employees, nil).Once()
+	mockEventRepo.On("ListByPeriod", ctx, startDate, endDate).Return(events, nil).Once()
+
+	report, err := uc.GetTurnoverReport(ctx, startDate, endDate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.HeadcountStart)
+	assert.Equal(t, 3, report.HeadcountEnd)
+	assert.Equal(t, 1, report.Hires)
+	assert.Equal(t, 1, report.Resignations)
+	assert.Equal(t, 1, report.Promotions)
+	assert.Equal(t, 1, report.Transfers)
+	assert.Equal(t, 33.33, report.TurnoverRate)
+	mockEmployeeRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestEmployeeUseCase_ApplyDueEmploymentEvents(t *testing.T) {
+	ctx := context.Background()
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockEventRepo := new(mocks.EmploymentEventRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{EmploymentEventRepo: mockEventRepo})
+
+	salary := 9000000.0
+	due := &domain.EmploymentEvent{ID: 7, EmployeeID: 1, EventType: domain.EmploymentEventSalaryChange, NewBaseSalary: &salary, Scheduled: true}
+	resigned := &domain.EmploymentEvent{ID: 8, EmployeeID: 2, EventType: domain.EmploymentEventPromotion, NewPositionName: stringPtr("Lead"), Scheduled: true}
+	previousSalary := 7000000.0
+	employee := &domain.Employee{ID: 1, EmploymentStatus: true, BaseSalary: &previousSalary}
+
+	mockEventRepo.On("ListDue", ctx, startOfDay(time.Now())).Return([]*domain.EmploymentEvent{due, resigned}, nil).Once()
+	mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil).Once()
+	mockEmployeeRepo.On("GetByID", ctx, uint(2)).Return(&domain.Employee{ID: 2, EmploymentStatus: false}, nil).Once()
+	mockEmployeeRepo.On("ApplyEmploymentEvent", ctx, employee, due).Return(nil).Once()
+
+	result, err := uc.ApplyDueEmploymentEvents(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Due)
+	assert.Equal(t, 1, result.Applied)
+	assert.Equal(t, 1, result.Failed)
+	assert.False(t, due.Scheduled)
+	assert.Equal(t, previousSalary, *due.PreviousBaseSalary)
+	assert.Equal(t, salary, *employee.BaseSalary)
+	assert.True(t, resigned.Scheduled)
+	mockEmployeeRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestEmployeeUseCase_StartOffboarding(t *testing.T) {
+	ctx := context.Background()
+	managerID := uint(10)
+	hrUserID := uint(20)
+	hrEmployee := &domain.Employee{ID: 30, UserID: hrUserID}
+	today := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.Now().Location())
+
+	tests := []struct {
+		name           string
+		employee       *domain.Employee
+		existing       *domain.Offboarding
+		lastWorkingDay time.Time
+		checkErrorIs   error
+	}{
+		{
+			name:           "schedules with the default checklist",
+			employee:       &domain.Employee{ID: 1, EmploymentStatus: true, ManagerID: &managerID},
+			lastWorkingDay: today.AddDate(0, 0, 30),
+		},
+		{
+			name:           "previous offboarding was cancelled",
+			employee:       &domain.Employee{ID: 1, EmploymentStatus: true, ManagerID: &managerID},
+			existing:       &domain.Offboarding{ID: 5, Status: domain.OffboardingCancelled},
+			lastWorkingDay: today.AddDate(0, 0, 30),
+		},
+		{
+			name:           "offboarding already scheduled",
+			employee:       &domain.Employee{ID: 1, EmploymentStatus: true, ManagerID: &managerID},
+			existing:       &domain.Offboarding{ID: 5, Status: domain.OffboardingScheduled},
+			lastWorkingDay: today.AddDate(0, 0, 30),
+			checkErrorIs:   domain.ErrOffboardingExists,
+		},
+		{
+			name:           "last working day in the past",
+			employee:       &domain.Employee{ID: 1, EmploymentStatus: true, ManagerID: &managerID},
+			lastWorkingDay: today.AddDate(0, 0, -1),
+			checkErrorIs:   domain.ErrInvalidOffboarding,
+		},
+		{
+			name:           "employee already resigned",
+			employee:       &domain.Employee{ID: 1, EmploymentStatus: false},
+			lastWorkingDay: today.AddDate(0, 0, 30),
+			checkErrorIs:   domain.ErrInvalidOffboarding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockOffboardingRepo := new(mocks.OffboardingRepository)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{OffboardingRepo: mockOffboardingRepo})
+
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(tt.employee, nil).Once()
+			if tt.employee.EmploymentStatus {
+				if tt.existing != nil {
+					mockOffboardingRepo.On("GetLatestByEmployee", ctx, uint(1)).Return(tt.existing, nil).Once()
+				} else {
+					mockOffboardingRepo.On("GetLatestByEmployee", ctx, uint(1)).Return(nil, domain.ErrOffboardingNotFound).Once()
+				}
+			}
+			if tt.checkErrorIs == nil {
+				mockEmployeeRepo.On("GetByUserID", ctx, hrUserID).Return(hrEmployee, nil).Once()
+				mockEmployeeRepo.On("GetByID", ctx, managerID).Return(&domain.Employee{ID: managerID}, nil)
+				mockEmployeeRepo.On("GetByID", ctx, hrEmployee.ID).Return(hrEmployee, nil)
+				mockOffboardingRepo.On("Create", ctx, mock.AnythingOfType("*domain.Offboarding")).Return(nil).Once()
+			}
+
+			offboarding := &domain.Offboarding{
+				EmployeeID:      1,
+				ResignationType: domain.ResignationVoluntary,
+				ExitReason:      domain.ExitReasonCareerGrowth,
+				LastWorkingDay:  tt.lastWorkingDay,
+				InitiatedBy:     hrUserID,
+			}
+			result, err := uc.StartOffboarding(ctx, offboarding)
+
+			if tt.checkErrorIs != nil {
+				assert.ErrorIs(t, err, tt.checkErrorIs)
+				assert.Nil(t, result)
+				mockOffboardingRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, string(domain.OffboardingScheduled), result.Status)
+				assert.Equal(t, today.Format("2006-01-02"), result.NoticeDate)
+				assert.Equal(t, tt.lastWorkingDay.AddDate(0, 0, 1).Format("2006-01-02"), result.EffectiveDate)
+				assert.Len(t, offboarding.Tasks, 5)
+				for _, task := range offboarding.Tasks {
+					assert.NotNil(t, task.AssigneeID)
+					assert.False(t, task.DueDate.After(tt.lastWorkingDay))
+				}
+			}
+			mockEmployeeRepo.AssertExpectations(t)
+			mockOffboardingRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeUseCase_ProcessDueOffboardings(t *testing.T) {
+	ctx := context.Background()
+	lastWorkingDay := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	due := []*domain.Offboarding{
+		{ID: 1, EmployeeID: 1, LastWorkingDay: lastWorkingDay, Status: domain.OffboardingScheduled,
+			Employee: domain.Employee{ID: 1, UserID: 11, EmploymentStatus: true}},
+		{ID: 2, EmployeeID: 2, LastWorkingDay: lastWorkingDay, Status: domain.OffboardingScheduled,
+			Employee: domain.Employee{ID: 2, UserID: 12, EmploymentStatus: true}},
+	}
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockOffboardingRepo := new(mocks.OffboardingRepository)
+	mockContractRepo := new(mocks.EmploymentContractRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ContractRepo: mockContractRepo, OffboardingRepo: mockOffboardingRepo})
+
+	mockOffboardingRepo.On("ListDue", ctx, mock.AnythingOfType("time.Time")).Return(due, nil).Once()
+
+	mockEmployeeRepo.On("Update", ctx, &due[0].Employee).Return(nil).Once()
+	mockAuthRepo.On("RevokeAllUserRefreshTokens", ctx, uint(11)).Return(nil).Once()
+	mockAuthRepo.On("DeactivateUser", ctx, uint(11)).Return(nil).Once()
+	contract := &domain.EmploymentContract{ID: 7, EmployeeID: 1, Status: domain.ContractStatusActive}
+	mockContractRepo.On("GetActiveByEmployee", ctx, uint(1)).Return(contract, nil).Once()
+	mockContractRepo.On("Update", ctx, contract).Return(nil).Once()
+	mockOffboardingRepo.On("Update", ctx, due[0]).Return(nil).Once()
+
+	mockEmployeeRepo.On("Update", ctx, &due[1].Employee).Return(errors.New("database error")).Once()
+
+	result, err := uc.ProcessDueOffboardings(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Due)
+	assert.Equal(t, 1, result.Completed)
+	assert.Equal(t, 1, result.Failed)
+
+	assert.Equal(t, domain.OffboardingCompleted, due[0].Status)
+	assert.NotNil(t, due[0].CompletedAt)
+	assert.False(t, due[0].Employee.EmploymentStatus)
+	assert.Equal(t, lastWorkingDay, *due[0].Employee.ResignationDate)
+	assert.Equal(t, domain.ContractStatusEnded, contract.Status)
+	assert.Equal(t, domain.OffboardingScheduled, due[1].Status)
+
+	mockEmployeeRepo.AssertExpectations(t)
+	mockAuthRepo.AssertExpectations(t)
+	mockContractRepo.AssertExpectations(t)
+	mockOffboardingRepo.AssertExpectations(t)
+}
+
+func TestEmployeeUseCase_GetFinalSettlement(t *testing.T) {
+	ctx := context.Background()
+	lastWorkingDay := time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC)
+	baseSalary := 9000000.0
+	completedAt := time.Now()
+
+	employee := &domain.Employee{ID: 1, FirstName: "John", BaseSalary: &baseSalary, EmploymentStatus: true}
+	offboarding := &domain.Offboarding{
+		ID:              3,
+		EmployeeID:      1,
+		ResignationType: domain.ResignationVoluntary,
+		LastWorkingDay:  lastWorkingDay,
+		Status:          domain.OffboardingScheduled,
+		Tasks: []domain.OffboardingTask{
+			{ID: 1, Title: "Exit interview", DueDate: lastWorkingDay, CompletedAt: &completedAt},
+			{ID: 2, Title: "Return company equipment", DueDate: lastWorkingDay},
+		},
+	}
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockOffboardingRepo := new(mocks.OffboardingRepository)
+	mockLeaveEncashmentUC := new(mocks.LeaveEncashmentUseCase)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{LeaveEncashmentUC: mockLeaveEncashmentUC, OffboardingRepo: mockOffboardingRepo})
+
+	mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil).Twice()
+	mockOffboardingRepo.On("GetLatestByEmployee", ctx, uint(1)).Return(offboarding, nil).Once()
+	mockLeaveEncashmentUC.On("PreviewResignationEncashment", ctx, employee, lastWorkingDay).
+		Return(&domain.LeaveEncashment{Year: 2025, EntitledDays: 4, UsedDays: 1, EncashedDays: 3, DailyRate: 409090.91, Amount: 1227272.73}, nil).Once()
+
+	settlement, err := uc.GetFinalSettlement(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, settlement.DaysWorked)
+	assert.Equal(t, 30, settlement.DaysInFinalMonth)
+	assert.Equal(t, 3000000.0, settlement.ProratedSalary)
+	assert.Equal(t, 3, settlement.LeaveBalance.RemainingDays)
+	assert.Equal(t, 4227272.73, settlement.TotalPayable)
+	assert.Len(t, settlement.OutstandingTasks, 1)
+	assert.Equal(t, uint(2), settlement.OutstandingTasks[0].ID)
+	mockEmployeeRepo.AssertExpectations(t)
+	mockOffboardingRepo.AssertExpectations(t)
+	mockLeaveEncashmentUC.AssertExpectations(t)
+}
+
+func TestEmployeeUseCase_ReviewProbation(t *testing.T) {
+	ctx := context.Background()
+	today := startOfDay(time.Now())
+	startDate := today.AddDate(0, -2, 0)
+	endDate := startDate.AddDate(0, domain.DefaultProbationMonths, -1)
+	extendedTo := endDate.AddDate(0, 1, 0)
+	tooLong := startDate.AddDate(0, domain.MaxProbationMonths+1, 0)
+	reviewerID := uint(5)
+	employeeUserID := uint(11)
+	contractType := enums.Contract
+
+	tests := []struct {
+		name          string
+		review        *domain.ProbationReview
+		status        domain.ProbationStatus
+		setupMocks    func(mockEmployeeRepo *mocks.EmployeeRepository, mockContractRepo *mocks.EmploymentContractRepository, mockOffboardingRepo *mocks.OffboardingRepository, mockProbationRepo *mocks.ProbationRepository, probation *domain.Probation, employee *domain.Employee)
+		expectedErr   error
+		checkResponse func(t *testing.T, probation *domain.Probation, employee *domain.Employee, result *dtoemployee.ProbationResponseDTO)
+	}{
+		{
+			name:   "confirm makes the employee permanent and converts the contract",
+			review: &domain.ProbationReview{ReviewerID: reviewerID, Outcome: domain.ProbationOutcomeConfirm},
+			status: domain.ProbationInProgress,
+			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockContractRepo *mocks.EmploymentContractRepository, mockOffboardingRepo *mocks.OffboardingRepository, mockProbationRepo *mocks.ProbationRepository, probation *domain.Probation, employee *domain.Employee) {
+				mockEmployeeRepo.On("Update", ctx, employee).Return(nil).Once()
+				contract := &domain.EmploymentContract{ID: 4, EmployeeID: 1, ContractType: enums.Contract, Status: domain.ContractStatusActive}
+				mockContractRepo.On("GetActiveByEmployee", ctx, uint(1)).Return(contract, nil).Once()
+				mockContractRepo.On("Update", ctx, contract).Return(nil).Once()
+				mockContractRepo.On("Create", ctx, mock.MatchedBy(func(c *domain.EmploymentContract) bool {
+					return c.ContractType == enums.Permanent && c.Status == domain.ContractStatusActive
+				})).Return(nil).Once()
+				mockProbationRepo.On("CreateReview", ctx, mock.Anything).Return(nil).Once()
+				mockProbationRepo.On("Update", ctx, probation).Return(nil).Once()
+			},
+			checkResponse: func(t *testing.T, probation *domain.Probation, employee *domain.Employee, result *dtoemployee.ProbationResponseDTO) {
+				assert.Equal(t, domain.ProbationConfirmed, probation.Status)
+				assert.Equal(t, enums.Permanent, *employee.ContractType)
+				assert.Len(t, result.Reviews, 1)
+			},
+		},
+		{
+			name:   "extend moves the end date",
+			review: &domain.ProbationReview{ReviewerID: reviewerID, Outcome: domain.ProbationOutcomeExtend, NewEndDate: &extendedTo},
+			status: domain.ProbationInProgress,
+			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockContractRepo *mocks.EmploymentContractRepository, mockOffboardingRepo *mocks.OffboardingRepository, mockProbationRepo *mocks.ProbationRepository, probation *domain.Probation, employee *domain.Employee) {
+				mockProbationRepo.On("CreateReview", ctx, mock.Anything).Return(nil).Once()
+				mockProbationRepo.On("Update", ctx, probation).Return(nil).Once()
+			},
+			checkResponse: func(t *testing.T, probation *domain.Probation, employee *domain.Employee, result *dtoemployee.ProbationResponseDTO) {
+				assert.Equal(t, domain.ProbationInProgress, probation.Status)
+				assert.Equal(t, extendedTo, probation.EndDate)
+				assert.Nil(t, probation.LastReminderDays)
+				assert.Equal(t, endDate, probation.Reviews[0].PreviousEndDate)
+			},
+		},
+		{
+			name:   "extend beyond the maximum probation length",
+			review: &domain.ProbationReview{ReviewerID: reviewerID, Outcome: domain.ProbationOutcomeExtend, NewEndDate: &tooLong},
+			status: domain.ProbationInProgress,
+			setupMocks: func(*mocks.EmployeeRepository, *mocks.EmploymentContractRepository, *mocks.OffboardingRepository, *mocks.ProbationRepository, *domain.Probation, *domain.Employee) {
+			},
+			expectedErr: domain.ErrInvalidProbation,
+		},
+		{
+			name:   "terminate schedules the offboarding",
+			review: &domain.ProbationReview{ReviewerID: reviewerID, Outcome: domain.ProbationOutcomeTerminate},
+			status: domain.ProbationInProgress,
+			setupMocks: func(mockEmployeeRepo *mocks.EmployeeRepository, mockContractRepo *mocks.EmploymentContractRepository, mockOffboardingRepo *mocks.OffboardingRepository, mockProbationRepo *mocks.ProbationRepository, probation *domain.Probation, employee *domain.Employee) {
+				mockOffboardingRepo.On("GetLatestByEmployee", ctx, uint(1)).Return(nil, domain.ErrOffboardingNotFound).Once()
+				mockEmployeeRepo.On("GetByUserID", ctx, reviewerID).Return(nil, gorm.ErrRecordNotFound).Once()
+				mockOffboardingRepo.On("Create", ctx, mock.MatchedBy(func(o *domain.Offboarding) bool {
+					return o.ResignationType == domain.ResignationTermination && o.ExitReason == domain.ExitReasonPerformance && o.LastWorkingDay.Equal(endDate)
+				})).Return(nil).Once()
+				mockProbationRepo.On("CreateReview", ctx, mock.Anything).Return(nil).Once()
+				mockProbationRepo.On("Update", ctx, probation).Return(nil).Once()
+			},
+			checkResponse: func(t *testing.T, probation *domain.Probation, employee *domain.Employee, result *dtoemployee.ProbationResponseDTO) {
+				assert.Equal(t, domain.ProbationTerminated, probation.Status)
+				assert.NotNil(t, result.Offboarding)
+			},
+		},
+		{
+			name:   "probation already concluded",
+			review: &domain.ProbationReview{ReviewerID: reviewerID, Outcome: domain.ProbationOutcomeConfirm},
+			status: domain.ProbationConfirmed,
+			setupMocks: func(*mocks.EmployeeRepository, *mocks.EmploymentContractRepository, *mocks.OffboardingRepository, *mocks.ProbationRepository, *domain.Probation, *domain.Employee) {
+			},
+			expectedErr: domain.ErrProbationNotInProgress,
+		},
+		{
+			name:   "the employee cannot review their own probation",
+			review: &domain.ProbationReview{ReviewerID: employeeUserID, Outcome: domain.ProbationOutcomeConfirm},
+			status: domain.ProbationInProgress,
+			setupMocks: func(*mocks.EmployeeRepository, *mocks.EmploymentContractRepository, *mocks.OffboardingRepository, *mocks.ProbationRepository, *domain.Probation, *domain.Employee) {
+			},
+			expectedErr: domain.ErrProbationSelfReview,
+		},
+		{
+			name:   "someone other than the manager or the owner cannot review",
+			review: &domain.ProbationReview{ReviewerID: 77, Outcome: domain.ProbationOutcomeConfirm},
+			status: domain.ProbationInProgress,
+			setupMocks: func(*mocks.EmployeeRepository, *mocks.EmploymentContractRepository, *mocks.OffboardingRepository, *mocks.ProbationRepository, *domain.Probation, *domain.Employee) {
+			},
+			expectedErr: domain.ErrProbationReviewDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employee := &domain.Employee{ID: 1, UserID: employeeUserID, FirstName: "John", ContractType: &contractType, EmploymentStatus: true, Manager: &domain.Employee{ID: 9, UserID: reviewerID}}
+			probation := &domain.Probation{ID: 2, EmployeeID: 1, StartDate: startDate, EndDate: endDate, Status: tt.status}
+
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockContractRepo := new(mocks.EmploymentContractRepository)
+			mockOffboardingRepo := new(mocks.OffboardingRepository)
+			mockProbationRepo := new(mocks.ProbationRepository)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ContractRepo: mockContractRepo, OffboardingRepo: mockOffboardingRepo, ProbationRepo: mockProbationRepo})
+
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil)
+			mockProbationRepo.On("GetLatestByEmployee", ctx, uint(1)).Return(probation, nil).Once()
+			tt.setupMocks(mockEmployeeRepo, mockContractRepo, mockOffboardingRepo, mockProbationRepo, probation, employee)
+
+			result, err := uc.ReviewProbation(ctx, 1, tt.review, nil)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				mockProbationRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				tt.checkResponse(t, probation, employee, result)
+			}
+
+			mockEmployeeRepo.AssertExpectations(t)
+			mockContractRepo.AssertExpectations(t)
+			mockOffboardingRepo.AssertExpectations(t)
+			mockProbationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmployeeUseCase_ProcessProbationReviewReminders(t *testing.T) {
+	ctx := context.Background()
+	today := startOfDay(time.Now())
+	companyID := uint(3)
+	sevenDays := 7
+
+	manager := &domain.Employee{ID: 9, UserID: 19, FirstName: "Jane"}
+	dueSoon := &domain.Probation{ID: 1, CompanyID: &companyID, EmployeeID: 1, Status: domain.ProbationInProgress,
+		EndDate:  today.AddDate(0, 0, domain.ProbationReviewLeadDays+7),
+		Employee: domain.Employee{ID: 1, FirstName: "John", Manager: manager}}
+	alreadyReminded := &domain.Probation{ID: 2, CompanyID: &companyID, EmployeeID: 2, Status: domain.ProbationInProgress,
+		EndDate: today.AddDate(0, 0, domain.ProbationReviewLeadDays+5), LastReminderDays: &sevenDays,
+		Employee: domain.Employee{ID: 2, FirstName: "Bob"}}
+	notDue := &domain.Probation{ID: 3, CompanyID: &companyID, EmployeeID: 3, Status: domain.ProbationInProgress,
+		EndDate:  today.AddDate(0, 2, 0),
+		Employee: domain.Employee{ID: 3, FirstName: "Ann"}}
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockCompanyRepo := new(mocks.CompanyRepository)
+	mockProbationRepo := new(mocks.ProbationRepository)
+	mockNotifier := new(mocks.EmploymentNotifier)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{CompanyRepo: mockCompanyRepo, ProbationRepo: mockProbationRepo, Notifier: mockNotifier})
+
+	managerUser := &domain.User{ID: 19, Email: "manager@example.com"}
+	ownerUser := &domain.User{ID: 20, Email: "owner@example.com"}
+	mockProbationRepo.On("ListInProgress", ctx).Return([]*domain.Probation{dueSoon, alreadyReminded, notDue}, nil).Once()
+	mockCompanyRepo.On("GetByID", ctx, companyID).Return(&domain.Company{ID: companyID, OwnerUserID: 20}, nil).Once()
+	mockAuthRepo.On("GetUserByID", ctx, uint(19)).Return(managerUser, nil).Once()
+	mockAuthRepo.On("GetUserByID", ctx, uint(20)).Return(ownerUser, nil).Once()
+	mockNotifier.On("SendProbationReviewReminder", ctx, managerUser, dueSoon, 7).Return(nil).Once()
+	mockNotifier.On("SendProbationReviewReminder", ctx, ownerUser, dueSoon, 7).Return(nil).Once()
+	mockProbationRepo.On("Update", ctx, dueSoon).Return(nil).Once()
+
+	result, err := uc.ProcessProbationReviewReminders(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Checked)
+	assert.Equal(t, 1, result.Reminded)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, 7, *dueSoon.LastReminderDays)
+
+	mockAuthRepo.AssertExpectations(t)
+	mockCompanyRepo.AssertExpectations(t)
+	mockProbationRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestMergeCustomFields(t *testing.T) {
+	minSize, maxSize := 0.0, 10.0
+	pattern := `^\d{11,13}$`
+	definitions := map[string]*domain.CustomFieldDefinition{
+		"blood_type":  {Key: "blood_type", Type: domain.CustomFieldSelect, Options: []string{"A", "B", "AB", "O"}, Required: true},
+		"bpjs_number": {Key: "bpjs_number", Type: domain.CustomFieldText, Pattern: &pattern},
+		"children":    {Key: "children", Type: domain.CustomFieldNumber, Min: &minSize, Max: &maxSize},
+		"married_on":  {Key: "married_on", Type: domain.CustomFieldDate},
+		"id_scan":     {Key: "id_scan", Type: domain.CustomFieldFile},
+	}
+
+	tests := []struct {
+		name        string
+		current     map[string]interface{}
+		changes     map[string]interface{}
+		creating    bool
+		expected    map[string]interface{}
+		expectedErr error
+	}{
+		{
+			name:     "valid values are normalized",
+			changes:  map[string]interface{}{"blood_type": "O", "bpjs_number": "0001234567890", "children": "2", "married_on": "2020-05-17", "id_scan": "https://files.example.com/ktp.png"},
+			creating: true,
+			expected: map[string]interface{}{"blood_type": "O", "bpjs_number": "0001234567890", "children": 2.0, "married_on": "2020-05-17", "id_scan": "https://files.example.com/ktp.png"},
+		},
+		{
+			name:        "required field missing on create",
+			changes:     map[string]interface{}{"children": 1.0},
+			creating:    true,
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:     "update keeps other values and clears null ones",
+			current:  map[string]interface{}{"blood_type": "A", "children": 1.0},
+			changes:  map[string]interface{}{"children": nil, "married_on": "2024-01-02"},
+			expected: map[string]interface{}{"blood_type": "A", "married_on": "2024-01-02"},
+		},
+		{
+			name:        "required field cannot be cleared",
+			current:     map[string]interface{}{"blood_type": "A"},
+			changes:     map[string]interface{}{"blood_type": nil},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "unknown field",
+			changes:     map[string]interface{}{"shoe_size": "42"},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "option not allowed",
+			changes:     map[string]interface{}{"blood_type": "C"},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "number out of range",
+			changes:     map[string]interface{}{"children": 11.0},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "text not matching pattern",
+			changes:     map[string]interface{}{"bpjs_number": "12-34"},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "invalid date",
+			changes:     map[string]interface{}{"married_on": "17/05/2020"},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+		{
+			name:        "file that is not a URL",
+			changes:     map[string]interface{}{"id_scan": "ktp.png"},
+			expectedErr: domain.ErrInvalidCustomFieldValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeCustomFields(definitions, tt.current, tt.changes, tt.creating)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, merged)
+			}
+		})
+	}
+}
+
+func TestEmployeeUseCase_CustomFieldVisibility(t *testing.T) {
+	ctx := context.Background()
+	definitions := []*domain.CustomFieldDefinition{
+		{Key: "shirt_size", Type: domain.CustomFieldText, Visibility: domain.CustomFieldEmployeeEditable},
+		{Key: "performance_flag", Type: domain.CustomFieldText, Visibility: domain.CustomFieldAdminOnly},
+	}
+
+	mockCustomFieldRepo := new(mocks.CustomFieldRepository)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{CustomFieldRepo: mockCustomFieldRepo})
+	mockCustomFieldRepo.On("List", ctx).Return(definitions, nil)
+
+	assert.NoError(t, uc.CheckSelfEditableCustomFields(ctx, map[string]interface{}{"shirt_size": "L"}))
+	assert.ErrorIs(t, uc.CheckSelfEditableCustomFields(ctx, map[string]interface{}{"performance_flag": "watch"}), domain.ErrCustomFieldNotEditable)
+
+	employee := &domain.Employee{ID: 1, CustomFields: map[string]interface{}{"shirt_size": "L", "performance_flag": "watch"}}
+	assert.NoError(t, uc.HideAdminOnlyCustomFields(ctx, employee))
+	assert.Equal(t, map[string]interface{}{"shirt_size": "L"}, employee.CustomFields)
+}
+
+func TestEmployeeUseCase_CheckSelfEditableProfileFields(t *testing.T) {
+	ctx := context.Background()
+	companyID := uint(3)
+	bankAccount := "1234567890"
+	newBankAccount := "9999999999"
+	current := &domain.Employee{ID: 1, CompanyID: &companyID, FirstName: "John", BankAccountNumber: &bankAccount}
+
+	mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+	mockProfileChangeRepo.On("GetPolicy", ctx, companyID).Return(nil, domain.ErrProfileChangePolicyNotFound)
+
+	assert.NoError(t, uc.CheckSelfEditableProfileFields(ctx, current, &domain.Employee{ID: 1, FirstName: "John", BankAccountNumber: &bankAccount}))
+	assert.ErrorIs(t, uc.CheckSelfEditableProfileFields(ctx, current, &domain.Employee{ID: 1, BankAccountNumber: &newBankAccount}), domain.ErrProfileChangeRequiresApproval)
+	assert.ErrorIs(t, uc.CheckSelfEditableProfileFields(ctx, current, &domain.Employee{ID: 1, FirstName: "Johnny"}), domain.ErrProfileChangeRequiresApproval)
+}
+
+func TestEmployeeUseCase_SubmitProfileChange(t *testing.T) {
+	ctx := context.Background()
+	bankAccount := "1234567890"
+	newBankAccount := " 9999999999 "
+	invalidTaxStatus := "X/9"
+	sameName := "John"
+
+	tests := []struct {
+		name          string
+		changes       map[domain.ProfileField]*string
+		pending       []*domain.ProfileChangeRequest
+		expectedDiff  map[domain.ProfileField]domain.ProfileFieldChange
+		expectedError error
+	}{
+		{
+			name:    "records the changed fields only",
+			changes: map[domain.ProfileField]*string{domain.ProfileFieldBankAccountNumber: &newBankAccount, domain.ProfileFieldFirstName: &sameName},
+			expectedDiff: map[domain.ProfileField]domain.ProfileFieldChange{
+				domain.ProfileFieldBankAccountNumber: {From: &bankAccount, To: func() *string { v := "9999999999"; return &v }()},
+			},
+		},
+		{
+			name:          "rejects a request without changes",
+			changes:       map[domain.ProfileField]*string{domain.ProfileFieldFirstName: &sameName},
+			expectedError: domain.ErrInvalidProfileChange,
+		},
+		{
+			name:          "rejects an invalid tax status",
+			changes:       map[domain.ProfileField]*string{domain.ProfileFieldTaxStatus: &invalidTaxStatus},
+			expectedError: domain.ErrInvalidProfileChange,
+		},
+		{
+			name:    "rejects a field that is already pending",
+			changes: map[domain.ProfileField]*string{domain.ProfileFieldBankAccountNumber: &newBankAccount},
+			pending: []*domain.ProfileChangeRequest{
+				{ID: 5, Changes: map[domain.ProfileField]domain.ProfileFieldChange{domain.ProfileFieldBankAccountNumber: {}}},
+			},
+			expectedError: domain.ErrProfileChangeAlreadyPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+
+			employee := &domain.Employee{ID: 1, FirstName: "John", BankAccountNumber: &bankAccount}
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil)
+			mockProfileChangeRepo.On("List", ctx, map[string]interface{}{"employee_id": uint(1), "status": domain.ProfileChangePending}, domain.PaginationParams{}).Return(tt.pending, int64(len(tt.pending)), nil)
+			mockProfileChangeRepo.On("Create", ctx, mock.AnythingOfType("*domain.ProfileChangeRequest")).Return(nil)
+
+			result, err := uc.SubmitProfileChange(ctx, 1, tt.changes, nil, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				mockProfileChangeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDiff, result.Changes)
+			assert.Equal(t, string(domain.ProfileChangePending), result.Status)
+			assert.Equal(t, "1234567890", *employee.BankAccountNumber)
+		})
+	}
+}
+
+func TestEmployeeUseCase_ReviewProfileChange(t *testing.T) {
+	ctx := context.Background()
+	oldAccount := "1234567890"
+	currentAccount := "5555555555"
+	newAccount := "9999999999"
+
+	t.Run("approval applies the changes and records the replaced values", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+
+		request := &domain.ProfileChangeRequest{
+			ID:         7,
+			EmployeeID: 1,
+			Status:     domain.ProfileChangePending,
+			Changes: map[domain.ProfileField]domain.ProfileFieldChange{
+				domain.ProfileFieldBankAccountNumber: {From: &oldAccount, To: &newAccount},
+			},
+		}
+		employee := &domain.Employee{ID: 1, FirstName: "John", BankAccountNumber: &currentAccount}
+		mockProfileChangeRepo.On("GetByID", ctx, uint(7)).Return(request, nil)
+		mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(employee, nil)
+		mockEmployeeRepo.On("Update", ctx, employee).Return(nil)
+		mockProfileChangeRepo.On("Update", ctx, request).Return(nil)
+
+		result, err := uc.ReviewProfileChange(ctx, 7, 42, true, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(domain.ProfileChangeApproved), result.Status)
+		assert.Equal(t, newAccount, *employee.BankAccountNumber)
+		assert.Equal(t, currentAccount, *request.Changes[domain.ProfileFieldBankAccountNumber].From)
+		assert.Equal(t, uint(42), *request.ReviewedBy)
+	})
+
+	t.Run("rejection leaves the employee unchanged", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+
+		request := &domain.ProfileChangeRequest{ID: 7, EmployeeID: 1, Status: domain.ProfileChangePending}
+		mockProfileChangeRepo.On("GetByID", ctx, uint(7)).Return(request, nil)
+		mockProfileChangeRepo.On("Update", ctx, request).Return(nil)
+
+		result, err := uc.ReviewProfileChange(ctx, 7, 42, false, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(domain.ProfileChangeRejected), result.Status)
+		mockEmployeeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a reviewed request cannot be reviewed again", func(t *testing.T) {
+		mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+
+		mockProfileChangeRepo.On("GetByID", ctx, uint(7)).Return(&domain.ProfileChangeRequest{ID: 7, Status: domain.ProfileChangeApproved}, nil)
+
+		_, err := uc.ReviewProfileChange(ctx, 7, 42, true, nil)
+
+		assert.ErrorIs(t, err, domain.ErrProfileChangeNotPending)
+	})
+
+	t.Run("an employee cannot review their own request", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockProfileChangeRepo := new(mocks.ProfileChangeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ProfileChangeRepo: mockProfileChangeRepo})
+
+		request := &domain.ProfileChangeRequest{ID: 7, EmployeeID: 1, Status: domain.ProfileChangePending, Employee: domain.Employee{ID: 1, UserID: 42}}
+		mockProfileChangeRepo.On("GetByID", ctx, uint(7)).Return(request, nil)
+
+		_, err := uc.ReviewProfileChange(ctx, 7, 42, true, nil)
+
+		assert.ErrorIs(t, err, domain.ErrProfileChangeSelfReview)
+		mockEmployeeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockProfileChangeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmployeeUseCase_BulkUpsert(t *testing.T) {
+	ctx := context.Background()
+	code := "EMP001"
+	grade := "B"
+	newGrade := "C"
+	branch := "Jakarta"
+	unknownCode := "EMP404"
+
+	existingEmployee := func() *domain.Employee {
+		return &domain.Employee{ID: 1, FirstName: "John", EmployeeCode: &code, Grade: &grade, Branch: &branch, PositionName: "Staff"}
+	}
+
+	tests := []struct {
+		name            string
+		rows            []*domain.Employee
+		dryRun          bool
+		mockSetup       func(*mocks.EmployeeRepository)
+		expectedAction  string
+		expectedChanges map[string]ImportFieldChange
+		expectedErrors  int
+		expectApplied   bool
+	}{
+		{
+			name:   "dry run previews the changes without applying them",
+			rows:   []*domain.Employee{{EmployeeCode: &code, Grade: &newGrade}},
+			dryRun: true,
+			mockSetup: func(repo *mocks.EmployeeRepository) {
+				repo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+			},
+			expectedAction:  ImportActionUpdate,
+			expectedChanges: map[string]ImportFieldChange{"grade": {From: grade, To: newGrade}},
+		},
+		{
+			name: "apply writes the updates in one batch with the employment history",
+			rows: []*domain.Employee{{EmployeeCode: &code, Grade: &newGrade}},
+			mockSetup: func(repo *mocks.EmployeeRepository) {
+				repo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+				repo.On("BulkUpdate", ctx, mock.MatchedBy(func(employees []*domain.Employee) bool {
+					return len(employees) == 1 && employees[0].ID == 1 && *employees[0].Grade == newGrade
+				}), mock.MatchedBy(func(events []*domain.EmploymentEvent) bool {
+					return len(events) == 1 && events[0].EventType == domain.EmploymentEventImportUpdate &&
+						*events[0].PreviousGrade == grade && *events[0].NewGrade == newGrade && events[0].NewBranch == nil
+				})).Return(nil)
+			},
+			expectedAction:  ImportActionUpdate,
+			expectedChanges: map[string]ImportFieldChange{"grade": {From: grade, To: newGrade}},
+			expectApplied:   true,
+		},
+		{
+			name: "rows without changes are left unchanged",
+			rows: []*domain.Employee{{EmployeeCode: &code, Grade: &grade}},
+			mockSetup: func(repo *mocks.EmployeeRepository) {
+				repo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+			},
+			expectedAction: ImportActionUnchanged,
+			expectApplied:  true,
+		},
+		{
+			name: "unmatched rows fail the whole import in update mode",
+			rows: []*domain.Employee{{EmployeeCode: &code, Grade: &newGrade}, {EmployeeCode: &unknownCode, Grade: &newGrade}},
+			mockSetup: func(repo *mocks.EmployeeRepository) {
+				repo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+				repo.On("GetByEmployeeCode", ctx, unknownCode).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedAction:  ImportActionUpdate,
+			expectedChanges: map[string]ImportFieldChange{"grade": {From: grade, To: newGrade}},
+			expectedErrors:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			tt.mockSetup(mockEmployeeRepo)
+			uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{})
+
+			result, err := uc.BulkUpsert(ctx, tt.rows, ImportMatchByEmployeeCode, false, tt.dryRun, 99)
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Errors, tt.expectedErrors)
+			assert.Equal(t, tt.expectApplied, result.Applied)
+			assert.Equal(t, tt.expectedAction, result.Rows[0].Action)
+			if tt.expectedChanges != nil {
+				assert.Equal(t, tt.expectedChanges, result.Rows[0].Changes)
+			}
+			if !tt.expectApplied {
+				mockEmployeeRepo.AssertNotCalled(t, "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockEmployeeRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("a tax status the recorded family does not support fails the row", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockFamilyRepo := new(mocks.EmployeeFamilyRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: mockFamilyRepo})
+		taxStatus := enums.TaxStatus("K/2")
+		mockEmployeeRepo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+		mockFamilyRepo.On("CountTaxDependants", ctx, uint(1)).Return(true, 0, nil)
+
+		result, err := uc.BulkUpsert(ctx, []*domain.Employee{{EmployeeCode: &code, TaxStatus: &taxStatus}}, ImportMatchByEmployeeCode, false, false, 99)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Applied)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, "tax_status", result.Errors[0].Field)
+		}
+		mockEmployeeRepo.AssertNotCalled(t, "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("updates are not applied when creating an employee fails", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockAuthRepo := new(mocks.AuthRepository)
+		mockXenditRepo := new(mocks.XenditRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, mockAuthRepo, mockXenditRepo, &supa.Client{}, &gorm.DB{})
+		newCode := "EMP002"
+		creator := &domain.Employee{ID: 99, User: domain.User{ID: 99, Role: enums.RoleAdmin}}
+
+		mockEmployeeRepo.On("GetByEmployeeCode", ctx, code).Return(existingEmployee(), nil)
+		mockEmployeeRepo.On("GetByEmployeeCode", ctx, newCode).Return(nil, gorm.ErrRecordNotFound)
+		mockAuthRepo.On("GetUserByEmail", ctx, "jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+		mockEmployeeRepo.On("GetByID", ctx, uint(99)).Return(creator, nil)
+		mockAuthRepo.On("GetUserByID", ctx, uint(99)).Return(&creator.User, nil)
+		mockEmployeeRepo.On("GetByUserID", ctx, uint(99)).Return(creator, nil)
+		mockEmployeeRepo.On("List", ctx, mock.Anything, mock.Anything).Return([]*domain.Employee{}, int64(0), nil)
+		mockXenditRepo.On("GetSubscriptionByAdminUserID", ctx, uint(99)).Return(nil, errors.New("no subscription"))
+		mockAuthRepo.On("RegisterEmployeeUser", ctx, mock.Anything, mock.Anything).Return(errors.New("supabase signup failed"))
+
+		rows := []*domain.Employee{
+			{EmployeeCode: &code, Grade: &newGrade},
+			{EmployeeCode: &newCode, FirstName: "Jane", PositionName: "Staff", User: domain.User{Email: "jane@example.com"}},
+		}
+		result, err := uc.BulkUpsert(ctx, rows, ImportMatchByEmployeeCode, true, false, 99)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Applied)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, 3, result.Errors[0].Row)
+		}
+		mockEmployeeRepo.AssertNotCalled(t, "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmployeeUseCase_ResumeImportJobs(t *testing.T) {
+	ctx := context.Background()
+	companyID := uint(3)
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockImportJobRepo := new(mocks.ImportJobRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ImportJobRepo: mockImportJobRepo})
+
+	job := &domain.ImportJob{ID: 5, CompanyID: &companyID, CreatedBy: 1, Status: domain.ImportJobRunning, TotalRows: 3, ProcessedRows: 1, SucceededRows: 1}
+	succeeding := &domain.ImportJobRow{ID: 2, JobID: 5, Row: 3, Status: domain.ImportJobRowPending, Employee: &domain.Employee{FirstName: "Jane", User: domain.User{Email: "jane@example.com"}}}
+	failing := &domain.ImportJobRow{ID: 3, JobID: 5, Row: 4, Status: domain.ImportJobRowPending, Employee: &domain.Employee{FirstName: "Joe", ManagerID: uintPtr(7), User: domain.User{Email: "joe@example.com"}}}
+
+	inCompany := mock.MatchedBy(func(jobCtx context.Context) bool {
+		id, ok := tenant.CompanyID(jobCtx)
+		return ok && id == companyID
+	})
+	mockImportJobRepo.On("ListUnfinished", ctx).Return([]*domain.ImportJob{job}, nil)
+	mockImportJobRepo.On("Claim", inCompany, job, mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockImportJobRepo.On("ListPendingRows", inCompany, uint(5)).Return([]*domain.ImportJobRow{succeeding, failing}, nil)
+	mockImportJobRepo.On("Update", inCompany, job).Return(nil)
+	mockImportJobRepo.On("UpdateRow", inCompany, mock.Anything).Return(nil)
+	mockAuthRepo.On("GetUserByEmail", inCompany, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	mockAuthRepo.On("RegisterEmployeeUser", inCompany, mock.Anything, mock.MatchedBy(func(e *domain.Employee) bool { return e.FirstName == "Jane" && e.ManagerID == nil })).
+		Run(func(args mock.Arguments) { args.Get(2).(*domain.Employee).ID = 42 }).
+		Return(nil)
+	mockAuthRepo.On("RegisterEmployeeUser", inCompany, mock.Anything, mock.MatchedBy(func(e *domain.Employee) bool { return e.FirstName == "Joe" && *e.ManagerID == 7 })).
+		Return(errors.New("supabase signup failed"))
+	mockEmployeeRepo.On("GetByID", inCompany, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := uc.ResumeImportJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportJobCompleted, job.Status)
+	assert.Equal(t, 3, job.ProcessedRows)
+	assert.Equal(t, 2, job.SucceededRows)
+	assert.Equal(t, 1, job.FailedRows)
+	assert.NotNil(t, job.CompletedAt)
+
+	assert.Equal(t, domain.ImportJobRowSucceeded, succeeding.Status)
+	assert.Equal(t, uint(42), *succeeding.EmployeeID)
+	assert.Equal(t, domain.ImportJobRowFailed, failing.Status)
+	assert.Len(t, failing.Errors, 1)
+	assert.Empty(t, succeeding.Employee.User.Password)
+}
+
+func TestEmployeeUseCase_ResumeImportJobs_Claims(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("jobs claimed by another server are left to it", func(t *testing.T) {
+		mockImportJobRepo := new(mocks.ImportJobRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ImportJobRepo: mockImportJobRepo})
+
+		job := &domain.ImportJob{ID: 5, Status: domain.ImportJobQueued, TotalRows: 2}
+		mockImportJobRepo.On("ListUnfinished", ctx).Return([]*domain.ImportJob{job}, nil)
+		mockImportJobRepo.On("Claim", mock.Anything, job, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+		err := uc.ResumeImportJobs(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ImportJobQueued, job.Status)
+		mockImportJobRepo.AssertExpectations(t)
+		mockImportJobRepo.AssertNotCalled(t, "ListPendingRows", mock.Anything, mock.Anything)
+	})
+
+	t.Run("jobs still leased are claimed once the lease lapses", func(t *testing.T) {
+		mockImportJobRepo := new(mocks.ImportJobRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ImportJobRepo: mockImportJobRepo})
+
+		leaseExpiresAt := time.Now().Add(50 * time.Millisecond)
+		job := &domain.ImportJob{ID: 6, Status: domain.ImportJobRunning, TotalRows: 1, ProcessedRows: 1, FailedRows: 1, LeaseExpiresAt: &leaseExpiresAt}
+		var claimedAt time.Time
+		mockImportJobRepo.On("ListUnfinished", ctx).Return([]*domain.ImportJob{job}, nil)
+		mockImportJobRepo.On("Claim", mock.Anything, job, mock.AnythingOfType("time.Time")).
+			Run(func(mock.Arguments) { claimedAt = time.Now() }).
+			Return(true, nil).Once()
+		mockImportJobRepo.On("ListPendingRows", mock.Anything, uint(6)).Return([]*domain.ImportJobRow{}, nil)
+		mockImportJobRepo.On("Update", mock.Anything, job).Return(nil)
+
+		err := uc.ResumeImportJobs(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, claimedAt.Before(leaseExpiresAt))
+		assert.Equal(t, domain.ImportJobCompleted, job.Status)
+		assert.Nil(t, job.LeaseExpiresAt)
+		mockImportJobRepo.AssertExpectations(t)
+	})
+}
+
+func TestEmployeeUseCase_ExportEmployees(t *testing.T) {
+	ctx := context.Background()
+	code := "EMP001"
+	salary := 7500000.0
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockCustomFieldRepo := new(mocks.CustomFieldRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{CustomFieldRepo: mockCustomFieldRepo})
+	mockCustomFieldRepo.On("List", ctx).Return([]*domain.CustomFieldDefinition{
+		{Key: "blood_type", Label: "Blood Type", Type: domain.CustomFieldText},
+	}, nil)
+
+	t.Run("unknown columns are rejected", func(t *testing.T) {
+		_, err := uc.ResolveExportColumns(ctx, []string{"first_name", "password"}, true)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidExportColumn)
+	})
+
+	t.Run("rows hold the values of the chosen columns in order", func(t *testing.T) {
+		columns, err := uc.ResolveExportColumns(ctx, []string{"custom_blood_type", "employee_code", "first_name", "base_salary", "employment_status"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "Blood Type", columns[0].Header)
+
+		filters := map[string]interface{}{"branch_id": uint(2)}
+		mockEmployeeRepo.On("ForEachBatch", ctx, filters).Return([]*domain.Employee{
+			{ID: 1, FirstName: "John", EmployeeCode: &code, BaseSalary: &salary, EmploymentStatus: true, CustomFields: map[string]interface{}{"blood_type": "O"}},
+			{ID: 2, FirstName: "Jane", EmploymentStatus: false},
+		}, nil)
+
+		var rows [][]string
+		err = uc.ExportEmployees(ctx, filters, columns, func(values []string) error {
+			rows = append(rows, values)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"O", "EMP001", "John", "7500000.00", "Active"},
+			{"", "", "Jane", "", "Inactive"},
+		}, rows)
+		mockEmployeeRepo.AssertNumberOfCalls(t, "ForEachBatch", 1)
+	})
+}
+
+func TestEmployeeUseCase_DetectImportMapping(t *testing.T) {
+	ctx := context.Background()
+
+	mockCustomFieldRepo := new(mocks.CustomFieldRepository)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{CustomFieldRepo: mockCustomFieldRepo})
+	mockCustomFieldRepo.On("List", ctx).Return([]*domain.CustomFieldDefinition{
+		{Key: "blood_type", Label: "Golongan Darah", Type: domain.CustomFieldText},
+	}, nil)
+
+	headers := []string{"Nama Lengkap", "Alamat Email", "Jenis Kelamin", "Tanggal Masuk", "Golongan Darah", "Hobi"}
+	rows := [][]string{
+		{"Budi", "budi@example.com", "Laki-laki", "17/08/2021", "O", "Catur"},
+		{"Sari", "sari@example.com", "Perempuan", "01/12/2022", "A", ""},
+	}
+
+	detection, err := uc.DetectImportMapping(ctx, headers, rows)
+
+	assert.NoError(t, err)
+	fields := make(map[string]string)
+	for _, mapping := range detection.Mappings {
+		fields[mapping.SourceHeader] = mapping.Field
+	}
+	assert.Equal(t, map[string]string{
+		"Nama Lengkap":   "first_name",
+		"Alamat Email":   "email",
+		"Jenis Kelamin":  "gender",
+		"Tanggal Masuk":  "hire_date",
+		"Golongan Darah": "custom_blood_type",
+	}, fields)
+	assert.Equal(t, "DD/MM/YYYY", detection.Mappings[3].DateFormat)
+	assert.Equal(t, []string{"17/08/2021", "01/12/2022"}, detection.Mappings[3].Samples)
+	assert.Equal(t, []string{"Hobi"}, detection.UnmappedHeaders)
+	assert.Equal(t, []string{"position_name"}, detection.MissingRequiredFields)
+}
+
+func TestEmployeeUseCase_CreateImportMappingProfile(t *testing.T) {
+	ctx := context.Background()
+
+	mockCustomFieldRepo := new(mocks.CustomFieldRepository)
+	mockImportMappingRepo := new(mocks.ImportMappingRepository)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{CustomFieldRepo: mockCustomFieldRepo, ImportMappingRepo: mockImportMappingRepo})
+	mockCustomFieldRepo.On("List", ctx).Return([]*domain.CustomFieldDefinition{
+		{Key: "blood_type", Label: "Blood Type", Type: domain.CustomFieldText},
+	}, nil)
+
+	t.Run("mappings to unknown custom fields are rejected", func(t *testing.T) {
+		_, err := uc.CreateImportMappingProfile(ctx, &domain.ImportMappingProfile{
+			Name:     "Talenta",
+			Mappings: []domain.ImportColumnMapping{{SourceHeader: "Hobi", Field: "custom_hobby"}},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidImportMapping)
+	})
+
+	t.Run("date formats are only accepted for date fields", func(t *testing.T) {
+		_, err := uc.CreateImportMappingProfile(ctx, &domain.ImportMappingProfile{
+			Name:     "Talenta",
+			Mappings: []domain.ImportColumnMapping{{SourceHeader: "Nama", Field: "first_name", DateFormat: "DD/MM/YYYY"}},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidImportMapping)
+	})
+
+	t.Run("names are unique per company", func(t *testing.T) {
+		mockImportMappingRepo.On("GetByName", ctx, "Gadjian").Return(&domain.ImportMappingProfile{ID: 3, Name: "Gadjian"}, nil).Once()
+
+		_, err := uc.CreateImportMappingProfile(ctx, &domain.ImportMappingProfile{
+			Name:     "Gadjian",
+			Mappings: []domain.ImportColumnMapping{{SourceHeader: "Nama", Field: "first_name"}},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrImportMappingProfileExists)
+	})
+
+	t.Run("saved profiles convert source rows into import rows", func(t *testing.T) {
+		profile := &domain.ImportMappingProfile{
+			Name: "Talenta",
+			Mappings: []domain.ImportColumnMapping{
+				{SourceHeader: "Nama", Field: "first_name"},
+				{SourceHeader: "Jenis Kelamin", Field: "gender"},
+				{SourceHeader: "Tgl Masuk", Field: "hire_date", DateFormat: "D MMM YYYY"},
+				{SourceHeader: "Status", Field: "contract_type", ValueMap: map[string]string{"Magang": "freelance"}},
+				{SourceHeader: "Gol. Darah", Field: "custom_blood_type"},
+			},
+		}
+		mockImportMappingRepo.On("GetByName", ctx, "Talenta").Return(nil, domain.ErrImportMappingProfileNotFound).Once()
+		mockImportMappingRepo.On("Create", ctx, profile).Return(nil).Once()
+
+		_, err := uc.CreateImportMappingProfile(ctx, profile)
+		assert.NoError(t, err)
+
+		headers, record, valueErrors := profile.MapRecord(
+			[]string{"Nama", "email", "Jenis Kelamin", "Tgl Masuk", "Status", "Gol. Darah", "Catatan"},
+			[]string{"Budi", "budi@example.com", "L", "5 Jan 2021", "magang", "O", "ignored"},
+		)
+		assert.Empty(t, valueErrors)
+		assert.Equal(t, []string{"first_name", "email", "gender", "hire_date", "contract_type", "custom_blood_type"}, headers)
+		assert.Equal(t, []string{"Budi", "budi@example.com", "Male", "2021-01-05", "freelance", "O"}, record)
+
+		_, _, valueErrors = profile.MapRecord([]string{"Jenis Kelamin", "Tgl Masuk"}, []string{"X", "2021-01-05"})
+		if assert.Len(t, valueErrors, 2) {
+			assert.Equal(t, "gender", valueErrors[0].Field)
+			assert.Equal(t, "hire_date", valueErrors[1].Field)
+		}
+	})
+
+	t.Run("value map keys differing only in case are rejected", func(t *testing.T) {
+		_, err := uc.CreateImportMappingProfile(ctx, &domain.ImportMappingProfile{
+			Name: "Talenta",
+			Mappings: []domain.ImportColumnMapping{
+				{SourceHeader: "Status", Field: "contract_type", ValueMap: map[string]string{"Magang": "freelance", "magang ": "contract"}},
+			},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidImportMapping)
+	})
+}
+
+func TestImportColumnMapping_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  domain.ImportColumnMapping
+		value    string
+		expected string
+	}{
+		{
+			name:     "exact value map key wins over one differing in case",
+			mapping:  domain.ImportColumnMapping{Field: "contract_type", ValueMap: map[string]string{"PKWT": "freelance", "pkwt": "contract"}},
+			value:    "pkwt",
+			expected: "contract",
+		},
+		{
+			name:     "value map keys are matched regardless of case in sorted order",
+			mapping:  domain.ImportColumnMapping{Field: "contract_type", ValueMap: map[string]string{"PKWT": "freelance", "pkwt": "contract"}},
+			value:    "Pkwt",
+			expected: "freelance",
+		},
+		{
+			name:     "Indonesian month names",
+			mapping:  domain.ImportColumnMapping{Field: "hire_date", DateFormat: "D MMMM YYYY"},
+			value:    "17 Agustus 2021",
+			expected: "2021-08-17",
+		},
+		{
+			name:     "Indonesian month abbreviations",
+			mapping:  domain.ImportColumnMapping{Field: "hire_date", DateFormat: "DD-MMM-YYYY"},
+			value:    "05-Okt-2021",
+			expected: "2021-10-05",
+		},
+		{
+			name:     "English month names",
+			mapping:  domain.ImportColumnMapping{Field: "hire_date", DateFormat: "MMMM D, YYYY"},
+			value:    "May 3, 2022",
+			expected: "2022-05-03",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				converted, err := tt.mapping.Convert(tt.value)
+
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, converted)
+			}
+		})
+	}
+}
+
+func TestEmployeeUseCase_SearchEmployees(t *testing.T) {
+	ctx := context.Background()
+	code := "EMP-017"
+	branch := "Jakarta <HQ>"
+
+	t.Run("matching parts of fields are highlighted", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{})
+		mockEmployeeRepo.On("Search", mock.Anything, "muh jak", domain.EmployeeSearchScope{MatchNIK: true}, 10).Return([]*domain.EmployeeSearchHit{
+			{Employee: &domain.Employee{ID: 4, FirstName: "Muhammad", EmployeeCode: &code, Branch: &branch, PositionName: "Engineer", User: domain.User{Email: "muhammad@example.com"}}, Rank: 1.4},
+		}, nil)
+
+		result, err := uc.SearchEmployees(ctx, "muh jak", domain.EmployeeSearchScope{MatchNIK: true}, 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.Items, 1) {
+			assert.Equal(t, 1.4, result.Items[0].Rank)
+			assert.Equal(t, map[string]string{
+				"name":   "<mark>Muh</mark>ammad",
+				"email":  "<mark>muh</mark>ammad@example.com",
+				"branch": "<mark>Jak</mark>arta &lt;HQ&gt;",
+			}, result.Items[0].Highlights)
+		}
+	})
+
+	t.Run("NIKs are left out unless the scope matches them", func(t *testing.T) {
+		nik := "3174011403900001"
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{})
+		mockEmployeeRepo.On("Search", mock.Anything, "muh 3174", domain.EmployeeSearchScope{}, 10).Return([]*domain.EmployeeSearchHit{
+			{Employee: &domain.Employee{ID: 4, FirstName: "Muhammad", NIK: &nik, User: domain.User{Email: "muhammad@example.com"}}, Rank: 1.1},
+		}, nil)
+
+		result, err := uc.SearchEmployees(ctx, "muh 3174", domain.EmployeeSearchScope{}, 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.Items, 1) {
+			assert.Nil(t, result.Items[0].NIK)
+			assert.NotContains(t, result.Items[0].Highlights, "nik")
+		}
+	})
+
+	t.Run("searches over the latency budget time out", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{})
+		mockEmployeeRepo.On("Search", mock.Anything, "budi", domain.EmployeeSearchScope{IncludeInactive: true}, 5).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, context.DeadlineExceeded)
+
+		_, err := uc.SearchEmployees(ctx, "budi", domain.EmployeeSearchScope{IncludeInactive: true}, 5)
+
+		assert.ErrorIs(t, err, domain.ErrEmployeeSearchTimeout)
+	})
+}
+
+func TestEmployeeUseCase_DetectEmployeeDuplicates(t *testing.T) {
+	ctx := context.Background()
+	dateOfBirth := time.Date(1990, 3, 14, 0, 0, 0, 0, time.UTC)
+	nik := "3174011403900001"
+	typoNIK := "3174011403900010"
+
+	mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+	uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+
+	likely := &domain.EmployeeDuplicate{
+		EmployeeID:  1,
+		Employee:    domain.Employee{ID: 1, FirstName: "Muhammad", LastName: stringPtr("Rizky"), DateOfBirth: &dateOfBirth, NIK: &nik, User: domain.User{Phone: "+62 812-3456-7890"}},
+		DuplicateID: 2,
+		Duplicate:   domain.Employee{ID: 2, FirstName: "Muhamad", LastName: stringPtr("Rizki"), DateOfBirth: &dateOfBirth, NIK: &typoNIK, User: domain.User{Phone: "081234567890"}},
+	}
+	namesake := &domain.EmployeeDuplicate{
+		EmployeeID:  3,
+		Employee:    domain.Employee{ID: 3, FirstName: "Budi", LastName: stringPtr("Santoso")},
+		DuplicateID: 4,
+		Duplicate:   domain.Employee{ID: 4, FirstName: "Budi", LastName: stringPtr("Santoso")},
+	}
+	mockDuplicateRepo.On("ListCandidates", ctx).Return([]*domain.EmployeeDuplicate{likely, namesake}, nil)
+	mockDuplicateRepo.On("Save", ctx, likely).Return(nil)
+	mockDuplicateRepo.On("DeletePendingDetectedBefore", ctx, mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := uc.DetectEmployeeDuplicates(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dtoemployee.DuplicateDetectionResultDTO{Scored: 2, Flagged: 1}, result)
+	assert.Equal(t, domain.EmployeeDuplicatePending, likely.Status)
+	assert.Equal(t, []domain.DuplicateSignal{
+		domain.DuplicateSignalName, domain.DuplicateSignalDateOfBirth, domain.DuplicateSignalNIK, domain.DuplicateSignalPhone,
+	}, likely.Signals)
+	assert.GreaterOrEqual(t, likely.Score, 80)
+	mockDuplicateRepo.AssertNotCalled(t, "Save", ctx, namesake)
+	mockDuplicateRepo.AssertExpectations(t)
+}
+
+func TestEmployeeUseCase_MergeEmployeeDuplicate(t *testing.T) {
+	ctx := context.Background()
+	hired := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	rehired := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	newPair := func(status domain.EmployeeDuplicateStatus) *domain.EmployeeDuplicate {
+		return &domain.EmployeeDuplicate{
+			ID:          9,
+			Status:      status,
+			EmployeeID:  1,
+			Employee:    domain.Employee{ID: 1, UserID: 11, FirstName: "Siti", HireDate: &hired, User: domain.User{ID: 11, Email: "siti@example.com"}},
+			DuplicateID: 2,
+			Duplicate:   domain.Employee{ID: 2, UserID: 12, FirstName: "Siti", LastName: stringPtr("Aminah"), HireDate: &rehired, ManagerID: uintPtr(1), User: domain.User{ID: 12, Email: "siti.aminah@example.com"}},
+		}
+	}
+
+	t.Run("the survivor takes over the duplicate and its account is kept", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		mockAuthRepo := new(mocks.AuthRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(newPair(domain.EmployeeDuplicatePending), nil)
+		mockDuplicateRepo.On("Merge", ctx, mock.MatchedBy(func(survivor *domain.Employee) bool {
+			return survivor.ID == 2 && survivor.UserID == 12 && survivor.HireDate.Equal(hired) && survivor.ManagerID == nil
+		}), (*domain.EmploymentEvent)(nil), uint(1), uint(11)).Return(nil)
+		mockAuthRepo.On("DeactivateUser", ctx, uint(11)).Return(nil)
+
+		result, err := uc.MergeEmployeeDuplicate(ctx, 9, 2, false, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), result.ID)
+		mockDuplicateRepo.AssertExpectations(t)
+		mockAuthRepo.AssertExpectations(t)
+	})
+
+	t.Run("the duplicate's account can be moved to the survivor", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		mockAuthRepo := new(mocks.AuthRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(newPair(domain.EmployeeDuplicatePending), nil)
+		mockDuplicateRepo.On("Merge", ctx, mock.MatchedBy(func(survivor *domain.Employee) bool {
+			return survivor.ID == 1 && survivor.UserID == 12 && *survivor.LastName == "Aminah"
+		}), (*domain.EmploymentEvent)(nil), uint(2), uint(11)).Return(nil)
+		mockAuthRepo.On("DeactivateUser", ctx, uint(11)).Return(nil)
+
+		_, err := uc.MergeEmployeeDuplicate(ctx, 9, 1, true, 5)
+
+		assert.NoError(t, err)
+		mockDuplicateRepo.AssertExpectations(t)
+		mockAuthRepo.AssertExpectations(t)
+	})
+
+	t.Run("employment values taken from the duplicate are recorded as a merge event", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		mockAuthRepo := new(mocks.AuthRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		pair := newPair(domain.EmployeeDuplicatePending)
+		pair.Employee.Grade = stringPtr("G3")
+		pair.Duplicate.PositionName = "Accountant"
+		pair.Duplicate.Grade = stringPtr("G2")
+		salary := 9000000.0
+		pair.Duplicate.BaseSalary = &salary
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(pair, nil)
+		mockDuplicateRepo.On("Merge", ctx, mock.MatchedBy(func(survivor *domain.Employee) bool {
+			return survivor.PositionName == "Accountant" && *survivor.Grade == "G3" && *survivor.BaseSalary == 9000000
+		}), mock.MatchedBy(func(event *domain.EmploymentEvent) bool {
+			return event.EmployeeID == 1 && event.EventType == domain.EmploymentEventMerge &&
+				*event.NewPositionName == "Accountant" && *event.PreviousPositionName == "" &&
+				event.NewGrade == nil &&
+				*event.NewBaseSalary == 9000000 && event.PreviousBaseSalary == nil
+		}), uint(2), uint(12)).Return(nil)
+		mockAuthRepo.On("DeactivateUser", ctx, uint(12)).Return(nil)
+
+		_, err := uc.MergeEmployeeDuplicate(ctx, 9, 1, false, 5)
+
+		assert.NoError(t, err)
+		mockDuplicateRepo.AssertExpectations(t)
+	})
+
+	t.Run("the survivor must be one of the pair", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(newPair(domain.EmployeeDuplicatePending), nil)
+
+		_, err := uc.MergeEmployeeDuplicate(ctx, 9, 3, false, 5)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidEmployeeMerge)
+		mockDuplicateRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a merged pair cannot be merged again", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		mockAuthRepo := new(mocks.AuthRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), mockAuthRepo, new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		// Merging deletes the pair, so the second lookup no longer finds it.
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(newPair(domain.EmployeeDuplicatePending), nil).Once()
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(nil, domain.ErrEmployeeDuplicateNotFound).Once()
+		mockDuplicateRepo.On("Merge", ctx, mock.Anything, mock.Anything, uint(1), uint(11)).Return(nil).Once()
+		mockAuthRepo.On("DeactivateUser", ctx, uint(11)).Return(nil).Once()
+
+		_, err := uc.MergeEmployeeDuplicate(ctx, 9, 2, false, 5)
+		assert.NoError(t, err)
+
+		_, err = uc.MergeEmployeeDuplicate(ctx, 9, 2, false, 5)
+
+		assert.ErrorIs(t, err, domain.ErrEmployeeDuplicateNotFound)
+		mockDuplicateRepo.AssertNumberOfCalls(t, "Merge", 1)
+		mockAuthRepo.AssertNumberOfCalls(t, "DeactivateUser", 1)
+	})
+
+	t.Run("dismissed pairs cannot be merged", func(t *testing.T) {
+		mockDuplicateRepo := new(mocks.EmployeeDuplicateRepository)
+		uc := NewEmployeeUseCase(new(mocks.EmployeeRepository), new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{DuplicateRepo: mockDuplicateRepo})
+		mockDuplicateRepo.On("GetByID", ctx, uint(9)).Return(newPair(domain.EmployeeDuplicateDismissed), nil)
+
+		_, err := uc.MergeEmployeeDuplicate(ctx, 9, 1, false, 5)
+
+		assert.ErrorIs(t, err, domain.ErrEmployeeDuplicateDismissed)
+	})
+}
+
+func TestEmployeeUseCase_ReplaceFamily(t *testing.T) {
+	ctx := context.Background()
+	k1 := enums.K1
+	k2 := enums.K2
+	newFamily := func() []*domain.FamilyMember {
+		return []*domain.FamilyMember{
+			{Name: "Dewi Lestari", Relationship: domain.FamilySpouse, NIK: stringPtr("3174015507920002"), BPJSKesehatan: true},
+			{Name: "Raka", Relationship: domain.FamilyChild, TaxDependant: true, BPJSKesehatan: true},
+			{Name: "Sekar", Relationship: domain.FamilyChild, TaxDependant: true, BPJSKesehatan: true},
+		}
+	}
+
+	t.Run("a tax status given along with the family is set with it", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockFamilyRepo := new(mocks.EmployeeFamilyRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: mockFamilyRepo})
+		family := newFamily()
+		mockEmployeeRepo.On("GetByID", ctx, uint(7)).Return(&domain.Employee{ID: 7, TaxStatus: &k1}, nil)
+		mockFamilyRepo.On("ReplaceFamilyMembers", ctx, uint(7), family, &k2).Return(nil)
+
+		result, err := uc.ReplaceFamily(ctx, 7, family, &k2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtoemployee.TaxDependantsDTO{TaxStatus: stringPtr("K/2"), Declared: 2, Recorded: 2, Matches: true}, result.TaxDependants)
+		mockFamilyRepo.AssertExpectations(t)
+	})
+
+	t.Run("the dependants have to match the current tax status", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockFamilyRepo := new(mocks.EmployeeFamilyRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: mockFamilyRepo})
+		mockEmployeeRepo.On("GetByID", ctx, uint(7)).Return(&domain.Employee{ID: 7, TaxStatus: &k1}, nil)
+
+		_, err := uc.ReplaceFamily(ctx, 7, newFamily(), nil)
+
+		assert.ErrorIs(t, err, domain.ErrTaxDependantMismatch)
+		mockFamilyRepo.AssertNotCalled(t, "ReplaceFamilyMembers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a spouse cannot be declared a tax dependant", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: new(mocks.EmployeeFamilyRepository)})
+		mockEmployeeRepo.On("GetByID", ctx, uint(7)).Return(&domain.Employee{ID: 7, TaxStatus: &k2}, nil)
+		family := newFamily()
+		family[0].TaxDependant = true
+		family[2].TaxDependant = false
+
+		_, err := uc.ReplaceFamily(ctx, 7, family, nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidFamilyMember)
+	})
+}
+
+func TestEmployeeUseCase_Update_TaxStatusMustMatchFamily(t *testing.T) {
+	ctx := context.Background()
+	k0 := enums.K0
+	k2 := enums.K2
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockFamilyRepo := new(mocks.EmployeeFamilyRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: mockFamilyRepo})
+	mockEmployeeRepo.On("GetByID", ctx, uint(7)).Return(&domain.Employee{ID: 7, UserID: 3, FirstName: "Andi", TaxStatus: &k2}, nil)
+	mockFamilyRepo.On("CountTaxDependants", ctx, uint(7)).Return(true, 2, nil)
+
+	_, err := uc.Update(ctx, &domain.Employee{ID: 7, TaxStatus: &k0})
+
+	assert.ErrorIs(t, err, domain.ErrTaxDependantMismatch)
+	mockEmployeeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEmployeeUseCase_ReplaceEmergencyContacts(t *testing.T) {
+	ctx := context.Background()
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockFamilyRepo := new(mocks.EmployeeFamilyRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{FamilyRepo: mockFamilyRepo})
+	mockEmployeeRepo.On("GetByID", ctx, uint(7)).Return(&domain.Employee{ID: 7}, nil)
+
+	t.Run("the first contact is primary when none is marked", func(t *testing.T) {
+		contacts := []*domain.EmergencyContact{
+			{Name: "Siti", Relationship: "Ibu", Phone: "0812-3456-7890"},
+			{Name: "Bayu", Relationship: "Kakak", Phone: "+62 813 1111 2222"},
+		}
+		mockFamilyRepo.On("ReplaceEmergencyContacts", ctx, uint(7), contacts).Return(nil).Once()
+
+		result, err := uc.ReplaceEmergencyContacts(ctx, 7, contacts)
+
+		assert.NoError(t, err)
+		assert.True(t, result[0].IsPrimary)
+		assert.False(t, result[1].IsPrimary)
+	})
+
+	t.Run("only one contact can be primary", func(t *testing.T) {
+		_, err := uc.ReplaceEmergencyContacts(ctx, 7, []*domain.EmergencyContact{
+			{Name: "Siti", Relationship: "Ibu", Phone: "081234567890", IsPrimary: true},
+			{Name: "Bayu", Relationship: "Kakak", Phone: "081311112222", IsPrimary: true},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidEmergencyContact)
+	})
+}
+
+func TestEmployeeUseCase_ListTeamEvents(t *testing.T) {
+	ctx := context.Background()
+	today := startOfDay(time.Now())
+	date := func(years, days int) *time.Time {
+		d := today.AddDate(years, 0, days)
+		return &d
+	}
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockContractRepo := new(mocks.EmploymentContractRepository)
+	mockProbationRepo := new(mocks.ProbationRepository)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{ContractRepo: mockContractRepo, ProbationRepo: mockProbationRepo})
+
+	team := []*domain.Employee{
+		{ID: 11, FirstName: "Rina", DateOfBirth: date(-30, 3), HireDate: date(-2, 10)},
+		{ID: 12, FirstName: "Yoga", DateOfBirth: date(-25, 1), HireDate: date(-5, 1), HidePersonalEvents: true},
+		{ID: 13, FirstName: "Tono", DateOfBirth: date(-40, 45), HireDate: date(0, -20)},
+	}
+	mockEmployeeRepo.On("GetReportingLineIDs", ctx, uint(5)).Return([]uint{11, 12, 13}, nil)
+	mockEmployeeRepo.On("ListActiveByIDs", ctx, []uint{11, 12, 13}).Return(team, nil)
+	mockContractRepo.On("ListActiveEndingBefore", ctx, mock.AnythingOfType("time.Time")).Return([]*domain.EmploymentContract{
+		{ID: 1, EmployeeID: 12, EndDate: date(0, 7)},
+		{ID: 2, EmployeeID: 99, EndDate: date(0, 7)},
+	}, nil)
+	mockProbationRepo.On("ListInProgress", ctx).Return([]*domain.Probation{
+		{ID: 3, EmployeeID: 13, EndDate: *date(0, 5)},
+		{ID: 4, EmployeeID: 13, EndDate: *date(0, 60)},
+	}, nil)
+
+	result, err := uc.ListTeamEvents(ctx, 5, 30)
+
+	assert.NoError(t, err)
+	var got []string
+	for _, event := range result.Items {
+		got = append(got, fmt.Sprintf("%d %s %s %d", event.EmployeeID, event.Type, event.Date, event.Years))
+	}
+	assert.Equal(t, []string{
+		fmt.Sprintf("11 birthday %s 0", date(0, 3).Format("2006-01-02")),
+		fmt.Sprintf("13 probation_end %s 0", date(0, 5).Format("2006-01-02")),
+		fmt.Sprintf("12 contract_end %s 0", date(0, 7).Format("2006-01-02")),
+		fmt.Sprintf("11 work_anniversary %s 2", date(0, 10).Format("2006-01-02")),
+	}, got)
+}
+
+func TestEmployeeUseCase_ProcessEmployeeEventDigest(t *testing.T) {
+	ctx := context.Background()
+	today := startOfDay(time.Now())
+	birthday := today.AddDate(-28, 0, 0)
+
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockNotifier := new(mocks.EmploymentNotifier)
+	uc := NewEmployeeUseCase(mockEmployeeRepo, new(mocks.AuthRepository), new(mocks.XenditRepository), &supa.Client{}, &gorm.DB{}).WithDependencies(Dependencies{Notifier: mockNotifier})
+
+	withEvents := &domain.Employee{ID: 1, User: domain.User{Email: "lead@example.com"}}
+	quietTeam := &domain.Employee{ID: 2, User: domain.User{Email: "quiet@example.com"}}
+	mockEmployeeRepo.On("ListEventDigestSubscribers", ctx).Return([]*domain.Employee{withEvents, quietTeam}, nil)
+	mockEmployeeRepo.On("GetReportingLineIDs", ctx, uint(1)).Return([]uint{21}, nil)
+	mockEmployeeRepo.On("ListActiveByIDs", ctx, []uint{21}).Return([]*domain.Employee{{ID: 21, FirstName: "Ayu", DateOfBirth: &birthday}}, nil)
+	mockEmployeeRepo.On("GetReportingLineIDs", ctx, uint(2)).Return([]uint{22}, nil)
+	mockEmployeeRepo.On("ListActiveByIDs", ctx, []uint{22}).Return([]*domain.Employee{{ID: 22, FirstName: "Dimas", DateOfBirth: &birthday, HidePersonalEvents: true}}, nil)
+	mockNotifier.On("SendEmployeeEventDigest", ctx, &withEvents.User, mock.MatchedBy(func(events []*domain.EmployeeEvent) bool {
+		return len(events) == 1 && events[0].Type == domain.EmployeeEventBirthday && events[0].Employee.ID == 21
+	})).Return(nil)
+
+	result, err := uc.ProcessEmployeeEventDigest(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Managers)
+	assert.Equal(t, 1, result.Sent)
+	assert.Equal(t, 0, result.Failed)
+	mockNotifier.AssertNumberOfCalls(t, "SendEmployeeEventDigest", 1)
+}
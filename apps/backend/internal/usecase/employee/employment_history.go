@@ -0,0 +1,441 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"gorm.io/gorm"
+)
+
+// tenureBuckets groups active employees by how long they have been employed. A bucket holds
+// tenures below its upper bound in months; the last bucket has no bound.
+var tenureBuckets = []struct {
+	label     string
+	maxMonths int
+}{
+	{"< 1 year", 12},
+	{"1-3 years", 36},
+	{"3-5 years", 60},
+	{"5+ years", 0},
+}
+
+// recordEvent stores an employment event that happens as part of another operation, such as a hire
+// or a resignation. A failure is logged rather than returned so the operation itself still succeeds.
+func (uc *EmployeeUseCase) recordEvent(ctx context.Context, event *domain.EmploymentEvent) {
+	if uc.employmentEventRepo == nil {
+		return
+	}
+	if err := uc.employmentEventRepo.Create(ctx, event); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to record %s event for employee ID %d: %v", event.EventType, event.EmployeeID, err)
+	}
+}
+
+// hireEvent starts the employment history of a new employee with their initial position, grade,
+// branch, department, contract and salary.
+func hireEvent(employee *domain.Employee) *domain.EmploymentEvent {
+	effectiveDate := time.Now()
+	if employee.HireDate != nil {
+		effectiveDate = *employee.HireDate
+	}
+
+	event := &domain.EmploymentEvent{
+		CompanyID:       employee.CompanyID,
+		EmployeeID:      employee.ID,
+		EventType:       domain.EmploymentEventHire,
+		EffectiveDate:   effectiveDate,
+		NewGrade:        employee.Grade,
+		NewBranch:       employee.Branch,
+		NewDepartmentID: employee.DepartmentID,
+		NewContractType: employee.ContractType,
+		NewBaseSalary:   employee.BaseSalary,
+	}
+	if employee.PositionName != "" {
+		positionName := employee.PositionName
+		event.NewPositionName = &positionName
+	}
+	return event
+}
+
+// validateEmploymentChange checks that the change carries what the event type is about.
+func validateEmploymentChange(eventType domain.EmploymentEventType, change *domain.Employee) error {
+	switch eventType {
+	case domain.EmploymentEventPromotion, domain.EmploymentEventDemotion:
+		if change.PositionName == "" && change.Grade == nil {
+			return fmt.Errorf("%w: a %s needs a new position or grade", domain.ErrInvalidEmploymentEvent, eventType)
+		}
+	case domain.EmploymentEventTransfer:
+		if change.Branch == nil && change.DepartmentID == nil {
+			return fmt.Errorf("%w: a transfer needs a new branch or department", domain.ErrInvalidEmploymentEvent)
+		}
+	case domain.EmploymentEventSalaryChange:
+		if change.BaseSalary == nil {
+			return fmt.Errorf("%w: a salary change needs a new base salary", domain.ErrInvalidEmploymentEvent)
+		}
+	case domain.EmploymentEventContractRenewal:
+	default:
+		return fmt.Errorf("%w: %s events cannot be recorded manually", domain.ErrInvalidEmploymentEvent, eventType)
+	}
+	return nil
+}
+
+// RecordEmploymentEvent applies a promotion, demotion, transfer, contract renewal or salary change
+// to the employee and adds it to their employment history with the previous and new values. An
+// event effective after today is scheduled and applied by ApplyDueEmploymentEvents on that date.
+func (uc *EmployeeUseCase) RecordEmploymentEvent(ctx context.Context, event *domain.EmploymentEvent, change *domain.Employee) (*dtoemployee.EmploymentEventResponseDTO, error) {
+	log.Printf("EmployeeUseCase: RecordEmploymentEvent called for employee ID %d, type: %s", event.EmployeeID, event.EventType)
+
+	if uc.employmentEventRepo == nil {
+		return nil, fmt.Errorf("employment history is not configured")
+	}
+
+	existing, err := uc.employeeRepo.GetByID(ctx, event.EmployeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", event.EmployeeID, err)
+	}
+	if !existing.EmploymentStatus {
+		return nil, fmt.Errorf("%w: employee has resigned", domain.ErrInvalidEmploymentEvent)
+	}
+
+	if err := uc.applyOrganization(ctx, change); err != nil {
+		return nil, err
+	}
+	if err := validateEmploymentChange(event.EventType, change); err != nil {
+		return nil, err
+	}
+
+	setNewValues(event, change)
+	event.CompanyID = existing.CompanyID
+
+	if event.EffectiveDate.Format("2006-01-02") > time.Now().Format("2006-01-02") {
+		event.Scheduled = true
+		if err := uc.employmentEventRepo.Create(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to schedule employment event: %w", err)
+		}
+		log.Printf("EmployeeUseCase: Scheduled %s for employee ID %d effective %s", event.EventType, event.EmployeeID, event.EffectiveDate.Format("2006-01-02"))
+		return dtoemployee.ToEmploymentEventResponseDTO(event), nil
+	}
+
+	if err := uc.applyEmploymentEvent(ctx, existing, event); err != nil {
+		return nil, err
+	}
+
+	log.Printf("EmployeeUseCase: Recorded %s for employee ID %d effective %s", event.EventType, event.EmployeeID, event.EffectiveDate.Format("2006-01-02"))
+	return dtoemployee.ToEmploymentEventResponseDTO(event), nil
+}
+
+// setNewValues copies the values the change sets onto the event.
+func setNewValues(event *domain.EmploymentEvent, change *domain.Employee) {
+	if change.PositionName != "" {
+		newPositionName := change.PositionName
+		event.NewPositionName = &newPositionName
+		event.NewPositionID = change.PositionID
+	}
+	if change.Grade != nil {
+		event.NewGrade = change.Grade
+	}
+	if change.Branch != nil {
+		event.NewBranch = change.Branch
+		event.NewBranchID = change.BranchID
+	}
+	if change.DepartmentID != nil {
+		event.NewDepartmentID = change.DepartmentID
+	}
+	if change.ContractType != nil {
+		event.NewContractType = change.ContractType
+	}
+	if change.BaseSalary != nil {
+		event.NewBaseSalary = change.BaseSalary
+	}
+}
+
+// applyEmploymentEvent fills in the previous values of the event from the employee, applies its new
+// values to the employee and writes both together.
+func (uc *EmployeeUseCase) applyEmploymentEvent(ctx context.Context, employee *domain.Employee, event *domain.EmploymentEvent) error {
+	applyEmploymentValues(employee, event)
+	if err := uc.employeeRepo.ApplyEmploymentEvent(ctx, employee, event); err != nil {
+		return fmt.Errorf("failed to apply %s to employee ID %d: %w", event.EventType, employee.ID, err)
+	}
+	return nil
+}
+
+// applyEmploymentValues fills in the previous values of the event from the employee and applies its
+// new values to the employee, without writing either.
+func applyEmploymentValues(employee *domain.Employee, event *domain.EmploymentEvent) {
+	if event.NewPositionName != nil {
+		previousPositionName := employee.PositionName
+		event.PreviousPositionName = &previousPositionName
+		employee.PositionName = *event.NewPositionName
+		employee.PositionID = event.NewPositionID
+	}
+	if event.NewGrade != nil {
+		event.PreviousGrade = employee.Grade
+		employee.Grade = event.NewGrade
+	}
+	if event.NewBranch != nil {
+		event.PreviousBranch = employee.Branch
+		employee.Branch = event.NewBranch
+		employee.BranchID = event.NewBranchID
+	}
+	if event.NewDepartmentID != nil {
+		event.PreviousDepartmentID = employee.DepartmentID
+		employee.DepartmentID = event.NewDepartmentID
+	}
+	if event.NewContractType != nil {
+		event.PreviousContractType = employee.ContractType
+		employee.ContractType = event.NewContractType
+	}
+	if event.NewBaseSalary != nil {
+		event.PreviousBaseSalary = employee.BaseSalary
+		employee.BaseSalary = event.NewBaseSalary
+	}
+	event.Scheduled = false
+}
+
+// splitEmploymentChange moves the employment values of an update, its position, grade, branch,
+// department, contract type and base salary, to a change of their own. Values equal to the
+// employee's current ones are dropped, so a nil result means the update changes none.
+func splitEmploymentChange(existing, update *domain.Employee) *domain.Employee {
+	change := &domain.Employee{ID: existing.ID}
+	changed := false
+
+	if update.PositionName != "" && update.PositionName != existing.PositionName {
+		change.PositionName = update.PositionName
+		change.PositionID = update.PositionID
+		changed = true
+	}
+	if update.Grade != nil && !stringPtrEqual(update.Grade, existing.Grade) {
+		change.Grade = update.Grade
+		changed = true
+	}
+	if update.Branch != nil && !stringPtrEqual(update.Branch, existing.Branch) {
+		change.Branch = update.Branch
+		change.BranchID = update.BranchID
+		changed = true
+	}
+	if update.DepartmentID != nil && (existing.DepartmentID == nil || *update.DepartmentID != *existing.DepartmentID) {
+		change.DepartmentID = update.DepartmentID
+		changed = true
+	}
+	if update.ContractType != nil && (existing.ContractType == nil || *update.ContractType != *existing.ContractType) {
+		change.ContractType = update.ContractType
+		changed = true
+	}
+	if update.BaseSalary != nil && (existing.BaseSalary == nil || *update.BaseSalary != *existing.BaseSalary) {
+		change.BaseSalary = update.BaseSalary
+		changed = true
+	}
+
+	update.PositionName = ""
+	update.PositionID = nil
+	update.Grade = nil
+	update.Branch = nil
+	update.BranchID = nil
+	update.DepartmentID = nil
+	update.ContractType = nil
+	update.BaseSalary = nil
+
+	if !changed {
+		return nil
+	}
+	return change
+}
+
+// ApplyDueEmploymentEvents applies the scheduled employment events whose effective date has come.
+// Events of employees who have since resigned are left scheduled and counted as failed.
+func (uc *EmployeeUseCase) ApplyDueEmploymentEvents(ctx context.Context) (*dtoemployee.EmploymentEventRunResultDTO, error) {
+	today := startOfDay(time.Now())
+	log.Printf("EmployeeUseCase: Applying employment events due as of %s", today.Format("2006-01-02"))
+
+	if uc.employmentEventRepo == nil {
+		return nil, fmt.Errorf("employment history is not configured")
+	}
+
+	events, err := uc.employmentEventRepo.ListDue(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due employment events: %w", err)
+	}
+
+	result := &dtoemployee.EmploymentEventRunResultDTO{
+		ProcessedDate: today.Format("2006-01-02"),
+		Due:           len(events),
+	}
+	for _, event := range events {
+		employee, err := uc.employeeRepo.GetByID(ctx, event.EmployeeID)
+		if err != nil {
+			log.Printf("Warning: failed to get employee ID %d of employment event ID %d: %v", event.EmployeeID, event.ID, err)
+			result.Failed++
+			continue
+		}
+		if !employee.EmploymentStatus {
+			log.Printf("Warning: employment event ID %d not applied, employee ID %d has resigned", event.ID, event.EmployeeID)
+			result.Failed++
+			continue
+		}
+		if err := uc.applyEmploymentEvent(ctx, employee, event); err != nil {
+			log.Printf("Warning: failed to apply employment event ID %d: %v", event.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Applied++
+	}
+
+	log.Printf("EmployeeUseCase: Applied %d of %d due employment events", result.Applied, result.Due)
+	return result, nil
+}
+
+func (uc *EmployeeUseCase) ListEmploymentHistory(ctx context.Context, employeeID uint) ([]*dtoemployee.EmploymentEventResponseDTO, error) {
+	if _, err := uc.employeeRepo.GetByID(ctx, employeeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	if uc.employmentEventRepo == nil {
+		return []*dtoemployee.EmploymentEventResponseDTO{}, nil
+	}
+
+	events, err := uc.employmentEventRepo.ListByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employment history: %w", err)
+	}
+	return dtoemployee.ToEmploymentEventResponseDTOList(events), nil
+}
+
+// monthsBetween returns the number of whole months from start to end.
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// GetTenureReport groups the active employees by tenure as of the given date.
+func (uc *EmployeeUseCase) GetTenureReport(ctx context.Context, asOf time.Time) (*dtoemployee.TenureReportResponseDTO, error) {
+	report := &dtoemployee.TenureReportResponseDTO{
+		AsOf:    asOf.Format("2006-01-02"),
+		Buckets: make([]dtoemployee.TenureBucketDTO, len(tenureBuckets)),
+	}
+	for i, bucket := range tenureBuckets {
+		report.Buckets[i].Label = bucket.label
+	}
+
+	var totalMonths, counted int
+	err := uc.employeeRepo.ForEachBatch(ctx, map[string]interface{}{"employment_status": true}, func(employees []*domain.Employee) error {
+		report.ActiveEmployees += len(employees)
+		for _, employee := range employees {
+			if employee.HireDate == nil {
+				report.WithoutHireDate++
+				continue
+			}
+
+			months := monthsBetween(*employee.HireDate, asOf)
+			totalMonths += months
+			counted++
+
+			for i, bucket := range tenureBuckets {
+				if bucket.maxMonths == 0 || months < bucket.maxMonths {
+					report.Buckets[i].Count++
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active employees: %w", err)
+	}
+
+	if counted > 0 {
+		report.AverageTenureMonths = math.Round(float64(totalMonths)/float64(counted)*10) / 10
+	}
+	return report, nil
+}
+
+// employedOn reports whether the employee was on the payroll at the end of the given day.
+func employedOn(employee *domain.Employee, date time.Time) bool {
+	day := date.Format("2006-01-02")
+
+	startDate := employee.CreatedAt
+	if employee.HireDate != nil {
+		startDate = *employee.HireDate
+	}
+	if startDate.Format("2006-01-02") > day {
+		return false
+	}
+	return employee.ResignationDate == nil || employee.ResignationDate.Format("2006-01-02") > day
+}
+
+// GetTurnoverReport counts the hires, resignations and internal moves recorded between startDate
+// and endDate. The turnover rate is the resignations as a percentage of the average headcount.
+func (uc *EmployeeUseCase) GetTurnoverReport(ctx context.Context, startDate, endDate time.Time) (*dtoemployee.TurnoverReportResponseDTO, error) {
+	log.Printf("EmployeeUseCase: GetTurnoverReport called from %s to %s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("start date cannot be after end date")
+	}
+	if uc.employmentEventRepo == nil {
+		return nil, fmt.Errorf("employment history is not configured")
+	}
+
+	events, err := uc.employmentEventRepo.ListByPeriod(ctx, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employment events: %w", err)
+	}
+
+	report := &dtoemployee.TurnoverReportResponseDTO{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+	}
+
+	dayBeforeStart := startDate.AddDate(0, 0, -1)
+	err = uc.employeeRepo.ForEachBatch(ctx, map[string]interface{}{}, func(employees []*domain.Employee) error {
+		for _, employee := range employees {
+			if employedOn(employee, dayBeforeStart) {
+				report.HeadcountStart++
+			}
+			if employedOn(employee, endDate) {
+				report.HeadcountEnd++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employees: %w", err)
+	}
+
+	for _, event := range events {
+		switch event.EventType {
+		case domain.EmploymentEventHire:
+			report.Hires++
+		case domain.EmploymentEventResignation:
+			report.Resignations++
+		case domain.EmploymentEventPromotion:
+			report.Promotions++
+		case domain.EmploymentEventDemotion:
+			report.Demotions++
+		case domain.EmploymentEventTransfer:
+			report.Transfers++
+		}
+	}
+
+	averageHeadcount := float64(report.HeadcountStart+report.HeadcountEnd) / 2
+	if averageHeadcount > 0 {
+		report.TurnoverRate = math.Round(float64(report.Resignations)/averageHeadcount*100*100) / 100
+	}
+	return report, nil
+}
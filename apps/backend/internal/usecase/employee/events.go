@@ -0,0 +1,170 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+)
+
+// employeeMilestones are the contract and probation ends falling in a window of days, loaded once
+// and shared between the teams the events are collected for.
+type employeeMilestones struct {
+	contracts  []*domain.EmploymentContract
+	probations []*domain.Probation
+}
+
+func (uc *EmployeeUseCase) loadMilestones(ctx context.Context, from, to time.Time) (*employeeMilestones, error) {
+	milestones := &employeeMilestones{}
+	if uc.contractRepo != nil {
+		contracts, err := uc.contractRepo.ListActiveEndingBefore(ctx, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ending contracts: %w", err)
+		}
+		for _, contract := range contracts {
+			if domain.InEventWindow(*contract.EndDate, from, to) {
+				milestones.contracts = append(milestones.contracts, contract)
+			}
+		}
+	}
+	if uc.probationRepo != nil {
+		probations, err := uc.probationRepo.ListInProgress(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list probations in progress: %w", err)
+		}
+		for _, probation := range probations {
+			if domain.InEventWindow(probation.EndDate, from, to) {
+				milestones.probations = append(milestones.probations, probation)
+			}
+		}
+	}
+	return milestones, nil
+}
+
+// teamEvents returns the events of the employees in the reporting line of the manager between
+// from and to, in date order.
+func (uc *EmployeeUseCase) teamEvents(ctx context.Context, managerID uint, from, to time.Time, milestones *employeeMilestones) ([]*domain.EmployeeEvent, error) {
+	ids, err := uc.employeeRepo.GetReportingLineIDs(ctx, managerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reporting line of employee ID %d: %w", managerID, err)
+	}
+	team, err := uc.employeeRepo.ListActiveByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reporting line of employee ID %d: %w", managerID, err)
+	}
+
+	members := make(map[uint]*domain.Employee, len(team))
+	events := []*domain.EmployeeEvent{}
+	for _, employee := range team {
+		members[employee.ID] = employee
+		events = append(events, employee.PersonalEvents(from, to)...)
+	}
+	for _, contract := range milestones.contracts {
+		if employee, ok := members[contract.EmployeeID]; ok {
+			events = append(events, &domain.EmployeeEvent{Type: domain.EmployeeEventContractEnd, Date: *contract.EndDate, Employee: employee})
+		}
+	}
+	for _, probation := range milestones.probations {
+		if employee, ok := members[probation.EmployeeID]; ok {
+			events = append(events, &domain.EmployeeEvent{Type: domain.EmployeeEventProbationEnd, Date: probation.EndDate, Employee: employee})
+		}
+	}
+
+	domain.SortEmployeeEvents(events)
+	return events, nil
+}
+
+// ListTeamEvents returns the upcoming birthdays, work anniversaries, contract ends and probation
+// ends in the reporting line of the manager, from today to the given number of days ahead.
+// Birthdays and anniversaries of employees who hide their personal events are left out.
+func (uc *EmployeeUseCase) ListTeamEvents(ctx context.Context, managerID uint, days int) (*dtoemployee.EmployeeEventListResponseData, error) {
+	from := startOfDay(time.Now())
+	to := from.AddDate(0, 0, days)
+
+	milestones, err := uc.loadMilestones(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.teamEvents(ctx, managerID, from, to, milestones)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtoemployee.EmployeeEventListResponseData{
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Items: dtoemployee.ToEmployeeEventResponseDTOList(events),
+	}, nil
+}
+
+// UpdateEventPreferences changes whether the employee's birthday and work anniversary show in the
+// events feed and whether the employee receives the daily digest of their team's events. Nil
+// values are left unchanged.
+func (uc *EmployeeUseCase) UpdateEventPreferences(ctx context.Context, employeeID uint, hidePersonalEvents, eventDigest *bool) (*dtoemployee.EventPreferencesResponseDTO, error) {
+	employee, err := uc.getEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hidePersonalEvents != nil {
+		employee.HidePersonalEvents = *hidePersonalEvents
+	}
+	if eventDigest != nil {
+		employee.EventDigest = *eventDigest
+	}
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, fmt.Errorf("failed to update event preferences of employee ID %d: %w", employeeID, err)
+	}
+
+	return &dtoemployee.EventPreferencesResponseDTO{
+		HidePersonalEvents: employee.HidePersonalEvents,
+		EventDigest:        employee.EventDigest,
+	}, nil
+}
+
+// ProcessEmployeeEventDigest sends the managers subscribed to the daily digest the events of today
+// in their reporting line. Managers whose team has no events today get no email.
+func (uc *EmployeeUseCase) ProcessEmployeeEventDigest(ctx context.Context) (*dtoemployee.EventDigestResultDTO, error) {
+	today := startOfDay(time.Now())
+	log.Printf("EmployeeUseCase: Processing employee event digests as of %s", today.Format("2006-01-02"))
+
+	if uc.notifier == nil {
+		return nil, fmt.Errorf("event digests are not configured")
+	}
+
+	managers, err := uc.employeeRepo.ListEventDigestSubscribers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event digest subscribers: %w", err)
+	}
+	milestones, err := uc.loadMilestones(ctx, today, today)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtoemployee.EventDigestResultDTO{ProcessedDate: today.Format("2006-01-02")}
+	for _, manager := range managers {
+		result.Managers++
+		events, err := uc.teamEvents(ctx, manager.ID, today, today, milestones)
+		if err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to collect events for employee ID %d: %v", manager.ID, err)
+			result.Failed++
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+
+		if err := uc.notifier.SendEmployeeEventDigest(ctx, &manager.User, events); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to send event digest to %s: %v", manager.User.Email, err)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+
+	log.Printf("EmployeeUseCase: Sent %d event digests to %d subscribed managers", result.Sent, result.Managers)
+	return result, nil
+}
@@ -0,0 +1,152 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// ExportColumn is a column of an employee export. Keys are the column names of an import file,
+// with custom fields exported as custom_<key>.
+type ExportColumn struct {
+	Key    string `json:"key"`
+	Header string `json:"header"`
+}
+
+// standardExportColumns are the columns every export can include, in their default order.
+var standardExportColumns = []ExportColumn{
+	{Key: "employee_code", Header: "Employee Code"},
+	{Key: "first_name", Header: "First Name"},
+	{Key: "last_name", Header: "Last Name"},
+	{Key: "email", Header: "Email"},
+	{Key: "phone", Header: "Phone"},
+	{Key: "position_name", Header: "Position"},
+	{Key: "department", Header: "Department"},
+	{Key: "branch", Header: "Branch"},
+	{Key: "grade", Header: "Grade"},
+	{Key: "manager", Header: "Manager"},
+	{Key: "employment_status", Header: "Employment Status"},
+	{Key: "contract_type", Header: "Contract Type"},
+	{Key: "hire_date", Header: "Hire Date"},
+	{Key: "resignation_date", Header: "Resignation Date"},
+	{Key: "gender", Header: "Gender"},
+	{Key: "nik", Header: "NIK"},
+	{Key: "place_of_birth", Header: "Place of Birth"},
+	{Key: "date_of_birth", Header: "Date of Birth"},
+	{Key: "last_education", Header: "Last Education"},
+	{Key: "tax_status", Header: "Tax Status"},
+	{Key: "bank_name", Header: "Bank Name"},
+	{Key: "bank_account_number", Header: "Bank Account Number"},
+	{Key: "bank_account_holder_name", Header: "Bank Account Holder Name"},
+	{Key: "base_salary", Header: "Base Salary"},
+}
+
+// ExportColumns returns the columns an export can include: the standard columns followed by the
+// custom fields of the company. The custom fields only admins may see are left out unless
+// includeAdminOnly is set.
+func (uc *EmployeeUseCase) ExportColumns(ctx context.Context, includeAdminOnly bool) ([]ExportColumn, error) {
+	columns := make([]ExportColumn, len(standardExportColumns))
+	copy(columns, standardExportColumns)
+
+	if uc.customFieldRepo == nil {
+		return columns, nil
+	}
+	definitions, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	for _, definition := range definitions {
+		if !includeAdminOnly && definition.Visibility != domain.CustomFieldEmployeeEditable {
+			continue
+		}
+		columns = append(columns, ExportColumn{Key: customFieldColumn(definition.Key), Header: definition.Label})
+	}
+	return columns, nil
+}
+
+// ResolveExportColumns returns the columns with the given keys in the given order, or the standard
+// columns when no keys are given. Admin-only custom fields are rejected unless includeAdminOnly is
+// set.
+func (uc *EmployeeUseCase) ResolveExportColumns(ctx context.Context, keys []string, includeAdminOnly bool) ([]ExportColumn, error) {
+	if len(keys) == 0 {
+		columns := make([]ExportColumn, len(standardExportColumns))
+		copy(columns, standardExportColumns)
+		return columns, nil
+	}
+
+	available, err := uc.ExportColumns(ctx, includeAdminOnly)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]ExportColumn, len(available))
+	for _, column := range available {
+		byKey[column.Key] = column
+	}
+
+	columns := make([]ExportColumn, 0, len(keys))
+	for _, key := range keys {
+		column, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidExportColumn, key)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// ExportEmployees passes the values of the columns for every employee matching the filters to
+// writeRow, in ID order. Employees are read in batches, so an export of any size is written out as
+// it is read.
+func (uc *EmployeeUseCase) ExportEmployees(ctx context.Context, filters map[string]interface{}, columns []ExportColumn, writeRow func(values []string) error) error {
+	var writeErr error
+	err := uc.employeeRepo.ForEachBatch(ctx, filters, func(employees []*domain.Employee) error {
+		for _, employee := range employees {
+			values := exportColumnValues(employee)
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = values[column.Key]
+			}
+			if writeErr = writeRow(row); writeErr != nil {
+				return writeErr
+			}
+		}
+		return nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list employees to export: %w", err)
+	}
+	return nil
+}
+
+// exportColumnValues returns the values of an employee keyed by export column.
+func exportColumnValues(employee *domain.Employee) map[string]string {
+	values := importColumnValues(employee)
+
+	values["email"] = employee.User.Email
+	values["phone"] = employee.User.Phone
+	if employee.Department != nil {
+		values["department"] = employee.Department.Name
+	}
+	if manager := employee.Manager; manager != nil {
+		values["manager"] = manager.FirstName
+		if manager.LastName != nil {
+			values["manager"] += " " + *manager.LastName
+		}
+	}
+	values["employment_status"] = "Inactive"
+	if employee.EmploymentStatus {
+		values["employment_status"] = "Active"
+	}
+	if employee.ResignationDate != nil {
+		values["resignation_date"] = employee.ResignationDate.Format("2006-01-02")
+	}
+	if employee.BaseSalary != nil {
+		values["base_salary"] = strconv.FormatFloat(*employee.BaseSalary, 'f', 2, 64)
+	}
+	return values
+}
@@ -0,0 +1,118 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"gorm.io/gorm"
+)
+
+func (uc *EmployeeUseCase) getEmployee(ctx context.Context, employeeID uint) (*domain.Employee, error) {
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+	return employee, nil
+}
+
+// checkTaxDependants checks a new tax status of an employee against the dependants recorded in
+// its family. Employees whose family has not been recorded yet are not checked.
+func (uc *EmployeeUseCase) checkTaxDependants(ctx context.Context, employee *domain.Employee) error {
+	if uc.familyRepo == nil {
+		return nil
+	}
+	recorded, dependants, err := uc.familyRepo.CountTaxDependants(ctx, employee.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count tax dependants of employee ID %d: %w", employee.ID, err)
+	}
+	if !recorded {
+		return nil
+	}
+	return domain.CheckTaxDependants(employee.TaxStatus, dependants)
+}
+
+// GetFamily returns the family members of an employee, and whether the tax dependants among them
+// match the tax status.
+func (uc *EmployeeUseCase) GetFamily(ctx context.Context, employeeID uint) (*dtoemployee.FamilyResponseDTO, error) {
+	employee, err := uc.getEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*domain.FamilyMember
+	if uc.familyRepo != nil {
+		if members, err = uc.familyRepo.ListFamilyMembers(ctx, employeeID); err != nil {
+			return nil, fmt.Errorf("failed to list family members of employee ID %d: %w", employeeID, err)
+		}
+	}
+	return dtoemployee.ToFamilyResponseDTO(members, employee.TaxStatus), nil
+}
+
+// ReplaceFamily replaces the family members of an employee. A tax status given along is set
+// together with them; either way the tax status has to declare as many dependants as the family
+// records.
+func (uc *EmployeeUseCase) ReplaceFamily(ctx context.Context, employeeID uint, members []*domain.FamilyMember, taxStatus *enums.TaxStatus) (*dtoemployee.FamilyResponseDTO, error) {
+	log.Printf("EmployeeUseCase: ReplaceFamily called for employee ID %d with %d members", employeeID, len(members))
+
+	if uc.familyRepo == nil {
+		return nil, fmt.Errorf("family records are not configured")
+	}
+	employee, err := uc.getEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if taxStatus == nil {
+		taxStatus = employee.TaxStatus
+	}
+	if err := domain.ValidateFamilyMembers(members, taxStatus); err != nil {
+		return nil, err
+	}
+
+	if err := uc.familyRepo.ReplaceFamilyMembers(ctx, employeeID, members, taxStatus); err != nil {
+		return nil, fmt.Errorf("failed to save family members of employee ID %d: %w", employeeID, err)
+	}
+	return dtoemployee.ToFamilyResponseDTO(members, taxStatus), nil
+}
+
+func (uc *EmployeeUseCase) GetEmergencyContacts(ctx context.Context, employeeID uint) ([]*dtoemployee.EmergencyContactResponseDTO, error) {
+	if _, err := uc.getEmployee(ctx, employeeID); err != nil {
+		return nil, err
+	}
+
+	var contacts []*domain.EmergencyContact
+	if uc.familyRepo != nil {
+		var err error
+		if contacts, err = uc.familyRepo.ListEmergencyContacts(ctx, employeeID); err != nil {
+			return nil, fmt.Errorf("failed to list emergency contacts of employee ID %d: %w", employeeID, err)
+		}
+	}
+	return dtoemployee.ToEmergencyContactResponseDTOList(contacts), nil
+}
+
+// ReplaceEmergencyContacts replaces the emergency contacts of an employee.
+func (uc *EmployeeUseCase) ReplaceEmergencyContacts(ctx context.Context, employeeID uint, contacts []*domain.EmergencyContact) ([]*dtoemployee.EmergencyContactResponseDTO, error) {
+	log.Printf("EmployeeUseCase: ReplaceEmergencyContacts called for employee ID %d with %d contacts", employeeID, len(contacts))
+
+	if uc.familyRepo == nil {
+		return nil, fmt.Errorf("family records are not configured")
+	}
+	if _, err := uc.getEmployee(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateEmergencyContacts(contacts); err != nil {
+		return nil, err
+	}
+
+	if err := uc.familyRepo.ReplaceEmergencyContacts(ctx, employeeID, contacts); err != nil {
+		return nil, fmt.Errorf("failed to save emergency contacts of employee ID %d: %w", employeeID, err)
+	}
+	return dtoemployee.ToEmergencyContactResponseDTOList(contacts), nil
+}
@@ -0,0 +1,290 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+)
+
+// SubmitImportJob validates the rows of a create import and queues them as a background job. The
+// file is validated up front the way BulkImportWithTransaction does, so a job is only created for a
+// file without errors; the returned import errors list the problems otherwise.
+func (uc *EmployeeUseCase) SubmitImportJob(ctx context.Context, employees []*domain.Employee, fileName string, creatorEmployeeID uint) (*dtoemployee.ImportJobResponseDTO, []EmployeeImportError, error) {
+	log.Printf("EmployeeUseCase: SubmitImportJob called for %d employees by creator %d", len(employees), creatorEmployeeID)
+
+	if uc.importJobRepo == nil {
+		return nil, nil, fmt.Errorf("import jobs are not configured")
+	}
+
+	if err := uc.checkBulkEmployeeLimit(ctx, creatorEmployeeID, len(employees)); err != nil {
+		var importErrors []EmployeeImportError
+		for i := range employees {
+			importErrors = append(importErrors, EmployeeImportError{
+				Row:     i + 2,
+				Field:   "employee_limit",
+				Message: err.Error(),
+			})
+		}
+		return nil, importErrors, nil
+	}
+
+	if validationErrors := uc.preValidateEmployees(ctx, employees); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+	if validationErrors := uc.comprehensivePreValidation(ctx, employees); len(validationErrors) > 0 {
+		return nil, validationErrors, nil
+	}
+
+	rows := make([]*domain.ImportJobRow, len(employees))
+	for i, employee := range employees {
+		// The default password is set again when the row is processed rather than stored
+		employee.User.Password = ""
+		rows[i] = &domain.ImportJobRow{
+			Row:      i + 2,
+			Employee: employee,
+			Status:   domain.ImportJobRowPending,
+		}
+	}
+
+	job := &domain.ImportJob{
+		CreatedBy: creatorEmployeeID,
+		FileName:  fileName,
+		Status:    domain.ImportJobQueued,
+		TotalRows: len(rows),
+	}
+	if err := uc.importJobRepo.Create(ctx, job, rows); err != nil {
+		return nil, nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	result := dtoemployee.ToImportJobResponseDTO(job)
+	log.Printf("EmployeeUseCase: Queued import job ID %d with %d rows", job.ID, job.TotalRows)
+
+	go uc.runImportJob(importJobContext(job), job)
+	return result, nil, nil
+}
+
+// importJobLease is how long a server holds an import job it runs without saving progress. The
+// lease is renewed whenever the progress of the job is saved.
+const importJobLease = 2 * time.Minute
+
+// ResumeImportJobs carries on with the jobs a restart interrupted. It is meant to be run once when
+// the server starts. Jobs are claimed in the database, so a job is only resumed by one server and
+// not while another server still holds it. A job whose lease had not lapsed yet when the server
+// started is tried again once it has. A row that was being created when the server stopped is
+// retried and fails as a duplicate when its employee was created after all.
+func (uc *EmployeeUseCase) ResumeImportJobs(ctx context.Context) error {
+	if uc.importJobRepo == nil {
+		return nil
+	}
+
+	jobs, err := uc.importJobRepo.ListUnfinished(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list unfinished import jobs: %w", err)
+	}
+
+	var leased []*domain.ImportJob
+	var leaseExpiresAt time.Time
+	for _, job := range jobs {
+		if job.LeaseExpiresAt != nil && job.LeaseExpiresAt.After(time.Now()) {
+			leased = append(leased, job)
+			if job.LeaseExpiresAt.After(leaseExpiresAt) {
+				leaseExpiresAt = *job.LeaseExpiresAt
+			}
+			continue
+		}
+		log.Printf("EmployeeUseCase: Resuming import job ID %d (%d of %d rows processed)", job.ID, job.ProcessedRows, job.TotalRows)
+		uc.runImportJob(importJobContext(job), job)
+	}
+	if len(leased) == 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(leaseExpiresAt)):
+	}
+	for _, job := range leased {
+		log.Printf("EmployeeUseCase: Resuming import job ID %d once its lease lapsed", job.ID)
+		uc.runImportJob(importJobContext(job), job)
+	}
+	return nil
+}
+
+// importJobContext returns the context a job runs in. It outlives the request that submitted the
+// job and carries the company of the job instead.
+func importJobContext(job *domain.ImportJob) context.Context {
+	ctx := context.Background()
+	if job.CompanyID != nil {
+		ctx = tenant.WithCompanyID(ctx, *job.CompanyID)
+	}
+	return ctx
+}
+
+// runImportJob creates the employees of the pending rows of a job, recording the result of every
+// row and the progress of the job as it goes. The job is only run when it can be claimed, that is
+// when no server holds it.
+func (uc *EmployeeUseCase) runImportJob(ctx context.Context, job *domain.ImportJob) {
+	claimed, err := uc.importJobRepo.Claim(ctx, job, time.Now().Add(importJobLease))
+	if err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to claim import job ID %d: %v", job.ID, err)
+		return
+	}
+	if !claimed {
+		log.Printf("EmployeeUseCase: Import job ID %d is run by another server", job.ID)
+		return
+	}
+
+	rows, err := uc.importJobRepo.ListPendingRows(ctx, job.ID)
+	if err != nil {
+		message := fmt.Sprintf("failed to list the rows of the job: %v", err)
+		log.Printf("EmployeeUseCase: Import job ID %d failed: %s", job.ID, message)
+		uc.finishImportJob(ctx, job, domain.ImportJobFailed, &message)
+		return
+	}
+
+	for _, row := range rows {
+		uc.processImportJobRow(ctx, job, row)
+
+		job.ProcessedRows++
+		if row.Status == domain.ImportJobRowSucceeded {
+			job.SucceededRows++
+		} else {
+			job.FailedRows++
+		}
+		if err := uc.importJobRepo.UpdateRow(ctx, row); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to save row %d of import job ID %d: %v", row.Row, job.ID, err)
+		}
+		uc.saveImportJob(ctx, job)
+	}
+
+	if job.SucceededRows > 0 {
+		if err := uc.updateSubscriptionEmployeeCount(ctx, job.CreatedBy); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to update subscription employee count after import job ID %d: %v", job.ID, err)
+		}
+	}
+
+	uc.finishImportJob(ctx, job, domain.ImportJobCompleted, nil)
+	log.Printf("EmployeeUseCase: Import job ID %d completed. Succeeded: %d, Failed: %d", job.ID, job.SucceededRows, job.FailedRows)
+}
+
+// processImportJobRow creates the employee of a row and sets the result on the row. The employee
+// reports to the manager given in the row, if any, not to whoever submitted the job.
+func (uc *EmployeeUseCase) processImportJobRow(ctx context.Context, job *domain.ImportJob, row *domain.ImportJobRow) {
+	if row.Employee == nil {
+		row.Status = domain.ImportJobRowFailed
+		row.Errors = []domain.ImportJobRowError{{Field: "row", Message: "Row has no employee data"}}
+		return
+	}
+
+	employee := *row.Employee
+	if employee.User.Password == "" {
+		employee.User.Password = defaultPassword
+	}
+
+	importErrors := uc.lastMinuteValidation(ctx, &employee, row.Row)
+	if len(importErrors) == 0 {
+		if err := uc.authRepo.RegisterEmployeeUser(ctx, &employee.User, &employee); err != nil {
+			log.Printf("EmployeeUseCase: Error creating employee %s in import job ID %d: %v", employee.FirstName, job.ID, err)
+			importErrors = append(importErrors, uc.convertToImportError(err, &employee, row.Row))
+		}
+	}
+
+	if len(importErrors) > 0 {
+		row.Status = domain.ImportJobRowFailed
+		row.Errors = make([]domain.ImportJobRowError, len(importErrors))
+		for i, importErr := range importErrors {
+			row.Errors[i] = domain.ImportJobRowError{Field: importErr.Field, Message: importErr.Message, Value: importErr.Value}
+		}
+		return
+	}
+
+	uc.recordEvent(ctx, hireEvent(&employee))
+	uc.startProbation(ctx, &employee)
+	uc.startOnboarding(ctx, &employee)
+
+	employeeID := employee.ID
+	row.Status = domain.ImportJobRowSucceeded
+	row.EmployeeID = &employeeID
+	row.Errors = nil
+}
+
+// saveImportJob saves the progress of a job and renews the lease of a running one.
+func (uc *EmployeeUseCase) saveImportJob(ctx context.Context, job *domain.ImportJob) {
+	job.LeaseExpiresAt = nil
+	if job.Status == domain.ImportJobRunning {
+		leaseExpiresAt := time.Now().Add(importJobLease)
+		job.LeaseExpiresAt = &leaseExpiresAt
+	}
+	if err := uc.importJobRepo.Update(ctx, job); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to save progress of import job ID %d: %v", job.ID, err)
+	}
+}
+
+func (uc *EmployeeUseCase) finishImportJob(ctx context.Context, job *domain.ImportJob, status domain.ImportJobStatus, message *string) {
+	now := time.Now()
+	job.Status = status
+	job.Error = message
+	job.CompletedAt = &now
+	uc.saveImportJob(ctx, job)
+}
+
+func (uc *EmployeeUseCase) GetImportJob(ctx context.Context, id uint) (*dtoemployee.ImportJobResponseDTO, error) {
+	if uc.importJobRepo == nil {
+		return nil, domain.ErrImportJobNotFound
+	}
+	job, err := uc.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dtoemployee.ToImportJobResponseDTO(job), nil
+}
+
+func (uc *EmployeeUseCase) ListImportJobs(ctx context.Context, paginationParams domain.PaginationParams) (*dtoemployee.ImportJobListResponseData, error) {
+	if uc.importJobRepo == nil {
+		return nil, fmt.Errorf("import jobs are not configured")
+	}
+
+	jobs, totalItems, err := uc.importJobRepo.List(ctx, paginationParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+
+	totalPages := uc.calculateTotalPages(totalItems, paginationParams.PageSize)
+	return &dtoemployee.ImportJobListResponseData{
+		Items: dtoemployee.ToImportJobResponseDTOList(jobs),
+		Pagination: domain.Pagination{
+			TotalItems:  totalItems,
+			TotalPages:  totalPages,
+			CurrentPage: paginationParams.Page,
+			PageSize:    paginationParams.PageSize,
+			HasNextPage: paginationParams.Page < totalPages,
+			HasPrevPage: paginationParams.Page > 1 && paginationParams.Page <= totalPages,
+		},
+	}, nil
+}
+
+// GetImportJobResults returns the rows of a finished job with their results.
+func (uc *EmployeeUseCase) GetImportJobResults(ctx context.Context, id uint) ([]*domain.ImportJobRow, error) {
+	if uc.importJobRepo == nil {
+		return nil, domain.ErrImportJobNotFound
+	}
+	job, err := uc.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !job.Status.IsFinished() {
+		return nil, domain.ErrImportJobNotFinished
+	}
+
+	rows, err := uc.importJobRepo.ListRows(ctx, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the rows of import job ID %d: %w", job.ID, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,283 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+)
+
+// importMappingSampleSize is the number of sample values returned for each detected column.
+const importMappingSampleSize = 3
+
+// importDateFormats are the date formats tried when detecting the format of a date column, in
+// order of preference. Day-first formats come before month-first ones because that is how dates
+// are written in Indonesia.
+var importDateFormats = []string{
+	"YYYY-MM-DD",
+	"DD/MM/YYYY",
+	"DD-MM-YYYY",
+	"DD.MM.YYYY",
+	"D/M/YYYY",
+	"D-M-YYYY",
+	"MM/DD/YYYY",
+	"M/D/YYYY",
+	"YYYY/MM/DD",
+	"DD/MM/YY",
+	"DD MMM YYYY",
+	"D MMM YYYY",
+	"DD-MMM-YYYY",
+	"DD MMMM YYYY",
+	"D MMMM YYYY",
+	"MMMM D, YYYY",
+}
+
+// ImportTemplateFields returns the columns of an employee import file: the standard fields
+// followed by the company's custom fields.
+func (uc *EmployeeUseCase) ImportTemplateFields(ctx context.Context) ([]domain.ImportField, error) {
+	fields := make([]domain.ImportField, len(domain.ImportFields))
+	copy(fields, domain.ImportFields)
+
+	if uc.customFieldRepo == nil {
+		return fields, nil
+	}
+	definitions, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	for _, definition := range definitions {
+		field := domain.ImportField{
+			Key:      customFieldColumn(definition.Key),
+			Label:    definition.Label,
+			Type:     domain.ImportFieldText,
+			Required: definition.Required,
+			Aliases:  []string{definition.Key},
+		}
+		switch definition.Type {
+		case domain.CustomFieldDate:
+			field.Type = domain.ImportFieldDate
+		case domain.CustomFieldSelect:
+			field.Type = domain.ImportFieldEnum
+			field.Options = definition.Options
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// DetectImportMapping suggests how the columns of a sample file map to the import fields from
+// their headers, and the date format of date columns from their values.
+func (uc *EmployeeUseCase) DetectImportMapping(ctx context.Context, headers []string, rows [][]string) (*dtoemployee.ImportMappingDetectionResponseDTO, error) {
+	fields, err := uc.ImportTemplateFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtoemployee.ImportMappingDetectionResponseDTO{
+		Mappings:              []dtoemployee.ImportMappingSuggestionDTO{},
+		UnmappedHeaders:       []string{},
+		MissingRequiredFields: []string{},
+	}
+	mapped := make(map[string]bool)
+	for i, header := range headers {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		values := columnValues(rows, i)
+
+		field, ok := matchImportField(fields, header, mapped)
+		if !ok {
+			result.UnmappedHeaders = append(result.UnmappedHeaders, header)
+			continue
+		}
+		mapped[field.Key] = true
+
+		suggestion := dtoemployee.ImportMappingSuggestionDTO{
+			ImportColumnMapping: domain.ImportColumnMapping{SourceHeader: header, Field: field.Key},
+			Samples:             values,
+		}
+		if len(values) > importMappingSampleSize {
+			suggestion.Samples = values[:importMappingSampleSize]
+		}
+		if field.Type == domain.ImportFieldDate {
+			suggestion.DateFormat = detectImportDateFormat(values)
+		}
+		result.Mappings = append(result.Mappings, suggestion)
+	}
+
+	for _, field := range fields {
+		if field.Required && !mapped[field.Key] {
+			result.MissingRequiredFields = append(result.MissingRequiredFields, field.Key)
+		}
+	}
+	return result, nil
+}
+
+// matchImportField returns the first field not mapped yet that the header names.
+func matchImportField(fields []domain.ImportField, header string, mapped map[string]bool) (domain.ImportField, bool) {
+	for _, field := range fields {
+		if !mapped[field.Key] && field.MatchesHeader(header) {
+			return field, true
+		}
+	}
+	return domain.ImportField{}, false
+}
+
+// columnValues returns the non-empty values of a column.
+func columnValues(rows [][]string, column int) []string {
+	var values []string
+	for _, row := range rows {
+		if column < len(row) {
+			if value := strings.TrimSpace(row[column]); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// detectImportDateFormat returns the first date format every value parses with, or an empty
+// string when there is none.
+func detectImportDateFormat(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	for _, format := range importDateFormats {
+		layout, err := domain.ImportDateLayoutOf(format)
+		if err != nil {
+			continue
+		}
+		matches := true
+		for _, value := range values {
+			if _, err := domain.ParseImportDate(layout, value); err != nil {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return format
+		}
+	}
+	return ""
+}
+
+// checkImportMappingProfile reports whether a profile is usable, including that its custom field
+// columns name existing custom fields.
+func (uc *EmployeeUseCase) checkImportMappingProfile(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	if err := profile.Check(); err != nil {
+		return err
+	}
+
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range profile.Mappings {
+		if !strings.HasPrefix(mapping.Field, domain.ImportCustomFieldPrefix) {
+			continue
+		}
+		definition, ok := definitions[strings.TrimPrefix(mapping.Field, domain.ImportCustomFieldPrefix)]
+		if !ok {
+			return fmt.Errorf("%w: unknown field %s", domain.ErrInvalidImportMapping, mapping.Field)
+		}
+		if mapping.DateFormat != "" && definition.Type != domain.CustomFieldDate {
+			return fmt.Errorf("%w: %s is not a date field", domain.ErrInvalidImportMapping, mapping.Field)
+		}
+	}
+	return nil
+}
+
+// checkImportMappingProfileName reports an error when another profile of the company has the name.
+func (uc *EmployeeUseCase) checkImportMappingProfileName(ctx context.Context, name string, id uint) error {
+	existing, err := uc.importMappingRepo.GetByName(ctx, name)
+	if err == nil {
+		if existing.ID != id {
+			return domain.ErrImportMappingProfileExists
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrImportMappingProfileNotFound) {
+		return fmt.Errorf("failed to get import mapping profile: %w", err)
+	}
+	return nil
+}
+
+func (uc *EmployeeUseCase) CreateImportMappingProfile(ctx context.Context, profile *domain.ImportMappingProfile) (*dtoemployee.ImportMappingProfileResponseDTO, error) {
+	log.Printf("EmployeeUseCase: CreateImportMappingProfile called with name %s", profile.Name)
+
+	if err := uc.checkImportMappingProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	if err := uc.checkImportMappingProfileName(ctx, profile.Name, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.importMappingRepo.Create(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to create import mapping profile: %w", err)
+	}
+	return dtoemployee.ToImportMappingProfileResponseDTO(profile), nil
+}
+
+func (uc *EmployeeUseCase) ListImportMappingProfiles(ctx context.Context) ([]*dtoemployee.ImportMappingProfileResponseDTO, error) {
+	profiles, err := uc.importMappingRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import mapping profiles: %w", err)
+	}
+	return dtoemployee.ToImportMappingProfileResponseDTOList(profiles), nil
+}
+
+// ImportMappingProfile returns the mapping profile an import file is parsed with.
+func (uc *EmployeeUseCase) ImportMappingProfile(ctx context.Context, id uint) (*domain.ImportMappingProfile, error) {
+	profile, err := uc.importMappingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import mapping profile: %w", err)
+	}
+	return profile, nil
+}
+
+func (uc *EmployeeUseCase) GetImportMappingProfile(ctx context.Context, id uint) (*dtoemployee.ImportMappingProfileResponseDTO, error) {
+	profile, err := uc.ImportMappingProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dtoemployee.ToImportMappingProfileResponseDTO(profile), nil
+}
+
+func (uc *EmployeeUseCase) UpdateImportMappingProfile(ctx context.Context, profile *domain.ImportMappingProfile) (*dtoemployee.ImportMappingProfileResponseDTO, error) {
+	log.Printf("EmployeeUseCase: UpdateImportMappingProfile called for ID %d", profile.ID)
+
+	existing, err := uc.ImportMappingProfile(ctx, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkImportMappingProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	if err := uc.checkImportMappingProfileName(ctx, profile.Name, existing.ID); err != nil {
+		return nil, err
+	}
+
+	existing.Name = profile.Name
+	existing.Mappings = profile.Mappings
+	if err := uc.importMappingRepo.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update import mapping profile: %w", err)
+	}
+	return dtoemployee.ToImportMappingProfileResponseDTO(existing), nil
+}
+
+func (uc *EmployeeUseCase) DeleteImportMappingProfile(ctx context.Context, id uint) error {
+	log.Printf("EmployeeUseCase: DeleteImportMappingProfile called for ID %d", id)
+
+	profile, err := uc.ImportMappingProfile(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.importMappingRepo.Delete(ctx, profile); err != nil {
+		return fmt.Errorf("failed to delete import mapping profile: %w", err)
+	}
+	return nil
+}
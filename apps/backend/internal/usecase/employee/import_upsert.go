@@ -0,0 +1,480 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+// Keys an update or upsert import matches its rows to existing employees on.
+const (
+	ImportMatchByEmployeeCode = "employee_code"
+	ImportMatchByNIK          = "nik"
+)
+
+// Actions an update or upsert import takes for a row.
+const (
+	ImportActionUpdate    = "update"
+	ImportActionCreate    = "create"
+	ImportActionUnchanged = "unchanged"
+)
+
+type ImportFieldChange struct {
+	From string
+	To   string
+}
+
+// EmployeeImportRowPreview is what an update or upsert import does with a row. Changes holds the
+// fields an update changes, keyed by import column.
+type EmployeeImportRowPreview struct {
+	Row        int
+	Action     string
+	EmployeeID *uint
+	Changes    map[string]ImportFieldChange
+}
+
+// EmployeeUpsertResult is the outcome of an update or upsert import. Nothing is applied when the
+// import is a dry run or any row has an error. When creating a new employee fails, the updates are
+// not applied either and Applied is false; the employees created by the other rows are kept and
+// are matched, not created again, when the import is run again.
+type EmployeeUpsertResult struct {
+	DryRun  bool
+	Applied bool
+	Rows    []EmployeeImportRowPreview
+	Errors  []EmployeeImportError
+}
+
+// Count returns the number of rows taking the given action.
+func (r *EmployeeUpsertResult) Count(action string) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// plannedImportRow is a validated row of an update or upsert import with the employee as it will
+// be written.
+type plannedImportRow struct {
+	preview  EmployeeImportRowPreview
+	employee *domain.Employee
+	event    *domain.EmploymentEvent
+}
+
+// BulkUpsert imports changes to existing employees, matched on their employee code or NIK. Blank
+// cells leave a field unchanged, and the email and phone of an existing employee are not changed.
+// With upsert, rows matching no employee create one the way a create import does. Changes to the
+// position, grade, branch, department, contract type or base salary are recorded in the employment
+// history. Every row is validated before anything is written. The new employees are created first,
+// and the updates are applied in a single transaction only once all of them have been created.
+func (uc *EmployeeUseCase) BulkUpsert(ctx context.Context, rows []*domain.Employee, matchBy string, upsert, dryRun bool, creatorEmployeeID uint) (*EmployeeUpsertResult, error) {
+	log.Printf("EmployeeUseCase: BulkUpsert called for %d rows matched by %s (upsert: %t, dry run: %t)", len(rows), matchBy, upsert, dryRun)
+
+	if matchBy != ImportMatchByEmployeeCode && matchBy != ImportMatchByNIK {
+		return nil, fmt.Errorf("unsupported import match key: %s", matchBy)
+	}
+
+	definitions, err := uc.customFieldDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EmployeeUpsertResult{DryRun: dryRun}
+	var planned []plannedImportRow
+	matchedRows := make(map[uint]int)
+
+	for i, row := range rows {
+		rowNum := i + 2
+		key := importMatchKey(row, matchBy)
+
+		var existing *domain.Employee
+		if key != "" {
+			existing, err = uc.findImportMatch(ctx, matchBy, key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if existing == nil {
+			if !upsert {
+				message := fmt.Sprintf("No employee found with %s '%s'", matchBy, key)
+				if key == "" {
+					message = fmt.Sprintf("%s is required to match an existing employee", matchBy)
+				}
+				result.Errors = append(result.Errors, EmployeeImportError{
+					Row:      rowNum,
+					Field:    matchBy,
+					Message:  message,
+					Value:    key,
+					Employee: row,
+				})
+				continue
+			}
+
+			rowErrors := uc.validateRequiredFields(row, rowNum)
+			rowErrors = append(rowErrors, uc.checkBatchDuplicates(row, rows, i)...)
+			rowErrors = append(rowErrors, uc.checkExistingRecords(ctx, row, rowNum)...)
+			rowErrors = append(rowErrors, customFieldImportErrors(definitions, row, rowNum)...)
+			if len(rowErrors) > 0 {
+				result.Errors = append(result.Errors, rowErrors...)
+				continue
+			}
+			planned = append(planned, plannedImportRow{
+				preview:  EmployeeImportRowPreview{Row: rowNum, Action: ImportActionCreate},
+				employee: row,
+			})
+			continue
+		}
+
+		if previousRow, ok := matchedRows[existing.ID]; ok {
+			result.Errors = append(result.Errors, EmployeeImportError{
+				Row:      rowNum,
+				Field:    matchBy,
+				Message:  fmt.Sprintf("Employee with %s '%s' is already updated in row %d", matchBy, key, previousRow),
+				Value:    key,
+				Employee: row,
+			})
+			continue
+		}
+		matchedRows[existing.ID] = rowNum
+
+		updated, event, rowErrors := uc.planImportUpdate(ctx, definitions, existing, row, rows, i)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		employeeID := existing.ID
+		preview := EmployeeImportRowPreview{
+			Row:        rowNum,
+			Action:     ImportActionUpdate,
+			EmployeeID: &employeeID,
+			Changes:    importChanges(existing, updated),
+		}
+		if len(preview.Changes) == 0 {
+			preview.Action = ImportActionUnchanged
+		}
+		planned = append(planned, plannedImportRow{preview: preview, employee: updated, event: event})
+	}
+
+	var updates, creates []*domain.Employee
+	var events []*domain.EmploymentEvent
+	for _, row := range planned {
+		result.Rows = append(result.Rows, row.preview)
+		switch row.preview.Action {
+		case ImportActionUpdate:
+			updates = append(updates, row.employee)
+			if row.event != nil {
+				events = append(events, row.event)
+			}
+		case ImportActionCreate:
+			creates = append(creates, row.employee)
+		}
+	}
+
+	if len(creates) > 0 && len(result.Errors) == 0 {
+		if err := uc.checkBulkEmployeeLimit(ctx, creatorEmployeeID, len(creates)); err != nil {
+			for _, row := range planned {
+				if row.preview.Action == ImportActionCreate {
+					result.Errors = append(result.Errors, EmployeeImportError{
+						Row:      row.preview.Row,
+						Field:    "employee_limit",
+						Message:  err.Error(),
+						Employee: row.employee,
+					})
+				}
+			}
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	created := 0
+	for _, row := range planned {
+		if row.preview.Action != ImportActionCreate {
+			continue
+		}
+		employee := row.employee
+		if employee.ManagerID == nil {
+			employee.ManagerID = &creatorEmployeeID
+		}
+		if employee.User.Password == "" {
+			employee.User.Password = defaultPassword
+		}
+
+		if err := uc.authRepo.RegisterEmployeeUser(ctx, &employee.User, employee); err != nil {
+			log.Printf("EmployeeUseCase: Error creating employee %s during upsert import: %v", employee.FirstName, err)
+			result.Errors = append(result.Errors, uc.convertToImportError(err, employee, row.preview.Row))
+			continue
+		}
+
+		created++
+		uc.recordEvent(ctx, hireEvent(employee))
+		uc.startProbation(ctx, employee)
+		uc.startOnboarding(ctx, employee)
+		employeeID := employee.ID
+		for i := range result.Rows {
+			if result.Rows[i].Row == row.preview.Row {
+				result.Rows[i].EmployeeID = &employeeID
+			}
+		}
+	}
+
+	if created > 0 {
+		if err := uc.updateSubscriptionEmployeeCount(ctx, creatorEmployeeID); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to update subscription employee count after upsert import: %v", err)
+		}
+	}
+	if created < len(creates) {
+		log.Printf("EmployeeUseCase: BulkUpsert created %d of %d employees, updates not applied", created, len(creates))
+		return result, nil
+	}
+
+	if len(updates) > 0 {
+		if err := uc.employeeRepo.BulkUpdate(ctx, updates, events); err != nil {
+			return nil, fmt.Errorf("failed to apply employee updates: %w", err)
+		}
+	}
+
+	result.Applied = true
+	log.Printf("EmployeeUseCase: BulkUpsert completed. Updated: %d, Created: %d, Errors: %d", len(updates), created, len(result.Errors))
+	return result, nil
+}
+
+// PreviewBulkImport validates the rows of a create import without creating any employee.
+func (uc *EmployeeUseCase) PreviewBulkImport(ctx context.Context, employees []*domain.Employee) *EmployeeUpsertResult {
+	result := &EmployeeUpsertResult{DryRun: true}
+
+	result.Errors = uc.preValidateEmployees(ctx, employees)
+	if len(result.Errors) == 0 {
+		result.Errors = uc.comprehensivePreValidation(ctx, employees)
+	}
+
+	failedRows := make(map[int]bool)
+	for _, importErr := range result.Errors {
+		failedRows[importErr.Row] = true
+	}
+	for i := range employees {
+		if rowNum := i + 2; !failedRows[rowNum] {
+			result.Rows = append(result.Rows, EmployeeImportRowPreview{Row: rowNum, Action: ImportActionCreate})
+		}
+	}
+	return result
+}
+
+func importMatchKey(row *domain.Employee, matchBy string) string {
+	var key *string
+	if matchBy == ImportMatchByNIK {
+		key = row.NIK
+	} else {
+		key = row.EmployeeCode
+	}
+	if key == nil {
+		return ""
+	}
+	return *key
+}
+
+// findImportMatch returns the employee of the current company an import row refers to, or nil
+// when there is none.
+func (uc *EmployeeUseCase) findImportMatch(ctx context.Context, matchBy, key string) (*domain.Employee, error) {
+	var existing *domain.Employee
+	var err error
+	if matchBy == ImportMatchByNIK {
+		existing, err = uc.employeeRepo.GetByNIK(ctx, key)
+	} else {
+		existing, err = uc.employeeRepo.GetByEmployeeCode(ctx, key)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up employee with %s %s: %w", matchBy, key, err)
+	}
+	if !belongsToCurrentCompany(ctx, existing) {
+		return nil, nil
+	}
+	return existing, nil
+}
+
+func belongsToCurrentCompany(ctx context.Context, employee *domain.Employee) bool {
+	companyID, ok := tenant.CompanyID(ctx)
+	if !ok {
+		return true
+	}
+	return employee.CompanyID != nil && *employee.CompanyID == companyID
+}
+
+// planImportUpdate returns the existing employee with the changes of an import row applied and
+// the employment event recording the changes to their employment, if any, or the errors that keep
+// the row from being applied.
+func (uc *EmployeeUseCase) planImportUpdate(ctx context.Context, definitions map[string]*domain.CustomFieldDefinition, existing, row *domain.Employee, rows []*domain.Employee, index int) (*domain.Employee, *domain.EmploymentEvent, []EmployeeImportError) {
+	rowNum := index + 2
+	var rowErrors []EmployeeImportError
+
+	// The login of an existing employee is not changed by an import
+	row.User = domain.User{}
+
+	// The row is copied so the employment values are still on it when it is reported with an error
+	personal := *row
+	change := splitEmploymentChange(existing, &personal)
+
+	updated := *existing
+	uc.updateEmployeeFields(&updated, &personal)
+
+	var event *domain.EmploymentEvent
+	if change != nil {
+		reason := "Updated by employee import"
+		event = &domain.EmploymentEvent{
+			CompanyID:     existing.CompanyID,
+			EmployeeID:    existing.ID,
+			EventType:     domain.EmploymentEventImportUpdate,
+			EffectiveDate: startOfDay(time.Now()),
+			Reason:        &reason,
+		}
+		setNewValues(event, change)
+		applyEmploymentValues(&updated, event)
+	}
+
+	if row.TaxStatus != nil {
+		if err := uc.checkTaxDependants(ctx, &updated); err != nil {
+			rowErrors = append(rowErrors, EmployeeImportError{
+				Row:      rowNum,
+				Field:    "tax_status",
+				Message:  err.Error(),
+				Value:    string(*row.TaxStatus),
+				Employee: row,
+			})
+		}
+	}
+
+	customFields, err := mergeCustomFields(definitions, existing.CustomFields, row.CustomFields, false)
+	if err != nil {
+		rowErrors = append(rowErrors, EmployeeImportError{
+			Row:      rowNum,
+			Field:    "custom_fields",
+			Message:  err.Error(),
+			Employee: row,
+		})
+	}
+	updated.CustomFields = customFields
+
+	for _, duplicate := range uc.checkBatchDuplicates(row, rows, index) {
+		if duplicate.Field == "nik" || duplicate.Field == "employee_code" {
+			rowErrors = append(rowErrors, duplicate)
+		}
+	}
+
+	if row.NIK != nil && !stringPtrEqual(row.NIK, existing.NIK) {
+		if other, err := uc.employeeRepo.GetByNIK(ctx, *row.NIK); err == nil && other != nil && other.ID != existing.ID {
+			rowErrors = append(rowErrors, EmployeeImportError{
+				Row:      rowNum,
+				Field:    "nik",
+				Message:  fmt.Sprintf("NIK '%s' is already registered to another employee", *row.NIK),
+				Value:    *row.NIK,
+				Employee: row,
+			})
+		}
+	}
+	if row.EmployeeCode != nil && !stringPtrEqual(row.EmployeeCode, existing.EmployeeCode) {
+		if other, err := uc.employeeRepo.GetByEmployeeCode(ctx, *row.EmployeeCode); err == nil && other != nil && other.ID != existing.ID {
+			rowErrors = append(rowErrors, EmployeeImportError{
+				Row:      rowNum,
+				Field:    "employee_code",
+				Message:  fmt.Sprintf("Employee code '%s' is already used by another employee", *row.EmployeeCode),
+				Value:    *row.EmployeeCode,
+				Employee: row,
+			})
+		}
+	}
+
+	return &updated, event, rowErrors
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// importChanges returns the import columns whose value differs between two versions of an employee.
+func importChanges(before, after *domain.Employee) map[string]ImportFieldChange {
+	beforeValues := importColumnValues(before)
+	afterValues := importColumnValues(after)
+
+	changes := make(map[string]ImportFieldChange)
+	for column, to := range afterValues {
+		if from := beforeValues[column]; from != to {
+			changes[column] = ImportFieldChange{From: from, To: to}
+		}
+	}
+	for column, from := range beforeValues {
+		if _, ok := afterValues[column]; !ok {
+			changes[column] = ImportFieldChange{From: from}
+		}
+	}
+	return changes
+}
+
+// importColumnValues returns the values of an employee keyed by import column, leaving out the
+// empty ones.
+func importColumnValues(employee *domain.Employee) map[string]string {
+	values := map[string]string{
+		"first_name":    employee.FirstName,
+		"position_name": employee.PositionName,
+	}
+	setString := func(column string, value *string) {
+		if value != nil {
+			values[column] = *value
+		}
+	}
+
+	setString("last_name", employee.LastName)
+	setString("employee_code", employee.EmployeeCode)
+	setString("branch", employee.Branch)
+	setString("nik", employee.NIK)
+	setString("place_of_birth", employee.PlaceOfBirth)
+	setString("grade", employee.Grade)
+	setString("bank_name", employee.BankName)
+	setString("bank_account_number", employee.BankAccountNumber)
+	setString("bank_account_holder_name", employee.BankAccountHolderName)
+	if employee.Gender != nil {
+		values["gender"] = string(*employee.Gender)
+	}
+	if employee.LastEducation != nil {
+		values["last_education"] = string(*employee.LastEducation)
+	}
+	if employee.ContractType != nil {
+		values["contract_type"] = string(*employee.ContractType)
+	}
+	if employee.TaxStatus != nil {
+		values["tax_status"] = string(*employee.TaxStatus)
+	}
+	if employee.DateOfBirth != nil {
+		values["date_of_birth"] = employee.DateOfBirth.Format("2006-01-02")
+	}
+	if employee.HireDate != nil {
+		values["hire_date"] = employee.HireDate.Format("2006-01-02")
+	}
+	for key, value := range employee.CustomFields {
+		values[customFieldColumn(key)] = customFieldString(value)
+	}
+
+	for column, value := range values {
+		if value == "" {
+			delete(values, column)
+		}
+	}
+	return values
+}
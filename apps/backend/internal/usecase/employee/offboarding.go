@@ -0,0 +1,430 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"gorm.io/gorm"
+)
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// completeResignation ends the employment of an employee as of their last working day: the
+// employee is marked as resigned, their sessions are revoked, their account is deactivated and
+// their active contract is ended. Everything after the status change is best effort.
+func (uc *EmployeeUseCase) completeResignation(ctx context.Context, employee *domain.Employee, lastWorkingDay time.Time, reason *string) error {
+	employee.EmploymentStatus = false
+	employee.ResignationDate = &lastWorkingDay
+
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		log.Printf("EmployeeUseCase: Error updating employee resignation status in repository: %v", err)
+		return fmt.Errorf("failed to update employee resignation status: %w", err)
+	}
+
+	uc.recordEvent(ctx, &domain.EmploymentEvent{
+		CompanyID:     employee.CompanyID,
+		EmployeeID:    employee.ID,
+		EventType:     domain.EmploymentEventResignation,
+		EffectiveDate: lastWorkingDay,
+		Reason:        reason,
+	})
+
+	if err := uc.authRepo.RevokeAllUserRefreshTokens(ctx, employee.UserID); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to revoke sessions of user ID %d: %v", employee.UserID, err)
+	}
+	if err := uc.authRepo.DeactivateUser(ctx, employee.UserID); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to deactivate user ID %d: %v", employee.UserID, err)
+	}
+
+	if uc.contractRepo != nil {
+		contract, err := uc.contractRepo.GetActiveByEmployee(ctx, employee.ID)
+		if err == nil {
+			contract.Status = domain.ContractStatusEnded
+			if err := uc.contractRepo.Update(ctx, contract); err != nil {
+				log.Printf("EmployeeUseCase: Warning - failed to end contract ID %d: %v", contract.ID, err)
+			}
+		} else if !errors.Is(err, domain.ErrContractNotFound) {
+			log.Printf("EmployeeUseCase: Warning - failed to get active contract of employee ID %d: %v", employee.ID, err)
+		}
+	}
+
+	if employee.ManagerID != nil {
+		if err := uc.updateSubscriptionEmployeeCount(ctx, *employee.ManagerID); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to update subscription employee count after resignation: %v", err)
+		}
+	}
+
+	if uc.leaveEncashmentUC != nil {
+		if err := uc.leaveEncashmentUC.CreateResignationEncashment(ctx, employee); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to draft leave encashment after resignation: %v", err)
+		}
+	}
+	return nil
+}
+
+// defaultOffboardingTasks is the exit checklist used when none is given. The leaver's manager
+// handles the handover and equipment, the person who started the offboarding the rest.
+func defaultOffboardingTasks(employee *domain.Employee, hrEmployeeID *uint, noticeDate, lastWorkingDay time.Time) []domain.OffboardingTask {
+	handoverDue := lastWorkingDay.AddDate(0, 0, -5)
+	if handoverDue.Before(noticeDate) {
+		handoverDue = noticeDate
+	}
+	interviewDue := lastWorkingDay.AddDate(0, 0, -1)
+	if interviewDue.Before(noticeDate) {
+		interviewDue = noticeDate
+	}
+
+	return []domain.OffboardingTask{
+		{Title: "Knowledge handover", AssigneeID: employee.ManagerID, DueDate: handoverDue},
+		{Title: "Exit interview", AssigneeID: hrEmployeeID, DueDate: interviewDue},
+		{Title: "Return company equipment", AssigneeID: employee.ManagerID, DueDate: lastWorkingDay},
+		{Title: "Revoke access to company systems", AssigneeID: hrEmployeeID, DueDate: lastWorkingDay},
+		{Title: "Approve final settlement", AssigneeID: hrEmployeeID, DueDate: lastWorkingDay},
+	}
+}
+
+// StartOffboarding schedules the departure of an employee. The employee keeps their access until
+// the end of the last working day, which cannot be in the past.
+func (uc *EmployeeUseCase) StartOffboarding(ctx context.Context, offboarding *domain.Offboarding) (*dtoemployee.OffboardingResponseDTO, error) {
+	log.Printf("EmployeeUseCase: StartOffboarding called for employee ID %d, type: %s", offboarding.EmployeeID, offboarding.ResignationType)
+
+	if uc.offboardingRepo == nil {
+		return nil, fmt.Errorf("offboarding is not configured")
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, offboarding.EmployeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", offboarding.EmployeeID, err)
+	}
+	if !employee.EmploymentStatus {
+		return nil, fmt.Errorf("%w: employee has already resigned", domain.ErrInvalidOffboarding)
+	}
+
+	existing, err := uc.offboardingRepo.GetLatestByEmployee(ctx, offboarding.EmployeeID)
+	if err != nil && !errors.Is(err, domain.ErrOffboardingNotFound) {
+		return nil, fmt.Errorf("failed to get offboarding of employee ID %d: %w", offboarding.EmployeeID, err)
+	}
+	if existing != nil && existing.Status == domain.OffboardingScheduled {
+		return nil, domain.ErrOffboardingExists
+	}
+
+	today := startOfDay(time.Now())
+	if offboarding.NoticeDate.IsZero() {
+		offboarding.NoticeDate = today
+	}
+	if offboarding.LastWorkingDay.Before(today) {
+		return nil, fmt.Errorf("%w: the last working day cannot be in the past", domain.ErrInvalidOffboarding)
+	}
+	if offboarding.LastWorkingDay.Before(offboarding.NoticeDate) {
+		return nil, fmt.Errorf("%w: the last working day cannot be before the notice date", domain.ErrInvalidOffboarding)
+	}
+
+	if len(offboarding.Tasks) == 0 {
+		var hrEmployeeID *uint
+		if initiator, err := uc.employeeRepo.GetByUserID(ctx, offboarding.InitiatedBy); err == nil {
+			hrEmployeeID = &initiator.ID
+		}
+		offboarding.Tasks = defaultOffboardingTasks(employee, hrEmployeeID, offboarding.NoticeDate, offboarding.LastWorkingDay)
+	}
+	for i := range offboarding.Tasks {
+		task := &offboarding.Tasks[i]
+		if task.DueDate.IsZero() {
+			task.DueDate = offboarding.LastWorkingDay
+		}
+		if task.AssigneeID != nil {
+			if _, err := uc.employeeRepo.GetByID(ctx, *task.AssigneeID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, fmt.Errorf("%w: assignee %d of task %q not found", domain.ErrInvalidOffboarding, *task.AssigneeID, task.Title)
+				}
+				return nil, fmt.Errorf("failed to get assignee ID %d: %w", *task.AssigneeID, err)
+			}
+		}
+	}
+
+	offboarding.CompanyID = employee.CompanyID
+	offboarding.Status = domain.OffboardingScheduled
+	if err := uc.offboardingRepo.Create(ctx, offboarding); err != nil {
+		return nil, fmt.Errorf("failed to create offboarding: %w", err)
+	}
+
+	log.Printf("EmployeeUseCase: Scheduled offboarding ID %d for employee ID %d, last working day %s",
+		offboarding.ID, offboarding.EmployeeID, offboarding.LastWorkingDay.Format("2006-01-02"))
+	return dtoemployee.ToOffboardingResponseDTO(offboarding), nil
+}
+
+// getOffboarding returns the most recent offboarding of an employee.
+func (uc *EmployeeUseCase) getOffboarding(ctx context.Context, employeeID uint) (*domain.Offboarding, error) {
+	if uc.offboardingRepo == nil {
+		return nil, domain.ErrOffboardingNotFound
+	}
+	if _, err := uc.employeeRepo.GetByID(ctx, employeeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	offboarding, err := uc.offboardingRepo.GetLatestByEmployee(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOffboardingNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get offboarding of employee ID %d: %w", employeeID, err)
+	}
+	return offboarding, nil
+}
+
+func (uc *EmployeeUseCase) GetOffboarding(ctx context.Context, employeeID uint) (*dtoemployee.OffboardingResponseDTO, error) {
+	offboarding, err := uc.getOffboarding(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return dtoemployee.ToOffboardingResponseDTO(offboarding), nil
+}
+
+// CancelOffboarding withdraws a scheduled offboarding, for example when a resignation is retracted.
+func (uc *EmployeeUseCase) CancelOffboarding(ctx context.Context, employeeID uint) (*dtoemployee.OffboardingResponseDTO, error) {
+	log.Printf("EmployeeUseCase: CancelOffboarding called for employee ID %d", employeeID)
+
+	offboarding, err := uc.getOffboarding(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if offboarding.Status != domain.OffboardingScheduled {
+		return nil, domain.ErrOffboardingNotScheduled
+	}
+
+	offboarding.Status = domain.OffboardingCancelled
+	if err := uc.offboardingRepo.Update(ctx, offboarding); err != nil {
+		return nil, fmt.Errorf("failed to cancel offboarding ID %d: %w", offboarding.ID, err)
+	}
+	return dtoemployee.ToOffboardingResponseDTO(offboarding), nil
+}
+
+// UpdateOffboardingTask ticks off or reopens an item of the exit checklist. Items can still be
+// ticked off after the employee has left, but not once the offboarding is cancelled.
+func (uc *EmployeeUseCase) UpdateOffboardingTask(ctx context.Context, employeeID, taskID uint, completed bool, userID uint) (*dtoemployee.OffboardingTaskResponseDTO, error) {
+	offboarding, err := uc.getOffboarding(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if offboarding.Status == domain.OffboardingCancelled {
+		return nil, domain.ErrOffboardingNotScheduled
+	}
+
+	task, err := uc.offboardingRepo.GetTaskByID(ctx, offboarding.ID, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOffboardingTaskNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get offboarding task ID %d: %w", taskID, err)
+	}
+
+	if completed {
+		now := time.Now()
+		task.CompletedAt = &now
+		task.CompletedBy = &userID
+	} else {
+		task.CompletedAt = nil
+		task.CompletedBy = nil
+	}
+	if err := uc.offboardingRepo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update offboarding task ID %d: %w", taskID, err)
+	}
+	return dtoemployee.ToOffboardingTaskResponseDTO(task), nil
+}
+
+// ProcessDueOffboardings completes the scheduled offboardings whose last working day has passed.
+func (uc *EmployeeUseCase) ProcessDueOffboardings(ctx context.Context) (*dtoemployee.OffboardingRunResultDTO, error) {
+	today := startOfDay(time.Now())
+	log.Printf("EmployeeUseCase: Processing due offboardings as of %s", today.Format("2006-01-02"))
+
+	if uc.offboardingRepo == nil {
+		return nil, fmt.Errorf("offboarding is not configured")
+	}
+
+	offboardings, err := uc.offboardingRepo.ListDue(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due offboardings: %w", err)
+	}
+
+	result := &dtoemployee.OffboardingRunResultDTO{
+		ProcessedDate: today.Format("2006-01-02"),
+		Due:           len(offboardings),
+	}
+	for _, offboarding := range offboardings {
+		if err := uc.completeResignation(ctx, &offboarding.Employee, offboarding.LastWorkingDay, offboarding.Notes); err != nil {
+			log.Printf("Warning: failed to complete offboarding ID %d: %v", offboarding.ID, err)
+			result.Failed++
+			continue
+		}
+
+		now := time.Now()
+		offboarding.Status = domain.OffboardingCompleted
+		offboarding.CompletedAt = &now
+		if err := uc.offboardingRepo.Update(ctx, offboarding); err != nil {
+			log.Printf("Warning: failed to mark offboarding ID %d as completed: %v", offboarding.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Completed++
+	}
+
+	log.Printf("EmployeeUseCase: Completed %d of %d due offboardings", result.Completed, result.Due)
+	return result, nil
+}
+
+// daysWorkedInFinalMonth returns the number of calendar days of the last month the employee was
+// employed, counting from their hire date when they joined that month.
+func daysWorkedInFinalMonth(employee *domain.Employee, lastWorkingDay time.Time) (worked, daysInMonth int) {
+	daysInMonth = time.Date(lastWorkingDay.Year(), lastWorkingDay.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	worked = lastWorkingDay.Day()
+	if hireDate := employee.HireDate; hireDate != nil &&
+		hireDate.Year() == lastWorkingDay.Year() && hireDate.Month() == lastWorkingDay.Month() {
+		worked = lastWorkingDay.Day() - hireDate.Day() + 1
+	}
+	return worked, daysInMonth
+}
+
+// GetFinalSettlement summarises what is owed to an employee who is leaving or has left: the
+// prorated salary of the final month, the payout of their remaining annual leave, and the exit
+// checklist items that are still open.
+func (uc *EmployeeUseCase) GetFinalSettlement(ctx context.Context, employeeID uint) (*dtoemployee.FinalSettlementResponseDTO, error) {
+	offboarding, err := uc.getOffboarding(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if offboarding.Status == domain.OffboardingCancelled {
+		return nil, domain.ErrOffboardingNotFound
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	employeeName := employee.FirstName
+	if employee.LastName != nil {
+		employeeName += " " + *employee.LastName
+	}
+
+	lastWorkingDay := offboarding.LastWorkingDay
+	settlement := &dtoemployee.FinalSettlementResponseDTO{
+		EmployeeID:       employee.ID,
+		EmployeeName:     employeeName,
+		ResignationType:  string(offboarding.ResignationType),
+		LastWorkingDay:   lastWorkingDay.Format("2006-01-02"),
+		BaseSalary:       employee.BaseSalary,
+		OutstandingTasks: []*dtoemployee.OffboardingTaskResponseDTO{},
+	}
+
+	settlement.DaysWorked, settlement.DaysInFinalMonth = daysWorkedInFinalMonth(employee, lastWorkingDay)
+	if employee.BaseSalary != nil {
+		settlement.ProratedSalary = math.Round(*employee.BaseSalary*float64(settlement.DaysWorked)/float64(settlement.DaysInFinalMonth)*100) / 100
+	}
+
+	if uc.leaveEncashmentUC != nil {
+		encashment, err := uc.leaveEncashmentUC.PreviewResignationEncashment(ctx, employee, lastWorkingDay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate leave balance: %w", err)
+		}
+		settlement.LeaveBalance = &dtoemployee.LeaveSettlementDTO{
+			Year:             encashment.Year,
+			EntitledDays:     encashment.EntitledDays,
+			UsedDays:         encashment.UsedDays,
+			RemainingDays:    encashment.EncashedDays,
+			DailyRate:        encashment.DailyRate,
+			EncashmentAmount: encashment.Amount,
+		}
+	}
+
+	settlement.TotalPayable = settlement.ProratedSalary
+	if settlement.LeaveBalance != nil {
+		settlement.TotalPayable += settlement.LeaveBalance.EncashmentAmount
+	}
+	settlement.TotalPayable = math.Round(settlement.TotalPayable*100) / 100
+
+	for i := range offboarding.Tasks {
+		if offboarding.Tasks[i].CompletedAt == nil {
+			settlement.OutstandingTasks = append(settlement.OutstandingTasks, dtoemployee.ToOffboardingTaskResponseDTO(&offboarding.Tasks[i]))
+		}
+	}
+	return settlement, nil
+}
+
+// exitCounts turns counts per key into a list sorted from the most to the least common.
+func exitCounts(counts map[string]int, total int) []*dtoemployee.ExitCountDTO {
+	result := make([]*dtoemployee.ExitCountDTO, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, &dtoemployee.ExitCountDTO{
+			Key:        key,
+			Count:      count,
+			Percentage: math.Round(float64(count)/float64(total)*100*100) / 100,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// GetExitReasonReport breaks down the completed offboardings with a last working day between
+// startDate and endDate by resignation type and exit reason.
+func (uc *EmployeeUseCase) GetExitReasonReport(ctx context.Context, startDate, endDate time.Time) (*dtoemployee.ExitReasonReportResponseDTO, error) {
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("start date cannot be after end date")
+	}
+	if uc.offboardingRepo == nil {
+		return nil, fmt.Errorf("offboarding is not configured")
+	}
+
+	offboardings, err := uc.offboardingRepo.ListCompletedBetween(ctx, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed offboardings: %w", err)
+	}
+
+	report := &dtoemployee.ExitReasonReportResponseDTO{
+		StartDate:         startDate.Format("2006-01-02"),
+		EndDate:           endDate.Format("2006-01-02"),
+		TotalExits:        len(offboardings),
+		ByResignationType: []*dtoemployee.ExitCountDTO{},
+		ByExitReason:      []*dtoemployee.ExitCountDTO{},
+	}
+	if len(offboardings) == 0 {
+		return report, nil
+	}
+
+	byType := make(map[string]int)
+	byReason := make(map[string]int)
+	var totalMonths, counted int
+	for _, offboarding := range offboardings {
+		byType[string(offboarding.ResignationType)]++
+		byReason[string(offboarding.ExitReason)]++
+		if offboarding.Employee.HireDate != nil {
+			totalMonths += monthsBetween(*offboarding.Employee.HireDate, offboarding.LastWorkingDay)
+			counted++
+		}
+	}
+
+	report.ByResignationType = exitCounts(byType, len(offboardings))
+	report.ByExitReason = exitCounts(byReason, len(offboardings))
+	if counted > 0 {
+		report.AverageTenureMonthsAtExit = math.Round(float64(totalMonths)/float64(counted)*10) / 10
+	}
+	return report, nil
+}
@@ -0,0 +1,19 @@
+package employee
+
+import (
+	"context"
+	"log"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+)
+
+// startOnboarding creates the onboarding checklist of a new hire. A failure is logged rather than
+// returned so the hire itself still succeeds.
+func (uc *EmployeeUseCase) startOnboarding(ctx context.Context, employee *domain.Employee) {
+	if uc.onboardingUC == nil {
+		return
+	}
+	if err := uc.onboardingUC.StartOnboarding(ctx, employee); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to start onboarding of employee ID %d: %v", employee.ID, err)
+	}
+}
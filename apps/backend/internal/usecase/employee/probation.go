@@ -0,0 +1,366 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"gorm.io/gorm"
+)
+
+// startProbation puts a new hire on probation for the default length from their hire date.
+// Freelancers have no probation. A failure is logged rather than returned so the hire itself
+// still succeeds.
+func (uc *EmployeeUseCase) startProbation(ctx context.Context, employee *domain.Employee) {
+	if uc.probationRepo == nil {
+		return
+	}
+	if employee.ContractType != nil && *employee.ContractType == enums.Freelance {
+		return
+	}
+
+	startDate := startOfDay(time.Now())
+	if employee.HireDate != nil {
+		startDate = *employee.HireDate
+	}
+	probation := &domain.Probation{
+		CompanyID:  employee.CompanyID,
+		EmployeeID: employee.ID,
+		StartDate:  startDate,
+		EndDate:    startDate.AddDate(0, domain.DefaultProbationMonths, -1),
+		Status:     domain.ProbationInProgress,
+	}
+	if err := uc.probationRepo.Create(ctx, probation); err != nil {
+		log.Printf("EmployeeUseCase: Warning - failed to start probation of employee ID %d: %v", employee.ID, err)
+	}
+}
+
+// validateProbationEnd checks that a probation ends after it starts and is not longer than the
+// maximum probation length.
+func validateProbationEnd(startDate, endDate time.Time) error {
+	if !endDate.After(startDate) {
+		return fmt.Errorf("%w: the end date must be after the start date", domain.ErrInvalidProbation)
+	}
+	if endDate.After(startDate.AddDate(0, domain.MaxProbationMonths, -1)) {
+		return fmt.Errorf("%w: a probation cannot be longer than %d months", domain.ErrInvalidProbation, domain.MaxProbationMonths)
+	}
+	return nil
+}
+
+// getProbation returns the most recent probation of an employee together with the employee.
+func (uc *EmployeeUseCase) getProbation(ctx context.Context, employeeID uint) (*domain.Probation, *domain.Employee, error) {
+	if uc.probationRepo == nil {
+		return nil, nil, domain.ErrProbationNotFound
+	}
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, domain.ErrEmployeeNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	probation, err := uc.probationRepo.GetLatestByEmployee(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrProbationNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get probation of employee ID %d: %w", employeeID, err)
+	}
+	return probation, employee, nil
+}
+
+func (uc *EmployeeUseCase) GetProbation(ctx context.Context, employeeID uint) (*dtoemployee.ProbationResponseDTO, error) {
+	probation, _, err := uc.getProbation(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return dtoemployee.ToProbationResponseDTO(probation, startOfDay(time.Now())), nil
+}
+
+// UpdateProbation corrects the dates of a probation that is still in progress. The review
+// reminders start over for the new dates.
+func (uc *EmployeeUseCase) UpdateProbation(ctx context.Context, employeeID uint, startDate, endDate time.Time) (*dtoemployee.ProbationResponseDTO, error) {
+	log.Printf("EmployeeUseCase: UpdateProbation called for employee ID %d", employeeID)
+
+	probation, _, err := uc.getProbation(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if probation.Status != domain.ProbationInProgress {
+		return nil, domain.ErrProbationNotInProgress
+	}
+	if err := validateProbationEnd(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	probation.StartDate = startDate
+	probation.EndDate = endDate
+	probation.LastReminderDays = nil
+	if err := uc.probationRepo.Update(ctx, probation); err != nil {
+		return nil, fmt.Errorf("failed to update probation ID %d: %w", probation.ID, err)
+	}
+	return dtoemployee.ToProbationResponseDTO(probation, startOfDay(time.Now())), nil
+}
+
+// confirmEmployee makes an employee who passed their probation permanent and adds the
+// confirmation to their employment history. An active fixed-term contract is closed as converted
+// and followed by a permanent one.
+func (uc *EmployeeUseCase) confirmEmployee(ctx context.Context, employee *domain.Employee, review *domain.ProbationReview, effectiveDate time.Time) error {
+	previousContractType := employee.ContractType
+	permanent := enums.Permanent
+	if previousContractType == nil || *previousContractType != permanent {
+		employee.ContractType = &permanent
+		if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+			return fmt.Errorf("failed to update contract type of employee ID %d: %w", employee.ID, err)
+		}
+	}
+
+	if uc.contractRepo != nil {
+		active, err := uc.contractRepo.GetActiveByEmployee(ctx, employee.ID)
+		if err == nil && active.ContractType != permanent {
+			active.Status = domain.ContractStatusConverted
+			if err := uc.contractRepo.Update(ctx, active); err != nil {
+				log.Printf("EmployeeUseCase: Warning - failed to close contract ID %d: %v", active.ID, err)
+			} else if err := uc.contractRepo.Create(ctx, &domain.EmploymentContract{
+				CompanyID:    employee.CompanyID,
+				EmployeeID:   employee.ID,
+				ContractType: permanent,
+				StartDate:    effectiveDate,
+				Status:       domain.ContractStatusActive,
+				CreatedBy:    &review.ReviewerID,
+			}); err != nil {
+				log.Printf("EmployeeUseCase: Warning - failed to start permanent contract of employee ID %d: %v", employee.ID, err)
+			}
+		} else if err != nil && !errors.Is(err, domain.ErrContractNotFound) {
+			log.Printf("EmployeeUseCase: Warning - failed to get active contract of employee ID %d: %v", employee.ID, err)
+		}
+	}
+
+	uc.recordEvent(ctx, &domain.EmploymentEvent{
+		CompanyID:            employee.CompanyID,
+		EmployeeID:           employee.ID,
+		EventType:            domain.EmploymentEventProbationConfirmed,
+		EffectiveDate:        effectiveDate,
+		Reason:               review.Comments,
+		PreviousContractType: previousContractType,
+		NewContractType:      &permanent,
+		RecordedBy:           &review.ReviewerID,
+	})
+	return nil
+}
+
+// checkProbationReviewer checks that the reviewer is the employee's manager or the owner of their
+// company, and not the employee themselves.
+func (uc *EmployeeUseCase) checkProbationReviewer(ctx context.Context, employee *domain.Employee, reviewerID uint) error {
+	if employee.UserID == reviewerID {
+		return domain.ErrProbationSelfReview
+	}
+	if employee.Manager != nil && employee.Manager.UserID == reviewerID {
+		return nil
+	}
+	if employee.CompanyID != nil && uc.companyRepo != nil {
+		company, err := uc.companyRepo.GetByID(ctx, *employee.CompanyID)
+		if err != nil {
+			return fmt.Errorf("failed to get company ID %d: %w", *employee.CompanyID, err)
+		}
+		if company.OwnerUserID == reviewerID {
+			return nil
+		}
+	}
+	return domain.ErrProbationReviewDenied
+}
+
+// ReviewProbation records the manager's review of a probation in progress. Confirming makes the
+// employee permanent, extending moves the end date, and terminating schedules the employee's
+// offboarding with the given last working day, which defaults to the end of the probation. Only
+// the employee's manager or the company owner can review, and never the employee themselves.
+func (uc *EmployeeUseCase) ReviewProbation(ctx context.Context, employeeID uint, review *domain.ProbationReview, lastWorkingDay *time.Time) (*dtoemployee.ProbationResponseDTO, error) {
+	log.Printf("EmployeeUseCase: ReviewProbation called for employee ID %d, outcome: %s", employeeID, review.Outcome)
+
+	probation, employee, err := uc.getProbation(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkProbationReviewer(ctx, employee, review.ReviewerID); err != nil {
+		return nil, err
+	}
+	if probation.Status != domain.ProbationInProgress || !employee.EmploymentStatus {
+		return nil, domain.ErrProbationNotInProgress
+	}
+
+	today := startOfDay(time.Now())
+	review.ProbationID = probation.ID
+	review.PreviousEndDate = probation.EndDate
+
+	var offboarding *dtoemployee.OffboardingResponseDTO
+	switch review.Outcome {
+	case domain.ProbationOutcomeConfirm:
+		if err := uc.confirmEmployee(ctx, employee, review, today); err != nil {
+			return nil, err
+		}
+		probation.Status = domain.ProbationConfirmed
+	case domain.ProbationOutcomeExtend:
+		if review.NewEndDate == nil || !review.NewEndDate.After(probation.EndDate) {
+			return nil, fmt.Errorf("%w: an extension needs an end date after %s", domain.ErrInvalidProbation, probation.EndDate.Format("2006-01-02"))
+		}
+		if err := validateProbationEnd(probation.StartDate, *review.NewEndDate); err != nil {
+			return nil, err
+		}
+		probation.EndDate = *review.NewEndDate
+		probation.LastReminderDays = nil
+	case domain.ProbationOutcomeTerminate:
+		end := probation.EndDate
+		if lastWorkingDay != nil {
+			end = *lastWorkingDay
+		}
+		if end.Before(today) {
+			end = today
+		}
+		offboarding, err = uc.StartOffboarding(ctx, &domain.Offboarding{
+			EmployeeID:      employee.ID,
+			ResignationType: domain.ResignationTermination,
+			ExitReason:      domain.ExitReasonPerformance,
+			Notes:           review.Comments,
+			LastWorkingDay:  end,
+			InitiatedBy:     review.ReviewerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		probation.Status = domain.ProbationTerminated
+	default:
+		return nil, fmt.Errorf("%w: unknown outcome %s", domain.ErrInvalidProbation, review.Outcome)
+	}
+
+	if err := uc.probationRepo.CreateReview(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to record probation review: %w", err)
+	}
+	if err := uc.probationRepo.Update(ctx, probation); err != nil {
+		return nil, fmt.Errorf("failed to update probation ID %d: %w", probation.ID, err)
+	}
+
+	probation.Reviews = append(probation.Reviews, *review)
+	response := dtoemployee.ToProbationResponseDTO(probation, today)
+	response.Offboarding = offboarding
+	return response, nil
+}
+
+// GetProbationReport lists everyone currently on probation by the end of their probation.
+func (uc *EmployeeUseCase) GetProbationReport(ctx context.Context) ([]*dtoemployee.ProbationReportItemDTO, error) {
+	if uc.probationRepo == nil {
+		return []*dtoemployee.ProbationReportItemDTO{}, nil
+	}
+
+	probations, err := uc.probationRepo.ListInProgress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list probations in progress: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	items := make([]*dtoemployee.ProbationReportItemDTO, len(probations))
+	for i, probation := range probations {
+		items[i] = dtoemployee.ToProbationReportItemDTO(probation, today)
+	}
+	return items, nil
+}
+
+// probationReminderThreshold returns the reminder that is due for a review due in daysLeft days:
+// the closest of the reminder days that has been reached.
+func probationReminderThreshold(daysLeft int) (int, bool) {
+	threshold, due := 0, false
+	for _, days := range domain.ProbationReminderDays {
+		if daysLeft <= days && (!due || days < threshold) {
+			threshold, due = days, true
+		}
+	}
+	return threshold, due
+}
+
+// probationReminderRecipients returns the users to remind about a probation review: the
+// employee's manager, who writes the review, and the owner of the company, who acts as HR.
+func (uc *EmployeeUseCase) probationReminderRecipients(ctx context.Context, probation *domain.Probation) []*domain.User {
+	var userIDs []uint
+	if manager := probation.Employee.Manager; manager != nil {
+		userIDs = append(userIDs, manager.UserID)
+	}
+	if probation.CompanyID != nil && uc.companyRepo != nil {
+		company, err := uc.companyRepo.GetByID(ctx, *probation.CompanyID)
+		if err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to get company ID %d: %v", *probation.CompanyID, err)
+		} else if len(userIDs) == 0 || userIDs[0] != company.OwnerUserID {
+			userIDs = append(userIDs, company.OwnerUserID)
+		}
+	}
+
+	recipients := make([]*domain.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := uc.authRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to get user ID %d: %v", userID, err)
+			continue
+		}
+		recipients = append(recipients, user)
+	}
+	return recipients
+}
+
+// ProcessProbationReviewReminders reminds managers and HR of probation reviews due in 14, 7 and 1
+// days. Each reminder is sent once per probation and again after the probation is extended.
+func (uc *EmployeeUseCase) ProcessProbationReviewReminders(ctx context.Context) (*dtoemployee.ProbationReminderResultDTO, error) {
+	today := startOfDay(time.Now())
+	log.Printf("EmployeeUseCase: Processing probation review reminders as of %s", today.Format("2006-01-02"))
+
+	if uc.probationRepo == nil || uc.notifier == nil {
+		return nil, fmt.Errorf("probation reminders are not configured")
+	}
+
+	probations, err := uc.probationRepo.ListInProgress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list probations in progress: %w", err)
+	}
+
+	result := &dtoemployee.ProbationReminderResultDTO{ProcessedDate: today.Format("2006-01-02")}
+	for _, probation := range probations {
+		daysLeft := probation.DaysUntilReview(today)
+		if daysLeft < 0 {
+			continue
+		}
+
+		threshold, due := probationReminderThreshold(daysLeft)
+		if !due {
+			continue
+		}
+		result.Checked++
+		if probation.LastReminderDays != nil && *probation.LastReminderDays <= threshold {
+			continue
+		}
+
+		sent := 0
+		for _, recipient := range uc.probationReminderRecipients(ctx, probation) {
+			if err := uc.notifier.SendProbationReviewReminder(ctx, recipient, probation, daysLeft); err != nil {
+				log.Printf("EmployeeUseCase: Warning - failed to send probation review reminder to %s: %v", recipient.Email, err)
+				continue
+			}
+			sent++
+		}
+		if sent == 0 {
+			result.Failed++
+			continue
+		}
+
+		probation.LastReminderDays = &threshold
+		if err := uc.probationRepo.Update(ctx, probation); err != nil {
+			log.Printf("EmployeeUseCase: Warning - failed to record reminder for probation ID %d: %v", probation.ID, err)
+		}
+		result.Reminded++
+	}
+
+	log.Printf("EmployeeUseCase: Sent %d probation review reminders for %d upcoming reviews", result.Reminded, result.Checked)
+	return result, nil
+}
@@ -0,0 +1,373 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	storage "github.com/supabase-community/storage-go"
+	"gorm.io/gorm"
+)
+
+// bucketNameProfileChange is the private bucket supporting documents of profile change requests
+// are stored in. They are only handed out as signed, expiring URLs.
+const bucketNameProfileChange = "profilechange"
+
+// profileChangeDocumentURLExpirySeconds is how long a signed supporting document URL stays valid.
+const profileChangeDocumentURLExpirySeconds = 60 * 60
+
+// profileChangePolicy returns the policy of the given company, or the defaults when it has not
+// configured one.
+func (uc *EmployeeUseCase) profileChangePolicy(ctx context.Context, companyID *uint) *domain.ProfileChangePolicy {
+	if uc.profileChangeRepo == nil || companyID == nil {
+		return domain.DefaultProfileChangePolicy()
+	}
+
+	policy, err := uc.profileChangeRepo.GetPolicy(ctx, *companyID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrProfileChangePolicyNotFound) {
+			log.Printf("EmployeeUseCase: Warning - failed to get profile change policy of company ID %d, using defaults: %v", *companyID, err)
+		}
+		return domain.DefaultProfileChangePolicy()
+	}
+	return policy
+}
+
+func (uc *EmployeeUseCase) GetProfileChangePolicy(ctx context.Context) (*dtoemployee.ProfileChangePolicyResponseDTO, error) {
+	companyID, ok := tenant.CompanyID(ctx)
+	if !ok {
+		return dtoemployee.ToProfileChangePolicyResponseDTO(domain.DefaultProfileChangePolicy()), nil
+	}
+	return dtoemployee.ToProfileChangePolicyResponseDTO(uc.profileChangePolicy(ctx, &companyID)), nil
+}
+
+// UpdateProfileChangePolicy sets the fields employees of the current company can only change with
+// approval.
+func (uc *EmployeeUseCase) UpdateProfileChangePolicy(ctx context.Context, fields []domain.ProfileField) (*dtoemployee.ProfileChangePolicyResponseDTO, error) {
+	companyID, ok := tenant.CompanyID(ctx)
+	if !ok || uc.profileChangeRepo == nil {
+		return nil, domain.ErrCompanyNotFound
+	}
+
+	seen := make(map[domain.ProfileField]bool, len(fields))
+	policy := &domain.ProfileChangePolicy{CompanyID: companyID, Fields: []domain.ProfileField{}}
+	for _, field := range fields {
+		if !field.IsValid() {
+			return nil, fmt.Errorf("%w: unknown field %s", domain.ErrInvalidProfileChange, field)
+		}
+		if !seen[field] {
+			seen[field] = true
+			policy.Fields = append(policy.Fields, field)
+		}
+	}
+
+	if err := uc.profileChangeRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save profile change policy: %w", err)
+	}
+	return dtoemployee.ToProfileChangePolicyResponseDTO(policy), nil
+}
+
+// CheckSelfEditableProfileFields rejects a self-service profile update that changes a field the
+// company only allows to be changed through an approved profile change request.
+func (uc *EmployeeUseCase) CheckSelfEditableProfileFields(ctx context.Context, current, update *domain.Employee) error {
+	policy := uc.profileChangePolicy(ctx, current.CompanyID)
+	for _, field := range policy.Fields {
+		requested := update.ProfileFieldValue(field)
+		if requested == nil {
+			continue
+		}
+		if existing := current.ProfileFieldValue(field); existing == nil || *existing != *requested {
+			return fmt.Errorf("%w: %s", domain.ErrProfileChangeRequiresApproval, field)
+		}
+	}
+	return nil
+}
+
+// SubmitProfileChange records the requested changes of an employee's profile for approval. Fields
+// requested with their current value are left out; a nil value clears the field.
+func (uc *EmployeeUseCase) SubmitProfileChange(ctx context.Context, employeeID uint, changes map[domain.ProfileField]*string, reason *string, document *multipart.FileHeader) (*dtoemployee.ProfileChangeResponseDTO, error) {
+	log.Printf("EmployeeUseCase: SubmitProfileChange called for employee ID %d", employeeID)
+
+	if uc.profileChangeRepo == nil {
+		return nil, domain.ErrProfileChangeNotFound
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	// Changes are validated on a copy so the values are normalised the way they would be applied
+	proposed := *employee
+	diff := make(map[domain.ProfileField]domain.ProfileFieldChange, len(changes))
+	for field, value := range changes {
+		if err := proposed.SetProfileField(field, value); err != nil {
+			return nil, err
+		}
+		from := employee.ProfileFieldValue(field)
+		to := proposed.ProfileFieldValue(field)
+		if profileValuesEqual(from, to) {
+			continue
+		}
+		diff[field] = domain.ProfileFieldChange{From: from, To: to}
+	}
+	if len(diff) == 0 {
+		return nil, fmt.Errorf("%w: the request does not change any field", domain.ErrInvalidProfileChange)
+	}
+
+	pending, _, err := uc.profileChangeRepo.List(ctx, map[string]interface{}{
+		"employee_id": employeeID,
+		"status":      domain.ProfileChangePending,
+	}, domain.PaginationParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending profile changes of employee ID %d: %w", employeeID, err)
+	}
+	for _, request := range pending {
+		for field := range diff {
+			if _, ok := request.Changes[field]; ok {
+				return nil, fmt.Errorf("%w: %s", domain.ErrProfileChangeAlreadyPending, field)
+			}
+		}
+	}
+
+	request := &domain.ProfileChangeRequest{
+		CompanyID:  employee.CompanyID,
+		EmployeeID: employee.ID,
+		Changes:    diff,
+		Reason:     reason,
+		Status:     domain.ProfileChangePending,
+	}
+	if document != nil {
+		documentPath, err := uc.uploadProfileChangeDocument(employee, document)
+		if err != nil {
+			return nil, err
+		}
+		request.DocumentURL = &documentPath
+	}
+
+	if err := uc.profileChangeRepo.Create(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to create profile change request: %w", err)
+	}
+	request.Employee = *employee
+	return uc.toProfileChangeResponseDTO(request), nil
+}
+
+func profileValuesEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// uploadProfileChangeDocument uploads the supporting document of a profile change request to the
+// private bucket and returns its path.
+func (uc *EmployeeUseCase) uploadProfileChangeDocument(employee *domain.Employee, file *multipart.FileHeader) (string, error) {
+	if uc.supabaseClient == nil || uc.supabaseClient.Storage == nil {
+		return "", fmt.Errorf("storage client not available")
+	}
+
+	fileName := fmt.Sprintf("profile_change_%d_%d%s", employee.ID, time.Now().UnixNano(), filepath.Ext(file.Filename))
+
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open supporting document: %w", err)
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close supporting document: %v", closeErr)
+		}
+	}()
+
+	contentType := file.Header.Get("Content-Type")
+	_, err = uc.supabaseClient.Storage.UploadFile(bucketNameProfileChange, fileName, src, storage.FileOptions{
+		ContentType: &contentType,
+		Upsert:      &[]bool{true}[0],
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload supporting document: %w", err)
+	}
+
+	return fileName, nil
+}
+
+// profileChangeDocumentURL returns a signed, expiring URL for the supporting document at path.
+// Requests made before documents were kept private hold a full URL, which is returned as is.
+func (uc *EmployeeUseCase) profileChangeDocumentURL(path *string) *string {
+	if path == nil || *path == "" {
+		return nil
+	}
+	if strings.HasPrefix(*path, "http://") || strings.HasPrefix(*path, "https://") {
+		return path
+	}
+	if uc.supabaseClient == nil || uc.supabaseClient.Storage == nil {
+		return nil
+	}
+
+	signed, err := uc.supabaseClient.Storage.CreateSignedUrl(bucketNameProfileChange, *path, profileChangeDocumentURLExpirySeconds)
+	if err != nil {
+		log.Printf("Warning: failed to sign supporting document URL for %s: %v", *path, err)
+		return nil
+	}
+	return &signed.SignedURL
+}
+
+// toProfileChangeResponseDTO maps a single request, signing the URL of its supporting document.
+// Lists leave the URL out so a page costs no signing calls; it is fetched with the request.
+func (uc *EmployeeUseCase) toProfileChangeResponseDTO(request *domain.ProfileChangeRequest) *dtoemployee.ProfileChangeResponseDTO {
+	dto := dtoemployee.ToProfileChangeResponseDTO(request)
+	dto.DocumentURL = uc.profileChangeDocumentURL(request.DocumentURL)
+	return dto
+}
+
+func (uc *EmployeeUseCase) listProfileChanges(ctx context.Context, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoemployee.ProfileChangeListResponseData, error) {
+	if uc.profileChangeRepo == nil {
+		return &dtoemployee.ProfileChangeListResponseData{Items: []*dtoemployee.ProfileChangeResponseDTO{}}, nil
+	}
+
+	requests, totalItems, err := uc.profileChangeRepo.List(ctx, filters, paginationParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile change requests: %w", err)
+	}
+
+	if paginationParams.Cursor != nil {
+		requests, pagination := domain.NewCursorPage(requests, paginationParams, func(request *domain.ProfileChangeRequest) domain.Cursor {
+			return domain.Cursor{Date: request.CreatedAt, ID: request.ID}
+		})
+		return &dtoemployee.ProfileChangeListResponseData{
+			Items:      dtoemployee.ToProfileChangeResponseDTOList(requests),
+			Pagination: pagination,
+		}, nil
+	}
+
+	totalPages := uc.calculateTotalPages(totalItems, paginationParams.PageSize)
+	return &dtoemployee.ProfileChangeListResponseData{
+		Items: dtoemployee.ToProfileChangeResponseDTOList(requests),
+		Pagination: domain.Pagination{
+			TotalItems:  totalItems,
+			TotalPages:  totalPages,
+			CurrentPage: paginationParams.Page,
+			PageSize:    paginationParams.PageSize,
+			HasNextPage: paginationParams.Page < totalPages,
+			HasPrevPage: paginationParams.Page > 1 && paginationParams.Page <= totalPages,
+		},
+	}, nil
+}
+
+// ListProfileChanges returns the profile change requests of the company, optionally of a single
+// employee or status.
+func (uc *EmployeeUseCase) ListProfileChanges(ctx context.Context, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoemployee.ProfileChangeListResponseData, error) {
+	return uc.listProfileChanges(ctx, filters, paginationParams)
+}
+
+// ListMyProfileChanges returns the profile change history of an employee.
+func (uc *EmployeeUseCase) ListMyProfileChanges(ctx context.Context, employeeID uint, paginationParams domain.PaginationParams) (*dtoemployee.ProfileChangeListResponseData, error) {
+	return uc.listProfileChanges(ctx, map[string]interface{}{"employee_id": employeeID}, paginationParams)
+}
+
+func (uc *EmployeeUseCase) getProfileChange(ctx context.Context, id uint) (*domain.ProfileChangeRequest, error) {
+	if uc.profileChangeRepo == nil {
+		return nil, domain.ErrProfileChangeNotFound
+	}
+	request, err := uc.profileChangeRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileChangeNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get profile change request ID %d: %w", id, err)
+	}
+	return request, nil
+}
+
+func (uc *EmployeeUseCase) GetProfileChange(ctx context.Context, id uint) (*dtoemployee.ProfileChangeResponseDTO, error) {
+	request, err := uc.getProfileChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toProfileChangeResponseDTO(request), nil
+}
+
+// ReviewProfileChange approves or rejects a pending profile change request. On approval the
+// changes are applied to the employee and the replaced values are recorded in the request. An
+// employee cannot review their own request.
+func (uc *EmployeeUseCase) ReviewProfileChange(ctx context.Context, id, reviewerID uint, approve bool, note *string) (*dtoemployee.ProfileChangeResponseDTO, error) {
+	log.Printf("EmployeeUseCase: ReviewProfileChange called for request ID %d (approve: %t)", id, approve)
+
+	request, err := uc.getProfileChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.ProfileChangePending {
+		return nil, domain.ErrProfileChangeNotPending
+	}
+	if request.Employee.UserID == reviewerID {
+		return nil, domain.ErrProfileChangeSelfReview
+	}
+
+	if approve {
+		employee, err := uc.employeeRepo.GetByID(ctx, request.EmployeeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employee ID %d: %w", request.EmployeeID, err)
+		}
+
+		for field, change := range request.Changes {
+			change.From = employee.ProfileFieldValue(field)
+			if err := employee.SetProfileField(field, change.To); err != nil {
+				return nil, err
+			}
+			request.Changes[field] = change
+		}
+		if _, ok := request.Changes[domain.ProfileFieldTaxStatus]; ok {
+			if err := uc.checkTaxDependants(ctx, employee); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+			return nil, fmt.Errorf("failed to apply profile change request ID %d: %w", id, err)
+		}
+		request.Employee = *employee
+		request.Status = domain.ProfileChangeApproved
+	} else {
+		request.Status = domain.ProfileChangeRejected
+	}
+
+	now := time.Now()
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+	if err := uc.profileChangeRepo.Update(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to update profile change request ID %d: %w", id, err)
+	}
+	return uc.toProfileChangeResponseDTO(request), nil
+}
+
+// CancelProfileChange withdraws a pending request of the given employee.
+func (uc *EmployeeUseCase) CancelProfileChange(ctx context.Context, id, employeeID uint) (*dtoemployee.ProfileChangeResponseDTO, error) {
+	request, err := uc.getProfileChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.EmployeeID != employeeID {
+		return nil, domain.ErrProfileChangeNotFound
+	}
+	if request.Status != domain.ProfileChangePending {
+		return nil, domain.ErrProfileChangeNotPending
+	}
+
+	request.Status = domain.ProfileChangeCancelled
+	if err := uc.profileChangeRepo.Update(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to update profile change request ID %d: %w", id, err)
+	}
+	return uc.toProfileChangeResponseDTO(request), nil
+}
@@ -0,0 +1,139 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoemployee "github.com/SukaMajuu/hris/apps/backend/domain/dto/employee"
+)
+
+// employeeSearchBudget is how long a typeahead search may take before it is abandoned, so a slow
+// query cannot pile up behind the keystrokes that follow it.
+const employeeSearchBudget = 300 * time.Millisecond
+
+// SearchEmployees returns the employees within the scope best matching a typeahead query, with the
+// matching parts of their fields highlighted. NIKs are returned only when the scope matches them.
+// Searches running over the latency budget fail with ErrEmployeeSearchTimeout.
+func (uc *EmployeeUseCase) SearchEmployees(ctx context.Context, query string, scope domain.EmployeeSearchScope, limit int) (*dtoemployee.EmployeeSearchResponseData, error) {
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, employeeSearchBudget)
+	defer cancel()
+
+	hits, err := uc.employeeRepo.Search(ctx, query, scope, limit)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, domain.ErrEmployeeSearchTimeout
+		}
+		return nil, fmt.Errorf("failed to search employees: %w", err)
+	}
+
+	words := domain.EmployeeSearchWords(query)
+	items := make([]*dtoemployee.EmployeeSearchResultDTO, len(hits))
+	for i, hit := range hits {
+		items[i] = toEmployeeSearchResult(hit, words, scope.MatchNIK)
+	}
+
+	return &dtoemployee.EmployeeSearchResponseData{
+		Query:  query,
+		Items:  items,
+		TookMs: time.Since(started).Milliseconds(),
+	}, nil
+}
+
+func toEmployeeSearchResult(hit *domain.EmployeeSearchHit, words []string, includeNIK bool) *dtoemployee.EmployeeSearchResultDTO {
+	employee := hit.Employee
+	fullName := employee.FirstName
+	if employee.LastName != nil && *employee.LastName != "" {
+		fullName += " " + *employee.LastName
+	}
+
+	fields := map[string]string{
+		"name":          fullName,
+		"email":         employee.User.Email,
+		"position_name": employee.PositionName,
+	}
+	if employee.EmployeeCode != nil {
+		fields["employee_code"] = *employee.EmployeeCode
+	}
+	var nik *string
+	if includeNIK && employee.NIK != nil {
+		nik = employee.NIK
+		fields["nik"] = *employee.NIK
+	}
+	if employee.Branch != nil {
+		fields["branch"] = *employee.Branch
+	}
+
+	highlights := make(map[string]string)
+	for field, value := range fields {
+		if highlighted, ok := highlightSearchWords(value, words); ok {
+			highlights[field] = highlighted
+		}
+	}
+
+	return &dtoemployee.EmployeeSearchResultDTO{
+		ID:               employee.ID,
+		FirstName:        employee.FirstName,
+		LastName:         employee.LastName,
+		EmployeeCode:     employee.EmployeeCode,
+		NIK:              nik,
+		Email:            employee.User.Email,
+		PositionName:     employee.PositionName,
+		Branch:           employee.Branch,
+		ProfilePhotoURL:  employee.ProfilePhotoURL,
+		EmploymentStatus: employee.EmploymentStatus,
+		Rank:             hit.Rank,
+		Highlights:       highlights,
+	}
+}
+
+// highlightSearchWords HTML-escapes value and wraps the parts matching any of the words in <mark>
+// tags, reporting whether anything matched. Fuzzy matches are not highlighted.
+func highlightSearchWords(value string, words []string) (string, bool) {
+	if value == "" || len(words) == 0 {
+		return "", false
+	}
+
+	runes := []rune(value)
+	lower := []rune(strings.ToLower(value))
+	if len(lower) != len(runes) {
+		return "", false
+	}
+	marked := make([]bool, len(runes))
+	matched := false
+	for _, word := range words {
+		w := []rune(word)
+		for i := 0; i+len(w) <= len(lower); i++ {
+			if string(lower[i:i+len(w)]) == word {
+				for j := i; j < i+len(w); j++ {
+					marked[j] = true
+				}
+				matched = true
+			}
+		}
+	}
+	if !matched {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		part := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			b.WriteString("<mark>" + part + "</mark>")
+		} else {
+			b.WriteString(part)
+		}
+		i = j
+	}
+	return b.String(), true
+}
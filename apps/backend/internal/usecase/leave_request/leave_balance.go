@@ -0,0 +1,344 @@
+package leave_request
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+)
+
+// leaveRequestBatchSize is the number of leave requests read at a time when every leave request
+// matching a filter is needed.
+const leaveRequestBatchSize = 500
+
+// listAllLeaveRequests returns every leave request matching the filters, read in batches by cursor
+// so that none is cut off by a page size.
+func (uc *LeaveRequestUseCase) listAllLeaveRequests(ctx context.Context, filters map[string]interface{}) ([]*domain.LeaveRequest, error) {
+	var all []*domain.LeaveRequest
+	cursor := &domain.Cursor{}
+	for {
+		leaveRequests, _, err := uc.leaveRequestRepo.List(ctx, filters, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: cursor})
+		if err != nil {
+			return nil, err
+		}
+		if len(leaveRequests) <= leaveRequestBatchSize {
+			return append(all, leaveRequests...), nil
+		}
+		leaveRequests = leaveRequests[:leaveRequestBatchSize]
+		all = append(all, leaveRequests...)
+		last := leaveRequests[len(leaveRequests)-1]
+		cursor = &domain.Cursor{Date: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// annualLeaveUsed returns the working days of approved annual leave the employee takes in the given year.
+func (uc *LeaveRequestUseCase) annualLeaveUsed(ctx context.Context, employeeID uint, year int) (int, error) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	leaveRequests, err := uc.listAllLeaveRequests(ctx, map[string]interface{}{
+		"employee_id":    employeeID,
+		"status":         domain.LeaveStatusApproved,
+		"leave_type":     enums.AnnualLeave,
+		"start_date_lte": yearEnd,
+		"end_date_gte":   yearStart,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list annual leave for employee %d: %w", employeeID, err)
+	}
+
+	usedDays := make(map[string]bool)
+	for _, lr := range leaveRequests {
+		for _, day := range workingDaysBetween(laterOf(lr.StartDate, yearStart), earlierOf(lr.EndDate, yearEnd)) {
+			usedDays[day] = true
+		}
+	}
+	return len(usedDays), nil
+}
+
+// annualLeaveYear is the annual leave an employee is entitled to in a year: the days carried over
+// from the previous year and an allowance that the leave policy accrues monthly or grants in
+// January. The allowance of the year an employee is hired is pro-rated to the months from their
+// hire month and accrues or is granted from that month. The projection, the encashments and the
+// sick leave fallback all read balances from it.
+type annualLeaveYear struct {
+	carriedIn      float64
+	allowance      float64
+	accruesMonthly bool
+	carryOverCap   float64
+	// firstMonth is the month the employee was hired in during the year, January when they were
+	// hired before it, or after December when they were hired after it.
+	firstMonth time.Month
+}
+
+// annualLeaveYearOf returns the employee's annual leave entitlement of the given year under the
+// leave policy.
+func (uc *LeaveRequestUseCase) annualLeaveYearOf(ctx context.Context, employee *domain.Employee, policy *domain.LeavePolicy, year int) (*annualLeaveYear, error) {
+	carriedIn, err := uc.carriedOverDays(ctx, employee, year, policy)
+	if err != nil {
+		return nil, err
+	}
+	return &annualLeaveYear{
+		carriedIn:      float64(carriedIn),
+		allowance:      float64(employee.AnnualLeaveAllowance),
+		accruesMonthly: policy.AnnualLeaveAccruesMonthly,
+		carryOverCap:   float64(policy.AnnualLeaveCarryOverCap),
+		firstMonth:     firstEmployedMonth(employee, year),
+	}, nil
+}
+
+// firstEmployedMonth returns the first month of the year the employee was employed in: January
+// for employees hired before the year or without a hire date, and after December for employees
+// hired after it.
+func firstEmployedMonth(employee *domain.Employee, year int) time.Month {
+	switch {
+	case employee.HireDate == nil || employee.HireDate.Year() < year:
+		return time.January
+	case employee.HireDate.Year() > year:
+		return time.December + 1
+	}
+	return employee.HireDate.Month()
+}
+
+// monthsEmployedThrough returns the months of the year the employee was employed in by the end
+// of the month.
+func (y *annualLeaveYear) monthsEmployedThrough(month time.Month) float64 {
+	if month < y.firstMonth {
+		return 0
+	}
+	return float64(month - y.firstMonth + 1)
+}
+
+// granted returns the allowance granted at once when the policy does not accrue it monthly.
+func (y *annualLeaveYear) granted() float64 {
+	return math.Floor(y.allowance * y.monthsEmployedThrough(time.December) / 12)
+}
+
+// accruedIn returns the allowance accrued in the month.
+func (y *annualLeaveYear) accruedIn(month time.Month) float64 {
+	if month < y.firstMonth {
+		return 0
+	}
+	if y.accruesMonthly {
+		return y.allowance / 12
+	}
+	if month == y.firstMonth {
+		return y.granted()
+	}
+	return 0
+}
+
+// entitlementThrough returns the days carried in and the allowance accrued by the end of the month.
+func (y *annualLeaveYear) entitlementThrough(month time.Month) float64 {
+	if y.accruesMonthly {
+		return y.carriedIn + y.allowance*y.monthsEmployedThrough(month)/12
+	}
+	if month < y.firstMonth {
+		return y.carriedIn
+	}
+	return y.carriedIn + y.granted()
+}
+
+// proRatedThrough returns the entitlement of an employee leaving in the month, whose allowance is
+// pro-rated to the months worked whether it accrues monthly or not.
+func (y *annualLeaveYear) proRatedThrough(month time.Month) float64 {
+	return y.carriedIn + math.Floor(y.allowance*y.monthsEmployedThrough(month)/12)
+}
+
+// remainingAnnualLeave returns the annual leave the employee has left at the end of the month of
+// asOf: the entitlement accrued by then less the approved annual leave of the year.
+func (uc *LeaveRequestUseCase) remainingAnnualLeave(ctx context.Context, employee *domain.Employee, policy *domain.LeavePolicy, asOf time.Time) (float64, error) {
+	leaveYear, err := uc.annualLeaveYearOf(ctx, employee, policy, asOf.Year())
+	if err != nil {
+		return 0, err
+	}
+	usedDays, err := uc.annualLeaveUsed(ctx, employee.ID, asOf.Year())
+	if err != nil {
+		return 0, err
+	}
+	return leaveYear.entitlementThrough(asOf.Month()) - float64(usedDays), nil
+}
+
+// balanceSimulation is the input of an annual leave projection: the days already approved, the
+// days still waiting for approval and the request previewed as if it were approved.
+type balanceSimulation struct {
+	includePending bool
+	assumeApproved *domain.LeaveRequest
+}
+
+// projectAnnualLeave simulates the employee's annual leave balance month by month, starting in
+// January of the year of asOf with the days carried over from the previous year, so that past
+// accruals and leave are accounted for, and returns the months from asOf onwards. Unused days
+// above the policy's carry-over cap expire every December.
+func (uc *LeaveRequestUseCase) projectAnnualLeave(ctx context.Context, employee *domain.Employee, asOf time.Time, months int, sim balanceSimulation) (*dtoleave.LeaveBalanceProjectionDTO, error) {
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	currentMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	yearStart := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	horizonEnd := currentMonth.AddDate(0, months, -1)
+
+	leaveYear, err := uc.annualLeaveYearOf(ctx, employee, uc.getLeavePolicy(ctx, employee.ID), today.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	leaveRequests, err := uc.listAllLeaveRequests(ctx, map[string]interface{}{
+		"employee_id":    employee.ID,
+		"leave_type":     enums.AnnualLeave,
+		"start_date_lte": horizonEnd,
+		"end_date_gte":   yearStart,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list annual leave for employee %d: %w", employee.ID, err)
+	}
+	if sim.assumeApproved != nil {
+		leaveRequests = append(leaveRequests, sim.assumeApproved)
+	}
+
+	approvedDays := make(map[string]bool)
+	pendingDays := make(map[string]bool)
+	for _, lr := range leaveRequests {
+		approved := lr.Status == domain.LeaveStatusApproved || lr == sim.assumeApproved ||
+			(sim.assumeApproved != nil && sim.assumeApproved.ID != 0 && lr.ID == sim.assumeApproved.ID)
+		if !approved && (!sim.includePending || lr.Status != domain.LeaveStatusPending) {
+			continue
+		}
+		for _, day := range workingDaysBetween(laterOf(lr.StartDate, yearStart), earlierOf(lr.EndDate, horizonEnd)) {
+			if approved {
+				approvedDays[day] = true
+				delete(pendingDays, day)
+			} else if !approvedDays[day] {
+				pendingDays[day] = true
+			}
+		}
+	}
+
+	projection := &dtoleave.LeaveBalanceProjectionDTO{
+		EmployeeID:           employee.ID,
+		AsOf:                 today.Format("2006-01-02"),
+		AnnualLeaveAllowance: employee.AnnualLeaveAllowance,
+		CarriedOver:          leaveYear.carriedIn,
+		IncludePending:       sim.includePending,
+	}
+
+	balance := leaveYear.carriedIn
+	for month := yearStart; !month.After(horizonEnd); month = month.AddDate(0, 1, 0) {
+		accrued := leaveYear.accruedIn(month.Month())
+
+		row := &dtoleave.MonthlyBalanceDTO{
+			Month:   month.Format("2006-01"),
+			Accrued: roundDays(accrued),
+		}
+		for _, day := range workingDaysBetween(month, month.AddDate(0, 1, -1)) {
+			if approvedDays[day] {
+				row.ApprovedDays++
+			} else if pendingDays[day] {
+				row.PendingDays++
+			}
+		}
+
+		if month.Equal(currentMonth) {
+			takenSoFar := 0
+			for _, day := range workingDaysBetween(month, today) {
+				if approvedDays[day] {
+					takenSoFar++
+				}
+			}
+			projection.CurrentBalance = roundDays(balance + accrued - float64(takenSoFar))
+		}
+
+		balance += accrued - float64(row.ApprovedDays+row.PendingDays)
+		if month.Month() == time.December && balance > leaveYear.carryOverCap {
+			row.ExpiringDays = roundDays(balance - leaveYear.carryOverCap)
+			balance = leaveYear.carryOverCap
+		}
+		row.ClosingBalance = roundDays(balance)
+
+		if !month.Before(currentMonth) {
+			projection.Months = append(projection.Months, row)
+		}
+	}
+
+	return projection, nil
+}
+
+// ProjectLeaveBalance returns the employee's projected annual leave balance for the given number
+// of months, or until the end of the year when months is zero.
+func (uc *LeaveRequestUseCase) ProjectLeaveBalance(ctx context.Context, employeeID uint, months int, includePending bool) (*dtoleave.LeaveBalanceProjectionDTO, error) {
+	log.Printf("LeaveRequestUseCase: ProjectLeaveBalance called for employee ID %d", employeeID)
+
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	now := time.Now()
+	if months <= 0 {
+		months = int(time.December-now.Month()) + 1
+	}
+	return uc.projectAnnualLeave(ctx, employee, now, months, balanceSimulation{includePending: includePending})
+}
+
+// PreviewLeaveBalance shows the annual leave balance the employee would have at the end of the
+// request's last month if the request were approved. Other leave types do not use the balance.
+func (uc *LeaveRequestUseCase) PreviewLeaveBalance(ctx context.Context, leaveRequest *domain.LeaveRequest) (*dtoleave.LeaveBalancePreviewDTO, error) {
+	if leaveRequest.LeaveType != enums.AnnualLeave {
+		return nil, nil
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, leaveRequest.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", leaveRequest.EmployeeID, err)
+	}
+
+	now := time.Now()
+	months := (leaveRequest.EndDate.Year()-now.Year())*12 + int(leaveRequest.EndDate.Month()-now.Month()) + 1
+	if months < 1 {
+		months = 1
+	}
+
+	projection, err := uc.projectAnnualLeave(ctx, employee, now, months, balanceSimulation{assumeApproved: leaveRequest})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtoleave.LeaveBalancePreviewDTO{
+		CurrentBalance:       projection.CurrentBalance,
+		RequestedDays:        len(workingDaysBetween(leaveRequest.StartDate, leaveRequest.EndDate)),
+		BalanceAfterApproval: projection.Months[len(projection.Months)-1].ClosingBalance,
+	}, nil
+}
+
+func roundDays(days float64) float64 {
+	return math.Round(days*100) / 100
+}
+
+// workingDaysBetween returns the weekdays from start to end inclusive, formatted as dates.
+func workingDaysBetween(start, end time.Time) []string {
+	var days []string
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		days = append(days, day.Format("2006-01-02"))
+	}
+	return days
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
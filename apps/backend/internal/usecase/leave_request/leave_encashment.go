@@ -0,0 +1,348 @@
+package leave_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+)
+
+func toLeaveEncashmentResponseDTO(encashment *domain.LeaveEncashment) *dtoleave.LeaveEncashmentResponseDTO {
+	employeeName := encashment.Employee.FirstName
+	if encashment.Employee.LastName != nil {
+		employeeName += " " + *encashment.Employee.LastName
+	}
+
+	var finalizedAt *string
+	if encashment.FinalizedAt != nil {
+		formatted := encashment.FinalizedAt.Format("2006-01-02T15:04:05Z07:00")
+		finalizedAt = &formatted
+	}
+
+	return &dtoleave.LeaveEncashmentResponseDTO{
+		ID:            encashment.ID,
+		EmployeeID:    encashment.EmployeeID,
+		EmployeeName:  employeeName,
+		PositionName:  encashment.Employee.PositionName,
+		Reason:        string(encashment.Reason),
+		Year:          encashment.Year,
+		EntitledDays:  encashment.EntitledDays,
+		UsedDays:      encashment.UsedDays,
+		CarryOverDays: encashment.CarryOverDays,
+		EncashedDays:  encashment.EncashedDays,
+		DailyRate:     encashment.DailyRate,
+		Amount:        encashment.Amount,
+		Status:        string(encashment.Status),
+		AdminNote:     encashment.AdminNote,
+		ReviewedBy:    encashment.ReviewedBy,
+		FinalizedAt:   finalizedAt,
+		CreatedAt:     encashment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     encashment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func encashmentAmount(days int, dailyRate float64) float64 {
+	return math.Round(float64(days)*dailyRate*100) / 100
+}
+
+// carriedOverDays returns the unused annual leave the employee carries into the given year: the
+// carry-over of the previous year's encashment, or when that year was not encashed, the days left
+// of the previous year's allowance, pro-rated from the hire date, up to the carry-over cap.
+// Employees hired in the year or later carry nothing in.
+func (uc *LeaveRequestUseCase) carriedOverDays(ctx context.Context, employee *domain.Employee, year int, policy *domain.LeavePolicy) (int, error) {
+	if employee.HireDate != nil && employee.HireDate.Year() >= year {
+		return 0, nil
+	}
+
+	if uc.leaveEncashmentRepo != nil {
+		previous, err := uc.leaveEncashmentRepo.GetByPeriod(ctx, employee.ID, year-1, domain.EncashmentReasonYearEnd)
+		if err == nil && previous.Status != domain.EncashmentStatusCancelled {
+			return previous.CarryOverDays, nil
+		}
+		if err != nil && !errors.Is(err, domain.ErrLeaveEncashmentNotFound) {
+			return 0, fmt.Errorf("failed to get the encashment of %d: %w", year-1, err)
+		}
+	}
+
+	usedDays, err := uc.annualLeaveUsed(ctx, employee.ID, year-1)
+	if err != nil {
+		return 0, err
+	}
+	previousYear := &annualLeaveYear{
+		allowance:      float64(employee.AnnualLeaveAllowance),
+		accruesMonthly: policy.AnnualLeaveAccruesMonthly,
+		firstMonth:     firstEmployedMonth(employee, year-1),
+	}
+	remainingDays := int(previousYear.entitlementThrough(time.December)) - usedDays
+	if remainingDays < 0 {
+		remainingDays = 0
+	}
+	return int(math.Min(float64(remainingDays), float64(policy.AnnualLeaveCarryOverCap))), nil
+}
+
+// calculateEncashment drafts an encashment of the employee's unused annual leave for the year.
+// The days carried over from the previous year are part of the entitlement. At year end only the
+// days above the carry-over cap are paid out. On resignation the allowance is pro-rated to the
+// months worked in the year and the whole remainder is paid out.
+func (uc *LeaveRequestUseCase) calculateEncashment(ctx context.Context, employee *domain.Employee, year int, reason domain.EncashmentReason, asOf time.Time) (*domain.LeaveEncashment, error) {
+	policy := uc.getLeavePolicy(ctx, employee.ID)
+
+	usedDays, err := uc.annualLeaveUsed(ctx, employee.ID, year)
+	if err != nil {
+		return nil, err
+	}
+	leaveYear, err := uc.annualLeaveYearOf(ctx, employee, policy, year)
+	if err != nil {
+		return nil, err
+	}
+
+	entitledDays := int(leaveYear.entitlementThrough(time.December))
+	if reason == domain.EncashmentReasonResignation && asOf.Year() == year {
+		entitledDays = int(leaveYear.proRatedThrough(asOf.Month()))
+	}
+
+	remainingDays := entitledDays - usedDays
+	if remainingDays < 0 {
+		remainingDays = 0
+	}
+
+	carryOverDays := 0
+	encashedDays := remainingDays
+	if reason == domain.EncashmentReasonYearEnd {
+		carryOverDays = int(math.Min(float64(remainingDays), float64(policy.AnnualLeaveCarryOverCap)))
+		encashedDays = remainingDays - carryOverDays
+	}
+
+	dailyRate := policy.DailyRate(employee.BaseSalary)
+	return &domain.LeaveEncashment{
+		EmployeeID:    employee.ID,
+		Reason:        reason,
+		Year:          year,
+		EntitledDays:  entitledDays,
+		UsedDays:      usedDays,
+		CarryOverDays: carryOverDays,
+		EncashedDays:  encashedDays,
+		DailyRate:     dailyRate,
+		Amount:        encashmentAmount(encashedDays, dailyRate),
+		Status:        domain.EncashmentStatusDraft,
+	}, nil
+}
+
+// ProcessYearEndEncashment drafts an encashment for every active employee whose unused annual
+// leave of the given year exceeds the carry-over cap. Employees already processed are skipped.
+// Employees are read in batches and each is processed within their own company.
+func (uc *LeaveRequestUseCase) ProcessYearEndEncashment(ctx context.Context, year int) (*dtoleave.EncashmentRunResultDTO, error) {
+	log.Printf("LeaveRequestUseCase: Processing year-end leave encashment for %d", year)
+
+	result := &dtoleave.EncashmentRunResultDTO{Year: year}
+	yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	err := uc.employeeRepo.ForEachBatch(ctx, map[string]interface{}{"employment_status": true}, func(employees []*domain.Employee) error {
+		result.Checked += len(employees)
+		for _, employee := range employees {
+			uc.processYearEndEncashment(companyContext(ctx, employee), employee, year, yearEnd, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active employees: %w", err)
+	}
+
+	log.Printf("LeaveRequestUseCase: Drafted %d year-end encashments for %d", result.Created, year)
+	return result, nil
+}
+
+func (uc *LeaveRequestUseCase) processYearEndEncashment(ctx context.Context, employee *domain.Employee, year int, yearEnd time.Time, result *dtoleave.EncashmentRunResultDTO) {
+	exists, err := uc.leaveEncashmentRepo.Exists(ctx, employee.ID, year, domain.EncashmentReasonYearEnd)
+	if err != nil {
+		log.Printf("Warning: failed to check encashment for employee ID %d: %v", employee.ID, err)
+		result.Failed++
+		return
+	}
+	if exists {
+		result.Skipped++
+		return
+	}
+
+	encashment, err := uc.calculateEncashment(ctx, employee, year, domain.EncashmentReasonYearEnd, yearEnd)
+	if err != nil {
+		log.Printf("Warning: failed to calculate encashment for employee ID %d: %v", employee.ID, err)
+		result.Failed++
+		return
+	}
+	if encashment.EncashedDays == 0 {
+		result.Skipped++
+		return
+	}
+
+	if err := uc.leaveEncashmentRepo.Create(ctx, encashment); err != nil {
+		log.Printf("Warning: failed to create encashment for employee ID %d: %v", employee.ID, err)
+		result.Failed++
+		return
+	}
+	result.Created++
+}
+
+// companyContext scopes a job running across companies to the company of the employee it is
+// processing.
+func companyContext(ctx context.Context, employee *domain.Employee) context.Context {
+	if employee.CompanyID == nil {
+		return ctx
+	}
+	return tenant.WithCompanyID(ctx, *employee.CompanyID)
+}
+
+// CreateResignationEncashment drafts the payout of a leaver's remaining annual leave so it can be
+// reviewed and included in the final settlement.
+func (uc *LeaveRequestUseCase) CreateResignationEncashment(ctx context.Context, employee *domain.Employee) error {
+	log.Printf("LeaveRequestUseCase: CreateResignationEncashment called for employee ID %d", employee.ID)
+
+	resignationDate := time.Now()
+	if employee.ResignationDate != nil {
+		resignationDate = *employee.ResignationDate
+	}
+	year := resignationDate.Year()
+
+	exists, err := uc.leaveEncashmentRepo.Exists(ctx, employee.ID, year, domain.EncashmentReasonResignation)
+	if err != nil {
+		return fmt.Errorf("failed to check existing encashment: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	encashment, err := uc.calculateEncashment(ctx, employee, year, domain.EncashmentReasonResignation, resignationDate)
+	if err != nil {
+		return fmt.Errorf("failed to calculate encashment: %w", err)
+	}
+	if encashment.EncashedDays == 0 {
+		log.Printf("LeaveRequestUseCase: No remaining annual leave to encash for employee ID %d", employee.ID)
+		return nil
+	}
+
+	if err := uc.leaveEncashmentRepo.Create(ctx, encashment); err != nil {
+		return fmt.Errorf("failed to create encashment: %w", err)
+	}
+	return nil
+}
+
+// PreviewResignationEncashment calculates the leave payout of a leaver as of their last working day
+// without drafting it, so the final settlement can be shown before the employee has left.
+func (uc *LeaveRequestUseCase) PreviewResignationEncashment(ctx context.Context, employee *domain.Employee, lastWorkingDay time.Time) (*domain.LeaveEncashment, error) {
+	encashment, err := uc.calculateEncashment(ctx, employee, lastWorkingDay.Year(), domain.EncashmentReasonResignation, lastWorkingDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate encashment: %w", err)
+	}
+	return encashment, nil
+}
+
+func (uc *LeaveRequestUseCase) ListEncashments(ctx context.Context, filters map[string]interface{}, paginationParams domain.PaginationParams) (*dtoleave.LeaveEncashmentListResponseData, error) {
+	encashments, totalItems, err := uc.leaveEncashmentRepo.List(ctx, filters, paginationParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leave encashments: %w", err)
+	}
+
+	encashmentDTOs := make([]*dtoleave.LeaveEncashmentResponseDTO, len(encashments))
+	for i, encashment := range encashments {
+		encashmentDTOs[i] = toLeaveEncashmentResponseDTO(encashment)
+	}
+
+	totalPages := 0
+	if paginationParams.PageSize > 0 {
+		totalPages = int(math.Ceil(float64(totalItems) / float64(paginationParams.PageSize)))
+	}
+
+	return &dtoleave.LeaveEncashmentListResponseData{
+		Items: encashmentDTOs,
+		Pagination: domain.Pagination{
+			TotalItems:  totalItems,
+			TotalPages:  totalPages,
+			CurrentPage: paginationParams.Page,
+			PageSize:    paginationParams.PageSize,
+			HasNextPage: paginationParams.Page < totalPages,
+			HasPrevPage: paginationParams.Page > 1,
+		},
+	}, nil
+}
+
+// GetEncashmentByID returns an encashment. With a managerID, encashments of employees who do not
+// report directly to that manager are not found, as with the manager filter of ListEncashments.
+func (uc *LeaveRequestUseCase) GetEncashmentByID(ctx context.Context, id uint, managerID *uint) (*dtoleave.LeaveEncashmentResponseDTO, error) {
+	encashment, err := uc.leaveEncashmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave encashment: %w", err)
+	}
+	if managerID != nil && (encashment.Employee.ManagerID == nil || *encashment.Employee.ManagerID != *managerID) {
+		return nil, fmt.Errorf("failed to get leave encashment: %w", domain.ErrLeaveEncashmentNotFound)
+	}
+	return toLeaveEncashmentResponseDTO(encashment), nil
+}
+
+// ReviewEncashment lets an admin correct the days or daily rate of a draft before finalizing it.
+func (uc *LeaveRequestUseCase) ReviewEncashment(ctx context.Context, id uint, reviewerID uint, encashedDays *int, dailyRate *float64, adminNote *string) (*dtoleave.LeaveEncashmentResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: ReviewEncashment called for ID %d", id)
+
+	encashment, err := uc.leaveEncashmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave encashment: %w", err)
+	}
+	if encashment.Status != domain.EncashmentStatusDraft {
+		return nil, domain.ErrEncashmentNotDraft
+	}
+
+	if encashedDays != nil {
+		encashment.EncashedDays = *encashedDays
+	}
+	if dailyRate != nil {
+		encashment.DailyRate = *dailyRate
+	}
+	if adminNote != nil {
+		encashment.AdminNote = adminNote
+	}
+	encashment.Amount = encashmentAmount(encashment.EncashedDays, encashment.DailyRate)
+	encashment.ReviewedBy = &reviewerID
+
+	if err := uc.leaveEncashmentRepo.Update(ctx, encashment); err != nil {
+		return nil, fmt.Errorf("failed to update leave encashment: %w", err)
+	}
+	return toLeaveEncashmentResponseDTO(encashment), nil
+}
+
+// UpdateEncashmentStatus finalizes or cancels a draft encashment. Finalized entries are the ones
+// payroll and final settlements should pay out.
+func (uc *LeaveRequestUseCase) UpdateEncashmentStatus(ctx context.Context, id uint, reviewerID uint, status domain.EncashmentStatus, adminNote *string) (*dtoleave.LeaveEncashmentResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: UpdateEncashmentStatus called for ID %d, status: %s", id, status)
+
+	if status != domain.EncashmentStatusFinalized && status != domain.EncashmentStatusCancelled {
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+
+	encashment, err := uc.leaveEncashmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave encashment: %w", err)
+	}
+	if encashment.Status != domain.EncashmentStatusDraft {
+		return nil, domain.ErrEncashmentNotDraft
+	}
+
+	encashment.Status = status
+	encashment.ReviewedBy = &reviewerID
+	if adminNote != nil {
+		encashment.AdminNote = adminNote
+	}
+	if status == domain.EncashmentStatusFinalized {
+		now := time.Now()
+		encashment.FinalizedAt = &now
+	}
+
+	if err := uc.leaveEncashmentRepo.Update(ctx, encashment); err != nil {
+		return nil, fmt.Errorf("failed to update leave encashment: %w", err)
+	}
+	return toLeaveEncashmentResponseDTO(encashment), nil
+}
@@ -32,23 +32,32 @@ const (
 )
 
 type LeaveRequestUseCase struct {
-	leaveRequestRepo interfaces.LeaveRequestRepository
-	employeeRepo     interfaces.EmployeeRepository
-	attendanceRepo   interfaces.AttendanceRepository
-	supabaseClient   *supabase.Client
+	leaveRequestRepo    interfaces.LeaveRequestRepository
+	employeeRepo        interfaces.EmployeeRepository
+	attendanceRepo      interfaces.AttendanceRepository
+	staffingRuleRepo    interfaces.LeaveStaffingRuleRepository
+	leavePolicyRepo     interfaces.LeavePolicyRepository
+	leaveEncashmentRepo interfaces.LeaveEncashmentRepository
+	supabaseClient      *supabase.Client
 }
 
 func NewLeaveRequestUseCase(
 	leaveRequestRepo interfaces.LeaveRequestRepository,
 	employeeRepo interfaces.EmployeeRepository,
 	attendanceRepo interfaces.AttendanceRepository,
+	staffingRuleRepo interfaces.LeaveStaffingRuleRepository,
+	leavePolicyRepo interfaces.LeavePolicyRepository,
+	leaveEncashmentRepo interfaces.LeaveEncashmentRepository,
 	supabaseClient *supabase.Client,
 ) *LeaveRequestUseCase {
 	return &LeaveRequestUseCase{
-		leaveRequestRepo: leaveRequestRepo,
-		employeeRepo:     employeeRepo,
-		attendanceRepo:   attendanceRepo,
-		supabaseClient:   supabaseClient,
+		leaveRequestRepo:    leaveRequestRepo,
+		employeeRepo:        employeeRepo,
+		attendanceRepo:      attendanceRepo,
+		staffingRuleRepo:    staffingRuleRepo,
+		leavePolicyRepo:     leavePolicyRepo,
+		leaveEncashmentRepo: leaveEncashmentRepo,
+		supabaseClient:      supabaseClient,
 	}
 }
 
@@ -62,30 +71,64 @@ func (uc *LeaveRequestUseCase) toLeaveRequestResponseDTO(lr *domain.LeaveRequest
 	if lr.Employee.PositionName != "" {
 		positionName = lr.Employee.PositionName
 	}
-	var attachmentURL *string
-	if lr.Attachment != nil && *lr.Attachment != "" {
-		bucketName := bucketNameAttachments
-		publicURL := uc.supabaseClient.Storage.GetPublicUrl(bucketName, *lr.Attachment)
-		attachmentURL = &publicURL.SignedURL
+	var certificateDueDate *string
+	if lr.CertificateDueDate != nil {
+		dueDate := lr.CertificateDueDate.Format("2006-01-02")
+		certificateDueDate = &dueDate
+	}
+
+	var originalLeaveType *string
+	if lr.OriginalLeaveType != nil {
+		leaveType := string(*lr.OriginalLeaveType)
+		originalLeaveType = &leaveType
 	}
 
 	return &dtoleave.LeaveRequestResponseDTO{
-		ID:           lr.ID,
-		EmployeeID:   lr.EmployeeID,
-		EmployeeName: employeeName,
-		PositionName: positionName,
-		LeaveType:    string(lr.LeaveType),
-		StartDate:    lr.StartDate.Format("2006-01-02"),
-		EndDate:      lr.EndDate.Format("2006-01-02"),
-		Attachment:   attachmentURL,
-		EmployeeNote: lr.EmployeeNote,
-		AdminNote:    lr.AdminNote,
-		Status:       string(lr.Status),
+		ID:            lr.ID,
+		EmployeeID:    lr.EmployeeID,
+		EmployeeName:  employeeName,
+		PositionName:  positionName,
+		LeaveType:     string(lr.LeaveType),
+		StartDate:     lr.StartDate.Format("2006-01-02"),
+		EndDate:       lr.EndDate.Format("2006-01-02"),
+		HasAttachment: lr.Attachment != nil && *lr.Attachment != "",
+		EmployeeNote:  lr.EmployeeNote,
+		AdminNote:     lr.AdminNote,
+		Status:        string(lr.Status),
+
+		CertificateStatus:  string(lr.CertificateStatus),
+		CertificateDueDate: certificateDueDate,
+		OriginalLeaveType:  originalLeaveType,
+
 		CreatedAt:    lr.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:    lr.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
+// toLeaveRequestDetailDTO is toLeaveRequestResponseDTO with a signed URL for the attachment.
+// Lists leave it out so they do not sign a URL for every row.
+func (uc *LeaveRequestUseCase) toLeaveRequestDetailDTO(lr *domain.LeaveRequest) *dtoleave.LeaveRequestResponseDTO {
+	responseDTO := uc.toLeaveRequestResponseDTO(lr)
+	responseDTO.Attachment = uc.attachmentURL(lr.Attachment)
+	return responseDTO
+}
+
+func (uc *LeaveRequestUseCase) toLeaveRequestResponseDTOs(leaveRequests []*domain.LeaveRequest) []*dtoleave.LeaveRequestResponseDTO {
+	items := make([]*dtoleave.LeaveRequestResponseDTO, len(leaveRequests))
+	for i, lr := range leaveRequests {
+		items[i] = uc.toLeaveRequestResponseDTO(lr)
+	}
+	return items
+}
+
+// newLeaveRequestCursorPage trims a cursor page of leave requests, which are ordered by when they
+// were created.
+func newLeaveRequestCursorPage(leaveRequests []*domain.LeaveRequest, paginationParams domain.PaginationParams) ([]*domain.LeaveRequest, domain.Pagination) {
+	return domain.NewCursorPage(leaveRequests, paginationParams, func(lr *domain.LeaveRequest) domain.Cursor {
+		return domain.Cursor{Date: lr.CreatedAt, ID: lr.ID}
+	})
+}
+
 func (uc *LeaveRequestUseCase) Create(ctx context.Context, leaveRequest *domain.LeaveRequest, file *multipart.FileHeader) (*dtoleave.LeaveRequestResponseDTO, error) {
 	log.Printf("LeaveRequestUseCase: Create called for employee ID %d", leaveRequest.EmployeeID)
 
@@ -109,6 +152,13 @@ func (uc *LeaveRequestUseCase) Create(ctx context.Context, leaveRequest *domain.
 	if hasOverlapping {
 		return nil, domain.ErrOverlappingLeaveRequest
 	}
+
+	// Staffing rules only warn at this point; they are enforced when the request is approved
+	staffingConflicts, err := uc.evaluateStaffingRules(ctx, leaveRequest, employee)
+	if err != nil {
+		log.Printf("Warning: failed to evaluate staffing rules for employee ID %d: %v", leaveRequest.EmployeeID, err)
+	}
+
 	if file != nil && file.Size > 0 && file.Filename != "" && uc.supabaseClient != nil { // Validate file type
 		if err := uc.validateAttachmentFile(file); err != nil {
 			return nil, err
@@ -138,6 +188,7 @@ func (uc *LeaveRequestUseCase) Create(ctx context.Context, leaveRequest *domain.
 	}
 
 	leaveRequest.Status = domain.LeaveStatusPending
+	uc.applyCertificateRequirement(ctx, leaveRequest)
 
 	err = uc.leaveRequestRepo.Create(ctx, leaveRequest)
 	if err != nil {
@@ -152,10 +203,12 @@ func (uc *LeaveRequestUseCase) Create(ctx context.Context, leaveRequest *domain.
 		return nil, fmt.Errorf("failed to retrieve created leave request: %w", err)
 	}
 	log.Printf("LeaveRequestUseCase: Successfully created leave request with ID %d", leaveRequest.ID)
-	return uc.toLeaveRequestResponseDTO(createdLeaveRequest), nil
+	responseDTO := uc.toLeaveRequestDetailDTO(createdLeaveRequest)
+	responseDTO.StaffingConflicts = staffingConflicts
+	return responseDTO, nil
 }
 
-func (uc *LeaveRequestUseCase) CreateForEmployee(ctx context.Context, leaveRequest *domain.LeaveRequest, file *multipart.FileHeader) (*dtoleave.LeaveRequestResponseDTO, error) {
+func (uc *LeaveRequestUseCase) CreateForEmployee(ctx context.Context, leaveRequest *domain.LeaveRequest, file *multipart.FileHeader, overrideStaffingRules bool) (*dtoleave.LeaveRequestResponseDTO, error) {
 	log.Printf("LeaveRequestUseCase: CreateForEmployee called for employee ID %d", leaveRequest.EmployeeID)
 
 	// Validate employee exists
@@ -180,6 +233,12 @@ func (uc *LeaveRequestUseCase) CreateForEmployee(ctx context.Context, leaveReque
 		return nil, domain.ErrOverlappingLeaveRequest
 	}
 
+	// Admin-created requests are approved immediately, so staffing rules are enforced here
+	staffingConflicts, err := uc.checkStaffingRulesForApproval(ctx, leaveRequest, employee, overrideStaffingRules)
+	if err != nil {
+		return nil, err
+	}
+
 	// Handle file upload if provided
 	if file != nil && file.Size > 0 && file.Filename != "" && uc.supabaseClient != nil {
 		// Validate file type
@@ -215,6 +274,7 @@ func (uc *LeaveRequestUseCase) CreateForEmployee(ctx context.Context, leaveReque
 
 	// Set status to approved and add timestamps for admin-created requests
 	leaveRequest.Status = domain.LeaveStatusApproved
+	uc.applyCertificateRequirement(ctx, leaveRequest)
 	now := time.Now()
 	leaveRequest.CreatedAt = now
 	leaveRequest.UpdatedAt = now
@@ -244,7 +304,9 @@ func (uc *LeaveRequestUseCase) CreateForEmployee(ctx context.Context, leaveReque
 	}
 
 	log.Printf("LeaveRequestUseCase: Successfully created leave request with ID %d for employee ID %d", leaveRequest.ID, leaveRequest.EmployeeID)
-	return uc.toLeaveRequestResponseDTO(createdLeaveRequest), nil
+	responseDTO := uc.toLeaveRequestDetailDTO(createdLeaveRequest)
+	responseDTO.StaffingConflicts = staffingConflicts
+	return responseDTO, nil
 }
 
 func (uc *LeaveRequestUseCase) GetByID(ctx context.Context, id uint) (*dtoleave.LeaveRequestResponseDTO, error) {
@@ -255,7 +317,7 @@ func (uc *LeaveRequestUseCase) GetByID(ctx context.Context, id uint) (*dtoleave.
 		return nil, fmt.Errorf("failed to get leave request by ID %d: %w", id, err)
 	}
 
-	return uc.toLeaveRequestResponseDTO(leaveRequest), nil
+	return uc.toLeaveRequestDetailDTO(leaveRequest), nil
 }
 
 func (uc *LeaveRequestUseCase) GetByEmployeeID(ctx context.Context, employeeID uint, paginationParams domain.PaginationParams) (*dtoleave.LeaveRequestListResponseData, error) {
@@ -265,6 +327,13 @@ func (uc *LeaveRequestUseCase) GetByEmployeeID(ctx context.Context, employeeID u
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leave requests for employee ID %d: %w", employeeID, err)
 	}
+	if paginationParams.Cursor != nil {
+		leaveRequests, pagination := newLeaveRequestCursorPage(leaveRequests, paginationParams)
+		return &dtoleave.LeaveRequestListResponseData{
+			Items:      uc.toLeaveRequestResponseDTOs(leaveRequests),
+			Pagination: pagination,
+		}, nil
+	}
 	responseItems := make([]*dtoleave.LeaveRequestResponseDTO, len(leaveRequests))
 	for i, lr := range leaveRequests {
 		responseItems[i] = uc.toLeaveRequestResponseDTO(lr)
@@ -296,6 +365,13 @@ func (uc *LeaveRequestUseCase) List(ctx context.Context, filters map[string]inte
 	if err != nil {
 		return nil, fmt.Errorf("failed to list leave requests: %w", err)
 	}
+	if paginationParams.Cursor != nil {
+		leaveRequests, pagination := newLeaveRequestCursorPage(leaveRequests, paginationParams)
+		return &dtoleave.LeaveRequestListResponseData{
+			Items:      uc.toLeaveRequestResponseDTOs(leaveRequests),
+			Pagination: pagination,
+		}, nil
+	}
 	// Convert to response DTOs
 	leaveRequestDTOs := make([]*dtoleave.LeaveRequestResponseDTO, len(leaveRequests))
 	for i, lr := range leaveRequests {
@@ -351,6 +427,12 @@ func (uc *LeaveRequestUseCase) GetByEmployeeUserID(
 		return nil, fmt.Errorf("failed to get leave requests for employee ID %d: %w", employee.ID, err)
 	}
 
+	// A cursor page is trimmed before filtering so the next cursor follows the last request fetched
+	var cursorPagination domain.Pagination
+	if pagination.Cursor != nil {
+		leaveRequests, cursorPagination = newLeaveRequestCursorPage(leaveRequests, pagination)
+	}
+
 	// Apply additional filters if needed
 	filteredRequests := leaveRequests
 	filteredTotal := total
@@ -381,6 +463,13 @@ func (uc *LeaveRequestUseCase) GetByEmployeeUserID(
 		leaveRequestDTOs[i] = uc.toLeaveRequestResponseDTO(lr)
 	}
 
+	if pagination.Cursor != nil {
+		return &dtoleave.LeaveRequestListResponseData{
+			Items:      leaveRequestDTOs,
+			Pagination: cursorPagination,
+		}, nil
+	}
+
 	// Calculate pagination info
 	totalPages := int((filteredTotal + int64(pagination.PageSize) - 1) / int64(pagination.PageSize))
 	if totalPages == 0 {
@@ -486,6 +575,11 @@ func (uc *LeaveRequestUseCase) Update(ctx context.Context, id uint, updates *dom
 		existingLeaveRequest.Attachment = &fileName
 	}
 
+	// Re-evaluate the certificate requirement unless the request was already converted
+	if existingLeaveRequest.OriginalLeaveType == nil {
+		uc.applyCertificateRequirement(ctx, existingLeaveRequest)
+	}
+
 	// Update the leave request
 	err = uc.leaveRequestRepo.Update(ctx, existingLeaveRequest)
 	if err != nil {
@@ -507,10 +601,10 @@ func (uc *LeaveRequestUseCase) Update(ctx context.Context, id uint, updates *dom
 		return nil, fmt.Errorf("failed to retrieve updated leave request: %w", err)
 	}
 	log.Printf("LeaveRequestUseCase: Successfully updated leave request with ID %d", id)
-	return uc.toLeaveRequestResponseDTO(updatedLeaveRequest), nil
+	return uc.toLeaveRequestDetailDTO(updatedLeaveRequest), nil
 }
 
-func (uc *LeaveRequestUseCase) UpdateStatus(ctx context.Context, id uint, status domain.LeaveStatus, adminNote *string) (*dtoleave.LeaveRequestResponseDTO, error) {
+func (uc *LeaveRequestUseCase) UpdateStatus(ctx context.Context, id uint, status domain.LeaveStatus, adminNote *string, overrideStaffingRules bool) (*dtoleave.LeaveRequestResponseDTO, error) {
 	log.Printf("LeaveRequestUseCase: UpdateStatus called for ID %d, status: %s", id, string(status))
 
 	// Validate status
@@ -524,6 +618,14 @@ func (uc *LeaveRequestUseCase) UpdateStatus(ctx context.Context, id uint, status
 		return nil, fmt.Errorf("failed to get leave request for status update: %w", err)
 	}
 
+	var staffingConflicts []*dtoleave.StaffingConflictDTO
+	if status == domain.LeaveStatusApproved && leaveRequest.Status != domain.LeaveStatusApproved {
+		staffingConflicts, err = uc.checkStaffingRulesForApproval(ctx, leaveRequest, &leaveRequest.Employee, overrideStaffingRules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Update status
 	err = uc.leaveRequestRepo.UpdateStatus(ctx, id, status, adminNote)
 	if err != nil {
@@ -546,7 +648,9 @@ func (uc *LeaveRequestUseCase) UpdateStatus(ctx context.Context, id uint, status
 		return nil, fmt.Errorf("failed to retrieve updated leave request: %w", err)
 	}
 	log.Printf("LeaveRequestUseCase: Successfully updated leave request status to %s for ID %d", string(status), id)
-	return uc.toLeaveRequestResponseDTO(updatedLeaveRequest), nil
+	responseDTO := uc.toLeaveRequestDetailDTO(updatedLeaveRequest)
+	responseDTO.StaffingConflicts = staffingConflicts
+	return responseDTO, nil
 }
 
 func (uc *LeaveRequestUseCase) Delete(ctx context.Context, id uint) error {
@@ -11,6 +11,7 @@ import (
 	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
 	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
 	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -38,6 +39,7 @@ func TestLeaveRequestUseCase_Create(t *testing.T) {
 		EndDate:    endDate,
 		Duration:   2,
 		Status:     domain.LeaveStatusPending,
+		CertificateStatus: domain.CertificateNotRequired,
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
@@ -51,6 +53,7 @@ func TestLeaveRequestUseCase_Create(t *testing.T) {
 		StartDate:    "2023-12-25",
 		EndDate:      "2023-12-26",
 		Status:       "Waiting Approval",
+		CertificateStatus: "not_required",
 		CreatedAt:    now.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:    now.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -148,7 +151,7 @@ func TestLeaveRequestUseCase_Create(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockEmployeeRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 
 			result, err := useCase.Create(ctx, tt.leaveRequest, tt.file)
 
@@ -255,8 +258,8 @@ func TestLeaveRequestUseCase_CreateForEmployee(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
-			result, err := useCase.CreateForEmployee(ctx, tt.leaveRequest, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
+			result, err := useCase.CreateForEmployee(ctx, tt.leaveRequest, nil, false)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -360,7 +363,7 @@ func TestLeaveRequestUseCase_GetByID(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.GetByID(ctx, tt.id)
 
 			if tt.expectedError != "" {
@@ -391,6 +394,7 @@ func TestLeaveRequestUseCase_List(t *testing.T) {
 		PositionName: "Software Engineer",
 	}
 
+	attachmentPath := "leave_requests/1/certificate.pdf"
 	mockLeaveRequest := &domain.LeaveRequest{
 		ID:         1,
 		EmployeeID: 1,
@@ -399,6 +403,7 @@ func TestLeaveRequestUseCase_List(t *testing.T) {
 		StartDate:  time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC),
 		EndDate:    time.Date(2023, 12, 26, 0, 0, 0, 0, time.UTC),
 		Duration:   2,
+		Attachment: &attachmentPath,
 		Status:     domain.LeaveStatusPending,
 		CreatedAt:  now,
 		UpdatedAt:  now,
@@ -407,17 +412,19 @@ func TestLeaveRequestUseCase_List(t *testing.T) {
 	mockLeaveRequests := []*domain.LeaveRequest{mockLeaveRequest}
 	var mockTotalItems int64 = 1
 
+	// Lists only say whether there is an attachment; its URL is signed when a single request is read
 	expectedResponseDTO := &dtoleave.LeaveRequestResponseDTO{
-		ID:           1,
-		EmployeeID:   1,
-		EmployeeName: "John Doe",
-		PositionName: "Software Engineer",
-		LeaveType:    string(enums.AnnualLeave),
-		StartDate:    "2023-12-25",
-		EndDate:      "2023-12-26",
-		Status:       "Waiting Approval",
-		CreatedAt:    now.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:    now.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            1,
+		EmployeeID:    1,
+		EmployeeName:  "John Doe",
+		PositionName:  "Software Engineer",
+		LeaveType:     string(enums.AnnualLeave),
+		StartDate:     "2023-12-25",
+		EndDate:       "2023-12-26",
+		HasAttachment: true,
+		Status:        "Waiting Approval",
+		CreatedAt:     now.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     now.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	expectedSuccessResponseData := &dtoleave.LeaveRequestListResponseData{
@@ -492,7 +499,7 @@ func TestLeaveRequestUseCase_List(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.List(ctx, tt.filters, tt.pagination)
 
 			if tt.expectedError != "" {
@@ -603,7 +610,7 @@ func TestLeaveRequestUseCase_GetByEmployeeID(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.GetByEmployeeID(ctx, tt.employeeID, tt.pagination)
 
 			if tt.expectedError != "" {
@@ -796,7 +803,7 @@ func TestLeaveRequestUseCase_Update(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockEmployeeRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.Update(ctx, tt.id, tt.updates, nil)
 
 			if tt.expectedError != "" {
@@ -990,8 +997,8 @@ func TestLeaveRequestUseCase_UpdateStatus(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockAttendanceRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
-			result, err := useCase.UpdateStatus(ctx, tt.id, tt.status, tt.adminNote)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
+			result, err := useCase.UpdateStatus(ctx, tt.id, tt.status, tt.adminNote, false)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -1078,7 +1085,7 @@ func TestLeaveRequestUseCase_Delete(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			err := useCase.Delete(ctx, tt.id)
 
 			if tt.expectedError != "" {
@@ -1204,7 +1211,7 @@ func TestLeaveRequestUseCase_GetByEmployeeUserID(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockEmployeeRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.GetByEmployeeUserID(ctx, tt.userID, tt.filters, tt.pagination)
 
 			if tt.expectedError != "" {
@@ -1279,7 +1286,7 @@ func TestLeaveRequestUseCase_GetEmployeeByUserID(t *testing.T) {
 
 			tt.setupMocks(mockEmployeeRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			result, err := useCase.GetEmployeeByUserID(ctx, tt.userID)
 
 			if tt.expectedError != "" {
@@ -1356,7 +1363,7 @@ func TestLeaveRequestUseCase_CreateWithFile(t *testing.T) {
 
 			tt.setupMocks(mockLeaveRequestRepo, mockEmployeeRepo)
 
-			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil)
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, nil, nil, nil)
 			_, err := useCase.Create(ctx, tt.leaveRequest, tt.file)
 
 			if tt.expectedError != "" {
@@ -1371,3 +1378,872 @@ func TestLeaveRequestUseCase_CreateWithFile(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaveRequestUseCase_UpdateStatusStaffingRules(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	startDate := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	branch := "Warehouse"
+	maxAbsent := uint(2)
+	mockEmployee := &domain.Employee{ID: 1, FirstName: "John", PositionName: "Supervisor", Branch: &branch}
+	pendingRequest := &domain.LeaveRequest{
+		ID:         1,
+		EmployeeID: 1,
+		Employee:   *mockEmployee,
+		LeaveType:  enums.AnnualLeave,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Status:     domain.LeaveStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	approvedRequest := *pendingRequest
+	approvedRequest.Status = domain.LeaveStatusApproved
+
+	rootEmployeeID := uint(10)
+	rule := &domain.LeaveStaffingRule{ID: 7, Name: "Warehouse supervisors", RootEmployeeID: &rootEmployeeID, MaxAbsent: &maxAbsent}
+	colleagueLeaves := []*domain.LeaveRequest{
+		{ID: 2, EmployeeID: 2, Employee: domain.Employee{ID: 2, FirstName: "Jane"}, LeaveType: enums.AnnualLeave, StartDate: startDate, EndDate: endDate, Status: domain.LeaveStatusApproved},
+		{ID: 3, EmployeeID: 3, Employee: domain.Employee{ID: 3, FirstName: "Budi"}, LeaveType: enums.SickLeave, StartDate: startDate, EndDate: endDate, Status: domain.LeaveStatusApproved},
+	}
+
+	tests := []struct {
+		name          string
+		override      bool
+		setupMocks    func(*mocks.LeaveRequestRepository, *mocks.AttendanceRepository, *mocks.LeaveStaffingRuleRepository)
+		expectedError error
+		conflicts     int
+	}{
+		{
+			name:     "approval blocked when rule would be breached",
+			override: false,
+			setupMocks: func(lrRepo *mocks.LeaveRequestRepository, attRepo *mocks.AttendanceRepository, ruleRepo *mocks.LeaveStaffingRuleRepository) {
+				lrRepo.On("GetByID", ctx, uint(1)).Return(pendingRequest, nil)
+				ruleRepo.On("ListApplicableToEmployee", ctx, mock.AnythingOfType("*domain.Employee")).Return([]*domain.LeaveStaffingRule{rule}, nil)
+				ruleRepo.On("CountScopeEmployees", ctx, rule).Return(int64(3), nil)
+				ruleRepo.On("ListScopeLeaves", ctx, rule, startDate, endDate, []domain.LeaveStatus{domain.LeaveStatusApproved}).Return(colleagueLeaves, nil)
+			},
+			expectedError: domain.ErrStaffingRuleBreached,
+		},
+		{
+			name:     "approval allowed with override",
+			override: true,
+			setupMocks: func(lrRepo *mocks.LeaveRequestRepository, attRepo *mocks.AttendanceRepository, ruleRepo *mocks.LeaveStaffingRuleRepository) {
+				lrRepo.On("GetByID", ctx, uint(1)).Return(pendingRequest, nil).Once()
+				ruleRepo.On("ListApplicableToEmployee", ctx, mock.AnythingOfType("*domain.Employee")).Return([]*domain.LeaveStaffingRule{rule}, nil)
+				ruleRepo.On("CountScopeEmployees", ctx, rule).Return(int64(3), nil)
+				ruleRepo.On("ListScopeLeaves", ctx, rule, startDate, endDate, []domain.LeaveStatus{domain.LeaveStatusApproved}).Return(colleagueLeaves, nil)
+				lrRepo.On("UpdateStatus", ctx, uint(1), domain.LeaveStatusApproved, (*string)(nil)).Return(nil)
+				attRepo.On("GetByEmployeeAndDate", ctx, uint(1), "2023-12-25").Return(nil, errors.New("not found"))
+				attRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+				lrRepo.On("GetByID", ctx, uint(1)).Return(&approvedRequest, nil).Once()
+			},
+			conflicts: 1,
+		},
+		{
+			name:     "approval allowed when within limit",
+			override: false,
+			setupMocks: func(lrRepo *mocks.LeaveRequestRepository, attRepo *mocks.AttendanceRepository, ruleRepo *mocks.LeaveStaffingRuleRepository) {
+				lrRepo.On("GetByID", ctx, uint(1)).Return(pendingRequest, nil).Once()
+				ruleRepo.On("ListApplicableToEmployee", ctx, mock.AnythingOfType("*domain.Employee")).Return([]*domain.LeaveStaffingRule{rule}, nil)
+				ruleRepo.On("CountScopeEmployees", ctx, rule).Return(int64(3), nil)
+				ruleRepo.On("ListScopeLeaves", ctx, rule, startDate, endDate, []domain.LeaveStatus{domain.LeaveStatusApproved}).Return(colleagueLeaves[:1], nil)
+				lrRepo.On("UpdateStatus", ctx, uint(1), domain.LeaveStatusApproved, (*string)(nil)).Return(nil)
+				attRepo.On("GetByEmployeeAndDate", ctx, uint(1), "2023-12-25").Return(nil, errors.New("not found"))
+				attRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+				lrRepo.On("GetByID", ctx, uint(1)).Return(&approvedRequest, nil).Once()
+			},
+			conflicts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockAttendanceRepo := new(mocks.AttendanceRepository)
+			mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+
+			tt.setupMocks(mockLeaveRequestRepo, mockAttendanceRepo, mockRuleRepo)
+
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, mockRuleRepo, nil, nil, nil)
+			result, err := useCase.UpdateStatus(ctx, 1, domain.LeaveStatusApproved, nil, tt.override)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				var violation *StaffingRuleViolationError
+				assert.True(t, errors.As(err, &violation))
+				assert.Len(t, violation.Conflicts, 1)
+				assert.Len(t, violation.Conflicts[0].Colleagues, 2)
+				assert.Nil(t, result)
+				mockLeaveRequestRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.StaffingConflicts, tt.conflicts)
+			}
+
+			mockLeaveRequestRepo.AssertExpectations(t)
+			mockRuleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveRequestUseCase_CreateSickLeaveCertificate(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	mockEmployee := &domain.Employee{ID: 1, FirstName: "John", PositionName: "Software Engineer"}
+	policy := &domain.LeavePolicy{
+		SickCertificateRequiredAfterDays: 2,
+		SickCertificateGraceDays:         5,
+		SickCertificateFallbackType:      enums.UnpaidLeave,
+	}
+
+	tests := []struct {
+		name           string
+		leaveType      enums.LeaveType
+		days           int
+		expectedStatus domain.CertificateStatus
+		expectedDue    *time.Time
+	}{
+		{
+			name:           "short sick leave needs no certificate",
+			leaveType:      enums.SickLeave,
+			days:           2,
+			expectedStatus: domain.CertificateNotRequired,
+		},
+		{
+			name:           "long sick leave without attachment is pending",
+			leaveType:      enums.SickLeave,
+			days:           3,
+			expectedStatus: domain.CertificatePending,
+			expectedDue:    &[]time.Time{startDate.AddDate(0, 0, 2+5)}[0],
+		},
+		{
+			name:           "annual leave needs no certificate",
+			leaveType:      enums.AnnualLeave,
+			days:           5,
+			expectedStatus: domain.CertificateNotRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockAttendanceRepo := new(mocks.AttendanceRepository)
+			mockPolicyRepo := new(mocks.LeavePolicyRepository)
+
+			endDate := startDate.AddDate(0, 0, tt.days-1)
+			leaveRequest := &domain.LeaveRequest{
+				EmployeeID: 1,
+				LeaveType:  tt.leaveType,
+				StartDate:  startDate,
+				EndDate:    endDate,
+			}
+
+			mockEmployeeRepo.On("GetByID", ctx, uint(1)).Return(mockEmployee, nil)
+			mockLeaveRequestRepo.On("HasOverlappingLeaveRequest", ctx, uint(1), startDate, endDate, (*uint)(nil)).Return(false, nil)
+			mockPolicyRepo.On("GetForEmployee", ctx, uint(1)).Return(policy, nil).Maybe()
+			mockLeaveRequestRepo.On("Create", ctx, leaveRequest).Return(nil)
+			mockLeaveRequestRepo.On("GetByID", ctx, uint(1)).Return(leaveRequest, nil)
+
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, mockAttendanceRepo, nil, mockPolicyRepo, nil, nil)
+			result, err := useCase.Create(ctx, leaveRequest, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, leaveRequest.CertificateStatus)
+			assert.Equal(t, string(tt.expectedStatus), result.CertificateStatus)
+			if tt.expectedDue != nil {
+				assert.Equal(t, *tt.expectedDue, *leaveRequest.CertificateDueDate)
+				assert.Equal(t, tt.expectedDue.Format("2006-01-02"), *result.CertificateDueDate)
+			} else {
+				assert.Nil(t, leaveRequest.CertificateDueDate)
+			}
+		})
+	}
+}
+
+func TestLeaveRequestUseCase_ProcessMissingCertificates(t *testing.T) {
+	ctx := context.Background()
+	dueDate := time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC)
+	adminNote := "Get well soon"
+
+	// Wednesday 20 to Thursday 21 December 2023: two working days
+	startDate := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 12, 21, 0, 0, 0, 0, time.UTC)
+
+	overdue := []*domain.LeaveRequest{
+		{ID: 1, EmployeeID: 1, Employee: domain.Employee{ID: 1, AnnualLeaveAllowance: 12}, LeaveType: enums.SickLeave, StartDate: startDate, EndDate: endDate, Status: domain.LeaveStatusApproved, CertificateStatus: domain.CertificatePending, CertificateDueDate: &dueDate, AdminNote: &adminNote},
+		{ID: 2, EmployeeID: 2, Employee: domain.Employee{ID: 2, AnnualLeaveAllowance: 12}, LeaveType: enums.SickLeave, StartDate: startDate, EndDate: endDate, Status: domain.LeaveStatusPending, CertificateStatus: domain.CertificatePending, CertificateDueDate: &dueDate},
+		{ID: 3, EmployeeID: 3, Employee: domain.Employee{ID: 3}, LeaveType: enums.SickLeave, StartDate: startDate, EndDate: endDate, Status: domain.LeaveStatusApproved, CertificateStatus: domain.CertificatePending, CertificateDueDate: &dueDate},
+	}
+
+	mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+	mockPolicyRepo := new(mocks.LeavePolicyRepository)
+
+	mockLeaveRequestRepo.On("ListCertificatesOverdue", ctx, mock.AnythingOfType("time.Time")).Return(overdue, nil)
+	mockPolicyRepo.On("GetForEmployee", ctx, uint(1)).Return(&domain.LeavePolicy{SickCertificateFallbackType: enums.UnpaidLeave}, nil)
+	mockPolicyRepo.On("GetForEmployee", ctx, uint(2)).Return(nil, domain.ErrLeavePolicyNotFound)
+	mockPolicyRepo.On("GetForEmployee", ctx, uint(3)).Return(nil, domain.ErrLeavePolicyNotFound)
+	mockLeaveRequestRepo.On("List", ctx, mock.Anything, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}).
+		Return([]*domain.LeaveRequest{}, int64(0), nil)
+	mockLeaveRequestRepo.On("Update", ctx, overdue[0]).Return(nil)
+	mockLeaveRequestRepo.On("Update", ctx, overdue[1]).Return(errors.New("database error"))
+	mockLeaveRequestRepo.On("Update", ctx, overdue[2]).Return(nil)
+
+	useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, mockPolicyRepo, nil, nil)
+	result, err := useCase.ProcessMissingCertificates(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, 2, result.Converted)
+	assert.Equal(t, 1, result.Failed)
+
+	assert.Equal(t, enums.UnpaidLeave, overdue[0].LeaveType)
+	assert.Equal(t, enums.SickLeave, *overdue[0].OriginalLeaveType)
+	assert.Equal(t, domain.CertificateMissing, overdue[0].CertificateStatus)
+	assert.Contains(t, *overdue[0].AdminNote, "Get well soon; Converted to unpaid_leave")
+
+	// Employees without a configured policy fall back to annual leave
+	assert.Equal(t, enums.AnnualLeave, overdue[1].LeaveType)
+	// unless they have too little annual leave left to cover the sick leave
+	assert.Equal(t, enums.UnpaidLeave, overdue[2].LeaveType)
+	assert.Contains(t, *overdue[2].AdminNote, "Converted to unpaid_leave")
+
+	mockLeaveRequestRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestLeaveRequestUseCase_GetUnpaidDays(t *testing.T) {
+	ctx := context.Background()
+	// Monday 1 January to Friday 12 January 2024: ten working days
+	periodStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	budi := domain.Employee{ID: 2, FirstName: "Budi", PositionName: "Engineer"}
+	suspension := enums.Suspension
+	absenceStart := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	absenceEnd := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	siti := &domain.Employee{ID: 3, FirstName: "Siti", PositionName: "Designer", AbsenceType: &suspension, AbsenceStartDate: &absenceStart, AbsenceEndDate: &absenceEnd}
+
+	unpaidLeaves := []*domain.LeaveRequest{
+		// Starts before the period: only 1-3 January count
+		{ID: 1, EmployeeID: 2, Employee: budi, LeaveType: enums.UnpaidLeave, StartDate: time.Date(2023, 12, 28, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// Spans a weekend: 5 and 8 January count
+		{ID: 2, EmployeeID: 2, Employee: budi, LeaveType: enums.UnpaidLeave, StartDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		// Overlaps the suspension on 10 January, which must only count once
+		{ID: 3, EmployeeID: 3, Employee: *siti, LeaveType: enums.UnpaidLeave, StartDate: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+
+	mockLeaveRequestRepo.On("List", ctx, map[string]interface{}{
+		"status":         domain.LeaveStatusApproved,
+		"leave_type":     enums.UnpaidLeave,
+		"start_date_lte": periodEnd,
+		"end_date_gte":   periodStart,
+	}, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}).Return(unpaidLeaves, int64(0), nil)
+	mockEmployeeRepo.On("ForEachBatch", ctx, map[string]interface{}{
+		"has_long_term_absence": true,
+	}).Return([]*domain.Employee{siti}, nil)
+
+	useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, new(mocks.AttendanceRepository), nil, nil, nil, nil)
+	report, err := useCase.GetUnpaidDays(ctx, periodStart, periodEnd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01", report.StartDate)
+	assert.Len(t, report.Items, 2)
+
+	assert.Equal(t, uint(2), report.Items[0].EmployeeID)
+	assert.Equal(t, 5, report.Items[0].UnpaidLeaveDays)
+	assert.Equal(t, 0, report.Items[0].LongTermAbsenceDays)
+	assert.Equal(t, 5, report.Items[0].TotalUnpaidDays)
+
+	assert.Equal(t, uint(3), report.Items[1].EmployeeID)
+	assert.Equal(t, 2, report.Items[1].UnpaidLeaveDays)
+	assert.Equal(t, 2, report.Items[1].LongTermAbsenceDays)
+	assert.Equal(t, 4, report.Items[1].TotalUnpaidDays)
+
+	mockLeaveRequestRepo.AssertExpectations(t)
+	mockEmployeeRepo.AssertExpectations(t)
+}
+
+func TestLeaveRequestUseCase_LongTermAbsenceValidation(t *testing.T) {
+	ctx := context.Background()
+	suspension := enums.Suspension
+	start := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("missing dates", func(t *testing.T) {
+		useCase := NewLeaveRequestUseCase(nil, new(mocks.EmployeeRepository), nil, nil, nil, nil, nil)
+		_, err := useCase.SetLongTermAbsence(ctx, &domain.Employee{ID: 3, AbsenceType: &suspension})
+		assert.ErrorIs(t, err, domain.ErrInvalidLongTermAbsence)
+	})
+
+	t.Run("ends before it starts", func(t *testing.T) {
+		useCase := NewLeaveRequestUseCase(nil, new(mocks.EmployeeRepository), nil, nil, nil, nil, nil)
+		_, err := useCase.SetLongTermAbsence(ctx, &domain.Employee{ID: 3, AbsenceType: &suspension, AbsenceStartDate: &start, AbsenceEndDate: &end})
+		assert.ErrorIs(t, err, domain.ErrInvalidLongTermAbsence)
+	})
+
+	t.Run("unknown employee", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockEmployeeRepo.On("GetByID", ctx, uint(3)).Return(nil, gorm.ErrRecordNotFound)
+		useCase := NewLeaveRequestUseCase(nil, mockEmployeeRepo, nil, nil, nil, nil, nil)
+		_, err := useCase.SetLongTermAbsence(ctx, &domain.Employee{ID: 3, AbsenceType: &suspension, AbsenceStartDate: &end, AbsenceEndDate: &start})
+		assert.ErrorIs(t, err, domain.ErrEmployeeNotFound)
+	})
+
+	t.Run("unpaid days of a period ending before it starts", func(t *testing.T) {
+		useCase := NewLeaveRequestUseCase(new(mocks.LeaveRequestRepository), new(mocks.EmployeeRepository), nil, nil, nil, nil, nil)
+		_, err := useCase.GetUnpaidDays(ctx, start, end)
+		assert.ErrorIs(t, err, domain.ErrInvalidPeriod)
+	})
+}
+
+func TestLeaveRequestUseCase_ProcessYearEndEncashment(t *testing.T) {
+	ctx := tenant.AcrossCompanies(context.Background())
+	companyID := uint(4)
+	companyCtx := tenant.WithCompanyID(ctx, companyID)
+	year := 2023
+	baseSalary := 4200000.0
+
+	budi := &domain.Employee{ID: 1, CompanyID: &companyID, FirstName: "Budi", AnnualLeaveAllowance: 12, BaseSalary: &baseSalary}
+	siti := &domain.Employee{ID: 2, CompanyID: &companyID, FirstName: "Siti", AnnualLeaveAllowance: 12, BaseSalary: &baseSalary}
+	andi := &domain.Employee{ID: 3, CompanyID: &companyID, FirstName: "Andi", AnnualLeaveAllowance: 3}
+
+	annualLeaveFilters := func(employeeID uint, year int) map[string]interface{} {
+		return map[string]interface{}{
+			"employee_id":    employeeID,
+			"status":         domain.LeaveStatusApproved,
+			"leave_type":     enums.AnnualLeave,
+			"start_date_lte": time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+			"end_date_gte":   time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	allPages := domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}
+
+	mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+	mockPolicyRepo := new(mocks.LeavePolicyRepository)
+	mockEncashmentRepo := new(mocks.LeaveEncashmentRepository)
+
+	mockEmployeeRepo.On("ForEachBatch", ctx, map[string]interface{}{"employment_status": true}).
+		Return([]*domain.Employee{budi, siti, andi}, nil)
+	mockPolicyRepo.On("GetForEmployee", companyCtx, mock.AnythingOfType("uint")).Return(nil, domain.ErrLeavePolicyNotFound)
+
+	// Budi carried three days over from 2022 and took two working days off (Monday 6 and
+	// Tuesday 7 March), leaving thirteen days
+	mockEncashmentRepo.On("Exists", companyCtx, uint(1), year, domain.EncashmentReasonYearEnd).Return(false, nil)
+	mockEncashmentRepo.On("GetByPeriod", companyCtx, uint(1), year-1, domain.EncashmentReasonYearEnd).
+		Return(&domain.LeaveEncashment{EmployeeID: 1, Year: year - 1, CarryOverDays: 3, Status: domain.EncashmentStatusFinalized}, nil)
+	mockLeaveRequestRepo.On("List", companyCtx, annualLeaveFilters(1, year), allPages).Return([]*domain.LeaveRequest{
+		{ID: 1, EmployeeID: 1, StartDate: time.Date(year, 3, 6, 0, 0, 0, 0, time.UTC), EndDate: time.Date(year, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}, int64(1), nil)
+	var created *domain.LeaveEncashment
+	mockEncashmentRepo.On("Create", companyCtx, mock.AnythingOfType("*domain.LeaveEncashment")).Return(nil).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*domain.LeaveEncashment)
+	})
+
+	// Siti was already processed
+	mockEncashmentRepo.On("Exists", companyCtx, uint(2), year, domain.EncashmentReasonYearEnd).Return(true, nil)
+
+	// Andi used all of 2022 and his remaining days fit under the carry-over cap
+	mockEncashmentRepo.On("Exists", companyCtx, uint(3), year, domain.EncashmentReasonYearEnd).Return(false, nil)
+	mockEncashmentRepo.On("GetByPeriod", companyCtx, uint(3), year-1, domain.EncashmentReasonYearEnd).Return(nil, domain.ErrLeaveEncashmentNotFound)
+	mockLeaveRequestRepo.On("List", companyCtx, annualLeaveFilters(3, year-1), allPages).Return([]*domain.LeaveRequest{
+		{ID: 2, EmployeeID: 3, StartDate: time.Date(year-1, 3, 7, 0, 0, 0, 0, time.UTC), EndDate: time.Date(year-1, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}, int64(1), nil)
+	mockLeaveRequestRepo.On("List", companyCtx, annualLeaveFilters(3, year), allPages).Return([]*domain.LeaveRequest{}, int64(0), nil)
+
+	useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, mockEmployeeRepo, new(mocks.AttendanceRepository), nil, mockPolicyRepo, mockEncashmentRepo, nil)
+	result, err := useCase.ProcessYearEndEncashment(ctx, year)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 0, result.Failed)
+
+	mockEncashmentRepo.AssertNumberOfCalls(t, "Create", 1)
+	assert.Equal(t, uint(1), created.EmployeeID)
+	assert.Equal(t, 15, created.EntitledDays)
+	assert.Equal(t, 2, created.UsedDays)
+	assert.Equal(t, 5, created.CarryOverDays)
+	assert.Equal(t, 8, created.EncashedDays)
+	assert.Equal(t, 200000.0, created.DailyRate)
+	assert.Equal(t, 1600000.0, created.Amount)
+	assert.Equal(t, domain.EncashmentStatusDraft, created.Status)
+
+	mockLeaveRequestRepo.AssertExpectations(t)
+	mockEmployeeRepo.AssertExpectations(t)
+	mockEncashmentRepo.AssertExpectations(t)
+}
+
+func TestLeaveRequestUseCase_GetEncashmentByID(t *testing.T) {
+	ctx := context.Background()
+	managerID := uint(5)
+	otherManagerID := uint(6)
+
+	tests := []struct {
+		name        string
+		managerID   *uint
+		expectedErr error
+	}{
+		{name: "admin", managerID: nil},
+		{name: "manager of the employee", managerID: &managerID},
+		{name: "another manager", managerID: &otherManagerID, expectedErr: domain.ErrLeaveEncashmentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEncashmentRepo := new(mocks.LeaveEncashmentRepository)
+			mockEncashmentRepo.On("GetByID", ctx, uint(3)).Return(&domain.LeaveEncashment{
+				ID: 3, EmployeeID: 1, Employee: domain.Employee{ID: 1, FirstName: "Budi", ManagerID: &managerID},
+			}, nil)
+			useCase := NewLeaveRequestUseCase(new(mocks.LeaveRequestRepository), new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, nil, mockEncashmentRepo, nil)
+
+			result, err := useCase.GetEncashmentByID(ctx, 3, tt.managerID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint(3), result.ID)
+		})
+	}
+}
+
+func TestLeaveRequestUseCase_ReviewEncashment(t *testing.T) {
+	ctx := context.Background()
+	reviewerID := uint(9)
+	encashedDays := 3
+	dailyRate := 150000.0
+
+	tests := []struct {
+		name          string
+		encashment    *domain.LeaveEncashment
+		expectUpdate  bool
+		expectedError error
+		expectedDTO   *dtoleave.LeaveEncashmentResponseDTO
+	}{
+		{
+			name:         "draft is adjusted and the amount recalculated",
+			encashment:   &domain.LeaveEncashment{ID: 1, EmployeeID: 1, Employee: domain.Employee{FirstName: "Budi"}, Reason: domain.EncashmentReasonYearEnd, Year: 2023, EncashedDays: 5, DailyRate: 200000, Amount: 1000000, Status: domain.EncashmentStatusDraft},
+			expectUpdate: true,
+			expectedDTO: &dtoleave.LeaveEncashmentResponseDTO{
+				ID: 1, EmployeeID: 1, EmployeeName: "Budi", Reason: "year_end", Year: 2023,
+				EncashedDays: 3, DailyRate: 150000, Amount: 450000, Status: "draft", ReviewedBy: &reviewerID,
+				CreatedAt: "0001-01-01T00:00:00Z", UpdatedAt: "0001-01-01T00:00:00Z",
+			},
+		},
+		{
+			name:          "finalized encashment cannot be changed",
+			encashment:    &domain.LeaveEncashment{ID: 2, EmployeeID: 1, Status: domain.EncashmentStatusFinalized},
+			expectedError: domain.ErrEncashmentNotDraft,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEncashmentRepo := new(mocks.LeaveEncashmentRepository)
+			mockEncashmentRepo.On("GetByID", ctx, tt.encashment.ID).Return(tt.encashment, nil)
+			if tt.expectUpdate {
+				mockEncashmentRepo.On("Update", ctx, tt.encashment).Return(nil)
+			}
+
+			useCase := NewLeaveRequestUseCase(new(mocks.LeaveRequestRepository), new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, nil, mockEncashmentRepo, nil)
+			result, err := useCase.ReviewEncashment(ctx, tt.encashment.ID, reviewerID, &encashedDays, &dailyRate, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDTO, result)
+			}
+			mockEncashmentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveRequestUseCase_ProjectAnnualLeave(t *testing.T) {
+	ctx := context.Background()
+	asOf := time.Date(2024, 10, 15, 9, 30, 0, 0, time.UTC)
+	employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12}
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	pendingNovember := &domain.LeaveRequest{ID: 4, EmployeeID: 1, Status: domain.LeaveStatusPending, StartDate: date(2024, 11, 4), EndDate: date(2024, 11, 5)}
+	// Nine working days last year leave three to carry over
+	lastYear := &domain.LeaveRequest{ID: 1, EmployeeID: 1, Status: domain.LeaveStatusApproved, StartDate: date(2023, 7, 3), EndDate: date(2023, 7, 13)}
+	leaveRequests := []*domain.LeaveRequest{
+		{ID: 2, EmployeeID: 1, Status: domain.LeaveStatusApproved, StartDate: date(2024, 3, 4), EndDate: date(2024, 3, 8)},
+		{ID: 3, EmployeeID: 1, Status: domain.LeaveStatusApproved, StartDate: date(2024, 10, 1), EndDate: date(2024, 10, 2)},
+		pendingNovember,
+		{ID: 5, EmployeeID: 1, Status: domain.LeaveStatusRejected, StartDate: date(2024, 12, 2), EndDate: date(2024, 12, 6)},
+	}
+
+	tests := []struct {
+		name           string
+		sim            balanceSimulation
+		expectedMonths []*dtoleave.MonthlyBalanceDTO
+	}{
+		{
+			name: "approved leave only",
+			sim:  balanceSimulation{},
+			expectedMonths: []*dtoleave.MonthlyBalanceDTO{
+				{Month: "2024-10", ApprovedDays: 2, ClosingBalance: 8},
+				{Month: "2024-11", ClosingBalance: 8},
+				{Month: "2024-12", ExpiringDays: 3, ClosingBalance: 5},
+			},
+		},
+		{
+			name: "pending requests are deducted when included",
+			sim:  balanceSimulation{includePending: true},
+			expectedMonths: []*dtoleave.MonthlyBalanceDTO{
+				{Month: "2024-10", ApprovedDays: 2, ClosingBalance: 8},
+				{Month: "2024-11", PendingDays: 2, ClosingBalance: 6},
+				{Month: "2024-12", ExpiringDays: 1, ClosingBalance: 5},
+			},
+		},
+		{
+			name: "previewed request counts as approved",
+			sim:  balanceSimulation{assumeApproved: pendingNovember},
+			expectedMonths: []*dtoleave.MonthlyBalanceDTO{
+				{Month: "2024-10", ApprovedDays: 2, ClosingBalance: 8},
+				{Month: "2024-11", ApprovedDays: 2, ClosingBalance: 6},
+				{Month: "2024-12", ExpiringDays: 1, ClosingBalance: 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allPages := domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}
+			mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+			mockLeaveRequestRepo.On("List", ctx, map[string]interface{}{
+				"employee_id":    uint(1),
+				"status":         domain.LeaveStatusApproved,
+				"leave_type":     enums.AnnualLeave,
+				"start_date_lte": date(2023, 12, 31),
+				"end_date_gte":   date(2023, 1, 1),
+			}, allPages).Return([]*domain.LeaveRequest{lastYear}, int64(0), nil)
+			mockLeaveRequestRepo.On("List", ctx, map[string]interface{}{
+				"employee_id":    uint(1),
+				"leave_type":     enums.AnnualLeave,
+				"start_date_lte": date(2024, 12, 31),
+				"end_date_gte":   date(2024, 1, 1),
+			}, allPages).Return(append([]*domain.LeaveRequest{}, leaveRequests...), int64(0), nil)
+
+			useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, nil, nil, nil)
+			projection, err := useCase.projectAnnualLeave(ctx, employee, asOf, 3, tt.sim)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "2024-10-15", projection.AsOf)
+			assert.Equal(t, 3.0, projection.CarriedOver)
+			assert.Equal(t, 8.0, projection.CurrentBalance)
+			assert.Equal(t, tt.expectedMonths, projection.Months)
+			mockLeaveRequestRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveRequestUseCase_AnnualLeaveYear(t *testing.T) {
+	ctx := context.Background()
+	employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12}
+	policy := domain.DefaultLeavePolicy()
+	policy.AnnualLeaveAccruesMonthly = true
+
+	allPages := domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}
+	newUseCase := func() *LeaveRequestUseCase {
+		mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+		mockLeaveRequestRepo.On("List", ctx, mock.Anything, allPages).Return([]*domain.LeaveRequest{}, int64(0), nil)
+		mockPolicyRepo := new(mocks.LeavePolicyRepository)
+		mockPolicyRepo.On("GetForEmployee", ctx, uint(1)).Return(policy, nil)
+		// Two days were carried over when 2023 was encashed
+		mockEncashmentRepo := new(mocks.LeaveEncashmentRepository)
+		mockEncashmentRepo.On("GetByPeriod", ctx, uint(1), 2023, domain.EncashmentReasonYearEnd).
+			Return(&domain.LeaveEncashment{EmployeeID: 1, Year: 2023, CarryOverDays: 2, Status: domain.EncashmentStatusFinalized}, nil)
+		return NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, mockPolicyRepo, mockEncashmentRepo, nil)
+	}
+
+	t.Run("the projection opens with the carry-over and accrues monthly", func(t *testing.T) {
+		projection, err := newUseCase().projectAnnualLeave(ctx, employee, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), 2, balanceSimulation{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, projection.CarriedOver)
+		assert.Equal(t, 5.0, projection.CurrentBalance)
+		assert.Equal(t, []*dtoleave.MonthlyBalanceDTO{
+			{Month: "2024-03", Accrued: 1, ClosingBalance: 5},
+			{Month: "2024-04", Accrued: 1, ClosingBalance: 6},
+		}, projection.Months)
+	})
+
+	t.Run("encashments count the same carry-over", func(t *testing.T) {
+		useCase := newUseCase()
+
+		yearEnd, err := useCase.calculateEncashment(ctx, employee, 2024, domain.EncashmentReasonYearEnd, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, 14, yearEnd.EntitledDays)
+
+		resignation, err := useCase.calculateEncashment(ctx, employee, 2024, domain.EncashmentReasonResignation, time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, 8, resignation.EntitledDays)
+	})
+}
+
+func TestLeaveRequestUseCase_AnnualLeaveYear_HireDate(t *testing.T) {
+	ctx := context.Background()
+	allPages := domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}
+	newUseCase := func(policy *domain.LeavePolicy) (*LeaveRequestUseCase, *mocks.LeaveEncashmentRepository) {
+		mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+		mockLeaveRequestRepo.On("List", ctx, mock.Anything, allPages).Return([]*domain.LeaveRequest{}, int64(0), nil)
+		mockPolicyRepo := new(mocks.LeavePolicyRepository)
+		mockPolicyRepo.On("GetForEmployee", ctx, uint(1)).Return(policy, nil)
+		mockEncashmentRepo := new(mocks.LeaveEncashmentRepository)
+		mockEncashmentRepo.On("GetByPeriod", ctx, uint(1), mock.AnythingOfType("int"), domain.EncashmentReasonYearEnd).
+			Return(nil, domain.ErrLeaveEncashmentNotFound)
+		return NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, mockPolicyRepo, mockEncashmentRepo, nil), mockEncashmentRepo
+	}
+
+	t.Run("employees hired this year carry nothing in and accrue from their hire month", func(t *testing.T) {
+		hired := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+		employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12, HireDate: &hired}
+		policy := domain.DefaultLeavePolicy()
+		policy.AnnualLeaveAccruesMonthly = true
+		useCase, mockEncashmentRepo := newUseCase(policy)
+
+		projection, err := useCase.projectAnnualLeave(ctx, employee, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), 1, balanceSimulation{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, projection.CarriedOver)
+		assert.Equal(t, 3.0, projection.CurrentBalance)
+
+		yearEnd, err := useCase.calculateEncashment(ctx, employee, 2024, domain.EncashmentReasonYearEnd, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, 9, yearEnd.EntitledDays)
+		mockEncashmentRepo.AssertNotCalled(t, "GetByPeriod", ctx, uint(1), 2023, domain.EncashmentReasonYearEnd)
+	})
+
+	t.Run("the allowance granted in the year of hire is pro-rated", func(t *testing.T) {
+		hired := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12, HireDate: &hired}
+		useCase, _ := newUseCase(domain.DefaultLeavePolicy())
+
+		projection, err := useCase.projectAnnualLeave(ctx, employee, time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC), 2, balanceSimulation{})
+		assert.NoError(t, err)
+		assert.Equal(t, []*dtoleave.MonthlyBalanceDTO{
+			{Month: "2024-09"},
+			{Month: "2024-10", Accrued: 3, ClosingBalance: 3},
+		}, projection.Months)
+	})
+
+	t.Run("the carry-in of the year after the hire is left of the pro-rated allowance", func(t *testing.T) {
+		hired := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+		employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12, HireDate: &hired}
+		useCase, _ := newUseCase(domain.DefaultLeavePolicy())
+
+		yearEnd, err := useCase.calculateEncashment(ctx, employee, 2024, domain.EncashmentReasonYearEnd, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 15, yearEnd.EntitledDays)
+	})
+}
+
+func TestLeaveRequestUseCase_ListAllLeaveRequests(t *testing.T) {
+	ctx := context.Background()
+	filters := map[string]interface{}{"employee_id": uint(1)}
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	firstBatch := make([]*domain.LeaveRequest, leaveRequestBatchSize+1)
+	for i := range firstBatch {
+		firstBatch[i] = &domain.LeaveRequest{ID: uint(1000 - i), CreatedAt: createdAt}
+	}
+	last := firstBatch[leaveRequestBatchSize-1]
+	secondBatch := []*domain.LeaveRequest{{ID: 7, CreatedAt: createdAt}}
+
+	mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+	mockLeaveRequestRepo.On("List", ctx, filters, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}).
+		Return(firstBatch, int64(0), nil)
+	mockLeaveRequestRepo.On("List", ctx, filters, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{Date: createdAt, ID: last.ID}}).
+		Return(secondBatch, int64(0), nil)
+
+	useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, nil, nil, nil)
+	leaveRequests, err := useCase.listAllLeaveRequests(ctx, filters)
+
+	assert.NoError(t, err)
+	assert.Len(t, leaveRequests, leaveRequestBatchSize+1)
+	assert.Equal(t, uint(7), leaveRequests[leaveRequestBatchSize].ID)
+	mockLeaveRequestRepo.AssertExpectations(t)
+}
+
+func TestLeaveRequestUseCase_ProjectAnnualLeave_UncreatedRequest(t *testing.T) {
+	ctx := context.Background()
+	employee := &domain.Employee{ID: 1, FirstName: "Budi", AnnualLeaveAllowance: 12}
+
+	mockLeaveRequestRepo := new(mocks.LeaveRequestRepository)
+	mockLeaveRequestRepo.On("List", ctx, mock.Anything, domain.PaginationParams{PageSize: leaveRequestBatchSize, Cursor: &domain.Cursor{}}).
+		Return([]*domain.LeaveRequest{}, int64(0), nil)
+
+	// The preview is taken before the request is created, so it has no ID yet
+	leaveRequest := &domain.LeaveRequest{EmployeeID: 1, LeaveType: enums.AnnualLeave, Status: domain.LeaveStatusPending,
+		StartDate: time.Date(2024, 11, 4, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 11, 5, 0, 0, 0, 0, time.UTC)}
+
+	useCase := NewLeaveRequestUseCase(mockLeaveRequestRepo, new(mocks.EmployeeRepository), new(mocks.AttendanceRepository), nil, nil, nil, nil)
+	projection, err := useCase.projectAnnualLeave(ctx, employee, time.Date(2024, 10, 15, 0, 0, 0, 0, time.UTC), 2, balanceSimulation{assumeApproved: leaveRequest})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 17.0, projection.CurrentBalance)
+	assert.Equal(t, 2, projection.Months[1].ApprovedDays)
+	assert.Equal(t, 15.0, projection.Months[1].ClosingBalance)
+}
+
+func TestLeaveRequestUseCase_CreateStaffingRuleValidation(t *testing.T) {
+	ctx := tenant.WithCompanyID(context.Background(), 1)
+	companyID, otherCompanyID := uint(1), uint(2)
+	rootEmployeeID := uint(10)
+	maxAbsent := uint(2)
+	percent := 150.0
+
+	tests := []struct {
+		name       string
+		rule       *domain.LeaveStaffingRule
+		setupMocks func(*mocks.EmployeeRepository)
+	}{
+		{
+			name:       "no maximum",
+			rule:       &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeManagerSubtree, RootEmployeeID: &rootEmployeeID},
+			setupMocks: func(*mocks.EmployeeRepository) {},
+		},
+		{
+			name:       "percentage out of range",
+			rule:       &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeManagerSubtree, RootEmployeeID: &rootEmployeeID, MaxAbsentPercent: &percent},
+			setupMocks: func(*mocks.EmployeeRepository) {},
+		},
+		{
+			name:       "manager subtree rule without a root employee",
+			rule:       &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeManagerSubtree, MaxAbsent: &maxAbsent},
+			setupMocks: func(*mocks.EmployeeRepository) {},
+		},
+		{
+			name:       "branch rule without a branch",
+			rule:       &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeBranch, RootEmployeeID: &rootEmployeeID, MaxAbsent: &maxAbsent},
+			setupMocks: func(*mocks.EmployeeRepository) {},
+		},
+		{
+			name: "root employee not found",
+			rule: &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeManagerSubtree, RootEmployeeID: &rootEmployeeID, MaxAbsent: &maxAbsent},
+			setupMocks: func(empRepo *mocks.EmployeeRepository) {
+				empRepo.On("GetByID", ctx, uint(10)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "root employee of another company",
+			rule: &domain.LeaveStaffingRule{Scope: domain.StaffingRuleScopeManagerSubtree, RootEmployeeID: &rootEmployeeID, MaxAbsent: &maxAbsent},
+			setupMocks: func(empRepo *mocks.EmployeeRepository) {
+				empRepo.On("GetByID", ctx, uint(10)).Return(&domain.Employee{ID: 10, CompanyID: &otherCompanyID}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+			tt.setupMocks(mockEmployeeRepo)
+
+			useCase := NewLeaveRequestUseCase(nil, mockEmployeeRepo, nil, mockRuleRepo, nil, nil, nil)
+			result, err := useCase.CreateStaffingRule(ctx, tt.rule)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidStaffingRule)
+			assert.Nil(t, result)
+			mockRuleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("root employee in the company", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+		rule := &domain.LeaveStaffingRule{ID: 3, Scope: domain.StaffingRuleScopeManagerSubtree, RootEmployeeID: &rootEmployeeID, MaxAbsent: &maxAbsent}
+		mockEmployeeRepo.On("GetByID", ctx, uint(10)).Return(&domain.Employee{ID: 10, CompanyID: &companyID}, nil)
+		mockRuleRepo.On("Create", ctx, rule).Return(nil)
+		mockRuleRepo.On("GetByID", ctx, uint(3)).Return(rule, nil)
+
+		useCase := NewLeaveRequestUseCase(nil, mockEmployeeRepo, nil, mockRuleRepo, nil, nil, nil)
+		result, err := useCase.CreateStaffingRule(ctx, rule)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), result.ID)
+		mockRuleRepo.AssertExpectations(t)
+	})
+
+	t.Run("branch rule without a root employee covers the whole branch", func(t *testing.T) {
+		mockEmployeeRepo := new(mocks.EmployeeRepository)
+		mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+		branch := "Surabaya"
+		rule := &domain.LeaveStaffingRule{ID: 4, Scope: domain.StaffingRuleScopeBranch, Branch: &branch, MaxAbsent: &maxAbsent}
+		mockRuleRepo.On("Create", ctx, rule).Return(nil)
+		mockRuleRepo.On("GetByID", ctx, uint(4)).Return(rule, nil)
+
+		useCase := NewLeaveRequestUseCase(nil, mockEmployeeRepo, nil, mockRuleRepo, nil, nil, nil)
+		result, err := useCase.CreateStaffingRule(ctx, rule)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.RootEmployeeID)
+		assert.Equal(t, &branch, result.Branch)
+		mockEmployeeRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		mockRuleRepo.AssertExpectations(t)
+	})
+}
+
+func TestLeaveRequestUseCase_ManageStaffingRuleOfAnotherAdmin(t *testing.T) {
+	ctx := tenant.WithCompanyID(context.Background(), 1)
+	maxAbsent := uint(2)
+	branch := "Surabaya"
+	rule := &domain.LeaveStaffingRule{ID: 5, Scope: domain.StaffingRuleScopeBranch, Branch: &branch, MaxAbsent: &maxAbsent, CreatedBy: 1}
+
+	t.Run("list", func(t *testing.T) {
+		mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+		mockRuleRepo.On("List", ctx).Return([]*domain.LeaveStaffingRule{rule}, nil)
+
+		useCase := NewLeaveRequestUseCase(nil, nil, nil, mockRuleRepo, nil, nil, nil)
+		result, err := useCase.ListStaffingRules(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, uint(5), result[0].ID)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+		updates := &domain.LeaveStaffingRule{Name: "Surabaya floor", Scope: domain.StaffingRuleScopeBranch, Branch: &branch, MaxAbsent: &maxAbsent, CreatedBy: 2}
+		mockRuleRepo.On("GetByID", ctx, uint(5)).Return(rule, nil)
+		mockRuleRepo.On("Update", ctx, updates).Return(nil)
+
+		useCase := NewLeaveRequestUseCase(nil, nil, nil, mockRuleRepo, nil, nil, nil)
+		result, err := useCase.UpdateStaffingRule(ctx, 5, updates)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, uint(5), updates.ID)
+		mockRuleRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockRuleRepo := new(mocks.LeaveStaffingRuleRepository)
+		mockRuleRepo.On("Delete", ctx, uint(5)).Return(nil)
+
+		useCase := NewLeaveRequestUseCase(nil, nil, nil, mockRuleRepo, nil, nil, nil)
+		err := useCase.DeleteStaffingRule(ctx, 5)
+
+		assert.NoError(t, err)
+		mockRuleRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,209 @@
+package leave_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"gorm.io/gorm"
+)
+
+func toLongTermAbsenceResponseDTO(employee *domain.Employee) *dtoleave.LongTermAbsenceResponseDTO {
+	employeeName := employee.FirstName
+	if employee.LastName != nil {
+		employeeName += " " + *employee.LastName
+	}
+
+	responseDTO := &dtoleave.LongTermAbsenceResponseDTO{
+		EmployeeID:        employee.ID,
+		EmployeeName:      employeeName,
+		PositionName:      employee.PositionName,
+		ExcludedFromSeats: employee.AbsenceExcludedFromSeats,
+		IsActive:          employee.IsOnLongTermAbsence(time.Now()),
+	}
+	if employee.AbsenceType != nil {
+		responseDTO.AbsenceType = string(*employee.AbsenceType)
+	}
+	if employee.AbsenceStartDate != nil {
+		responseDTO.StartDate = employee.AbsenceStartDate.Format("2006-01-02")
+	}
+	if employee.AbsenceEndDate != nil {
+		responseDTO.EndDate = employee.AbsenceEndDate.Format("2006-01-02")
+	}
+	return responseDTO
+}
+
+// SetLongTermAbsence records a sabbatical, suspension or extended leave for an employee. Whether
+// the absence is excluded from seat billing follows the leave policy at the time it is recorded.
+func (uc *LeaveRequestUseCase) SetLongTermAbsence(ctx context.Context, absence *domain.Employee) (*dtoleave.LongTermAbsenceResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: SetLongTermAbsence called for employee ID %d", absence.ID)
+
+	if absence.AbsenceType == nil || absence.AbsenceStartDate == nil || absence.AbsenceEndDate == nil {
+		return nil, fmt.Errorf("%w: absence type, start date and end date are required", domain.ErrInvalidLongTermAbsence)
+	}
+	if absence.AbsenceStartDate.After(*absence.AbsenceEndDate) {
+		return nil, fmt.Errorf("%w: start date cannot be after end date", domain.ErrInvalidLongTermAbsence)
+	}
+
+	employee, err := uc.employeeRepo.GetByID(ctx, absence.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", absence.ID, err)
+	}
+
+	policy := uc.getLeavePolicy(ctx, employee.ID)
+
+	employee.AbsenceType = absence.AbsenceType
+	employee.AbsenceStartDate = absence.AbsenceStartDate
+	employee.AbsenceEndDate = absence.AbsenceEndDate
+	employee.AbsenceExcludedFromSeats = policy.ExcludeLongTermAbsenceFromSeats
+
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, fmt.Errorf("failed to save long-term absence: %w", err)
+	}
+
+	log.Printf("LeaveRequestUseCase: Recorded %s for employee ID %d from %s to %s", *employee.AbsenceType, employee.ID,
+		employee.AbsenceStartDate.Format("2006-01-02"), employee.AbsenceEndDate.Format("2006-01-02"))
+	return toLongTermAbsenceResponseDTO(employee), nil
+}
+
+func (uc *LeaveRequestUseCase) ClearLongTermAbsence(ctx context.Context, employeeID uint) error {
+	log.Printf("LeaveRequestUseCase: ClearLongTermAbsence called for employee ID %d", employeeID)
+
+	employee, err := uc.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrEmployeeNotFound
+		}
+		return fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	employee.AbsenceType = nil
+	employee.AbsenceStartDate = nil
+	employee.AbsenceEndDate = nil
+	employee.AbsenceExcludedFromSeats = false
+
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		return fmt.Errorf("failed to clear long-term absence: %w", err)
+	}
+	return nil
+}
+
+// listLongTermAbsentEmployees returns every employee with a long-term absence on record.
+func (uc *LeaveRequestUseCase) listLongTermAbsentEmployees(ctx context.Context) ([]*domain.Employee, error) {
+	var absentEmployees []*domain.Employee
+	err := uc.employeeRepo.ForEachBatch(ctx, map[string]interface{}{"has_long_term_absence": true}, func(employees []*domain.Employee) error {
+		absentEmployees = append(absentEmployees, employees...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list employees on long-term absence: %w", err)
+	}
+	return absentEmployees, nil
+}
+
+func (uc *LeaveRequestUseCase) ListLongTermAbsences(ctx context.Context) ([]*dtoleave.LongTermAbsenceResponseDTO, error) {
+	employees, err := uc.listLongTermAbsentEmployees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	absenceDTOs := make([]*dtoleave.LongTermAbsenceResponseDTO, len(employees))
+	for i, employee := range employees {
+		absenceDTOs[i] = toLongTermAbsenceResponseDTO(employee)
+	}
+	return absenceDTOs, nil
+}
+
+// GetUnpaidDays reports, per employee, the working days between startDate and endDate that were
+// spent on approved unpaid leave or on a long-term absence. Days covered by both count once.
+func (uc *LeaveRequestUseCase) GetUnpaidDays(ctx context.Context, startDate, endDate time.Time) (*dtoleave.UnpaidDaysReportDTO, error) {
+	log.Printf("LeaveRequestUseCase: GetUnpaidDays called from %s to %s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	if startDate.After(endDate) {
+		return nil, fmt.Errorf("%w: start date cannot be after end date", domain.ErrInvalidPeriod)
+	}
+
+	unpaidLeaves, err := uc.listAllLeaveRequests(ctx, map[string]interface{}{
+		"status":         domain.LeaveStatusApproved,
+		"leave_type":     enums.UnpaidLeave,
+		"start_date_lte": endDate,
+		"end_date_gte":   startDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unpaid leave requests: %w", err)
+	}
+
+	absentEmployees, err := uc.listLongTermAbsentEmployees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByEmployee := make(map[uint]*dtoleave.UnpaidDaysResponseDTO)
+	unpaidLeaveDays := make(map[uint]map[string]bool)
+	var items []*dtoleave.UnpaidDaysResponseDTO
+
+	itemFor := func(employee *domain.Employee) *dtoleave.UnpaidDaysResponseDTO {
+		if item, ok := itemsByEmployee[employee.ID]; ok {
+			return item
+		}
+		employeeName := employee.FirstName
+		if employee.LastName != nil {
+			employeeName += " " + *employee.LastName
+		}
+		item := &dtoleave.UnpaidDaysResponseDTO{
+			EmployeeID:   employee.ID,
+			EmployeeName: employeeName,
+			PositionName: employee.PositionName,
+		}
+		itemsByEmployee[employee.ID] = item
+		items = append(items, item)
+		return item
+	}
+
+	for _, lr := range unpaidLeaves {
+		item := itemFor(&lr.Employee)
+		if unpaidLeaveDays[lr.EmployeeID] == nil {
+			unpaidLeaveDays[lr.EmployeeID] = make(map[string]bool)
+		}
+		for _, day := range workingDaysBetween(laterOf(lr.StartDate, startDate), earlierOf(lr.EndDate, endDate)) {
+			if !unpaidLeaveDays[lr.EmployeeID][day] {
+				unpaidLeaveDays[lr.EmployeeID][day] = true
+				item.UnpaidLeaveDays++
+			}
+		}
+	}
+
+	for _, employee := range absentEmployees {
+		if employee.AbsenceStartDate == nil || employee.AbsenceEndDate == nil {
+			continue
+		}
+		var absenceDays int
+		for _, day := range workingDaysBetween(laterOf(*employee.AbsenceStartDate, startDate), earlierOf(*employee.AbsenceEndDate, endDate)) {
+			if !unpaidLeaveDays[employee.ID][day] {
+				absenceDays++
+			}
+		}
+		if absenceDays > 0 {
+			itemFor(employee).LongTermAbsenceDays = absenceDays
+		}
+	}
+
+	for _, item := range items {
+		item.TotalUnpaidDays = item.UnpaidLeaveDays + item.LongTermAbsenceDays
+	}
+
+	return &dtoleave.UnpaidDaysReportDTO{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Items:     items,
+	}, nil
+}
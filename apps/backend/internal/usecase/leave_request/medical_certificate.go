@@ -0,0 +1,258 @@
+package leave_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	storage "github.com/supabase-community/storage-go"
+)
+
+// attachmentURLExpirySeconds is how long a signed attachment URL stays valid.
+const attachmentURLExpirySeconds = 60 * 60
+
+// attachmentURL returns a signed, expiring URL for an attachment stored in the leave request bucket.
+func (uc *LeaveRequestUseCase) attachmentURL(path *string) *string {
+	if path == nil || *path == "" || uc.supabaseClient == nil {
+		return nil
+	}
+
+	signed, err := uc.supabaseClient.Storage.CreateSignedUrl(bucketNameAttachments, *path, attachmentURLExpirySeconds)
+	if err != nil {
+		log.Printf("Warning: failed to sign attachment URL for %s: %v", *path, err)
+		return nil
+	}
+	return &signed.SignedURL
+}
+
+// uploadAttachment validates and uploads a file to the leave request bucket and returns its path.
+func (uc *LeaveRequestUseCase) uploadAttachment(employee *domain.Employee, file *multipart.FileHeader) (string, error) {
+	if err := uc.validateAttachmentFile(file); err != nil {
+		return "", err
+	}
+
+	fileName := uc.generateFileName(employee, file.Filename)
+
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open attachment file: %w", err)
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close attachment file: %v", closeErr)
+		}
+	}()
+
+	_, err = uc.supabaseClient.Storage.UploadFile(bucketNameAttachments, fileName, src, storage.FileOptions{
+		ContentType: &[]string{uc.getContentTypeFromExtension(file.Filename)}[0],
+		Upsert:      &[]bool{true}[0],
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	return fileName, nil
+}
+
+// getLeavePolicy returns the leave policy covering the employee, or the defaults when their
+// organisation has not configured one.
+func (uc *LeaveRequestUseCase) getLeavePolicy(ctx context.Context, employeeID uint) *domain.LeavePolicy {
+	if uc.leavePolicyRepo == nil {
+		return domain.DefaultLeavePolicy()
+	}
+
+	policy, err := uc.leavePolicyRepo.GetForEmployee(ctx, employeeID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrLeavePolicyNotFound) {
+			log.Printf("Warning: failed to get leave policy for employee ID %d, using defaults: %v", employeeID, err)
+		}
+		return domain.DefaultLeavePolicy()
+	}
+	return policy
+}
+
+// applyCertificateRequirement sets the certificate state of a sick leave request. Requests longer
+// than the policy threshold need a certificate; without one they stay pending until the due date.
+func (uc *LeaveRequestUseCase) applyCertificateRequirement(ctx context.Context, leaveRequest *domain.LeaveRequest) {
+	leaveRequest.CertificateStatus = domain.CertificateNotRequired
+	leaveRequest.CertificateDueDate = nil
+
+	if leaveRequest.LeaveType != enums.SickLeave {
+		return
+	}
+
+	policy := uc.getLeavePolicy(ctx, leaveRequest.EmployeeID)
+	days := int(leaveRequest.EndDate.Sub(leaveRequest.StartDate).Hours()/24) + 1
+	if !policy.RequiresSickCertificate(days) {
+		return
+	}
+
+	if leaveRequest.Attachment != nil && *leaveRequest.Attachment != "" {
+		leaveRequest.CertificateStatus = domain.CertificateSubmitted
+		return
+	}
+
+	dueDate := leaveRequest.EndDate.AddDate(0, 0, int(policy.SickCertificateGraceDays))
+	leaveRequest.CertificateStatus = domain.CertificatePending
+	leaveRequest.CertificateDueDate = &dueDate
+}
+
+// UploadCertificate attaches a medical certificate to the employee's own sick leave request.
+func (uc *LeaveRequestUseCase) UploadCertificate(ctx context.Context, id uint, employeeID uint, file *multipart.FileHeader) (*dtoleave.LeaveRequestResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: UploadCertificate called for ID %d", id)
+
+	leaveRequest, err := uc.leaveRequestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave request: %w", err)
+	}
+	if leaveRequest.EmployeeID != employeeID {
+		return nil, domain.ErrLeaveRequestNotFound
+	}
+	if leaveRequest.LeaveType != enums.SickLeave || leaveRequest.Status == domain.LeaveStatusRejected {
+		return nil, domain.ErrCertificateNotExpected
+	}
+	if leaveRequest.CertificateStatus == domain.CertificateMissing {
+		return nil, domain.ErrCertificateDeadlinePast
+	}
+	if uc.supabaseClient == nil {
+		return nil, fmt.Errorf("file storage is not configured")
+	}
+
+	fileName, err := uc.uploadAttachment(&leaveRequest.Employee, file)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAttachment := leaveRequest.Attachment
+	leaveRequest.Attachment = &fileName
+	if leaveRequest.CertificateStatus == domain.CertificatePending {
+		leaveRequest.CertificateStatus = domain.CertificateSubmitted
+	}
+
+	if err := uc.leaveRequestRepo.Update(ctx, leaveRequest); err != nil {
+		_, _ = uc.supabaseClient.Storage.RemoveFile(bucketNameAttachments, []string{fileName})
+		return nil, fmt.Errorf("failed to update leave request: %w", err)
+	}
+
+	if oldAttachment != nil && *oldAttachment != "" {
+		_, _ = uc.supabaseClient.Storage.RemoveFile(bucketNameAttachments, []string{*oldAttachment})
+	}
+
+	updatedLeaveRequest, err := uc.leaveRequestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve updated leave request: %w", err)
+	}
+	log.Printf("LeaveRequestUseCase: Medical certificate uploaded for leave request ID %d", id)
+	return uc.toLeaveRequestDetailDTO(updatedLeaveRequest), nil
+}
+
+// sickLeaveFallbackType returns the leave type a sick leave without a certificate is converted to:
+// the fallback type of the employee's leave policy, or unpaid leave when that is annual leave and
+// the employee has too little annual leave left to cover it.
+func (uc *LeaveRequestUseCase) sickLeaveFallbackType(ctx context.Context, leaveRequest *domain.LeaveRequest) (enums.LeaveType, error) {
+	policy := uc.getLeavePolicy(ctx, leaveRequest.EmployeeID)
+	if policy.SickCertificateFallbackType != enums.AnnualLeave {
+		return policy.SickCertificateFallbackType, nil
+	}
+
+	remainingDays, err := uc.remainingAnnualLeave(ctx, &leaveRequest.Employee, policy, leaveRequest.StartDate)
+	if err != nil {
+		return "", err
+	}
+	if remainingDays < float64(len(workingDaysBetween(leaveRequest.StartDate, leaveRequest.EndDate))) {
+		return enums.UnpaidLeave, nil
+	}
+	return enums.AnnualLeave, nil
+}
+
+// ProcessMissingCertificates converts every sick leave whose certificate is past its due date
+// into the fallback leave type of the employee's leave policy.
+func (uc *LeaveRequestUseCase) ProcessMissingCertificates(ctx context.Context) (*dtoleave.CertificateConversionResultDTO, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	log.Printf("LeaveRequestUseCase: Processing missing medical certificates as of %s", today.Format("2006-01-02"))
+
+	overdue, err := uc.leaveRequestRepo.ListCertificatesOverdue(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue certificates: %w", err)
+	}
+
+	result := &dtoleave.CertificateConversionResultDTO{
+		ProcessedDate: today.Format("2006-01-02"),
+		Checked:       len(overdue),
+	}
+
+	for _, leaveRequest := range overdue {
+		fallbackType, err := uc.sickLeaveFallbackType(ctx, leaveRequest)
+		if err != nil {
+			log.Printf("Warning: failed to choose the fallback type of leave request ID %d: %v", leaveRequest.ID, err)
+			result.Failed++
+			continue
+		}
+
+		originalType := leaveRequest.LeaveType
+		note := fmt.Sprintf("Converted to %s: medical certificate not received by %s",
+			fallbackType, leaveRequest.CertificateDueDate.Format("2006-01-02"))
+		if leaveRequest.AdminNote != nil && *leaveRequest.AdminNote != "" {
+			note = *leaveRequest.AdminNote + "; " + note
+		}
+
+		leaveRequest.OriginalLeaveType = &originalType
+		leaveRequest.LeaveType = fallbackType
+		leaveRequest.CertificateStatus = domain.CertificateMissing
+		leaveRequest.AdminNote = &note
+
+		if err := uc.leaveRequestRepo.Update(ctx, leaveRequest); err != nil {
+			log.Printf("Warning: failed to convert leave request ID %d: %v", leaveRequest.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Converted++
+	}
+
+	log.Printf("LeaveRequestUseCase: Converted %d of %d leave requests with missing certificates", result.Converted, result.Checked)
+	return result, nil
+}
+
+func toLeavePolicyResponseDTO(policy *domain.LeavePolicy, isDefault bool) *dtoleave.LeavePolicyResponseDTO {
+	return &dtoleave.LeavePolicyResponseDTO{
+		SickCertificateRequiredAfterDays: policy.SickCertificateRequiredAfterDays,
+		SickCertificateGraceDays:         policy.SickCertificateGraceDays,
+		SickCertificateFallbackType:      string(policy.SickCertificateFallbackType),
+		ExcludeLongTermAbsenceFromSeats:  policy.ExcludeLongTermAbsenceFromSeats,
+		AnnualLeaveAccruesMonthly:        policy.AnnualLeaveAccruesMonthly,
+		AnnualLeaveCarryOverCap:          policy.AnnualLeaveCarryOverCap,
+		EncashmentWorkingDaysPerMonth:    policy.EncashmentWorkingDaysPerMonth,
+		IsDefault:                        isDefault,
+	}
+}
+
+func (uc *LeaveRequestUseCase) GetLeavePolicy(ctx context.Context, createdBy uint) (*dtoleave.LeavePolicyResponseDTO, error) {
+	policy, err := uc.leavePolicyRepo.GetByCreator(ctx, createdBy)
+	if err != nil {
+		if errors.Is(err, domain.ErrLeavePolicyNotFound) {
+			return toLeavePolicyResponseDTO(domain.DefaultLeavePolicy(), true), nil
+		}
+		return nil, fmt.Errorf("failed to get leave policy: %w", err)
+	}
+	return toLeavePolicyResponseDTO(policy, false), nil
+}
+
+func (uc *LeaveRequestUseCase) UpdateLeavePolicy(ctx context.Context, policy *domain.LeavePolicy) (*dtoleave.LeavePolicyResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: UpdateLeavePolicy called by user ID %d", policy.CreatedBy)
+
+	if policy.SickCertificateFallbackType != enums.AnnualLeave && policy.SickCertificateFallbackType != enums.UnpaidLeave {
+		return nil, fmt.Errorf("invalid fallback leave type: %s", policy.SickCertificateFallbackType)
+	}
+
+	policy.UpdatedAt = time.Now()
+	if err := uc.leavePolicyRepo.Upsert(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save leave policy: %w", err)
+	}
+	return toLeavePolicyResponseDTO(policy, false), nil
+}
@@ -0,0 +1,260 @@
+package leave_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoleave "github.com/SukaMajuu/hris/apps/backend/domain/dto/leave_request"
+	"github.com/SukaMajuu/hris/apps/backend/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+// StaffingRuleViolationError is returned when approving a leave request would breach one or
+// more staffing rules and the approver did not ask to override them.
+type StaffingRuleViolationError struct {
+	Conflicts []*dtoleave.StaffingConflictDTO
+}
+
+func (e *StaffingRuleViolationError) Error() string {
+	return fmt.Sprintf("%s on %d day(s)", domain.ErrStaffingRuleBreached.Error(), len(e.Conflicts))
+}
+
+func (e *StaffingRuleViolationError) Unwrap() error {
+	return domain.ErrStaffingRuleBreached
+}
+
+func toStaffingRuleResponseDTO(rule *domain.LeaveStaffingRule) *dtoleave.StaffingRuleResponseDTO {
+	var rootEmployeeName string
+	if rule.RootEmployee != nil {
+		rootEmployeeName = rule.RootEmployee.FirstName
+		if rule.RootEmployee.LastName != nil {
+			rootEmployeeName += " " + *rule.RootEmployee.LastName
+		}
+	}
+
+	return &dtoleave.StaffingRuleResponseDTO{
+		ID:               rule.ID,
+		Name:             rule.Name,
+		Scope:            string(rule.Scope),
+		RootEmployeeID:   rule.RootEmployeeID,
+		RootEmployeeName: rootEmployeeName,
+		Branch:           rule.Branch,
+		MaxAbsent:        rule.MaxAbsent,
+		MaxAbsentPercent: rule.MaxAbsentPercent,
+		IsActive:         rule.IsActive,
+		CreatedAt:        rule.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        rule.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func (uc *LeaveRequestUseCase) validateStaffingRule(ctx context.Context, rule *domain.LeaveStaffingRule) error {
+	if rule.MaxAbsent == nil && rule.MaxAbsentPercent == nil {
+		return fmt.Errorf("%w: a maximum number or percentage of absences is required", domain.ErrInvalidStaffingRule)
+	}
+	if rule.MaxAbsentPercent != nil && (*rule.MaxAbsentPercent <= 0 || *rule.MaxAbsentPercent > 100) {
+		return fmt.Errorf("%w: max absent percent must be between 0 and 100", domain.ErrInvalidStaffingRule)
+	}
+	if rule.Scope == domain.StaffingRuleScopeBranch && (rule.Branch == nil || *rule.Branch == "") {
+		return fmt.Errorf("%w: branch is required for a branch staffing rule", domain.ErrInvalidStaffingRule)
+	}
+	if rule.Scope == domain.StaffingRuleScopeManagerSubtree {
+		if rule.RootEmployeeID == nil {
+			return fmt.Errorf("%w: root employee is required for a manager subtree staffing rule", domain.ErrInvalidStaffingRule)
+		}
+		rule.Branch = nil
+	}
+	if rule.RootEmployeeID == nil {
+		return nil
+	}
+
+	// The employee lookup is scoped to the caller's company, so an employee of another
+	// company is not found.
+	rootEmployeeID := *rule.RootEmployeeID
+	rootEmployee, err := uc.employeeRepo.GetByID(ctx, rootEmployeeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: root employee %d is not in your organization", domain.ErrInvalidStaffingRule, rootEmployeeID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to validate root employee ID %d: %w", rootEmployeeID, err)
+	}
+	if companyID, ok := tenant.CompanyID(ctx); ok && (rootEmployee.CompanyID == nil || *rootEmployee.CompanyID != companyID) {
+		return fmt.Errorf("%w: root employee %d is not in your organization", domain.ErrInvalidStaffingRule, rootEmployeeID)
+	}
+	return nil
+}
+
+func (uc *LeaveRequestUseCase) CreateStaffingRule(ctx context.Context, rule *domain.LeaveStaffingRule) (*dtoleave.StaffingRuleResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: CreateStaffingRule called by user ID %d", rule.CreatedBy)
+
+	if err := uc.validateStaffingRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := uc.staffingRuleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create staffing rule: %w", err)
+	}
+
+	createdRule, err := uc.staffingRuleRepo.GetByID(ctx, rule.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve created staffing rule: %w", err)
+	}
+	return toStaffingRuleResponseDTO(createdRule), nil
+}
+
+// ListStaffingRules returns every staffing rule of the caller's company, whichever admin created it.
+func (uc *LeaveRequestUseCase) ListStaffingRules(ctx context.Context) ([]*dtoleave.StaffingRuleResponseDTO, error) {
+	rules, err := uc.staffingRuleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staffing rules: %w", err)
+	}
+
+	ruleDTOs := make([]*dtoleave.StaffingRuleResponseDTO, len(rules))
+	for i, rule := range rules {
+		ruleDTOs[i] = toStaffingRuleResponseDTO(rule)
+	}
+	return ruleDTOs, nil
+}
+
+func (uc *LeaveRequestUseCase) UpdateStaffingRule(ctx context.Context, id uint, updates *domain.LeaveStaffingRule) (*dtoleave.StaffingRuleResponseDTO, error) {
+	log.Printf("LeaveRequestUseCase: UpdateStaffingRule called for ID %d", id)
+
+	existingRule, err := uc.staffingRuleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staffing rule: %w", err)
+	}
+
+	updates.ID = existingRule.ID
+	if err := uc.validateStaffingRule(ctx, updates); err != nil {
+		return nil, err
+	}
+
+	if err := uc.staffingRuleRepo.Update(ctx, updates); err != nil {
+		return nil, fmt.Errorf("failed to update staffing rule: %w", err)
+	}
+
+	updatedRule, err := uc.staffingRuleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve updated staffing rule: %w", err)
+	}
+	return toStaffingRuleResponseDTO(updatedRule), nil
+}
+
+func (uc *LeaveRequestUseCase) DeleteStaffingRule(ctx context.Context, id uint) error {
+	log.Printf("LeaveRequestUseCase: DeleteStaffingRule called for ID %d", id)
+
+	if err := uc.staffingRuleRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete staffing rule: %w", err)
+	}
+	return nil
+}
+
+// evaluateStaffingRules checks every staffing rule covering the employee and returns one
+// conflict per rule and working day on which approving the leave request would exceed the
+// allowed number of absences. Only approved leave of colleagues counts towards the limit.
+func (uc *LeaveRequestUseCase) evaluateStaffingRules(ctx context.Context, leaveRequest *domain.LeaveRequest, employee *domain.Employee) ([]*dtoleave.StaffingConflictDTO, error) {
+	if uc.staffingRuleRepo == nil || employee == nil {
+		return nil, nil
+	}
+
+	rules, err := uc.staffingRuleRepo.ListApplicableToEmployee(ctx, employee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staffing rules for employee %d: %w", employee.ID, err)
+	}
+
+	var conflicts []*dtoleave.StaffingConflictDTO
+	for _, rule := range rules {
+		headcount, err := uc.staffingRuleRepo.CountScopeEmployees(ctx, rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count employees for staffing rule %d: %w", rule.ID, err)
+		}
+
+		allowed := rule.AllowedAbsences(headcount)
+		if allowed < 0 {
+			continue
+		}
+
+		scopeLeaves, err := uc.staffingRuleRepo.ListScopeLeaves(ctx, rule, leaveRequest.StartDate, leaveRequest.EndDate,
+			[]domain.LeaveStatus{domain.LeaveStatusApproved})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leave requests for staffing rule %d: %w", rule.ID, err)
+		}
+
+		for day := leaveRequest.StartDate; !day.After(leaveRequest.EndDate); day = day.AddDate(0, 0, 1) {
+			if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+				continue
+			}
+
+			colleagues := absentColleaguesOn(day, scopeLeaves, leaveRequest)
+			absentCount := int64(len(colleagues)) + 1
+			if absentCount <= allowed {
+				continue
+			}
+
+			conflicts = append(conflicts, &dtoleave.StaffingConflictDTO{
+				RuleID:          rule.ID,
+				RuleName:        rule.Name,
+				Date:            day.Format("2006-01-02"),
+				Headcount:       headcount,
+				AllowedAbsences: allowed,
+				AbsentCount:     absentCount,
+				Colleagues:      colleagues,
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+func absentColleaguesOn(day time.Time, leaves []*domain.LeaveRequest, current *domain.LeaveRequest) []*dtoleave.ConflictingColleagueDTO {
+	date := day.Format("2006-01-02")
+	seen := make(map[uint]bool)
+
+	var colleagues []*dtoleave.ConflictingColleagueDTO
+	for _, lr := range leaves {
+		if lr.EmployeeID == current.EmployeeID || seen[lr.EmployeeID] {
+			continue
+		}
+		if date < lr.StartDate.Format("2006-01-02") || date > lr.EndDate.Format("2006-01-02") {
+			continue
+		}
+		seen[lr.EmployeeID] = true
+
+		employeeName := lr.Employee.FirstName
+		if lr.Employee.LastName != nil {
+			employeeName += " " + *lr.Employee.LastName
+		}
+
+		colleagues = append(colleagues, &dtoleave.ConflictingColleagueDTO{
+			EmployeeID:     lr.EmployeeID,
+			EmployeeName:   employeeName,
+			PositionName:   lr.Employee.PositionName,
+			LeaveRequestID: lr.ID,
+			LeaveType:      string(lr.LeaveType),
+			StartDate:      lr.StartDate.Format("2006-01-02"),
+			EndDate:        lr.EndDate.Format("2006-01-02"),
+		})
+	}
+	return colleagues
+}
+
+// checkStaffingRulesForApproval blocks an approval that would breach a staffing rule unless
+// the approver explicitly overrides it. Overridden conflicts are still returned for display.
+func (uc *LeaveRequestUseCase) checkStaffingRulesForApproval(ctx context.Context, leaveRequest *domain.LeaveRequest, employee *domain.Employee, override bool) ([]*dtoleave.StaffingConflictDTO, error) {
+	conflicts, err := uc.evaluateStaffingRules(ctx, leaveRequest, employee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate staffing rules: %w", err)
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	if !override {
+		return nil, &StaffingRuleViolationError{Conflicts: conflicts}
+	}
+
+	log.Printf("LeaveRequestUseCase: Staffing rules overridden for employee ID %d on %d day(s)", leaveRequest.EmployeeID, len(conflicts))
+	return conflicts, nil
+}
@@ -141,3 +141,8 @@ func (m *AuthRepository) UpdateUserPassword(ctx context.Context, userID uint, ac
 	args := m.Called(ctx, userID, accessToken, oldPassword, newPassword)
 	return args.Error(0)
 }
+
+func (m *AuthRepository) DeactivateUser(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type CompanyRepository struct {
+	mock.Mock
+}
+
+func (m *CompanyRepository) Create(ctx context.Context, company *domain.Company) error {
+	args := m.Called(ctx, company)
+	return args.Error(0)
+}
+
+func (m *CompanyRepository) GetByID(ctx context.Context, id uint) (*domain.Company, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Company), args.Error(1)
+}
+
+func (m *CompanyRepository) GetByOwnerUserID(ctx context.Context, ownerUserID uint) (*domain.Company, error) {
+	args := m.Called(ctx, ownerUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Company), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type CustomFieldRepository struct {
+	mock.Mock
+}
+
+func (m *CustomFieldRepository) Create(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	args := m.Called(ctx, definition)
+	return args.Error(0)
+}
+
+func (m *CustomFieldRepository) GetByID(ctx context.Context, id uint) (*domain.CustomFieldDefinition, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CustomFieldDefinition), args.Error(1)
+}
+
+func (m *CustomFieldRepository) GetByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CustomFieldDefinition), args.Error(1)
+}
+
+func (m *CustomFieldRepository) List(ctx context.Context) ([]*domain.CustomFieldDefinition, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CustomFieldDefinition), args.Error(1)
+}
+
+func (m *CustomFieldRepository) Update(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	args := m.Called(ctx, definition)
+	return args.Error(0)
+}
+
+func (m *CustomFieldRepository) Delete(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	args := m.Called(ctx, definition)
+	return args.Error(0)
+}
@@ -24,8 +24,8 @@ func (m *DocumentRepository) GetByID(ctx context.Context, id uint) (*domain.Docu
 	return args.Get(0).(*domain.Document), args.Error(1)
 }
 
-func (m *DocumentRepository) GetByEmployeeID(ctx context.Context, employeeID uint) ([]*domain.Document, error) {
-	args := m.Called(ctx, employeeID)
+func (m *DocumentRepository) GetByEmployeeID(ctx context.Context, employeeID uint, query *domain.ListQuery) ([]*domain.Document, error) {
+	args := m.Called(ctx, employeeID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
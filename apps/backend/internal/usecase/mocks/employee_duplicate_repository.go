@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmployeeDuplicateRepository struct {
+	mock.Mock
+}
+
+func (m *EmployeeDuplicateRepository) ListCandidates(ctx context.Context) ([]*domain.EmployeeDuplicate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmployeeDuplicate), args.Error(1)
+}
+
+func (m *EmployeeDuplicateRepository) Save(ctx context.Context, duplicate *domain.EmployeeDuplicate) error {
+	args := m.Called(ctx, duplicate)
+	return args.Error(0)
+}
+
+func (m *EmployeeDuplicateRepository) DeletePendingDetectedBefore(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func (m *EmployeeDuplicateRepository) GetByID(ctx context.Context, id uint) (*domain.EmployeeDuplicate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmployeeDuplicate), args.Error(1)
+}
+
+func (m *EmployeeDuplicateRepository) List(ctx context.Context, status domain.EmployeeDuplicateStatus, pagination domain.PaginationParams) ([]*domain.EmployeeDuplicate, int64, error) {
+	args := m.Called(ctx, status, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.EmployeeDuplicate), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *EmployeeDuplicateRepository) Update(ctx context.Context, duplicate *domain.EmployeeDuplicate) error {
+	args := m.Called(ctx, duplicate)
+	return args.Error(0)
+}
+
+func (m *EmployeeDuplicateRepository) Merge(ctx context.Context, survivor *domain.Employee, event *domain.EmploymentEvent, duplicateID, retiredUserID uint) error {
+	args := m.Called(ctx, survivor, event, duplicateID, retiredUserID)
+	return args.Error(0)
+}
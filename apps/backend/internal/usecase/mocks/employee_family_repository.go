@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/domain/enums"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmployeeFamilyRepository struct {
+	mock.Mock
+}
+
+func (m *EmployeeFamilyRepository) ListFamilyMembers(ctx context.Context, employeeID uint) ([]*domain.FamilyMember, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyMember), args.Error(1)
+}
+
+func (m *EmployeeFamilyRepository) CountTaxDependants(ctx context.Context, employeeID uint) (bool, int, error) {
+	args := m.Called(ctx, employeeID)
+	return args.Bool(0), args.Int(1), args.Error(2)
+}
+
+func (m *EmployeeFamilyRepository) ReplaceFamilyMembers(ctx context.Context, employeeID uint, members []*domain.FamilyMember, taxStatus *enums.TaxStatus) error {
+	args := m.Called(ctx, employeeID, members, taxStatus)
+	return args.Error(0)
+}
+
+func (m *EmployeeFamilyRepository) ListEmergencyContacts(ctx context.Context, employeeID uint) ([]*domain.EmergencyContact, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmergencyContact), args.Error(1)
+}
+
+func (m *EmployeeFamilyRepository) ReplaceEmergencyContacts(ctx context.Context, employeeID uint, contacts []*domain.EmergencyContact) error {
+	args := m.Called(ctx, employeeID, contacts)
+	return args.Error(0)
+}
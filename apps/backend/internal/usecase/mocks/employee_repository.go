@@ -54,6 +54,16 @@ func (m *EmployeeRepository) Update(ctx context.Context, employee *domain.Employ
 	return args.Error(0)
 }
 
+func (m *EmployeeRepository) BulkUpdate(ctx context.Context, employees []*domain.Employee, events []*domain.EmploymentEvent) error {
+	args := m.Called(ctx, employees, events)
+	return args.Error(0)
+}
+
+func (m *EmployeeRepository) ApplyEmploymentEvent(ctx context.Context, employee *domain.Employee, event *domain.EmploymentEvent) error {
+	args := m.Called(ctx, employee, event)
+	return args.Error(0)
+}
+
 func (m *EmployeeRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -76,6 +86,14 @@ func (m *EmployeeRepository) List(ctx context.Context, filters map[string]interf
 	return employees, totalItems, args.Error(2)
 }
 
+func (m *EmployeeRepository) Search(ctx context.Context, query string, scope domain.EmployeeSearchScope, limit int) ([]*domain.EmployeeSearchHit, error) {
+	args := m.Called(ctx, query, scope, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmployeeSearchHit), args.Error(1)
+}
+
 func (m *EmployeeRepository) GetStatisticsWithTrendsByManager(ctx context.Context, managerID uint) (
 	totalEmployees, newEmployees, activeEmployees, resignedEmployees,
 	permanentEmployees, contractEmployees, freelanceEmployees int64,
@@ -100,6 +118,46 @@ func (m *EmployeeRepository) GetStatisticsWithTrendsByManagerAndMonth(ctx contex
 		args.Get(8).(float64), args.Get(9).(float64), args.Error(10)
 }
 
+func (m *EmployeeRepository) GetReportingLineIDs(ctx context.Context, managerID uint) ([]uint, error) {
+	args := m.Called(ctx, managerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// ForEachBatch passes the employees the expectation returns to fn as a single batch.
+func (m *EmployeeRepository) ForEachBatch(ctx context.Context, filters map[string]interface{}, fn func(employees []*domain.Employee) error) error {
+	args := m.Called(ctx, filters)
+	if employees, ok := args.Get(0).([]*domain.Employee); ok && len(employees) > 0 {
+		if err := fn(employees); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *EmployeeRepository) ListActiveByIDs(ctx context.Context, ids []uint) ([]*domain.Employee, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Employee), args.Error(1)
+}
+
+func (m *EmployeeRepository) ListEventDigestSubscribers(ctx context.Context) ([]*domain.Employee, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Employee), args.Error(1)
+}
+
+func (m *EmployeeRepository) UpdateManager(ctx context.Context, employeeIDs []uint, managerID uint) error {
+	args := m.Called(ctx, employeeIDs, managerID)
+	return args.Error(0)
+}
+
 func (m *EmployeeRepository) GetHireDateRange(ctx context.Context, managerID uint) (earliestHireDate, latestHireDate *time.Time, err error) {
 	args := m.Called(ctx, managerID)
 	earliest := args.Get(0)
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmploymentContractRepository struct {
+	mock.Mock
+}
+
+func (m *EmploymentContractRepository) Create(ctx context.Context, contract *domain.EmploymentContract) error {
+	args := m.Called(ctx, contract)
+	return args.Error(0)
+}
+
+func (m *EmploymentContractRepository) Update(ctx context.Context, contract *domain.EmploymentContract) error {
+	args := m.Called(ctx, contract)
+	return args.Error(0)
+}
+
+func (m *EmploymentContractRepository) Replace(ctx context.Context, active, next *domain.EmploymentContract, event *domain.EmploymentEvent) error {
+	args := m.Called(ctx, active, next, event)
+	return args.Error(0)
+}
+
+func (m *EmploymentContractRepository) GetActiveByEmployee(ctx context.Context, employeeID uint) (*domain.EmploymentContract, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmploymentContract), args.Error(1)
+}
+
+func (m *EmploymentContractRepository) ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentContract, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmploymentContract), args.Error(1)
+}
+
+func (m *EmploymentContractRepository) ListActiveEndingBefore(ctx context.Context, endDate time.Time) ([]*domain.EmploymentContract, error) {
+	args := m.Called(ctx, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmploymentContract), args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmploymentEventRepository struct {
+	mock.Mock
+}
+
+func (m *EmploymentEventRepository) Create(ctx context.Context, event *domain.EmploymentEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *EmploymentEventRepository) ListByEmployee(ctx context.Context, employeeID uint) ([]*domain.EmploymentEvent, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmploymentEvent), args.Error(1)
+}
+
+func (m *EmploymentEventRepository) ListByPeriod(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmploymentEvent, error) {
+	args := m.Called(ctx, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmploymentEvent), args.Error(1)
+}
+
+func (m *EmploymentEventRepository) ListDue(ctx context.Context, date time.Time) ([]*domain.EmploymentEvent, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmploymentEvent), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmploymentNotifier struct {
+	mock.Mock
+}
+
+func (m *EmploymentNotifier) SendContractExpiryReminder(ctx context.Context, recipient *domain.User, contract *domain.EmploymentContract, daysLeft int) error {
+	args := m.Called(ctx, recipient, contract, daysLeft)
+	return args.Error(0)
+}
+
+func (m *EmploymentNotifier) SendOnboardingTaskReminder(ctx context.Context, recipient *domain.User, tasks []*domain.OnboardingTask) error {
+	args := m.Called(ctx, recipient, tasks)
+	return args.Error(0)
+}
+
+func (m *EmploymentNotifier) SendProbationReviewReminder(ctx context.Context, recipient *domain.User, probation *domain.Probation, daysLeft int) error {
+	args := m.Called(ctx, recipient, probation, daysLeft)
+	return args.Error(0)
+}
+
+func (m *EmploymentNotifier) SendEmployeeEventDigest(ctx context.Context, recipient *domain.User, events []*domain.EmployeeEvent) error {
+	args := m.Called(ctx, recipient, events)
+	return args.Error(0)
+}
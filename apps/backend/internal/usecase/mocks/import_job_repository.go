@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ImportJobRepository struct {
+	mock.Mock
+}
+
+func (m *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob, rows []*domain.ImportJobRow) error {
+	args := m.Called(ctx, job, rows)
+	return args.Error(0)
+}
+
+func (m *ImportJobRepository) GetByID(ctx context.Context, id uint) (*domain.ImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *ImportJobRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *ImportJobRepository) List(ctx context.Context, pagination domain.PaginationParams) ([]*domain.ImportJob, int64, error) {
+	args := m.Called(ctx, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.ImportJob), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ImportJobRepository) ListUnfinished(ctx context.Context) ([]*domain.ImportJob, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImportJob), args.Error(1)
+}
+
+func (m *ImportJobRepository) Claim(ctx context.Context, job *domain.ImportJob, leaseExpiresAt time.Time) (bool, error) {
+	args := m.Called(ctx, job, leaseExpiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ImportJobRepository) ListRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImportJobRow), args.Error(1)
+}
+
+func (m *ImportJobRepository) ListPendingRows(ctx context.Context, jobID uint) ([]*domain.ImportJobRow, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImportJobRow), args.Error(1)
+}
+
+func (m *ImportJobRepository) UpdateRow(ctx context.Context, row *domain.ImportJobRow) error {
+	args := m.Called(ctx, row)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ImportMappingRepository struct {
+	mock.Mock
+}
+
+func (m *ImportMappingRepository) Create(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *ImportMappingRepository) GetByID(ctx context.Context, id uint) (*domain.ImportMappingProfile, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportMappingProfile), args.Error(1)
+}
+
+func (m *ImportMappingRepository) GetByName(ctx context.Context, name string) (*domain.ImportMappingProfile, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportMappingProfile), args.Error(1)
+}
+
+func (m *ImportMappingRepository) List(ctx context.Context) ([]*domain.ImportMappingProfile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImportMappingProfile), args.Error(1)
+}
+
+func (m *ImportMappingRepository) Update(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *ImportMappingRepository) Delete(ctx context.Context, profile *domain.ImportMappingProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// LeaveEncashmentRepository is a mock implementation of interfaces.LeaveEncashmentRepository
+type LeaveEncashmentRepository struct {
+	mock.Mock
+}
+
+func (m *LeaveEncashmentRepository) Create(ctx context.Context, encashment *domain.LeaveEncashment) error {
+	args := m.Called(ctx, encashment)
+	return args.Error(0)
+}
+
+func (m *LeaveEncashmentRepository) GetByID(ctx context.Context, id uint) (*domain.LeaveEncashment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveEncashment), args.Error(1)
+}
+
+func (m *LeaveEncashmentRepository) Update(ctx context.Context, encashment *domain.LeaveEncashment) error {
+	args := m.Called(ctx, encashment)
+	return args.Error(0)
+}
+
+func (m *LeaveEncashmentRepository) List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.LeaveEncashment, int64, error) {
+	args := m.Called(ctx, filters, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.LeaveEncashment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *LeaveEncashmentRepository) Exists(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (bool, error) {
+	args := m.Called(ctx, employeeID, year, reason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *LeaveEncashmentRepository) GetByPeriod(ctx context.Context, employeeID uint, year int, reason domain.EncashmentReason) (*domain.LeaveEncashment, error) {
+	args := m.Called(ctx, employeeID, year, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveEncashment), args.Error(1)
+}
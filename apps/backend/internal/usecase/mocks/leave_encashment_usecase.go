@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type LeaveEncashmentUseCase struct {
+	mock.Mock
+}
+
+func (m *LeaveEncashmentUseCase) CreateResignationEncashment(ctx context.Context, employee *domain.Employee) error {
+	args := m.Called(ctx, employee)
+	return args.Error(0)
+}
+
+func (m *LeaveEncashmentUseCase) PreviewResignationEncashment(ctx context.Context, employee *domain.Employee, lastWorkingDay time.Time) (*domain.LeaveEncashment, error) {
+	args := m.Called(ctx, employee, lastWorkingDay)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveEncashment), args.Error(1)
+}
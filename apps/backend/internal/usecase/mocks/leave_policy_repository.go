@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// LeavePolicyRepository is a mock implementation of interfaces.LeavePolicyRepository
+type LeavePolicyRepository struct {
+	mock.Mock
+}
+
+func (m *LeavePolicyRepository) GetByCreator(ctx context.Context, createdBy uint) (*domain.LeavePolicy, error) {
+	args := m.Called(ctx, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeavePolicy), args.Error(1)
+}
+
+func (m *LeavePolicyRepository) GetForEmployee(ctx context.Context, employeeID uint) (*domain.LeavePolicy, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeavePolicy), args.Error(1)
+}
+
+func (m *LeavePolicyRepository) Upsert(ctx context.Context, policy *domain.LeavePolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
@@ -80,3 +80,12 @@ func (m *LeaveRequestRepository) HasOverlappingLeaveRequest(ctx context.Context,
 	args := m.Called(ctx, employeeID, startDate, endDate, excludeRequestID)
 	return args.Bool(0), args.Error(1)
 }
+
+// ListCertificatesOverdue mocks the ListCertificatesOverdue method
+func (m *LeaveRequestRepository) ListCertificatesOverdue(ctx context.Context, asOf time.Time) ([]*domain.LeaveRequest, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LeaveRequest), args.Error(1)
+}
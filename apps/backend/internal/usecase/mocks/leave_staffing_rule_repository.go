@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// LeaveStaffingRuleRepository is a mock implementation of interfaces.LeaveStaffingRuleRepository
+type LeaveStaffingRuleRepository struct {
+	mock.Mock
+}
+
+func (m *LeaveStaffingRuleRepository) Create(ctx context.Context, rule *domain.LeaveStaffingRule) error {
+	args := m.Called(ctx, rule)
+	if args.Error(0) == nil && rule.ID == 0 {
+		rule.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *LeaveStaffingRuleRepository) GetByID(ctx context.Context, id uint) (*domain.LeaveStaffingRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveStaffingRule), args.Error(1)
+}
+
+func (m *LeaveStaffingRuleRepository) List(ctx context.Context) ([]*domain.LeaveStaffingRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LeaveStaffingRule), args.Error(1)
+}
+
+func (m *LeaveStaffingRuleRepository) Update(ctx context.Context, rule *domain.LeaveStaffingRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *LeaveStaffingRuleRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *LeaveStaffingRuleRepository) ListApplicableToEmployee(ctx context.Context, employee *domain.Employee) ([]*domain.LeaveStaffingRule, error) {
+	args := m.Called(ctx, employee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LeaveStaffingRule), args.Error(1)
+}
+
+func (m *LeaveStaffingRuleRepository) CountScopeEmployees(ctx context.Context, rule *domain.LeaveStaffingRule) (int64, error) {
+	args := m.Called(ctx, rule)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *LeaveStaffingRuleRepository) ListScopeLeaves(ctx context.Context, rule *domain.LeaveStaffingRule, startDate, endDate time.Time, statuses []domain.LeaveStatus) ([]*domain.LeaveRequest, error) {
+	args := m.Called(ctx, rule, startDate, endDate, statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LeaveRequest), args.Error(1)
+}
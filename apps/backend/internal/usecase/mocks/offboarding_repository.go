@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type OffboardingRepository struct {
+	mock.Mock
+}
+
+func (m *OffboardingRepository) Create(ctx context.Context, offboarding *domain.Offboarding) error {
+	args := m.Called(ctx, offboarding)
+	return args.Error(0)
+}
+
+func (m *OffboardingRepository) Update(ctx context.Context, offboarding *domain.Offboarding) error {
+	args := m.Called(ctx, offboarding)
+	return args.Error(0)
+}
+
+func (m *OffboardingRepository) GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Offboarding, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Offboarding), args.Error(1)
+}
+
+func (m *OffboardingRepository) GetTaskByID(ctx context.Context, offboardingID, taskID uint) (*domain.OffboardingTask, error) {
+	args := m.Called(ctx, offboardingID, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OffboardingTask), args.Error(1)
+}
+
+func (m *OffboardingRepository) UpdateTask(ctx context.Context, task *domain.OffboardingTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *OffboardingRepository) ListDue(ctx context.Context, date time.Time) ([]*domain.Offboarding, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Offboarding), args.Error(1)
+}
+
+func (m *OffboardingRepository) ListCompletedBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Offboarding, error) {
+	args := m.Called(ctx, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Offboarding), args.Error(1)
+}
@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type OnboardingRepository struct {
+	mock.Mock
+}
+
+func (m *OnboardingRepository) CreateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *OnboardingRepository) GetTemplateByID(ctx context.Context, id uint) (*domain.OnboardingTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OnboardingTemplate), args.Error(1)
+}
+
+func (m *OnboardingRepository) ListTemplates(ctx context.Context) ([]*domain.OnboardingTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OnboardingTemplate), args.Error(1)
+}
+
+func (m *OnboardingRepository) UpdateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *OnboardingRepository) DeleteTemplate(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *OnboardingRepository) CreateTasks(ctx context.Context, tasks []*domain.OnboardingTask) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func (m *OnboardingRepository) ListTasksByEmployee(ctx context.Context, employeeID uint) ([]*domain.OnboardingTask, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OnboardingTask), args.Error(1)
+}
+
+func (m *OnboardingRepository) ListOpenTasksByAssignee(ctx context.Context, assigneeID uint) ([]*domain.OnboardingTask, error) {
+	args := m.Called(ctx, assigneeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OnboardingTask), args.Error(1)
+}
+
+func (m *OnboardingRepository) ListOpenTasksDueBefore(ctx context.Context, date time.Time) ([]*domain.OnboardingTask, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OnboardingTask), args.Error(1)
+}
+
+func (m *OnboardingRepository) GetTaskByID(ctx context.Context, employeeID, taskID uint) (*domain.OnboardingTask, error) {
+	args := m.Called(ctx, employeeID, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OnboardingTask), args.Error(1)
+}
+
+func (m *OnboardingRepository) UpdateTask(ctx context.Context, task *domain.OnboardingTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
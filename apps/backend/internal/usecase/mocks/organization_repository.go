@@ -0,0 +1,113 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *OrganizationRepository) CreateDepartment(ctx context.Context, department *domain.Department) error {
+	args := m.Called(ctx, department)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) GetDepartmentByID(ctx context.Context, id uint) (*domain.Department, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Department), args.Error(1)
+}
+
+func (m *OrganizationRepository) ListDepartments(ctx context.Context) ([]*domain.Department, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Department), args.Error(1)
+}
+
+func (m *OrganizationRepository) UpdateDepartment(ctx context.Context, department *domain.Department) error {
+	args := m.Called(ctx, department)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) DeleteDepartment(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) CountActiveEmployeesByDepartment(ctx context.Context) (map[uint]int64, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
+
+func (m *OrganizationRepository) CreateBranch(ctx context.Context, branch *domain.Branch) error {
+	args := m.Called(ctx, branch)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) GetBranchByID(ctx context.Context, id uint) (*domain.Branch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Branch), args.Error(1)
+}
+
+func (m *OrganizationRepository) ListBranches(ctx context.Context) ([]*domain.Branch, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Branch), args.Error(1)
+}
+
+func (m *OrganizationRepository) UpdateBranch(ctx context.Context, branch *domain.Branch) error {
+	args := m.Called(ctx, branch)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) DeleteBranch(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) CreatePosition(ctx context.Context, position *domain.Position) error {
+	args := m.Called(ctx, position)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) GetPositionByID(ctx context.Context, id uint) (*domain.Position, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Position), args.Error(1)
+}
+
+func (m *OrganizationRepository) ListPositions(ctx context.Context) ([]*domain.Position, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Position), args.Error(1)
+}
+
+func (m *OrganizationRepository) UpdatePosition(ctx context.Context, position *domain.Position) error {
+	args := m.Called(ctx, position)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepository) DeletePosition(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProbationRepository struct {
+	mock.Mock
+}
+
+func (m *ProbationRepository) Create(ctx context.Context, probation *domain.Probation) error {
+	args := m.Called(ctx, probation)
+	return args.Error(0)
+}
+
+func (m *ProbationRepository) Update(ctx context.Context, probation *domain.Probation) error {
+	args := m.Called(ctx, probation)
+	return args.Error(0)
+}
+
+func (m *ProbationRepository) GetLatestByEmployee(ctx context.Context, employeeID uint) (*domain.Probation, error) {
+	args := m.Called(ctx, employeeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Probation), args.Error(1)
+}
+
+func (m *ProbationRepository) CreateReview(ctx context.Context, review *domain.ProbationReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *ProbationRepository) ListInProgress(ctx context.Context) ([]*domain.Probation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Probation), args.Error(1)
+}
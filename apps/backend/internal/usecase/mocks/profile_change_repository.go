@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProfileChangeRepository struct {
+	mock.Mock
+}
+
+func (m *ProfileChangeRepository) GetPolicy(ctx context.Context, companyID uint) (*domain.ProfileChangePolicy, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProfileChangePolicy), args.Error(1)
+}
+
+func (m *ProfileChangeRepository) UpsertPolicy(ctx context.Context, policy *domain.ProfileChangePolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *ProfileChangeRepository) Create(ctx context.Context, request *domain.ProfileChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *ProfileChangeRepository) GetByID(ctx context.Context, id uint) (*domain.ProfileChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProfileChangeRequest), args.Error(1)
+}
+
+func (m *ProfileChangeRepository) Update(ctx context.Context, request *domain.ProfileChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *ProfileChangeRepository) List(ctx context.Context, filters map[string]interface{}, pagination domain.PaginationParams) ([]*domain.ProfileChangeRequest, int64, error) {
+	args := m.Called(ctx, filters, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.ProfileChangeRequest), args.Get(1).(int64), args.Error(2)
+}
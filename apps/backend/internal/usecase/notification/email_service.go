@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/smtp"
+	"strings"
+	"time"
 
 	"github.com/SukaMajuu/hris/apps/backend/domain"
 	"github.com/SukaMajuu/hris/apps/backend/pkg/config"
@@ -248,7 +250,282 @@ func (es *EmailService) SendPaymentSuccess(ctx context.Context, user *domain.Use
 	return es.sendEmail(ctx, user.Email, subject, htmlContent)
 }
 
+func (es *EmailService) SendContractExpiryReminder(ctx context.Context, recipient *domain.User, contract *domain.EmploymentContract, daysLeft int) error {
+	employeeName := contract.Employee.FirstName
+	if contract.Employee.LastName != nil {
+		employeeName += " " + *contract.Employee.LastName
+	}
+
+	subject := fmt.Sprintf("📄 Contract of %s Ends in %d Days", employeeName, daysLeft)
+
+	urgencyColor := "#ffc107" // yellow
+	if daysLeft <= 7 {
+		urgencyColor = "#dc3545" // red
+	}
+
+	htmlContent := fmt.Sprintf(`
+	<!DOCTYPE html>
+	<html>
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Contract Expiry Reminder</title>
+	</head>
+	<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+		<div style="background: %s; color: white; padding: 30px; border-radius: 10px 10px 0 0; text-align: center;">
+			<h1 style="margin: 0; font-size: 28px;">📄 Contract Ending Soon</h1>
+			<p style="margin: 10px 0 0 0; font-size: 18px; font-weight: bold;">%d days remaining</p>
+		</div>
+
+		<div style="background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px;">
+			<p>Hello <strong>%s</strong>,</p>
+
+			<p>The fixed-term contract of <strong>%s</strong> (%s) ends on <strong>%s</strong>.</p>
+
+			<div style="background: white; padding: 20px; border-radius: 8px; margin: 20px 0; border-left: 4px solid %s;">
+				<h3 style="margin-top: 0; color: %s;">Action Needed</h3>
+				<p>Please decide whether to renew the contract, convert the employee to permanent or let the contract end.</p>
+			</div>
+
+			<div style="text-align: center; margin: 30px 0;">
+				<a href="https://hrispblfrontend.agreeablecoast-95647c57.southeastasia.azurecontainerapps.io/employee-management"
+				   style="background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 6px; font-weight: bold; display: inline-block;">
+					Review Contract
+				</a>
+			</div>
+		</div>
+	</body>
+	</html>`,
+		urgencyColor,
+		daysLeft,
+		recipient.Email,
+		employeeName,
+		contract.Employee.PositionName,
+		contract.EndDate.Format("January 2, 2006"),
+		urgencyColor,
+		urgencyColor,
+	)
+
+	return es.sendEmail(ctx, recipient.Email, subject, htmlContent)
+}
+
+func (es *EmailService) SendOnboardingTaskReminder(ctx context.Context, recipient *domain.User, tasks []*domain.OnboardingTask) error {
+	subject := fmt.Sprintf("📝 You Have %d Onboarding Tasks Waiting", len(tasks))
+	if len(tasks) == 1 {
+		subject = "📝 You Have an Onboarding Task Waiting"
+	}
+
+	today := time.Now()
+	var rows strings.Builder
+	for _, task := range tasks {
+		employeeName := task.Employee.FirstName
+		if task.Employee.LastName != nil {
+			employeeName += " " + *task.Employee.LastName
+		}
+
+		dueColor := "#333"
+		if task.Overdue(today) {
+			dueColor = "#dc3545" // red
+		}
+
+		fmt.Fprintf(&rows, `
+				<tr>
+					<td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+					<td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+					<td style="padding: 8px; border-bottom: 1px solid #eee; color: %s;">%s</td>
+				</tr>`,
+			task.Title,
+			employeeName,
+			dueColor,
+			task.DueDate.Format("January 2, 2006"),
+		)
+	}
+
+	htmlContent := fmt.Sprintf(`
+	<!DOCTYPE html>
+	<html>
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Onboarding Task Reminder</title>
+	</head>
+	<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+		<div style="background: #17a2b8; color: white; padding: 30px; border-radius: 10px 10px 0 0; text-align: center;">
+			<h1 style="margin: 0; font-size: 28px;">📝 Onboarding Tasks</h1>
+			<p style="margin: 10px 0 0 0; font-size: 16px; opacity: 0.9;">Help your new colleagues get started</p>
+		</div>
+
+		<div style="background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px;">
+			<p>Hello <strong>%s</strong>,</p>
+
+			<p>The following onboarding tasks assigned to you are due soon or overdue:</p>
+
+			<table style="width: 100%%; background: white; border-radius: 8px; border-collapse: collapse; margin: 20px 0;">
+				<tr>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #17a2b8;">Task</th>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #17a2b8;">New Hire</th>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #17a2b8;">Due</th>
+				</tr>%s
+			</table>
+
+			<div style="text-align: center; margin: 30px 0;">
+				<a href="https://hrispblfrontend.agreeablecoast-95647c57.southeastasia.azurecontainerapps.io/dashboard"
+				   style="background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 6px; font-weight: bold; display: inline-block;">
+					View Tasks
+				</a>
+			</div>
+		</div>
+	</body>
+	</html>`,
+		recipient.Email,
+		rows.String(),
+	)
+
+	return es.sendEmail(ctx, recipient.Email, subject, htmlContent)
+}
+
 // Core email sending method
+func (es *EmailService) SendProbationReviewReminder(ctx context.Context, recipient *domain.User, probation *domain.Probation, daysLeft int) error {
+	employeeName := probation.Employee.FirstName
+	if probation.Employee.LastName != nil {
+		employeeName += " " + *probation.Employee.LastName
+	}
+
+	subject := fmt.Sprintf("📋 Probation Review of %s Due in %d Days", employeeName, daysLeft)
+
+	urgencyColor := "#ffc107" // yellow
+	if daysLeft <= 1 {
+		urgencyColor = "#dc3545" // red
+	}
+
+	htmlContent := fmt.Sprintf(`
+	<!DOCTYPE html>
+	<html>
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Probation Review Reminder</title>
+	</head>
+	<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+		<div style="background: %s; color: white; padding: 30px; border-radius: 10px 10px 0 0; text-align: center;">
+			<h1 style="margin: 0; font-size: 28px;">📋 Probation Review Due</h1>
+			<p style="margin: 10px 0 0 0; font-size: 18px; font-weight: bold;">%d days remaining</p>
+		</div>
+
+		<div style="background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px;">
+			<p>Hello <strong>%s</strong>,</p>
+
+			<p>The probation of <strong>%s</strong> (%s) ends on <strong>%s</strong>. The review is due by <strong>%s</strong>.</p>
+
+			<div style="background: white; padding: 20px; border-radius: 8px; margin: 20px 0; border-left: 4px solid %s;">
+				<h3 style="margin-top: 0; color: %s;">Action Needed</h3>
+				<p>Please review the employee and decide whether to confirm them, extend their probation or end their employment.</p>
+			</div>
+
+			<div style="text-align: center; margin: 30px 0;">
+				<a href="https://hrispblfrontend.agreeablecoast-95647c57.southeastasia.azurecontainerapps.io/employee-management"
+				   style="background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 6px; font-weight: bold; display: inline-block;">
+					Review Probation
+				</a>
+			</div>
+		</div>
+	</body>
+	</html>`,
+		urgencyColor,
+		daysLeft,
+		recipient.Email,
+		employeeName,
+		probation.Employee.PositionName,
+		probation.EndDate.Format("January 2, 2006"),
+		probation.ReviewDueDate().Format("January 2, 2006"),
+		urgencyColor,
+		urgencyColor,
+	)
+
+	return es.sendEmail(ctx, recipient.Email, subject, htmlContent)
+}
+
+func (es *EmailService) SendEmployeeEventDigest(ctx context.Context, recipient *domain.User, events []*domain.EmployeeEvent) error {
+	subject := fmt.Sprintf("🎉 %d Events in Your Team Today", len(events))
+	if len(events) == 1 {
+		subject = "🎉 An Event in Your Team Today"
+	}
+
+	var rows strings.Builder
+	for _, event := range events {
+		employeeName := event.Employee.FirstName
+		if event.Employee.LastName != nil {
+			employeeName += " " + *event.Employee.LastName
+		}
+
+		var description string
+		switch event.Type {
+		case domain.EmployeeEventBirthday:
+			description = "🎂 Birthday"
+		case domain.EmployeeEventWorkAnniversary:
+			description = fmt.Sprintf("🏆 %d-year work anniversary", event.Years)
+		case domain.EmployeeEventContractEnd:
+			description = "📄 Contract ends"
+		case domain.EmployeeEventProbationEnd:
+			description = "📋 Probation ends"
+		}
+
+		fmt.Fprintf(&rows, `
+				<tr>
+					<td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+					<td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+					<td style="padding: 8px; border-bottom: 1px solid #eee;">%s</td>
+				</tr>`,
+			employeeName,
+			event.Employee.PositionName,
+			description,
+		)
+	}
+
+	htmlContent := fmt.Sprintf(`
+	<!DOCTYPE html>
+	<html>
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Team Events Digest</title>
+	</head>
+	<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+		<div style="background: #6f42c1; color: white; padding: 30px; border-radius: 10px 10px 0 0; text-align: center;">
+			<h1 style="margin: 0; font-size: 28px;">🎉 Team Events</h1>
+			<p style="margin: 10px 0 0 0; font-size: 16px; opacity: 0.9;">%s</p>
+		</div>
+
+		<div style="background: #f8f9fa; padding: 30px; border-radius: 0 0 10px 10px;">
+			<p>Hello <strong>%s</strong>,</p>
+
+			<p>Here is what is happening in your team today:</p>
+
+			<table style="width: 100%%; background: white; border-radius: 8px; border-collapse: collapse; margin: 20px 0;">
+				<tr>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #6f42c1;">Employee</th>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #6f42c1;">Position</th>
+					<th style="padding: 8px; text-align: left; border-bottom: 2px solid #6f42c1;">Event</th>
+				</tr>%s
+			</table>
+
+			<div style="text-align: center; margin: 30px 0;">
+				<a href="https://hrispblfrontend.agreeablecoast-95647c57.southeastasia.azurecontainerapps.io/dashboard"
+				   style="background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 6px; font-weight: bold; display: inline-block;">
+					View Team Events
+				</a>
+			</div>
+		</div>
+	</body>
+	</html>`,
+		time.Now().Format("Monday, January 2, 2006"),
+		recipient.Email,
+		rows.String(),
+	)
+
+	return es.sendEmail(ctx, recipient.Email, subject, htmlContent)
+}
+
 func (es *EmailService) sendEmail(ctx context.Context, to, subject, htmlContent string) error {
 	if es.useResend {
 		return es.sendWithResend(ctx, to, subject, htmlContent)
@@ -0,0 +1,399 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtoonboarding "github.com/SukaMajuu/hris/apps/backend/domain/dto/onboarding"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+	"gorm.io/gorm"
+)
+
+type OnboardingUseCase struct {
+	onboardingRepo   interfaces.OnboardingRepository
+	employeeRepo     interfaces.EmployeeRepository
+	organizationRepo interfaces.OrganizationRepository
+	companyRepo      interfaces.CompanyRepository
+	authRepo         interfaces.AuthRepository
+	notifier         interfaces.EmploymentNotifier
+}
+
+func NewOnboardingUseCase(
+	onboardingRepo interfaces.OnboardingRepository,
+	employeeRepo interfaces.EmployeeRepository,
+	organizationRepo interfaces.OrganizationRepository,
+	companyRepo interfaces.CompanyRepository,
+	authRepo interfaces.AuthRepository,
+	notifier interfaces.EmploymentNotifier,
+) *OnboardingUseCase {
+	return &OnboardingUseCase{
+		onboardingRepo:   onboardingRepo,
+		employeeRepo:     employeeRepo,
+		organizationRepo: organizationRepo,
+		companyRepo:      companyRepo,
+		authRepo:         authRepo,
+		notifier:         notifier,
+	}
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// --- Templates ---
+
+// validateTemplate checks that the department and position of a template exist and that no other
+// template covers the same position and department.
+func (uc *OnboardingUseCase) validateTemplate(ctx context.Context, template *domain.OnboardingTemplate) error {
+	if len(template.Tasks) == 0 {
+		return fmt.Errorf("%w: a template needs at least one task", domain.ErrInvalidOnboardingTemplate)
+	}
+	if template.DepartmentID != nil {
+		if _, err := uc.organizationRepo.GetDepartmentByID(ctx, *template.DepartmentID); err != nil {
+			if errors.Is(err, domain.ErrDepartmentNotFound) {
+				return fmt.Errorf("%w: department %d not found", domain.ErrInvalidOnboardingTemplate, *template.DepartmentID)
+			}
+			return fmt.Errorf("failed to get department: %w", err)
+		}
+	}
+	if template.PositionID != nil {
+		if _, err := uc.organizationRepo.GetPositionByID(ctx, *template.PositionID); err != nil {
+			if errors.Is(err, domain.ErrPositionNotFound) {
+				return fmt.Errorf("%w: position %d not found", domain.ErrInvalidOnboardingTemplate, *template.PositionID)
+			}
+			return fmt.Errorf("failed to get position: %w", err)
+		}
+	}
+
+	templates, err := uc.onboardingRepo.ListTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list onboarding templates: %w", err)
+	}
+	for _, existing := range templates {
+		if existing.ID != template.ID && sameID(existing.DepartmentID, template.DepartmentID) && sameID(existing.PositionID, template.PositionID) {
+			return domain.ErrOnboardingTemplateExists
+		}
+	}
+	return nil
+}
+
+func (uc *OnboardingUseCase) CreateTemplate(ctx context.Context, template *domain.OnboardingTemplate) (*dtoonboarding.TemplateResponseDTO, error) {
+	log.Printf("OnboardingUseCase: CreateTemplate called with name %s", template.Name)
+
+	if err := uc.validateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	if err := uc.onboardingRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create onboarding template: %w", err)
+	}
+	return dtoonboarding.ToTemplateResponseDTO(template), nil
+}
+
+func (uc *OnboardingUseCase) ListTemplates(ctx context.Context) ([]*dtoonboarding.TemplateResponseDTO, error) {
+	templates, err := uc.onboardingRepo.ListTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list onboarding templates: %w", err)
+	}
+	return dtoonboarding.ToTemplateResponseDTOList(templates), nil
+}
+
+func (uc *OnboardingUseCase) GetTemplate(ctx context.Context, id uint) (*dtoonboarding.TemplateResponseDTO, error) {
+	template, err := uc.onboardingRepo.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding template: %w", err)
+	}
+	return dtoonboarding.ToTemplateResponseDTO(template), nil
+}
+
+// UpdateTemplate replaces a template. Checklists already created from it are not changed.
+func (uc *OnboardingUseCase) UpdateTemplate(ctx context.Context, template *domain.OnboardingTemplate) (*dtoonboarding.TemplateResponseDTO, error) {
+	log.Printf("OnboardingUseCase: UpdateTemplate called for ID %d", template.ID)
+
+	existing, err := uc.onboardingRepo.GetTemplateByID(ctx, template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding template: %w", err)
+	}
+	if err := uc.validateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	existing.Name = template.Name
+	existing.DepartmentID = template.DepartmentID
+	existing.PositionID = template.PositionID
+	existing.Tasks = template.Tasks
+	if err := uc.onboardingRepo.UpdateTemplate(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update onboarding template: %w", err)
+	}
+	return dtoonboarding.ToTemplateResponseDTO(existing), nil
+}
+
+func (uc *OnboardingUseCase) DeleteTemplate(ctx context.Context, id uint) error {
+	log.Printf("OnboardingUseCase: DeleteTemplate called for ID %d", id)
+
+	if err := uc.onboardingRepo.DeleteTemplate(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete onboarding template: %w", err)
+	}
+	return nil
+}
+
+// templateFor returns the template that applies to a new hire: one for their position and
+// department, then one for their position, then one for their department and finally the company
+// default. It returns nil when none applies.
+func templateFor(templates []*domain.OnboardingTemplate, employee *domain.Employee) *domain.OnboardingTemplate {
+	var best *domain.OnboardingTemplate
+	bestRank := 0
+	for _, template := range templates {
+		rank := 0
+		switch {
+		case template.PositionID != nil && template.DepartmentID != nil:
+			if sameID(template.PositionID, employee.PositionID) && sameID(template.DepartmentID, employee.DepartmentID) {
+				rank = 4
+			}
+		case template.PositionID != nil:
+			if sameID(template.PositionID, employee.PositionID) {
+				rank = 3
+			}
+		case template.DepartmentID != nil:
+			if sameID(template.DepartmentID, employee.DepartmentID) {
+				rank = 2
+			}
+		default:
+			rank = 1
+		}
+		if rank > bestRank {
+			best, bestRank = template, rank
+		}
+	}
+	return best
+}
+
+// --- Checklists ---
+
+// hrEmployeeID returns the employee record of the owner of the new hire's company, who acts as HR.
+func (uc *OnboardingUseCase) hrEmployeeID(ctx context.Context, employee *domain.Employee) *uint {
+	if employee.CompanyID == nil {
+		return nil
+	}
+	company, err := uc.companyRepo.GetByID(ctx, *employee.CompanyID)
+	if err != nil {
+		log.Printf("OnboardingUseCase: Warning - failed to get company ID %d: %v", *employee.CompanyID, err)
+		return nil
+	}
+	owner, err := uc.employeeRepo.GetByUserID(ctx, company.OwnerUserID)
+	if err != nil {
+		log.Printf("OnboardingUseCase: Warning - failed to get employee of user ID %d: %v", company.OwnerUserID, err)
+		return nil
+	}
+	return &owner.ID
+}
+
+// StartOnboarding creates the onboarding checklist of a new hire. Tasks are due relative to the
+// hire date and assigned to HR, the new hire's manager or the new hire themselves; manager tasks
+// go to HR when the new hire has no manager.
+func (uc *OnboardingUseCase) StartOnboarding(ctx context.Context, employee *domain.Employee) error {
+	log.Printf("OnboardingUseCase: StartOnboarding called for employee ID %d", employee.ID)
+
+	templates, err := uc.onboardingRepo.ListTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list onboarding templates: %w", err)
+	}
+
+	var templateID *uint
+	templateTasks := domain.DefaultOnboardingTasks
+	if template := templateFor(templates, employee); template != nil {
+		templateID = &template.ID
+		templateTasks = template.Tasks
+	}
+
+	hireDate := today()
+	if employee.HireDate != nil {
+		hireDate = *employee.HireDate
+	}
+	hrID := uc.hrEmployeeID(ctx, employee)
+	managerID := employee.ManagerID
+	if managerID == nil {
+		managerID = hrID
+	}
+
+	tasks := make([]*domain.OnboardingTask, len(templateTasks))
+	for i, templateTask := range templateTasks {
+		task := &domain.OnboardingTask{
+			CompanyID:   employee.CompanyID,
+			EmployeeID:  employee.ID,
+			TemplateID:  templateID,
+			Title:       templateTask.Title,
+			Description: templateTask.Description,
+			Owner:       templateTask.Owner,
+			DueDate:     hireDate.AddDate(0, 0, templateTask.DueDays),
+			SortOrder:   templateTask.SortOrder,
+		}
+		switch templateTask.Owner {
+		case domain.OnboardingOwnerEmployee:
+			employeeID := employee.ID
+			task.AssigneeID = &employeeID
+		case domain.OnboardingOwnerManager:
+			task.AssigneeID = managerID
+		default:
+			task.AssigneeID = hrID
+		}
+		tasks[i] = task
+	}
+
+	if err := uc.onboardingRepo.CreateTasks(ctx, tasks); err != nil {
+		return fmt.Errorf("failed to create onboarding tasks: %w", err)
+	}
+	log.Printf("OnboardingUseCase: Created %d onboarding tasks for employee ID %d", len(tasks), employee.ID)
+	return nil
+}
+
+func (uc *OnboardingUseCase) GetChecklist(ctx context.Context, employeeID uint) (*dtoonboarding.ChecklistResponseDTO, error) {
+	if _, err := uc.employeeRepo.GetByID(ctx, employeeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee ID %d: %w", employeeID, err)
+	}
+
+	tasks, err := uc.onboardingRepo.ListTasksByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list onboarding tasks: %w", err)
+	}
+	return dtoonboarding.ToChecklistResponseDTO(employeeID, tasks, today()), nil
+}
+
+// ListMyTasks lists the open onboarding tasks assigned to the current user.
+func (uc *OnboardingUseCase) ListMyTasks(ctx context.Context, userID uint) ([]*dtoonboarding.TaskResponseDTO, error) {
+	employee, err := uc.employeeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee of user ID %d: %w", userID, err)
+	}
+
+	tasks, err := uc.onboardingRepo.ListOpenTasksByAssignee(ctx, employee.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list onboarding tasks: %w", err)
+	}
+	return dtoonboarding.ToTaskResponseDTOList(tasks, today()), nil
+}
+
+// UpdateTask ticks off or reopens a task of a new hire's onboarding checklist.
+func (uc *OnboardingUseCase) UpdateTask(ctx context.Context, employeeID, taskID uint, completed bool, userID uint) (*dtoonboarding.TaskResponseDTO, error) {
+	task, err := uc.onboardingRepo.GetTaskByID(ctx, employeeID, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOnboardingTaskNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get onboarding task ID %d: %w", taskID, err)
+	}
+	if err := uc.checkTaskUpdater(ctx, task, userID); err != nil {
+		return nil, err
+	}
+
+	if completed {
+		now := time.Now()
+		task.CompletedAt = &now
+		task.CompletedBy = &userID
+	} else {
+		task.CompletedAt = nil
+		task.CompletedBy = nil
+	}
+	if err := uc.onboardingRepo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update onboarding task ID %d: %w", taskID, err)
+	}
+	return dtoonboarding.ToTaskResponseDTO(task, today()), nil
+}
+
+// checkTaskUpdater checks that the user is the assignee of the task or the owner of its company.
+func (uc *OnboardingUseCase) checkTaskUpdater(ctx context.Context, task *domain.OnboardingTask, userID uint) error {
+	if task.Assignee != nil && task.Assignee.UserID == userID {
+		return nil
+	}
+	if task.CompanyID != nil {
+		company, err := uc.companyRepo.GetByID(ctx, *task.CompanyID)
+		if err != nil {
+			return fmt.Errorf("failed to get company ID %d: %w", *task.CompanyID, err)
+		}
+		if company.OwnerUserID == userID {
+			return nil
+		}
+	}
+	return domain.ErrOnboardingTaskDenied
+}
+
+// needsReminder reports whether the assignee of an open task should be reminded of it: once when
+// it is due within the reminder window and once more when it is overdue.
+func needsReminder(task *domain.OnboardingTask, now time.Time) bool {
+	if task.RemindedAt == nil {
+		return true
+	}
+	return task.Overdue(now) && task.RemindedAt.Before(task.DueDate.AddDate(0, 0, 1))
+}
+
+// ProcessOnboardingReminders sends every assignee one email listing their onboarding tasks that
+// are due within three days or overdue.
+func (uc *OnboardingUseCase) ProcessOnboardingReminders(ctx context.Context) (*dtoonboarding.ReminderResultDTO, error) {
+	now := today()
+	log.Printf("OnboardingUseCase: Processing onboarding reminders as of %s", now.Format("2006-01-02"))
+
+	tasks, err := uc.onboardingRepo.ListOpenTasksDueBefore(ctx, now.AddDate(0, 0, domain.OnboardingReminderDays+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open onboarding tasks: %w", err)
+	}
+
+	result := &dtoonboarding.ReminderResultDTO{ProcessedDate: now.Format("2006-01-02")}
+	var assigneeIDs []uint
+	tasksByAssignee := make(map[uint][]*domain.OnboardingTask)
+	for _, task := range tasks {
+		if task.Assignee == nil || !needsReminder(task, now) {
+			continue
+		}
+		result.Checked++
+		if _, ok := tasksByAssignee[task.Assignee.ID]; !ok {
+			assigneeIDs = append(assigneeIDs, task.Assignee.ID)
+		}
+		tasksByAssignee[task.Assignee.ID] = append(tasksByAssignee[task.Assignee.ID], task)
+	}
+
+	for _, assigneeID := range assigneeIDs {
+		assigneeTasks := tasksByAssignee[assigneeID]
+		userID := assigneeTasks[0].Assignee.UserID
+
+		user, err := uc.authRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Printf("OnboardingUseCase: Warning - failed to get user ID %d: %v", userID, err)
+			result.Failed += len(assigneeTasks)
+			continue
+		}
+		if err := uc.notifier.SendOnboardingTaskReminder(ctx, user, assigneeTasks); err != nil {
+			log.Printf("OnboardingUseCase: Warning - failed to send onboarding reminder to %s: %v", user.Email, err)
+			result.Failed += len(assigneeTasks)
+			continue
+		}
+
+		remindedAt := time.Now()
+		for _, task := range assigneeTasks {
+			task.RemindedAt = &remindedAt
+			if err := uc.onboardingRepo.UpdateTask(ctx, task); err != nil {
+				log.Printf("OnboardingUseCase: Warning - failed to record reminder for onboarding task ID %d: %v", task.ID, err)
+			}
+		}
+		result.Reminded += len(assigneeTasks)
+	}
+
+	log.Printf("OnboardingUseCase: Sent onboarding reminders for %d of %d tasks", result.Reminded, result.Checked)
+	return result, nil
+}
@@ -0,0 +1,215 @@
+package onboarding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestTemplateFor(t *testing.T) {
+	companyDefault := &domain.OnboardingTemplate{ID: 1}
+	engineering := &domain.OnboardingTemplate{ID: 2, DepartmentID: uintPtr(10)}
+	developer := &domain.OnboardingTemplate{ID: 3, PositionID: uintPtr(20)}
+	engineeringDeveloper := &domain.OnboardingTemplate{ID: 4, DepartmentID: uintPtr(10), PositionID: uintPtr(20)}
+	templates := []*domain.OnboardingTemplate{companyDefault, engineering, developer, engineeringDeveloper}
+
+	tests := []struct {
+		name       string
+		templates  []*domain.OnboardingTemplate
+		employee   *domain.Employee
+		expectedID uint
+	}{
+		{"position and department", templates, &domain.Employee{DepartmentID: uintPtr(10), PositionID: uintPtr(20)}, 4},
+		{"position in another department", templates, &domain.Employee{DepartmentID: uintPtr(11), PositionID: uintPtr(20)}, 3},
+		{"department only", templates, &domain.Employee{DepartmentID: uintPtr(10), PositionID: uintPtr(21)}, 2},
+		{"company default", templates, &domain.Employee{}, 1},
+		{"no template applies", []*domain.OnboardingTemplate{engineering}, &domain.Employee{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := templateFor(tt.templates, tt.employee)
+			if tt.expectedID == 0 {
+				assert.Nil(t, template)
+			} else {
+				assert.Equal(t, tt.expectedID, template.ID)
+			}
+		})
+	}
+}
+
+func TestOnboardingUseCase_StartOnboarding(t *testing.T) {
+	ctx := context.Background()
+	hireDate := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	companyID := uint(1)
+	ownerUserID := uint(50)
+	hrEmployee := &domain.Employee{ID: 5, UserID: ownerUserID}
+
+	tests := []struct {
+		name              string
+		templates         []*domain.OnboardingTemplate
+		managerID         *uint
+		expectedTemplate  *uint
+		expectedTasks     int
+		expectedAssignees map[domain.OnboardingOwner]uint
+	}{
+		{
+			name:             "default checklist",
+			templates:        []*domain.OnboardingTemplate{},
+			managerID:        uintPtr(7),
+			expectedTemplate: nil,
+			expectedTasks:    len(domain.DefaultOnboardingTasks),
+			expectedAssignees: map[domain.OnboardingOwner]uint{
+				domain.OnboardingOwnerEmployee: 1,
+				domain.OnboardingOwnerManager:  7,
+				domain.OnboardingOwnerHR:       5,
+			},
+		},
+		{
+			name: "template without a manager",
+			templates: []*domain.OnboardingTemplate{{
+				ID: 3,
+				Tasks: []domain.OnboardingTemplateTask{
+					{Title: "Team introduction", Owner: domain.OnboardingOwnerManager, DueDays: 1},
+					{Title: "Sign code of conduct", Owner: domain.OnboardingOwnerEmployee, DueDays: 1},
+				},
+			}},
+			managerID:        nil,
+			expectedTemplate: uintPtr(3),
+			expectedTasks:    2,
+			expectedAssignees: map[domain.OnboardingOwner]uint{
+				domain.OnboardingOwnerEmployee: 1,
+				domain.OnboardingOwnerManager:  5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOnboardingRepo := new(mocks.OnboardingRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+			mockCompanyRepo := new(mocks.CompanyRepository)
+			uc := NewOnboardingUseCase(mockOnboardingRepo, mockEmployeeRepo, nil, mockCompanyRepo, nil, nil)
+
+			employee := &domain.Employee{ID: 1, CompanyID: &companyID, ManagerID: tt.managerID, HireDate: &hireDate}
+
+			mockOnboardingRepo.On("ListTemplates", ctx).Return(tt.templates, nil).Once()
+			mockCompanyRepo.On("GetByID", ctx, companyID).Return(&domain.Company{ID: companyID, OwnerUserID: ownerUserID}, nil).Once()
+			mockEmployeeRepo.On("GetByUserID", ctx, ownerUserID).Return(hrEmployee, nil).Once()
+
+			var created []*domain.OnboardingTask
+			mockOnboardingRepo.On("CreateTasks", ctx, mock.AnythingOfType("[]*domain.OnboardingTask")).
+				Run(func(args mock.Arguments) {
+					created = args.Get(1).([]*domain.OnboardingTask)
+				}).
+				Return(nil).Once()
+
+			err := uc.StartOnboarding(ctx, employee)
+
+			assert.NoError(t, err)
+			assert.Len(t, created, tt.expectedTasks)
+			for _, task := range created {
+				assert.Equal(t, uint(1), task.EmployeeID)
+				assert.Equal(t, tt.expectedTemplate, task.TemplateID)
+				assert.Equal(t, tt.expectedAssignees[task.Owner], *task.AssigneeID)
+				assert.False(t, task.DueDate.Before(hireDate))
+			}
+			mockOnboardingRepo.AssertExpectations(t)
+			mockEmployeeRepo.AssertExpectations(t)
+			mockCompanyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOnboardingUseCase_ProcessOnboardingReminders(t *testing.T) {
+	ctx := context.Background()
+	now := today()
+	remindedYesterday := now.AddDate(0, 0, -1)
+	remindedLastWeek := now.AddDate(0, 0, -7)
+
+	manager := &domain.Employee{ID: 7, UserID: 70}
+	hr := &domain.Employee{ID: 5, UserID: 50}
+	newHire := domain.Employee{ID: 1, FirstName: "Budi"}
+
+	dueSoon := &domain.OnboardingTask{ID: 1, Employee: newHire, Assignee: manager, DueDate: now.AddDate(0, 0, 2)}
+	overdue := &domain.OnboardingTask{ID: 2, Employee: newHire, Assignee: manager, DueDate: now.AddDate(0, 0, -2), RemindedAt: &remindedLastWeek}
+	alreadyReminded := &domain.OnboardingTask{ID: 3, Employee: newHire, Assignee: hr, DueDate: now.AddDate(0, 0, 1), RemindedAt: &remindedYesterday}
+	overdueReminded := &domain.OnboardingTask{ID: 4, Employee: newHire, Assignee: hr, DueDate: now.AddDate(0, 0, -3), RemindedAt: &remindedYesterday}
+	unassigned := &domain.OnboardingTask{ID: 5, Employee: newHire, DueDate: now}
+
+	mockOnboardingRepo := new(mocks.OnboardingRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockNotifier := new(mocks.EmploymentNotifier)
+	uc := NewOnboardingUseCase(mockOnboardingRepo, nil, nil, nil, mockAuthRepo, mockNotifier)
+
+	managerUser := &domain.User{ID: 70, Email: "manager@example.com"}
+	mockOnboardingRepo.On("ListOpenTasksDueBefore", ctx, now.AddDate(0, 0, domain.OnboardingReminderDays+1)).
+		Return([]*domain.OnboardingTask{overdue, dueSoon, alreadyReminded, overdueReminded, unassigned}, nil).Once()
+	mockAuthRepo.On("GetUserByID", ctx, uint(70)).Return(managerUser, nil).Once()
+	mockNotifier.On("SendOnboardingTaskReminder", ctx, managerUser, []*domain.OnboardingTask{overdue, dueSoon}).Return(nil).Once()
+	mockOnboardingRepo.On("UpdateTask", ctx, overdue).Return(nil).Once()
+	mockOnboardingRepo.On("UpdateTask", ctx, dueSoon).Return(nil).Once()
+
+	result, err := uc.ProcessOnboardingReminders(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Checked)
+	assert.Equal(t, 2, result.Reminded)
+	assert.Equal(t, 0, result.Failed)
+	assert.NotNil(t, dueSoon.RemindedAt)
+	assert.True(t, overdue.RemindedAt.After(remindedLastWeek))
+	mockOnboardingRepo.AssertExpectations(t)
+	mockAuthRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestOnboardingUseCase_UpdateTask(t *testing.T) {
+	ctx := context.Background()
+	companyID := uint(3)
+	company := &domain.Company{ID: companyID, OwnerUserID: 50}
+
+	tests := []struct {
+		name          string
+		userID        uint
+		expectedError error
+	}{
+		{name: "assignee completes the task", userID: 70},
+		{name: "company owner completes the task", userID: 50},
+		{name: "anyone else is denied", userID: 90, expectedError: domain.ErrOnboardingTaskDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOnboardingRepo := new(mocks.OnboardingRepository)
+			mockCompanyRepo := new(mocks.CompanyRepository)
+			uc := NewOnboardingUseCase(mockOnboardingRepo, nil, nil, mockCompanyRepo, nil, nil)
+
+			task := &domain.OnboardingTask{ID: 2, CompanyID: &companyID, EmployeeID: 1, Assignee: &domain.Employee{ID: 7, UserID: 70}, DueDate: today()}
+			mockOnboardingRepo.On("GetTaskByID", ctx, uint(1), uint(2)).Return(task, nil).Once()
+			mockCompanyRepo.On("GetByID", ctx, companyID).Return(company, nil).Maybe()
+			mockOnboardingRepo.On("UpdateTask", ctx, task).Return(nil).Maybe()
+
+			result, err := uc.UpdateTask(ctx, 1, 2, true, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				assert.Nil(t, task.CompletedAt)
+				mockOnboardingRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.userID, *task.CompletedBy)
+			mockOnboardingRepo.AssertExpectations(t)
+		})
+	}
+}
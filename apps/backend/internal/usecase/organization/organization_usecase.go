@@ -0,0 +1,330 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	dtobranch "github.com/SukaMajuu/hris/apps/backend/domain/dto/branches"
+	dtodepartment "github.com/SukaMajuu/hris/apps/backend/domain/dto/department"
+	dtoposition "github.com/SukaMajuu/hris/apps/backend/domain/dto/position"
+	"github.com/SukaMajuu/hris/apps/backend/domain/interfaces"
+)
+
+type OrganizationUseCase struct {
+	organizationRepo interfaces.OrganizationRepository
+	employeeRepo     interfaces.EmployeeRepository
+}
+
+func NewOrganizationUseCase(organizationRepo interfaces.OrganizationRepository, employeeRepo interfaces.EmployeeRepository) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		organizationRepo: organizationRepo,
+		employeeRepo:     employeeRepo,
+	}
+}
+
+func toDepartmentResponse(department *domain.Department) *dtodepartment.DepartmentResponse {
+	return &dtodepartment.DepartmentResponse{
+		ID:        department.ID,
+		Name:      department.Name,
+		ParentID:  department.ParentID,
+		HrID:      department.CreatedBy,
+		CreatedAt: department.CreatedAt,
+		UpdatedAt: department.UpdatedAt,
+	}
+}
+
+func toBranchResponse(branch *domain.Branch) *dtobranch.BranchResponse {
+	return &dtobranch.BranchResponse{
+		ID:        branch.ID,
+		Name:      branch.Name,
+		HrID:      branch.CreatedBy,
+		CreatedAt: branch.CreatedAt,
+		UpdatedAt: branch.UpdatedAt,
+	}
+}
+
+func toPositionResponse(position *domain.Position) *dtoposition.PositionResponse {
+	return &dtoposition.PositionResponse{
+		ID:        position.ID,
+		Name:      position.Name,
+		HrID:      position.CreatedBy,
+		CreatedAt: position.CreatedAt,
+		UpdatedAt: position.UpdatedAt,
+	}
+}
+
+// hasActiveEmployees reports whether any active employee matches the given filter.
+func (uc *OrganizationUseCase) hasActiveEmployees(ctx context.Context, filter string, id uint) (bool, error) {
+	_, count, err := uc.employeeRepo.List(ctx, map[string]interface{}{
+		filter:              id,
+		"employment_status": true,
+	}, domain.PaginationParams{Page: 1, PageSize: 1})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// --- Departments ---
+
+func (uc *OrganizationUseCase) CreateDepartment(ctx context.Context, department *domain.Department) (*dtodepartment.DepartmentResponse, error) {
+	log.Printf("OrganizationUseCase: CreateDepartment called with name %s", department.Name)
+
+	if department.ParentID != nil {
+		if _, err := uc.organizationRepo.GetDepartmentByID(ctx, *department.ParentID); err != nil {
+			return nil, fmt.Errorf("failed to get parent department: %w", err)
+		}
+	}
+
+	if err := uc.organizationRepo.CreateDepartment(ctx, department); err != nil {
+		return nil, fmt.Errorf("failed to create department: %w", err)
+	}
+	return toDepartmentResponse(department), nil
+}
+
+func (uc *OrganizationUseCase) ListDepartments(ctx context.Context) ([]*dtodepartment.DepartmentResponse, error) {
+	departments, err := uc.organizationRepo.ListDepartments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list departments: %w", err)
+	}
+
+	responses := make([]*dtodepartment.DepartmentResponse, len(departments))
+	for i, department := range departments {
+		responses[i] = toDepartmentResponse(department)
+	}
+	return responses, nil
+}
+
+func (uc *OrganizationUseCase) GetDepartmentByID(ctx context.Context, id uint) (*dtodepartment.DepartmentResponse, error) {
+	department, err := uc.organizationRepo.GetDepartmentByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department: %w", err)
+	}
+	return toDepartmentResponse(department), nil
+}
+
+// UpdateDepartment renames or moves a department. A department cannot be moved below itself or
+// one of its own sub-departments.
+func (uc *OrganizationUseCase) UpdateDepartment(ctx context.Context, department *domain.Department) (*dtodepartment.DepartmentResponse, error) {
+	log.Printf("OrganizationUseCase: UpdateDepartment called for ID %d", department.ID)
+
+	existing, err := uc.organizationRepo.GetDepartmentByID(ctx, department.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department: %w", err)
+	}
+
+	if department.ParentID != nil {
+		departments, err := uc.organizationRepo.ListDepartments(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list departments: %w", err)
+		}
+
+		parents := make(map[uint]*uint, len(departments))
+		for _, d := range departments {
+			parents[d.ID] = d.ParentID
+		}
+		if _, ok := parents[*department.ParentID]; !ok {
+			return nil, fmt.Errorf("failed to get parent department: %w", domain.ErrDepartmentNotFound)
+		}
+
+		for ancestorID := department.ParentID; ancestorID != nil; ancestorID = parents[*ancestorID] {
+			if *ancestorID == department.ID {
+				return nil, domain.ErrDepartmentCycle
+			}
+		}
+	}
+
+	existing.Name = department.Name
+	existing.ParentID = department.ParentID
+	if err := uc.organizationRepo.UpdateDepartment(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update department: %w", err)
+	}
+	return toDepartmentResponse(existing), nil
+}
+
+// DeleteDepartment removes a department that has no sub-departments and no active employees.
+func (uc *OrganizationUseCase) DeleteDepartment(ctx context.Context, id uint) error {
+	log.Printf("OrganizationUseCase: DeleteDepartment called for ID %d", id)
+
+	departments, err := uc.organizationRepo.ListDepartments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list departments: %w", err)
+	}
+	for _, department := range departments {
+		if department.ParentID != nil && *department.ParentID == id {
+			return domain.ErrDepartmentInUse
+		}
+	}
+
+	inUse, err := uc.hasActiveEmployees(ctx, "department_id", id)
+	if err != nil {
+		return fmt.Errorf("failed to check department employees: %w", err)
+	}
+	if inUse {
+		return domain.ErrDepartmentInUse
+	}
+
+	if err := uc.organizationRepo.DeleteDepartment(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete department: %w", err)
+	}
+	return nil
+}
+
+// GetOrgChart returns the department tree of the company with the active headcount of every
+// department and of the sub-tree below it.
+func (uc *OrganizationUseCase) GetOrgChart(ctx context.Context) (*dtodepartment.OrgChartResponse, error) {
+	departments, err := uc.organizationRepo.ListDepartments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list departments: %w", err)
+	}
+
+	headcounts, err := uc.organizationRepo.CountActiveEmployeesByDepartment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count employees by department: %w", err)
+	}
+
+	nodes := make(map[uint]*dtodepartment.OrgChartNodeResponse, len(departments))
+	for _, department := range departments {
+		nodes[department.ID] = &dtodepartment.OrgChartNodeResponse{
+			ID:        department.ID,
+			Name:      department.Name,
+			ParentID:  department.ParentID,
+			Headcount: headcounts[department.ID],
+			Children:  []*dtodepartment.OrgChartNodeResponse{},
+		}
+	}
+
+	chart := &dtodepartment.OrgChartResponse{
+		Departments:         []*dtodepartment.OrgChartNodeResponse{},
+		UnassignedHeadcount: headcounts[0],
+	}
+	for _, department := range departments {
+		node := nodes[department.ID]
+		if department.ParentID != nil {
+			if parent, ok := nodes[*department.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		chart.Departments = append(chart.Departments, node)
+	}
+
+	chart.TotalHeadcount = chart.UnassignedHeadcount
+	for _, root := range chart.Departments {
+		chart.TotalHeadcount += sumHeadcount(root)
+	}
+	return chart, nil
+}
+
+// sumHeadcount fills in the total headcount of node and everything below it.
+func sumHeadcount(node *dtodepartment.OrgChartNodeResponse) int64 {
+	node.TotalHeadcount = node.Headcount
+	for _, child := range node.Children {
+		node.TotalHeadcount += sumHeadcount(child)
+	}
+	return node.TotalHeadcount
+}
+
+// --- Branches ---
+
+func (uc *OrganizationUseCase) CreateBranch(ctx context.Context, branch *domain.Branch) (*dtobranch.BranchResponse, error) {
+	if err := uc.organizationRepo.CreateBranch(ctx, branch); err != nil {
+		return nil, fmt.Errorf("failed to create branch: %w", err)
+	}
+	return toBranchResponse(branch), nil
+}
+
+func (uc *OrganizationUseCase) ListBranches(ctx context.Context) ([]*dtobranch.BranchResponse, error) {
+	branches, err := uc.organizationRepo.ListBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	responses := make([]*dtobranch.BranchResponse, len(branches))
+	for i, branch := range branches {
+		responses[i] = toBranchResponse(branch)
+	}
+	return responses, nil
+}
+
+func (uc *OrganizationUseCase) UpdateBranch(ctx context.Context, branch *domain.Branch) (*dtobranch.BranchResponse, error) {
+	existing, err := uc.organizationRepo.GetBranchByID(ctx, branch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	existing.Name = branch.Name
+	if err := uc.organizationRepo.UpdateBranch(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update branch: %w", err)
+	}
+	return toBranchResponse(existing), nil
+}
+
+// DeleteBranch removes a branch that no active employee is assigned to.
+func (uc *OrganizationUseCase) DeleteBranch(ctx context.Context, id uint) error {
+	inUse, err := uc.hasActiveEmployees(ctx, "branch_id", id)
+	if err != nil {
+		return fmt.Errorf("failed to check branch employees: %w", err)
+	}
+	if inUse {
+		return domain.ErrBranchInUse
+	}
+
+	if err := uc.organizationRepo.DeleteBranch(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+	return nil
+}
+
+// --- Positions ---
+
+func (uc *OrganizationUseCase) CreatePosition(ctx context.Context, position *domain.Position) (*dtoposition.PositionResponse, error) {
+	if err := uc.organizationRepo.CreatePosition(ctx, position); err != nil {
+		return nil, fmt.Errorf("failed to create position: %w", err)
+	}
+	return toPositionResponse(position), nil
+}
+
+func (uc *OrganizationUseCase) ListPositions(ctx context.Context) ([]*dtoposition.PositionResponse, error) {
+	positions, err := uc.organizationRepo.ListPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list positions: %w", err)
+	}
+
+	responses := make([]*dtoposition.PositionResponse, len(positions))
+	for i, position := range positions {
+		responses[i] = toPositionResponse(position)
+	}
+	return responses, nil
+}
+
+func (uc *OrganizationUseCase) UpdatePosition(ctx context.Context, position *domain.Position) (*dtoposition.PositionResponse, error) {
+	existing, err := uc.organizationRepo.GetPositionByID(ctx, position.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position: %w", err)
+	}
+
+	existing.Name = position.Name
+	if err := uc.organizationRepo.UpdatePosition(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update position: %w", err)
+	}
+	return toPositionResponse(existing), nil
+}
+
+// DeletePosition removes a position that no active employee is assigned to.
+func (uc *OrganizationUseCase) DeletePosition(ctx context.Context, id uint) error {
+	inUse, err := uc.hasActiveEmployees(ctx, "position_id", id)
+	if err != nil {
+		return fmt.Errorf("failed to check position employees: %w", err)
+	}
+	if inUse {
+		return domain.ErrPositionInUse
+	}
+
+	if err := uc.organizationRepo.DeletePosition(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete position: %w", err)
+	}
+	return nil
+}
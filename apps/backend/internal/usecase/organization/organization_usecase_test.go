@@ -0,0 +1,191 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/SukaMajuu/hris/apps/backend/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// departmentTree returns Engineering(1) > Backend(2) > Platform(3) and a separate Sales(4).
+func departmentTree() []*domain.Department {
+	return []*domain.Department{
+		{ID: 1, Name: "Engineering"},
+		{ID: 2, Name: "Backend", ParentID: uintPtr(1)},
+		{ID: 3, Name: "Platform", ParentID: uintPtr(2)},
+		{ID: 4, Name: "Sales"},
+	}
+}
+
+func TestOrganizationUseCase_UpdateDepartment(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		department    *domain.Department
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name:         "move under an unrelated department",
+			department:   &domain.Department{ID: 2, Name: "Backend", ParentID: uintPtr(4)},
+			expectUpdate: true,
+		},
+		{
+			name:         "move to the top level",
+			department:   &domain.Department{ID: 2, Name: "Backend"},
+			expectUpdate: true,
+		},
+		{
+			name:          "move under itself",
+			department:    &domain.Department{ID: 2, Name: "Backend", ParentID: uintPtr(2)},
+			expectedError: domain.ErrDepartmentCycle,
+		},
+		{
+			name:          "move under its own sub-department",
+			department:    &domain.Department{ID: 1, Name: "Engineering", ParentID: uintPtr(3)},
+			expectedError: domain.ErrDepartmentCycle,
+		},
+		{
+			name:          "unknown parent",
+			department:    &domain.Department{ID: 2, Name: "Backend", ParentID: uintPtr(99)},
+			expectedError: domain.ErrDepartmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrganizationRepo := new(mocks.OrganizationRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+
+			existing := &domain.Department{ID: tt.department.ID, Name: "Old name"}
+			mockOrganizationRepo.On("GetDepartmentByID", ctx, tt.department.ID).Return(existing, nil).Once()
+			mockOrganizationRepo.On("ListDepartments", ctx).Return(departmentTree(), nil).Maybe()
+			if tt.expectUpdate {
+				mockOrganizationRepo.On("UpdateDepartment", ctx, existing).Return(nil).Once()
+			}
+
+			uc := NewOrganizationUseCase(mockOrganizationRepo, mockEmployeeRepo)
+			result, err := uc.UpdateDepartment(ctx, tt.department)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				mockOrganizationRepo.AssertNotCalled(t, "UpdateDepartment", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.department.Name, result.Name)
+				assert.Equal(t, tt.department.ParentID, result.ParentID)
+			}
+			mockOrganizationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationUseCase_DeleteDepartment(t *testing.T) {
+	ctx := context.Background()
+	activeFilter := func(id uint) map[string]interface{} {
+		return map[string]interface{}{"department_id": id, "employment_status": true}
+	}
+	onePage := domain.PaginationParams{Page: 1, PageSize: 1}
+
+	tests := []struct {
+		name          string
+		id            uint
+		activeCount   int64
+		expectList    bool
+		expectDelete  bool
+		expectedError error
+	}{
+		{
+			name:          "has sub-departments",
+			id:            1,
+			expectedError: domain.ErrDepartmentInUse,
+		},
+		{
+			name:          "has active employees",
+			id:            3,
+			activeCount:   2,
+			expectList:    true,
+			expectedError: domain.ErrDepartmentInUse,
+		},
+		{
+			name:         "empty leaf department",
+			id:           4,
+			expectList:   true,
+			expectDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrganizationRepo := new(mocks.OrganizationRepository)
+			mockEmployeeRepo := new(mocks.EmployeeRepository)
+
+			mockOrganizationRepo.On("ListDepartments", ctx).Return(departmentTree(), nil).Once()
+			if tt.expectList {
+				mockEmployeeRepo.On("List", ctx, activeFilter(tt.id), onePage).Return(nil, tt.activeCount, nil).Once()
+			}
+			if tt.expectDelete {
+				mockOrganizationRepo.On("DeleteDepartment", ctx, tt.id).Return(nil).Once()
+			}
+
+			uc := NewOrganizationUseCase(mockOrganizationRepo, mockEmployeeRepo)
+			err := uc.DeleteDepartment(ctx, tt.id)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				mockOrganizationRepo.AssertNotCalled(t, "DeleteDepartment", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockOrganizationRepo.AssertExpectations(t)
+			mockEmployeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationUseCase_GetOrgChart(t *testing.T) {
+	ctx := context.Background()
+	mockOrganizationRepo := new(mocks.OrganizationRepository)
+	mockEmployeeRepo := new(mocks.EmployeeRepository)
+
+	mockOrganizationRepo.On("ListDepartments", ctx).Return(departmentTree(), nil).Once()
+	mockOrganizationRepo.On("CountActiveEmployeesByDepartment", ctx).Return(map[uint]int64{
+		0: 1,
+		1: 2,
+		2: 3,
+		3: 4,
+		4: 5,
+	}, nil).Once()
+
+	uc := NewOrganizationUseCase(mockOrganizationRepo, mockEmployeeRepo)
+	chart, err := uc.GetOrgChart(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), chart.UnassignedHeadcount)
+	assert.Equal(t, int64(15), chart.TotalHeadcount)
+	if assert.Len(t, chart.Departments, 2) {
+		engineering := chart.Departments[0]
+		assert.Equal(t, "Engineering", engineering.Name)
+		assert.Equal(t, int64(2), engineering.Headcount)
+		assert.Equal(t, int64(9), engineering.TotalHeadcount)
+		if assert.Len(t, engineering.Children, 1) {
+			backend := engineering.Children[0]
+			assert.Equal(t, int64(7), backend.TotalHeadcount)
+			assert.Len(t, backend.Children, 1)
+		}
+
+		sales := chart.Departments[1]
+		assert.Equal(t, int64(5), sales.TotalHeadcount)
+		assert.Empty(t, sales.Children)
+	}
+	mockOrganizationRepo.AssertExpectations(t)
+}
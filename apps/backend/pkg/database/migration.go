@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"log"
 
 	models "github.com/SukaMajuu/hris/apps/backend/domain"
@@ -44,7 +45,7 @@ func Migrate(db *gorm.DB) error {
 		CREATE TYPE worktype_detail AS ENUM ('WFO', 'WFA');
 		-- leave_type Enum (New)
 		DROP TYPE IF EXISTS leave_type CASCADE;
-		CREATE TYPE leave_type AS ENUM ('sick_leave', 'annual_leave', 'maternity_leave', 'compassionate_leave', 'marriage_leave');
+		CREATE TYPE leave_type AS ENUM ('sick_leave', 'annual_leave', 'maternity_leave', 'compassionate_leave', 'marriage_leave', 'unpaid_leave');
 
 		-- attendance_status (new)
 		DROP TYPE IF EXISTS attendance_status CASCADE;
@@ -54,6 +55,82 @@ func Migrate(db *gorm.DB) error {
 		DROP TYPE IF EXISTS leave_status CASCADE;
 		CREATE TYPE leave_status AS ENUM ('Waiting Approval', 'Approved', 'Rejected');
 
+		-- staffing_rule_scope (new)
+		DROP TYPE IF EXISTS staffing_rule_scope CASCADE;
+		CREATE TYPE staffing_rule_scope AS ENUM ('manager_subtree', 'branch');
+
+		-- long_term_absence_type (new)
+		DROP TYPE IF EXISTS long_term_absence_type CASCADE;
+		CREATE TYPE long_term_absence_type AS ENUM ('sabbatical', 'suspension', 'extended_leave');
+
+		-- encashment_reason (new)
+		DROP TYPE IF EXISTS encashment_reason CASCADE;
+		CREATE TYPE encashment_reason AS ENUM ('year_end', 'resignation');
+
+		-- encashment_status (new)
+		DROP TYPE IF EXISTS encashment_status CASCADE;
+		CREATE TYPE encashment_status AS ENUM ('draft', 'finalized', 'cancelled');
+
+		-- certificate_status (new)
+		DROP TYPE IF EXISTS certificate_status CASCADE;
+		CREATE TYPE certificate_status AS ENUM ('not_required', 'pending', 'submitted', 'missing');
+
+		-- employment_event_type (new)
+		DROP TYPE IF EXISTS employment_event_type CASCADE;
+		CREATE TYPE employment_event_type AS ENUM ('hire', 'promotion', 'demotion', 'transfer', 'contract_renewal', 'contract_conversion', 'probation_confirmation', 'salary_change', 'resignation', 'import_update', 'merge');
+
+		-- employment_contract_status (new)
+		DROP TYPE IF EXISTS employment_contract_status CASCADE;
+		CREATE TYPE employment_contract_status AS ENUM ('active', 'renewed', 'converted', 'ended');
+
+		-- resignation_type (new)
+		DROP TYPE IF EXISTS resignation_type CASCADE;
+		CREATE TYPE resignation_type AS ENUM ('voluntary', 'termination', 'end_of_contract');
+
+		-- offboarding_status (new)
+		DROP TYPE IF EXISTS offboarding_status CASCADE;
+		CREATE TYPE offboarding_status AS ENUM ('scheduled', 'completed', 'cancelled');
+
+		-- onboarding_owner (new)
+		DROP TYPE IF EXISTS onboarding_owner CASCADE;
+		CREATE TYPE onboarding_owner AS ENUM ('hr', 'manager', 'employee');
+
+		-- probation_status (new)
+		DROP TYPE IF EXISTS probation_status CASCADE;
+		CREATE TYPE probation_status AS ENUM ('in_progress', 'confirmed', 'terminated');
+
+		-- probation_outcome (new)
+		DROP TYPE IF EXISTS probation_outcome CASCADE;
+		CREATE TYPE probation_outcome AS ENUM ('confirm', 'extend', 'terminate');
+
+		-- custom_field_type (new)
+		DROP TYPE IF EXISTS custom_field_type CASCADE;
+		CREATE TYPE custom_field_type AS ENUM ('text', 'number', 'date', 'select', 'file');
+
+		-- custom_field_visibility (new)
+		DROP TYPE IF EXISTS custom_field_visibility CASCADE;
+		CREATE TYPE custom_field_visibility AS ENUM ('admin_only', 'employee_editable');
+
+		-- profile_change_status (new)
+		DROP TYPE IF EXISTS profile_change_status CASCADE;
+		CREATE TYPE profile_change_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled');
+
+		-- employee_duplicate_status (new)
+		DROP TYPE IF EXISTS employee_duplicate_status CASCADE;
+		CREATE TYPE employee_duplicate_status AS ENUM ('pending', 'dismissed');
+
+		-- family_relationship (new)
+		DROP TYPE IF EXISTS family_relationship CASCADE;
+		CREATE TYPE family_relationship AS ENUM ('spouse', 'child', 'parent', 'parent_in_law', 'sibling', 'other');
+
+		-- import_job_status (new)
+		DROP TYPE IF EXISTS import_job_status CASCADE;
+		CREATE TYPE import_job_status AS ENUM ('queued', 'running', 'completed', 'failed');
+
+		-- import_job_row_status (new)
+		DROP TYPE IF EXISTS import_job_row_status CASCADE;
+		CREATE TYPE import_job_row_status AS ENUM ('pending', 'succeeded', 'failed');
+
 		-- Subscription Plan Type Enum (New)
 		DROP TYPE IF EXISTS subscription_plan_type CASCADE;
 		CREATE TYPE subscription_plan_type AS ENUM ('standard', 'premium', 'ultra');
@@ -84,14 +161,39 @@ func Migrate(db *gorm.DB) error {
 	}
 
 	if err := db.AutoMigrate(
+		&models.Company{},
+		&models.Department{},
+		&models.Branch{},
+		&models.Position{},
 		&models.User{},
 		&models.Employee{},
+		&models.EmploymentEvent{},
+		&models.EmploymentContract{},
+		&models.Offboarding{},
+		&models.OffboardingTask{},
+		&models.OnboardingTemplate{},
+		&models.OnboardingTemplateTask{},
+		&models.OnboardingTask{},
+		&models.Probation{},
+		&models.ProbationReview{},
+		&models.CustomFieldDefinition{},
+		&models.ProfileChangePolicy{},
+		&models.ProfileChangeRequest{},
+		&models.ImportJob{},
+		&models.ImportJobRow{},
+		&models.ImportMappingProfile{},
+		&models.EmployeeDuplicate{},
+		&models.FamilyMember{},
+		&models.EmergencyContact{},
 		&models.RefreshToken{},
 		&models.Location{},
 		&models.WorkSchedule{},
 		&models.WorkScheduleDetail{},
 		&models.Attendance{},
 		&models.LeaveRequest{},
+		&models.LeaveStaffingRule{},
+		&models.LeavePolicy{},
+		&models.LeaveEncashment{},
 		&models.SubscriptionFeature{},
 		&models.SubscriptionPlan{},
 		&models.SubscriptionPlanFeature{},
@@ -107,6 +209,267 @@ func Migrate(db *gorm.DB) error {
 		return err
 	}
 
+	if err := backfillCompanies(db); err != nil {
+		return err
+	}
+
+	if err := backfillBranchesAndPositions(db); err != nil {
+		return err
+	}
+
+	if err := backfillEmploymentEvents(db); err != nil {
+		return err
+	}
+
+	if err := createEmployeeSearchIndexes(db); err != nil {
+		return err
+	}
+
+	if err := createCursorIndexes(db); err != nil {
+		return err
+	}
+
 	log.Println("Database auto-migration completed successfully")
 	return nil
 }
+
+// backfillCompanies gives every admin a company and assigns everyone in the admin's reporting tree,
+// and the records they own, to it. Trees without an admin get a company owned by their top
+// employee. Rows that already have a company are left alone, so it is safe
+// to run on every start.
+func backfillCompanies(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO companies (name, owner_user_id, created_at, updated_at)
+		SELECT COALESCE(NULLIF(TRIM(e.first_name || ' ' || COALESCE(e.last_name, '')), ''), u.email) || '''s Company', u.id, NOW(), NOW()
+		FROM users u
+		LEFT JOIN employees e ON e.user_id = u.id
+		WHERE u.role = 'admin' AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.owner_user_id = u.id)`,
+
+		`UPDATE employees SET company_id = c.id
+		FROM companies c
+		WHERE employees.user_id = c.owner_user_id AND employees.company_id IS NULL`,
+
+		`WITH RECURSIVE tree AS (
+			SELECT id, company_id FROM employees WHERE company_id IS NOT NULL
+			UNION
+			SELECT e.id, t.company_id FROM employees e JOIN tree t ON e.manager_id = t.id
+		)
+		UPDATE employees SET company_id = tree.company_id
+		FROM tree
+		WHERE employees.id = tree.id AND employees.company_id IS NULL`,
+
+		// Reporting trees without an admin at the top get a company owned by their top employee,
+		// so no employee is left without one.
+		`INSERT INTO companies (name, owner_user_id, created_at, updated_at)
+		SELECT COALESCE(NULLIF(TRIM(e.first_name || ' ' || COALESCE(e.last_name, '')), ''), u.email) || '''s Company', u.id, NOW(), NOW()
+		FROM employees e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.company_id IS NULL
+			AND (e.manager_id IS NULL OR NOT EXISTS (SELECT 1 FROM employees m WHERE m.id = e.manager_id))
+			AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.owner_user_id = u.id)`,
+
+		`UPDATE employees SET company_id = c.id
+		FROM companies c
+		WHERE employees.user_id = c.owner_user_id AND employees.company_id IS NULL`,
+
+		`WITH RECURSIVE tree AS (
+			SELECT id, company_id FROM employees WHERE company_id IS NOT NULL
+			UNION
+			SELECT e.id, t.company_id FROM employees e JOIN tree t ON e.manager_id = t.id
+		)
+		UPDATE employees SET company_id = tree.company_id
+		FROM tree
+		WHERE employees.id = tree.id AND employees.company_id IS NULL`,
+
+		`UPDATE users SET company_id = c.id
+		FROM companies c
+		WHERE users.id = c.owner_user_id AND users.company_id IS NULL`,
+
+		`UPDATE users SET company_id = e.company_id
+		FROM employees e
+		WHERE e.user_id = users.id AND users.company_id IS NULL AND e.company_id IS NOT NULL`,
+
+		`UPDATE locations SET company_id = u.company_id
+		FROM users u
+		WHERE locations.created_by = u.id AND locations.company_id IS NULL AND u.company_id IS NOT NULL`,
+
+		`UPDATE work_schedules SET company_id = u.company_id
+		FROM users u
+		WHERE work_schedules.created_by = u.id AND work_schedules.company_id IS NULL AND u.company_id IS NOT NULL`,
+
+		`UPDATE leave_requests SET company_id = e.company_id
+		FROM employees e
+		WHERE leave_requests.employee_id = e.id AND leave_requests.company_id IS NULL AND e.company_id IS NOT NULL`,
+
+		`UPDATE attendances SET company_id = e.company_id
+		FROM employees e
+		WHERE attendances.employee_id = e.id AND attendances.company_id IS NULL AND e.company_id IS NOT NULL`,
+
+		`UPDATE leave_staffing_rules SET company_id = e.company_id
+		FROM employees e
+		WHERE leave_staffing_rules.root_employee_id = e.id AND leave_staffing_rules.company_id IS NULL AND e.company_id IS NOT NULL`,
+	}
+
+	// Records created for employees that had no company yet follow their employee.
+	for _, table := range []string{
+		"employment_events",
+		"employment_contracts",
+		"offboardings",
+		"onboarding_tasks",
+		"probations",
+		"profile_change_requests",
+		"family_members",
+		"emergency_contacts",
+	} {
+		statements = append(statements, `UPDATE `+table+` SET company_id = e.company_id
+		FROM employees e
+		WHERE `+table+`.employee_id = e.id AND `+table+`.company_id IS NULL AND e.company_id IS NOT NULL`)
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to backfill companies: %w", err)
+		}
+	}
+
+	log.Println("Company backfill completed successfully")
+	return nil
+}
+
+// backfillBranchesAndPositions turns the free-text branch and position names of employees into
+// branch and position records of their company and assigns the employees to them. Employees that
+// are already assigned are left alone.
+func backfillBranchesAndPositions(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO branches (company_id, name, created_by, created_at, updated_at)
+		SELECT DISTINCT ON (e.company_id, LOWER(TRIM(e.branch))) e.company_id, TRIM(e.branch), c.owner_user_id, NOW(), NOW()
+		FROM employees e
+		JOIN companies c ON c.id = e.company_id
+		WHERE e.branch_id IS NULL AND TRIM(COALESCE(e.branch, '')) <> ''
+			AND NOT EXISTS (SELECT 1 FROM branches b WHERE b.company_id = e.company_id AND LOWER(b.name) = LOWER(TRIM(e.branch)))`,
+
+		`UPDATE employees SET branch_id = b.id
+		FROM branches b
+		WHERE employees.branch_id IS NULL AND b.company_id = employees.company_id
+			AND LOWER(b.name) = LOWER(TRIM(employees.branch))`,
+
+		`INSERT INTO positions (company_id, name, created_by, created_at, updated_at)
+		SELECT DISTINCT ON (e.company_id, LOWER(TRIM(e.position_name))) e.company_id, TRIM(e.position_name), c.owner_user_id, NOW(), NOW()
+		FROM employees e
+		JOIN companies c ON c.id = e.company_id
+		WHERE e.position_id IS NULL AND TRIM(COALESCE(e.position_name, '')) <> ''
+			AND NOT EXISTS (SELECT 1 FROM positions p WHERE p.company_id = e.company_id AND LOWER(p.name) = LOWER(TRIM(e.position_name)))`,
+
+		`UPDATE employees SET position_id = p.id
+		FROM positions p
+		WHERE employees.position_id IS NULL AND p.company_id = employees.company_id
+			AND LOWER(p.name) = LOWER(TRIM(employees.position_name))`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to backfill branches and positions: %w", err)
+		}
+	}
+
+	log.Println("Branch and position backfill completed successfully")
+	return nil
+}
+
+// backfillEmploymentEvents starts the employment history of existing employees with their hire and,
+// for leavers, their resignation. Employees that already have such an event are left alone.
+func backfillEmploymentEvents(db *gorm.DB) error {
+	statements := []string{
+		`INSERT INTO employment_events (company_id, employee_id, event_type, effective_date, new_position_name, new_grade, new_branch, new_department_id, new_contract_type, new_base_salary, created_at)
+		SELECT e.company_id, e.id, 'hire', e.hire_date, NULLIF(e.position_name, ''), e.grade, e.branch, e.department_id, e.contract_type, e.base_salary, NOW()
+		FROM employees e
+		WHERE e.hire_date IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM employment_events ev WHERE ev.employee_id = e.id AND ev.event_type = 'hire')`,
+
+		`INSERT INTO employment_events (company_id, employee_id, event_type, effective_date, created_at)
+		SELECT e.company_id, e.id, 'resignation', e.resignation_date, NOW()
+		FROM employees e
+		WHERE e.resignation_date IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM employment_events ev WHERE ev.employee_id = e.id AND ev.event_type = 'resignation')`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to backfill employment events: %w", err)
+		}
+	}
+
+	log.Println("Employment event backfill completed successfully")
+	return nil
+}
+
+// employeeSearchDocument is the text employees are searched on: their name, code, position and
+// branch. NIKs are left out as only admins may search on them; their searches match the nik column
+// itself.
+const employeeSearchDocument = `LOWER(first_name || ' ' || COALESCE(last_name, '') || ' ' || COALESCE(employee_code, '') || ' ' ||
+	COALESCE(position_name, '') || ' ' || COALESCE(branch, ''))`
+
+// createEmployeeSearchIndexes adds the generated search columns of employees and indexes them for
+// full-text search and pg_trgm similarity, which also serves ILIKE '%term%' filters. Emails are
+// matched on their own trigram index as they live on users.
+func createEmployeeSearchIndexes(db *gorm.DB) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+
+		// The search columns used to include the NIK; generated columns cannot be altered, so
+		// those are dropped and added again without it.
+		`DO $$
+		BEGIN
+			IF EXISTS (SELECT 1 FROM information_schema.columns
+				WHERE table_name = 'employees' AND column_name = 'search_text' AND generation_expression LIKE '%nik%') THEN
+				ALTER TABLE employees DROP COLUMN search_text, DROP COLUMN IF EXISTS search_vector;
+			END IF;
+		END $$`,
+
+		`ALTER TABLE employees ADD COLUMN IF NOT EXISTS search_text text
+		GENERATED ALWAYS AS (` + employeeSearchDocument + `) STORED`,
+
+		`ALTER TABLE employees ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', ` + employeeSearchDocument + `)) STORED`,
+
+		`CREATE INDEX IF NOT EXISTS idx_employees_search_vector ON employees USING gin (search_vector)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_employees_search_text_trgm ON employees USING gin (search_text gin_trgm_ops)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (LOWER(email) gin_trgm_ops)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create employee search indexes: %w", err)
+		}
+	}
+
+	log.Println("Employee search indexes created successfully")
+	return nil
+}
+
+// createCursorIndexes indexes the lists paginated by cursor on the (date, id) order their cursors
+// follow, so each page is an index range scan however deep the client pages.
+func createCursorIndexes(db *gorm.DB) error {
+	statements := []string{
+		`CREATE INDEX IF NOT EXISTS idx_attendances_date_id ON attendances (date DESC, id DESC)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_attendances_employee_date_id ON attendances (employee_id, date DESC, id DESC)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_leave_requests_created_at_id ON leave_requests (created_at DESC, id DESC)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_leave_requests_employee_created_at_id ON leave_requests (employee_id, created_at DESC, id DESC)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_profile_change_requests_created_at_id ON profile_change_requests (created_at DESC, id DESC)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create cursor indexes: %w", err)
+		}
+	}
+
+	log.Println("Cursor pagination indexes created successfully")
+	return nil
+}
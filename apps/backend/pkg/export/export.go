@@ -0,0 +1,182 @@
+// Package export writes tables to CSV, XLSX and PDF files. Rows are written as they come so that
+// large exports do not have to be held in memory.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer writes a table with a header row. Close must be called to finish the file.
+type Writer interface {
+	WriteHeader(headers []string) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+// ContentType returns the MIME type of files of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv"
+	}
+}
+
+// NewWriter returns a writer of the format writing to w. The title names the XLSX sheet and heads
+// the PDF pages.
+func NewWriter(format Format, w io.Writer, title string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w, title)
+	case FormatPDF:
+		return NewPDFWriter(w, title), nil
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+// escapeFormula keeps a spreadsheet from evaluating a cell as a formula by prefixing values
+// starting with =, +, - or @ with a quote.
+func escapeFormula(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@':
+		return "'" + value
+	}
+	return value
+}
+
+func escapeFormulas(values []string) []string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeFormula(value)
+	}
+	return escaped
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteHeader(headers []string) error {
+	return w.writer.Write(escapeFormulas(headers))
+}
+
+func (w *csvWriter) WriteRow(values []string) error {
+	return w.writer.Write(escapeFormulas(values))
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// xlsxWriter writes rows through an excelize stream writer, which keeps large sheets on disk
+// rather than in memory. The workbook itself can only be written once it is complete.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+const xlsxColumnWidth = 20
+
+func NewXLSXWriter(w io.Writer, sheet string) (Writer, error) {
+	file := excelize.NewFile()
+	if sheet != "" {
+		if err := file.SetSheetName("Sheet1", sheet); err != nil {
+			return nil, fmt.Errorf("failed to name sheet: %w", err)
+		}
+	} else {
+		sheet = "Sheet1"
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheet writer: %w", err)
+	}
+	return &xlsxWriter{out: w, file: file, stream: stream, row: 1}, nil
+}
+
+func (w *xlsxWriter) WriteHeader(headers []string) error {
+	style, err := w.file.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"305496"}, Pattern: 1},
+		Border: []excelize.Border{
+			{Type: "bottom", Color: "000000", Style: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+
+	if len(headers) > 0 {
+		if err := w.stream.SetColWidth(1, len(headers), xlsxColumnWidth); err != nil {
+			return err
+		}
+	}
+	if err := w.stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	cells := make([]interface{}, len(headers))
+	for i, header := range headers {
+		cells[i] = excelize.Cell{StyleID: style, Value: escapeFormula(header)}
+	}
+	return w.writeCells(cells)
+}
+
+func (w *xlsxWriter) WriteRow(values []string) error {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = escapeFormula(value)
+	}
+	return w.writeCells(cells)
+}
+
+func (w *xlsxWriter) writeCells(cells []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	w.row++
+	return w.stream.SetRow(cell, cells)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer func() {
+		_ = w.file.Close()
+	}()
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.out)
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "formula", value: "=HYPERLINK(\"http://example.com\")", expected: "'=HYPERLINK(\"http://example.com\")"},
+		{name: "plus", value: "+62812345678", expected: "'+62812345678"},
+		{name: "minus", value: "-2+3", expected: "'-2+3"},
+		{name: "at", value: "@SUM(A1:A2)", expected: "'@SUM(A1:A2)"},
+		{name: "plain text", value: "John", expected: "John"},
+		{name: "sign inside the value", value: "a=b", expected: "a=b"},
+		{name: "empty", value: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeFormula(tt.value))
+		})
+	}
+}
+
+func TestCSVWriter_EscapesFormulas(t *testing.T) {
+	var out bytes.Buffer
+	writer := NewCSVWriter(&out)
+
+	require.NoError(t, writer.WriteHeader([]string{"Name", "=Label"}))
+	require.NoError(t, writer.WriteRow([]string{"John", "=1+1"}))
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, "Name,'=Label\nJohn,'=1+1\n", out.String())
+}
+
+func TestXLSXWriter_EscapesFormulas(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := NewXLSXWriter(&out, "Employees")
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteHeader([]string{"Name", "@Label"}))
+	require.NoError(t, writer.WriteRow([]string{"John", "+1+1"}))
+	require.NoError(t, writer.Close())
+
+	file, err := excelize.OpenReader(&out)
+	require.NoError(t, err)
+	defer file.Close()
+	rows, err := file.GetRows("Employees")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "'@Label"}, {"John", "'+1+1"}}, rows)
+}
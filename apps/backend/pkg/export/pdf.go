@@ -0,0 +1,255 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Layout of a PDF roster: landscape A4 pages with the table set in a monospaced font, so columns
+// line up without measuring text.
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 11.0
+	pdfTitleSize  = 12.0
+	// pdfCharWidth is the advance of a Courier glyph, as a fraction of the font size.
+	pdfCharWidth = 0.6
+)
+
+// Objects written before the pages. The page tree is written last, once its pages are known.
+const (
+	pdfCatalogObject  = 1
+	pdfPagesObject    = 2
+	pdfFontObject     = 3
+	pdfBoldFontObject = 4
+	pdfTitleObject    = 5
+	pdfFirstFreeObj   = 6
+)
+
+// pdfWriter writes a table as a PDF roster. Every page is written out as soon as it is full; only
+// the offsets of the objects are kept for the cross-reference table.
+type pdfWriter struct {
+	out     *countingWriter
+	title   string
+	created time.Time
+	encoder *encoding.Encoder
+
+	headers   []string
+	widths    []int
+	offsets   map[int]int64
+	nextObj   int
+	pages     []int
+	page      bytes.Buffer
+	pageLines int
+	started   bool
+	err       error
+}
+
+func NewPDFWriter(w io.Writer, title string) Writer {
+	return &pdfWriter{
+		out:     &countingWriter{w: bufio.NewWriter(w)},
+		title:   title,
+		created: time.Now(),
+		encoder: encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()),
+		offsets: make(map[int]int64),
+		nextObj: pdfFirstFreeObj,
+	}
+}
+
+func (w *pdfWriter) WriteHeader(headers []string) error {
+	w.headers = headers
+	w.widths = pdfColumnWidths(len(headers))
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.newPage()
+	return w.err
+}
+
+func (w *pdfWriter) WriteRow(values []string) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.page.Len() == 0 || w.pageLines >= pdfLinesPerPage() {
+		w.flushPage()
+		w.newPage()
+	}
+	w.line("F1", w.formatRow(values))
+	return w.err
+}
+
+func (w *pdfWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.page.Len() == 0 {
+		w.newPage()
+	}
+	w.flushPage()
+
+	kids := make([]string, len(w.pages))
+	for i, page := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	w.object(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+
+	if w.err != nil {
+		return w.err
+	}
+	xrefOffset := w.out.n
+	w.printf("xref\n0 %d\n0000000000 65535 f \n", w.nextObj)
+	for obj := 1; obj < w.nextObj; obj++ {
+		w.printf("%010d 00000 n \n", w.offsets[obj])
+	}
+	w.printf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", w.nextObj, pdfCatalogObject, xrefOffset)
+	if w.err != nil {
+		return w.err
+	}
+	return w.out.w.Flush()
+}
+
+// start writes the objects shared by every page.
+func (w *pdfWriter) start() error {
+	if w.started {
+		return w.err
+	}
+	w.started = true
+	w.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+	w.object(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+	w.object(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	w.object(pdfBoldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(pdfTitleObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	return w.err
+}
+
+// newPage starts a page with the title and the header row.
+func (w *pdfWriter) newPage() {
+	w.page.Reset()
+	w.pageLines = 0
+
+	pageNumber := len(w.pages) + 1
+	top := pdfPageHeight - pdfMargin
+	w.text("F3", pdfTitleSize, pdfMargin, top-pdfTitleSize, w.title)
+	w.text("F1", pdfFontSize, pdfMargin, top-pdfTitleSize-pdfLineHeight,
+		fmt.Sprintf("Generated %s - Page %d", w.created.Format("2006-01-02 15:04"), pageNumber))
+
+	if len(w.headers) > 0 {
+		w.line("F2", w.formatRow(w.headers))
+		y := w.lineY() + pdfLineHeight - 3
+		fmt.Fprintf(&w.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y, pdfPageWidth-pdfMargin, y)
+	}
+}
+
+func (w *pdfWriter) flushPage() {
+	contentObj := w.nextObj
+	pageObj := w.nextObj + 1
+	w.nextObj += 2
+
+	content := w.page.Bytes()
+	w.object(contentObj, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	w.object(pageObj, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R /F3 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, pdfTitleObject, contentObj))
+	w.pages = append(w.pages, pageObj)
+	w.page.Reset()
+}
+
+// line adds a line of the table to the current page.
+func (w *pdfWriter) line(font, value string) {
+	w.text(font, pdfFontSize, pdfMargin, w.lineY(), value)
+	w.pageLines++
+}
+
+// lineY returns the baseline of the next table line of the page.
+func (w *pdfWriter) lineY() float64 {
+	tableTop := pdfPageHeight - pdfMargin - pdfTitleSize - 3*pdfLineHeight
+	return tableTop - float64(w.pageLines)*pdfLineHeight
+}
+
+func (w *pdfWriter) text(font string, size, x, y float64, value string) {
+	fmt.Fprintf(&w.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, w.escape(value))
+}
+
+// formatRow pads or cuts every value to the width of its column.
+func (w *pdfWriter) formatRow(values []string) string {
+	var b strings.Builder
+	for i, width := range w.widths {
+		value := ""
+		if i < len(values) {
+			value = strings.Join(strings.Fields(values[i]), " ")
+		}
+		runes := []rune(value)
+		if len(runes) >= width {
+			runes = append(runes[:width-2], '~')
+		}
+		b.WriteString(string(runes))
+		b.WriteString(strings.Repeat(" ", width-len(runes)))
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// escape encodes a value in the encoding of the fonts and escapes it for a PDF string.
+func (w *pdfWriter) escape(value string) string {
+	encoded, err := w.encoder.String(value)
+	if err != nil {
+		encoded = value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", " ", "\n", " ")
+	return replacer.Replace(encoded)
+}
+
+func (w *pdfWriter) object(number int, body string) {
+	w.offsets[number] = w.out.n
+	w.printf("%d 0 obj\n%s\nendobj\n", number, body)
+}
+
+func (w *pdfWriter) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.out, format, args...)
+}
+
+// pdfColumnWidths splits the width of a line evenly between the columns, in characters.
+func pdfColumnWidths(columns int) []int {
+	if columns == 0 {
+		return nil
+	}
+	lineWidth := pdfPageWidth - 2*pdfMargin
+	lineChars := int(lineWidth / (pdfCharWidth * pdfFontSize))
+	width := lineChars / columns
+	if width < 4 {
+		width = 4
+	}
+	widths := make([]int, columns)
+	for i := range widths {
+		widths[i] = width
+	}
+	return widths
+}
+
+// pdfLinesPerPage is the number of table lines of a page, including the header row.
+func pdfLinesPerPage() int {
+	tableHeight := pdfPageHeight - 2*pdfMargin - pdfTitleSize - 3*pdfLineHeight
+	return int(tableHeight / pdfLineHeight)
+}
+
+type countingWriter struct {
+	w *bufio.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
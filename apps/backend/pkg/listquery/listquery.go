@@ -0,0 +1,387 @@
+// Package listquery parses the filters, sorts and field selection of list requests and applies
+// them to database queries.
+//
+// Filters are given as filter[field]=value, or filter[field][op]=value with op one of eq, in,
+// gte, lte, between and null. In and between take comma-separated values, dates are written as
+// 2006-01-02 and null takes true or false. Sorts are given as sort=-hire_date,first_name, a minus
+// sorting descending, and fields as fields=id,first_name.
+package listquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"gorm.io/gorm"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// maxSorts and maxInValues bound the work a single request can ask of the database.
+	maxSorts    = 5
+	maxInValues = 100
+)
+
+var filterParam = regexp.MustCompile(`^filter\[([a-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// Parse reads the list query of a request, validating its filters and sorts against the schema
+// and its fields against the JSON fields of item, the response DTO of a list item.
+func Parse(values url.Values, schema domain.ListSchema, item interface{}) (*domain.ListQuery, error) {
+	query := &domain.ListQuery{}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		match := filterParam.FindStringSubmatch(key)
+		if match == nil {
+			if strings.HasPrefix(key, "filter[") {
+				return nil, fmt.Errorf("%w: malformed filter %s", domain.ErrInvalidListQuery, key)
+			}
+			continue
+		}
+		operator := domain.FilterEq
+		if match[2] != "" {
+			operator = domain.FilterOperator(match[2])
+		}
+		for _, value := range values[key] {
+			filter, err := parseFilter(schema, match[1], operator, value)
+			if err != nil {
+				return nil, err
+			}
+			query.Filters = append(query.Filters, filter)
+		}
+	}
+
+	if raw := values.Get("sort"); raw != "" {
+		sorts, err := parseSorts(schema, raw)
+		if err != nil {
+			return nil, err
+		}
+		query.Sorts = sorts
+	}
+
+	if raw := values.Get("fields"); raw != "" {
+		fields, err := parseFields(item, raw)
+		if err != nil {
+			return nil, err
+		}
+		query.Fields = fields
+	}
+
+	return query, nil
+}
+
+func parseFilter(schema domain.ListSchema, name string, operator domain.FilterOperator, raw string) (domain.QueryFilter, error) {
+	field, ok := schema[name]
+	if !ok {
+		return domain.QueryFilter{}, fmt.Errorf("%w: cannot filter on %s", domain.ErrInvalidListQuery, name)
+	}
+	if !field.Allows(operator) {
+		return domain.QueryFilter{}, fmt.Errorf("%w: %s cannot be filtered with %s", domain.ErrInvalidListQuery, name, operator)
+	}
+
+	filter := domain.QueryFilter{Field: name, Operator: operator}
+	switch operator {
+	case domain.FilterNull:
+		isNull, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, fmt.Errorf("%w: filter[%s][null] must be true or false", domain.ErrInvalidListQuery, name)
+		}
+		filter.Values = []interface{}{isNull}
+		return filter, nil
+	case domain.FilterIn:
+		parts := strings.Split(raw, ",")
+		if len(parts) > maxInValues {
+			return filter, fmt.Errorf("%w: filter[%s][in] takes at most %d values", domain.ErrInvalidListQuery, name, maxInValues)
+		}
+		for _, part := range parts {
+			value, err := parseValue(field.Type, part)
+			if err != nil {
+				return filter, fmt.Errorf("%w: filter[%s][in]: %v", domain.ErrInvalidListQuery, name, err)
+			}
+			filter.Values = append(filter.Values, value)
+		}
+		return filter, nil
+	case domain.FilterBetween:
+		parts := strings.Split(raw, ",")
+		if len(parts) != 2 {
+			return filter, fmt.Errorf("%w: filter[%s][between] takes a start and an end date", domain.ErrInvalidListQuery, name)
+		}
+		for _, part := range parts {
+			value, err := parseValue(field.Type, part)
+			if err != nil {
+				return filter, fmt.Errorf("%w: filter[%s][between]: %v", domain.ErrInvalidListQuery, name, err)
+			}
+			filter.Values = append(filter.Values, value)
+		}
+		if filter.Values[1].(time.Time).Before(filter.Values[0].(time.Time)) {
+			return filter, fmt.Errorf("%w: filter[%s][between] ends before it starts", domain.ErrInvalidListQuery, name)
+		}
+		return filter, nil
+	}
+
+	value, err := parseValue(field.Type, raw)
+	if err != nil {
+		return filter, fmt.Errorf("%w: filter[%s][%s]: %v", domain.ErrInvalidListQuery, name, operator, err)
+	}
+	filter.Values = []interface{}{value}
+	return filter, nil
+}
+
+func parseValue(fieldType domain.ListFieldType, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch fieldType {
+	case domain.ListFieldNumber:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return value, nil
+	case domain.ListFieldBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", raw)
+		}
+		return value, nil
+	case domain.ListFieldDate:
+		value, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date in the YYYY-MM-DD format", raw)
+		}
+		return value, nil
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("value is empty")
+	}
+	return raw, nil
+}
+
+func parseSorts(schema domain.ListSchema, raw string) ([]domain.QuerySort, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxSorts {
+		return nil, fmt.Errorf("%w: sort takes at most %d fields", domain.ErrInvalidListQuery, maxSorts)
+	}
+
+	sorts := make([]domain.QuerySort, 0, len(parts))
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		if field, ok := schema[name]; !ok || !field.Sortable {
+			return nil, fmt.Errorf("%w: cannot sort on %s", domain.ErrInvalidListQuery, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s is sorted on twice", domain.ErrInvalidListQuery, name)
+		}
+		seen[name] = true
+		sorts = append(sorts, domain.QuerySort{Field: name, Desc: desc})
+	}
+	return sorts, nil
+}
+
+func parseFields(item interface{}, raw string) ([]string, error) {
+	available := JSONFields(item)
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if !available[name] {
+			return nil, fmt.Errorf("%w: unknown field %s", domain.ErrInvalidListQuery, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+// JSONFields returns the names of the JSON fields of a struct, including those of embedded
+// structs.
+func JSONFields(item interface{}) map[string]bool {
+	fields := make(map[string]bool)
+	collectJSONFields(reflect.TypeOf(item), fields)
+	return fields
+}
+
+func collectJSONFields(t reflect.Type, fields map[string]bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" {
+			collectJSONFields(field.Type, fields)
+			continue
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+}
+
+// Scope applies the filters of a query to a database query on the schema's tables. A nil query
+// filters nothing.
+func Scope(query *domain.ListQuery, schema domain.ListSchema) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query == nil {
+			return db
+		}
+		for _, filter := range query.Filters {
+			field, ok := schema[filter.Field]
+			if !ok {
+				continue
+			}
+			db = applyFilter(db, field, filter)
+		}
+		return db
+	}
+}
+
+func applyFilter(db *gorm.DB, field domain.ListField, filter domain.QueryFilter) *gorm.DB {
+	column := field.Column
+	switch filter.Operator {
+	case domain.FilterNull:
+		if filter.Values[0].(bool) {
+			return db.Where(column + " IS NULL")
+		}
+		return db.Where(column + " IS NOT NULL")
+	case domain.FilterIn:
+		return db.Where(column+" IN ?", filter.Values)
+	case domain.FilterGte:
+		return db.Where(column+" >= ?", filter.Values[0])
+	case domain.FilterLte:
+		if field.Type == domain.ListFieldDate {
+			return db.Where(column+" < ?", filter.Values[0].(time.Time).AddDate(0, 0, 1))
+		}
+		return db.Where(column+" <= ?", filter.Values[0])
+	case domain.FilterBetween:
+		return db.Where(column+" >= ? AND "+column+" < ?", filter.Values[0], filter.Values[1].(time.Time).AddDate(0, 0, 1))
+	}
+
+	// Dates match the whole day, so timestamps match too.
+	if field.Type == domain.ListFieldDate {
+		day := filter.Values[0].(time.Time)
+		return db.Where(column+" >= ? AND "+column+" < ?", day, day.AddDate(0, 0, 1))
+	}
+	return db.Where(column+" = ?", filter.Values[0])
+}
+
+// Order returns the ORDER BY clause of a query: its sorts followed by the schema's id field to
+// keep pages stable, or fallback when it has none.
+func Order(query *domain.ListQuery, schema domain.ListSchema, fallback string) string {
+	if query == nil || len(query.Sorts) == 0 {
+		return fallback
+	}
+
+	clauses := make([]string, 0, len(query.Sorts)+1)
+	sortedOnID := false
+	for _, s := range query.Sorts {
+		field, ok := schema[s.Field]
+		if !ok {
+			continue
+		}
+		direction := "ASC"
+		if s.Desc {
+			direction = "DESC"
+		}
+		clauses = append(clauses, field.Column+" "+direction+" NULLS LAST")
+		sortedOnID = sortedOnID || s.Field == "id"
+	}
+	if id, ok := schema["id"]; ok && !sortedOnID {
+		clauses = append(clauses, id.Column+" ASC")
+	}
+	if len(clauses) == 0 {
+		return fallback
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// After pages a query by cursor: it keeps the rows after the cursor of the pagination, ordered by
+// dateColumn and then idColumn, both descending, and fetches one row more than the page size to tell
+// whether another page follows. The id breaks ties on the date, so rows inserted while a client
+// pages never shift the rows it has yet to see.
+func After(pagination domain.PaginationParams, dateColumn, idColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor := pagination.Cursor; cursor != nil && !cursor.IsStart() {
+			db = db.Where("("+dateColumn+", "+idColumn+") < (?, ?)", cursor.Date, cursor.ID)
+		}
+		db = db.Order(dateColumn + " DESC, " + idColumn + " DESC")
+		if pagination.PageSize > 0 {
+			db = db.Limit(pagination.PageSize + 1)
+		}
+		return db
+	}
+}
+
+// SelectFields keeps only the selected fields, and the id, of the items of a list response: a
+// slice of items or an object holding them under items. Without selected fields the data is
+// returned as it is.
+func SelectFields(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode list: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode list: %w", err)
+	}
+
+	keep := map[string]bool{"id": true}
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	switch v := decoded.(type) {
+	case []interface{}:
+		return selectItemFields(v, keep), nil
+	case map[string]interface{}:
+		if items, ok := v["items"].([]interface{}); ok {
+			v["items"] = selectItemFields(items, keep)
+		}
+		return v, nil
+	}
+	return decoded, nil
+}
+
+func selectItemFields(items []interface{}, keep map[string]bool) []interface{} {
+	for i, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		selected := make(map[string]interface{}, len(keep))
+		for key, value := range object {
+			if keep[key] {
+				selected[key] = value
+			}
+		}
+		items[i] = selected
+	}
+	return items
+}
@@ -0,0 +1,189 @@
+package listquery
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SukaMajuu/hris/apps/backend/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = domain.ListSchema{
+	"id":          {Column: "employees.id", Type: domain.ListFieldNumber, Operators: []domain.FilterOperator{domain.FilterEq, domain.FilterIn}, Sortable: true},
+	"first_name":  {Column: "employees.first_name", Type: domain.ListFieldString, Operators: []domain.FilterOperator{domain.FilterEq, domain.FilterIn, domain.FilterNull}, Sortable: true},
+	"gender":      {Column: "employees.gender", Type: domain.ListFieldString, Operators: []domain.FilterOperator{domain.FilterEq}},
+	"active":      {Column: "employees.employment_status", Type: domain.ListFieldBool, Operators: []domain.FilterOperator{domain.FilterEq}},
+	"base_salary": {Column: "employees.base_salary", Type: domain.ListFieldNumber, Operators: []domain.FilterOperator{domain.FilterGte, domain.FilterLte}, Sortable: true},
+	"hire_date":   {Column: "employees.hire_date", Type: domain.ListFieldDate, Operators: []domain.FilterOperator{domain.FilterEq, domain.FilterBetween}, Sortable: true},
+}
+
+type testItem struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	Secret    string `json:"-"`
+	testEmbedded
+}
+
+type testEmbedded struct {
+	HireDate string `json:"hire_date"`
+}
+
+func date(value string) time.Time {
+	parsed, _ := time.Parse(dateLayout, value)
+	return parsed
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected *domain.ListQuery
+	}{
+		{
+			name:     "empty query",
+			query:    "",
+			expected: &domain.ListQuery{},
+		},
+		{
+			name:  "equality filter without an operator",
+			query: "filter[gender]=female",
+			expected: &domain.ListQuery{Filters: []domain.QueryFilter{
+				{Field: "gender", Operator: domain.FilterEq, Values: []interface{}{"female"}},
+			}},
+		},
+		{
+			name:  "typed values",
+			query: "filter[active]=true&filter[base_salary][gte]=5000000&filter[hire_date][between]=2024-01-01,2024-12-31",
+			expected: &domain.ListQuery{Filters: []domain.QueryFilter{
+				{Field: "active", Operator: domain.FilterEq, Values: []interface{}{true}},
+				{Field: "base_salary", Operator: domain.FilterGte, Values: []interface{}{5000000.0}},
+				{Field: "hire_date", Operator: domain.FilterBetween, Values: []interface{}{date("2024-01-01"), date("2024-12-31")}},
+			}},
+		},
+		{
+			name:  "in and null",
+			query: "filter[first_name][in]=Siti,Budi&filter[first_name][null]=false",
+			expected: &domain.ListQuery{Filters: []domain.QueryFilter{
+				{Field: "first_name", Operator: domain.FilterIn, Values: []interface{}{"Siti", "Budi"}},
+				{Field: "first_name", Operator: domain.FilterNull, Values: []interface{}{false}},
+			}},
+		},
+		{
+			name:     "sorts and fields",
+			query:    "sort=-hire_date,first_name&fields=first_name,hire_date,first_name",
+			expected: &domain.ListQuery{Sorts: []domain.QuerySort{{Field: "hire_date", Desc: true}, {Field: "first_name"}}, Fields: []string{"first_name", "hire_date"}},
+		},
+		{
+			name:     "other parameters are ignored",
+			query:    "page=2&search=siti",
+			expected: &domain.ListQuery{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			query, err := Parse(values, testSchema, testItem{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+		})
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "malformed filter", query: "filter[first_name=Siti"},
+		{name: "unknown filter field", query: "filter[password]=secret"},
+		{name: "operator the field does not allow", query: "filter[gender][in]=male,female"},
+		{name: "unknown operator", query: "filter[first_name][like]=Si"},
+		{name: "number that is not a number", query: "filter[base_salary][gte]=lots"},
+		{name: "bool that is not a bool", query: "filter[active]=yes"},
+		{name: "date in another layout", query: "filter[hire_date]=01/02/2024"},
+		{name: "empty string value", query: "filter[gender]="},
+		{name: "null that is not a bool", query: "filter[first_name][null]=maybe"},
+		{name: "between without an end", query: "filter[hire_date][between]=2024-01-01"},
+		{name: "between ending before it starts", query: "filter[hire_date][between]=2024-12-31,2024-01-01"},
+		{name: "too many in values", query: "filter[first_name][in]=" + strings.Repeat("a,", maxInValues) + "a"},
+		{name: "unsortable field", query: "sort=gender"},
+		{name: "unknown sort field", query: "sort=-password"},
+		{name: "field sorted twice", query: "sort=first_name,-first_name"},
+		{name: "too many sorts", query: "sort=id,first_name,base_salary,hire_date,-id,-first_name"},
+		{name: "unknown selected field", query: "fields=first_name,password"},
+		{name: "field hidden from JSON", query: "fields=Secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			_, err = Parse(values, testSchema, testItem{})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidListQuery)
+		})
+	}
+}
+
+func TestParse_AdminOnlyFields(t *testing.T) {
+	schema := domain.EmployeeListSchema.WithoutAdminOnly()
+
+	for _, query := range []string{"filter[nik]=3201010101010001", "filter[base_salary][gte]=10000000", "sort=-base_salary"} {
+		values, err := url.ParseQuery(query)
+		require.NoError(t, err)
+
+		_, err = Parse(values, schema, testItem{})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidListQuery, query)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *domain.ListQuery
+		expected string
+	}{
+		{name: "no query", query: nil, expected: "employees.id DESC"},
+		{name: "no sorts", query: &domain.ListQuery{}, expected: "employees.id DESC"},
+		{
+			name:     "sorts end with the id",
+			query:    &domain.ListQuery{Sorts: []domain.QuerySort{{Field: "hire_date", Desc: true}, {Field: "first_name"}}},
+			expected: "employees.hire_date DESC NULLS LAST, employees.first_name ASC NULLS LAST, employees.id ASC",
+		},
+		{
+			name:     "sort on the id",
+			query:    &domain.ListQuery{Sorts: []domain.QuerySort{{Field: "id", Desc: true}}},
+			expected: "employees.id DESC NULLS LAST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Order(tt.query, testSchema, "employees.id DESC"))
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []testItem{{ID: 1, FirstName: "Siti", testEmbedded: testEmbedded{HireDate: "2024-01-01"}}},
+		"total": 1,
+	}
+
+	selected, err := SelectFields(data, []string{"first_name"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"id": 1.0, "first_name": "Siti"}},
+		"total": 1.0,
+	}, selected)
+}
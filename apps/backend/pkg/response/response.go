@@ -64,3 +64,15 @@ func InternalServerError(c *gin.Context, err error) {
 func Conflict(c *gin.Context, message string, err error) {
 	Error(c, http.StatusConflict, message, err)
 }
+
+func ErrorWithData(c *gin.Context, status int, message string, err error, data interface{}) {
+	response := Response{
+		Status:  status,
+		Message: message,
+		Data:    data,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	c.JSON(status, response)
+}
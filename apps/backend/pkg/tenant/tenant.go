@@ -0,0 +1,115 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type companyIDKey struct{}
+
+type acrossCompaniesKey struct{}
+
+// ErrNoCompany is returned by queries scoped to a company when ctx carries no company and is not
+// marked as working across companies.
+var ErrNoCompany = errors.New("no company in context")
+
+// WithCompanyID returns a copy of ctx carrying the company of the authenticated user.
+func WithCompanyID(ctx context.Context, companyID uint) context.Context {
+	return context.WithValue(ctx, companyIDKey{}, companyID)
+}
+
+// CompanyID returns the company carried by ctx. Work across companies such as cron jobs has none.
+func CompanyID(ctx context.Context) (uint, bool) {
+	companyID, ok := ctx.Value(companyIDKey{}).(uint)
+	return companyID, ok
+}
+
+// AcrossCompanies returns a copy of ctx for work not done on behalf of a single company, such as
+// cron jobs, webhooks and signing in. Scoped queries in such a ctx see every company.
+func AcrossCompanies(ctx context.Context) context.Context {
+	return context.WithValue(ctx, acrossCompaniesKey{}, true)
+}
+
+func isAcrossCompanies(ctx context.Context) bool {
+	across, _ := ctx.Value(acrossCompaniesKey{}).(bool)
+	return across
+}
+
+// Assign returns the company a new record should belong to: current when already set,
+// otherwise the company carried by ctx.
+func Assign(ctx context.Context, current *uint) *uint {
+	if current != nil {
+		return current
+	}
+	if companyID, ok := CompanyID(ctx); ok && companyID != 0 {
+		return &companyID
+	}
+	return nil
+}
+
+// Scope restricts a query on table to the company carried by ctx. Queries across companies are
+// not restricted, and queries with neither fail with ErrNoCompany rather than seeing every company.
+func Scope(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if companyID, ok := CompanyID(ctx); ok {
+			return db.Where(table+".company_id = ?", companyID)
+		}
+		if isAcrossCompanies(ctx) {
+			return db
+		}
+		_ = db.AddError(ErrNoCompany)
+		return db
+	}
+}
+
+// ScopeVia restricts a query to the rows whose column references a row of table belonging to the
+// company carried by ctx, for tables whose rows have no company of their own.
+func ScopeVia(ctx context.Context, column, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if companyID, ok := CompanyID(ctx); ok {
+			return db.Where(column+" IN (SELECT id FROM "+table+" WHERE company_id = ?)", companyID)
+		}
+		if isAcrossCompanies(ctx) {
+			return db
+		}
+		_ = db.AddError(ErrNoCompany)
+		return db
+	}
+}
+
+// Save updates every field of record, a row of table, if it belongs to the company carried by ctx.
+// Unlike gorm's Save it never inserts, so a record of another company fails with
+// gorm.ErrRecordNotFound instead of being overwritten.
+func Save(ctx context.Context, db *gorm.DB, table string, record interface{}) error {
+	return save(ctx, db, Scope(ctx, table), record)
+}
+
+// SaveVia is Save for tables whose rows have no company of their own, scoped as ScopeVia.
+func SaveVia(ctx context.Context, db *gorm.DB, column, table string, record interface{}) error {
+	return save(ctx, db, ScopeVia(ctx, column, table), record)
+}
+
+func save(ctx context.Context, db *gorm.DB, scope func(*gorm.DB) *gorm.DB, record interface{}) error {
+	result := db.WithContext(ctx).Scopes(scope).Select("*").Save(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// EmployeeCompanyID returns the company of the given employee. Records owned by an employee
+// belong to the employee's company, including those created by background work.
+func EmployeeCompanyID(ctx context.Context, db *gorm.DB, employeeID uint) (*uint, error) {
+	var companyID *uint
+	err := db.WithContext(ctx).Table("employees").Select("company_id").Where("id = ?", employeeID).Row().Scan(&companyID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return companyID, nil
+}
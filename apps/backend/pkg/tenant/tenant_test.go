@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type employee struct {
+	ID        uint
+	CompanyID *uint
+}
+
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestScope(t *testing.T) {
+	companyCtx := WithCompanyID(context.Background(), 7)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		scope        func(ctx context.Context) func(*gorm.DB) *gorm.DB
+		expectedSQL  string
+		expectedVars []interface{}
+		expectedErr  error
+	}{
+		{
+			name:         "company in context",
+			ctx:          companyCtx,
+			scope:        func(ctx context.Context) func(*gorm.DB) *gorm.DB { return Scope(ctx, "employees") },
+			expectedSQL:  `SELECT * FROM "employees" WHERE employees.company_id = $1`,
+			expectedVars: []interface{}{uint(7)},
+		},
+		{
+			name:        "across companies",
+			ctx:         AcrossCompanies(context.Background()),
+			scope:       func(ctx context.Context) func(*gorm.DB) *gorm.DB { return Scope(ctx, "employees") },
+			expectedSQL: `SELECT * FROM "employees"`,
+		},
+		{
+			name:        "no company fails closed",
+			ctx:         context.Background(),
+			scope:       func(ctx context.Context) func(*gorm.DB) *gorm.DB { return Scope(ctx, "employees") },
+			expectedErr: ErrNoCompany,
+		},
+		{
+			name:         "via a table with a company",
+			ctx:          companyCtx,
+			scope:        func(ctx context.Context) func(*gorm.DB) *gorm.DB { return ScopeVia(ctx, "employees.manager_id", "employees") },
+			expectedSQL:  `SELECT * FROM "employees" WHERE employees.manager_id IN (SELECT id FROM employees WHERE company_id = $1)`,
+			expectedVars: []interface{}{uint(7)},
+		},
+		{
+			name:        "via across companies",
+			ctx:         AcrossCompanies(context.Background()),
+			scope:       func(ctx context.Context) func(*gorm.DB) *gorm.DB { return ScopeVia(ctx, "employees.manager_id", "employees") },
+			expectedSQL: `SELECT * FROM "employees"`,
+		},
+		{
+			name:        "via without a company fails closed",
+			ctx:         context.Background(),
+			scope:       func(ctx context.Context) func(*gorm.DB) *gorm.DB { return ScopeVia(ctx, "employees.manager_id", "employees") },
+			expectedErr: ErrNoCompany,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var employees []employee
+			stmt := newDryRunDB(t).WithContext(tt.ctx).Scopes(tt.scope(tt.ctx)).Find(&employees)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, stmt.Error, tt.expectedErr)
+				return
+			}
+			require.NoError(t, stmt.Error)
+			assert.Equal(t, tt.expectedSQL, stmt.Statement.SQL.String())
+			assert.Equal(t, tt.expectedVars, stmt.Statement.Vars)
+		})
+	}
+}
+
+func TestSave_FailsClosedWithoutCompany(t *testing.T) {
+	err := Save(context.Background(), newDryRunDB(t), "employees", &employee{ID: 1})
+	assert.ErrorIs(t, err, ErrNoCompany)
+}
+
+func TestAssign(t *testing.T) {
+	current := uint(3)
+
+	assert.Equal(t, &current, Assign(WithCompanyID(context.Background(), 7), &current))
+	assert.Equal(t, uint(7), *Assign(WithCompanyID(context.Background(), 7), nil))
+	assert.Nil(t, Assign(context.Background(), nil))
+	assert.Nil(t, Assign(AcrossCompanies(context.Background()), nil))
+}
+
+func TestCompanyID(t *testing.T) {
+	companyID, ok := CompanyID(WithCompanyID(context.Background(), 7))
+	assert.True(t, ok)
+	assert.Equal(t, uint(7), companyID)
+
+	_, ok = CompanyID(AcrossCompanies(context.Background()))
+	assert.False(t, ok)
+}